@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	flag "github.com/spf13/pflag"
+
+	"gitlab.com/thorchain/midgard/internal/config"
+	"gitlab.com/thorchain/midgard/internal/server"
+)
+
+// commandTimeout is the timeout of the requests sent to the running midgard.
+const commandTimeout = 5 * time.Minute
+
+// runCommand runs the subcommands which operate on a running midgard through
+// its API, so they share the state of the running scanner.
+func runCommand(cfgFile string, args []string) error {
+	cfg, err := config.LoadConfiguration(cfgFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to load config")
+	}
+	baseURL := fmt.Sprintf("http://localhost:%d", cfg.ListenPort)
+
+	switch args[0] {
+	case "quarantine":
+		if len(args) < 2 {
+			return errors.New("usage: midgard quarantine list [offset] [limit] | reprocess")
+		}
+		switch args[1] {
+		case "list":
+			offset, limit := "0", "50"
+			if len(args) > 2 {
+				offset = args[2]
+			}
+			if len(args) > 3 {
+				limit = args[3]
+			}
+			return callAPI(http.MethodGet, "", fmt.Sprintf("%s/v1/events/quarantine?offset=%s&limit=%s", baseURL, offset, limit))
+		case "reprocess":
+			return callAPI(http.MethodPost, cfg.Admin.Key, baseURL+"/v1/events/quarantine/reprocess")
+		}
+		return errors.Errorf("unknown quarantine command %q", args[1])
+	case "rebuild":
+		return callAPI(http.MethodPost, cfg.Admin.Key, baseURL+"/v1/rebuild")
+	}
+	return errors.Errorf("unknown command %q", args[0])
+}
+
+// callAPI sends a request to the running midgard and prints the response. The
+// admin key is sent if not empty.
+func callAPI(method, adminKey, url string) error {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return err
+	}
+	if adminKey != "" {
+		req.Header.Set("Authorization", "Bearer "+adminKey)
+	}
+	client := http.Client{Timeout: commandTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "request failed")
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "could not read response")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("request failed with status %d: %s", resp.StatusCode, body)
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, body, "", "  "); err != nil {
+		return errors.Wrap(err, "invalid response")
+	}
+	out.WriteByte('\n')
+	_, err = out.WriteTo(os.Stdout)
+	return err
+}
+
+// runReindex rebuilds the data derived from a range of blocks by replaying their
+// archived raw events, without the node. It works on the database directly, so
+// midgard must be stopped while it runs.
+func runReindex(args []string) error {
+	fs := flag.NewFlagSet("reindex", flag.ContinueOnError)
+	cfgFile := fs.StringP("cfg", "c", "config", "configuration file with extension")
+	from := fs.Int64("from", 0, "first height to reindex")
+	to := fs.Int64("to", 0, "last height to reindex (defaults to the last indexed height)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	return server.Reindex(*cfgFile, *from, *to)
+}
@@ -12,9 +12,23 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "reindex" {
+		if err := runReindex(os.Args[2:]); err != nil {
+			log.Fatal("failed to reindex: ", err)
+		}
+		return
+	}
+
 	cfgFile := flag.StringP("cfg", "c", "config", "configuration file with extension")
 	flag.Parse()
 
+	if flag.NArg() > 0 {
+		if err := runCommand(*cfgFile, flag.Args()); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	s, err := server.New(cfgFile)
 	if err != nil {
 		log.Fatal("failed to create service: ", err)
@@ -0,0 +1,34 @@
+-- +migrate Up
+
+CREATE VIEW swap_fees_5_min WITH
+(timescaledb.continuous, timescaledb.refresh_lag = "0", timescaledb.refresh_interval = '10 min')
+AS
+SELECT pool, time_bucket('5 min', time) AS time,
+    SUM(CASE WHEN runeAmt > 0 OR assetAmt < 0 THEN liquidity_fee ELSE 0 END) AS buy_fees,
+    SUM(CASE WHEN runeAmt < 0 OR assetAmt > 0 THEN liquidity_fee ELSE 0 END) AS sell_fees
+FROM swaps
+GROUP BY pool, time_bucket('5 min', time);
+
+CREATE VIEW swap_fees_hourly WITH
+(timescaledb.continuous, timescaledb.refresh_lag = "0", timescaledb.refresh_interval = '10 min')
+AS
+SELECT pool, time_bucket('1 hour', time) AS time,
+    SUM(CASE WHEN runeAmt > 0 OR assetAmt < 0 THEN liquidity_fee ELSE 0 END) AS buy_fees,
+    SUM(CASE WHEN runeAmt < 0 OR assetAmt > 0 THEN liquidity_fee ELSE 0 END) AS sell_fees
+FROM swaps
+GROUP BY pool, time_bucket('1 hour', time);
+
+CREATE VIEW swap_fees_daily WITH
+(timescaledb.continuous, timescaledb.refresh_lag = "0", timescaledb.refresh_interval = '10 min')
+AS
+SELECT pool, time_bucket('1 day', time) AS time,
+    SUM(CASE WHEN runeAmt > 0 OR assetAmt < 0 THEN liquidity_fee ELSE 0 END) AS buy_fees,
+    SUM(CASE WHEN runeAmt < 0 OR assetAmt > 0 THEN liquidity_fee ELSE 0 END) AS sell_fees
+FROM swaps
+GROUP BY pool, time_bucket('1 day', time);
+
+-- +migrate Down
+
+DROP VIEW swap_fees_5_min CASCADE;
+DROP VIEW swap_fees_hourly CASCADE;
+DROP VIEW swap_fees_daily CASCADE;
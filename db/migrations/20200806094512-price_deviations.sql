@@ -0,0 +1,33 @@
+-- +migrate Up
+
+-- Shared by all versions of derived tables, see the derived_schema migration.
+CREATE TABLE IF NOT EXISTS public.price_deviations (
+    time            TIMESTAMPTZ         NOT NULL,
+    id              BIGSERIAL           NOT NULL,
+    height          BIGINT              NOT NULL,
+    pool            VARCHAR             NOT NULL,
+    pool_price      DOUBLE PRECISION    NOT NULL,
+    reference_price DOUBLE PRECISION    NOT NULL,
+    deviation       DOUBLE PRECISION    NOT NULL,
+    PRIMARY KEY (id, time)
+);
+CREATE INDEX IF NOT EXISTS price_deviations_pool_idx ON public.price_deviations (pool);
+
+SELECT create_hypertable('public.price_deviations', 'time', if_not_exists => TRUE);
+
+-- The last deviation of the pools deviated more than the threshold since the
+-- time of the first deviated block.
+CREATE TABLE IF NOT EXISTS public.price_deviation_alerts (
+    pool            VARCHAR             PRIMARY KEY,
+    since           TIMESTAMPTZ         NOT NULL,
+    time            TIMESTAMPTZ         NOT NULL,
+    height          BIGINT              NOT NULL,
+    pool_price      DOUBLE PRECISION    NOT NULL,
+    reference_price DOUBLE PRECISION    NOT NULL,
+    deviation       DOUBLE PRECISION    NOT NULL
+);
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS public.price_deviation_alerts;
+DROP TABLE IF EXISTS public.price_deviations;
@@ -0,0 +1,61 @@
+-- +migrate Up
+
+CREATE TABLE block_rewards (
+    time            TIMESTAMPTZ     NOT NULL,
+    event_id        BIGINT          NOT NULL,
+    height          BIGINT          NOT NULL,
+    bond_reward     BIGINT          NOT NULL,
+    pool_rewards    BIGINT          NOT NULL,
+    PRIMARY KEY (event_id, time)
+);
+CREATE INDEX block_rewards_height_idx ON block_rewards (height);
+
+SELECT create_hypertable('block_rewards', 'time');
+
+-- Shared by all versions of derived tables, see the derived_schema migration.
+CREATE TABLE IF NOT EXISTS public.reserve_history (
+    time            TIMESTAMPTZ     NOT NULL,
+    height          BIGINT          NOT NULL,
+    total_reserve   BIGINT          NOT NULL,
+    PRIMARY KEY (height, time)
+);
+
+SELECT create_hypertable('public.reserve_history', 'time', if_not_exists => TRUE);
+
+CREATE VIEW block_rewards_5_min WITH
+(timescaledb.continuous, timescaledb.refresh_lag = "0", timescaledb.refresh_interval = '10 min')
+AS
+SELECT time_bucket('5 min', time) AS time,
+    SUM(bond_reward) AS bond_reward,
+    SUM(pool_rewards) AS pool_rewards,
+    COUNT(*) AS blocks
+FROM block_rewards
+GROUP BY time_bucket('5 min', time);
+
+CREATE VIEW block_rewards_hourly WITH
+(timescaledb.continuous, timescaledb.refresh_lag = "0", timescaledb.refresh_interval = '10 min')
+AS
+SELECT time_bucket('1 hour', time) AS time,
+    SUM(bond_reward) AS bond_reward,
+    SUM(pool_rewards) AS pool_rewards,
+    COUNT(*) AS blocks
+FROM block_rewards
+GROUP BY time_bucket('1 hour', time);
+
+CREATE VIEW block_rewards_daily WITH
+(timescaledb.continuous, timescaledb.refresh_lag = "0", timescaledb.refresh_interval = '10 min')
+AS
+SELECT time_bucket('1 day', time) AS time,
+    SUM(bond_reward) AS bond_reward,
+    SUM(pool_rewards) AS pool_rewards,
+    COUNT(*) AS blocks
+FROM block_rewards
+GROUP BY time_bucket('1 day', time);
+
+-- +migrate Down
+
+DROP VIEW block_rewards_5_min CASCADE;
+DROP VIEW block_rewards_hourly CASCADE;
+DROP VIEW block_rewards_daily CASCADE;
+DROP TABLE block_rewards;
+DROP TABLE IF EXISTS public.reserve_history;
@@ -0,0 +1,20 @@
+-- +migrate Up
+
+CREATE TABLE tx_fees (
+    time            TIMESTAMPTZ     NOT NULL,
+    id              BIGSERIAL       NOT NULL,
+    height          BIGINT          NOT NULL,
+    event_id        BIGINT          NOT NULL,
+    pool            VARCHAR         NOT NULL,
+    asset_amount    BIGINT          NOT NULL,
+    rune_amount     BIGINT          NOT NULL,
+    pool_deduct     BIGINT          NOT NULL,
+    PRIMARY KEY (id, time)
+);
+CREATE INDEX tx_fees_event_id_idx ON tx_fees (event_id);
+
+SELECT create_hypertable('tx_fees', 'time');
+
+-- +migrate Down
+
+DROP TABLE tx_fees;
@@ -0,0 +1,29 @@
+-- +migrate Up
+
+CREATE TABLE double_swaps (
+    time                TIMESTAMPTZ     NOT NULL,
+    tx_hash             VARCHAR         NOT NULL,
+    first_event_id      BIGINT          NOT NULL,
+    second_event_id     BIGINT          NOT NULL,
+    rune_amount         BIGINT          NOT NULL,
+    PRIMARY KEY (first_event_id, time)
+);
+CREATE INDEX double_swaps_second_event_id_idx ON double_swaps (second_event_id);
+
+SELECT create_hypertable('double_swaps', 'time');
+
+-- Link the legs of the double swaps ingested so far. The second leg has no type
+-- and spends the rune received by the first leg.
+INSERT INTO double_swaps (time, tx_hash, first_event_id, second_event_id, rune_amount)
+SELECT first.time, first_tx.tx_hash, first.id, second.id, COALESCE(SUM(coins.amount), 0)
+FROM events first
+JOIN txs first_tx ON first_tx.event_id = first.id AND first_tx.direction = 'in'
+JOIN txs second_tx ON second_tx.tx_hash = first_tx.tx_hash AND second_tx.direction = 'in' AND second_tx.event_id > first.id
+JOIN events second ON second.id = second_tx.event_id AND second.type = ''
+LEFT JOIN coins ON coins.event_id = second.id AND coins.tx_hash = second_tx.tx_hash AND coins.ticker = 'RUNE'
+WHERE first.type = 'doubleSwap'
+GROUP BY first.time, first_tx.tx_hash, first.id, second.id;
+
+-- +migrate Down
+
+DROP TABLE double_swaps;
@@ -0,0 +1,9 @@
+-- +migrate Up
+
+-- Number of outbound txs required to complete an event. Zero means the event
+-- is completed by its first outbound.
+ALTER TABLE events ADD COLUMN expected_outbounds SMALLINT NOT NULL DEFAULT 0;
+
+-- +migrate Down
+
+ALTER TABLE events DROP COLUMN expected_outbounds;
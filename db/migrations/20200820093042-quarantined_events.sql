@@ -0,0 +1,15 @@
+-- +migrate Up
+
+CREATE TABLE quarantined_events (
+    id              BIGSERIAL       PRIMARY KEY,
+    time            TIMESTAMPTZ     NOT NULL,
+    height          BIGINT          NOT NULL,
+    type            VARCHAR         NOT NULL,
+    attributes      JSONB           NOT NULL,
+    reason          VARCHAR         NOT NULL
+);
+CREATE INDEX quarantined_events_height_idx ON quarantined_events (height);
+
+-- +migrate Down
+
+DROP TABLE quarantined_events;
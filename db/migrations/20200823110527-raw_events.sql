@@ -0,0 +1,18 @@
+-- +migrate Up
+
+-- Shared by all versions of derived tables, see the derived_schema migration.
+CREATE TABLE IF NOT EXISTS public.raw_events (
+    height          BIGINT          NOT NULL,
+    time            TIMESTAMPTZ     NOT NULL,
+    stage           VARCHAR         NOT NULL,
+    tx_index        INTEGER         NOT NULL,
+    event_index     INTEGER         NOT NULL,
+    type            VARCHAR         NOT NULL,
+    attributes      JSONB           NOT NULL,
+    PRIMARY KEY (height, stage, tx_index, event_index)
+);
+CREATE INDEX IF NOT EXISTS raw_events_type_idx ON public.raw_events (type, height);
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS public.raw_events;
@@ -0,0 +1,16 @@
+-- +migrate Up
+
+-- The migrations run again in the schema of each new version of derived tables.
+-- The tables which aren't derived from events are shared by all versions, so
+-- they're created in public schema explicitly and only once.
+CREATE TABLE IF NOT EXISTS public.derived_schema (
+    name            VARCHAR         NOT NULL,
+    version         INTEGER         NOT NULL,
+    updated_at      TIMESTAMPTZ     NOT NULL
+);
+INSERT INTO public.derived_schema (name, version, updated_at)
+SELECT 'public', 0, NOW() WHERE NOT EXISTS (SELECT 1 FROM public.derived_schema);
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS public.derived_schema;
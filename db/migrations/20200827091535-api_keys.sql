@@ -0,0 +1,22 @@
+-- +migrate Up
+
+-- Shared by all versions of derived tables, see the derived_schema migration.
+-- The keys are stored as the hex encoded SHA-256 hash of the key.
+CREATE TABLE IF NOT EXISTS public.api_keys (
+    key_hash        VARCHAR         PRIMARY KEY,
+    name            VARCHAR         NOT NULL,
+    tier            VARCHAR         NOT NULL,
+    created_at      TIMESTAMPTZ     NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS public.api_key_usage (
+    key_hash        VARCHAR         NOT NULL,
+    day             DATE            NOT NULL,
+    requests        BIGINT          NOT NULL,
+    PRIMARY KEY (key_hash, day)
+);
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS public.api_key_usage;
+DROP TABLE IF EXISTS public.api_keys;
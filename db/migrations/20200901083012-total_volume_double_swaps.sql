@@ -0,0 +1,67 @@
+-- +migrate Up
+
+-- Continuous aggregates can't query double_swaps, so the double swaps are counted once
+-- by their first leg, typed 'doubleSwap', while the second leg has no type.
+DROP VIEW total_volume_changes_5_min CASCADE;
+DROP VIEW total_volume_changes_hourly CASCADE;
+DROP VIEW total_volume_changes_daily CASCADE;
+
+CREATE VIEW total_volume_changes_5_min WITH
+(timescaledb.continuous, timescaledb.refresh_lag = "0", timescaledb.refresh_interval = '10 min')
+AS
+SELECT time_bucket('5 minute', time) AS time,
+    SUM(CASE WHEN rune_amount > 0 AND event_type IN ('swap', 'doubleSwap') THEN rune_amount ELSE 0 END) AS buy_volume,
+    SUM(CASE WHEN rune_amount < 0 AND event_type IN ('swap', 'doubleSwap') THEN -rune_amount ELSE 0 END) AS sell_volume
+FROM pools_history
+GROUP BY time_bucket('5 minute', time);
+
+CREATE VIEW total_volume_changes_hourly WITH
+(timescaledb.continuous, timescaledb.refresh_lag = "0", timescaledb.refresh_interval = '10 min')
+AS
+SELECT time_bucket('1 hour', time) AS time,
+    SUM(CASE WHEN rune_amount > 0 AND event_type IN ('swap', 'doubleSwap') THEN rune_amount ELSE 0 END) AS buy_volume,
+    SUM(CASE WHEN rune_amount < 0 AND event_type IN ('swap', 'doubleSwap') THEN -rune_amount ELSE 0 END) AS sell_volume
+FROM pools_history
+GROUP BY time_bucket('1 hour', time);
+
+CREATE VIEW total_volume_changes_daily WITH
+(timescaledb.continuous, timescaledb.refresh_lag = "0", timescaledb.refresh_interval = '10 min')
+AS
+SELECT time_bucket('1 day', time) AS time,
+    SUM(CASE WHEN rune_amount > 0 AND event_type IN ('swap', 'doubleSwap') THEN rune_amount ELSE 0 END) AS buy_volume,
+    SUM(CASE WHEN rune_amount < 0 AND event_type IN ('swap', 'doubleSwap') THEN -rune_amount ELSE 0 END) AS sell_volume
+FROM pools_history
+GROUP BY time_bucket('1 day', time);
+
+-- +migrate Down
+
+DROP VIEW total_volume_changes_5_min CASCADE;
+DROP VIEW total_volume_changes_hourly CASCADE;
+DROP VIEW total_volume_changes_daily CASCADE;
+
+CREATE VIEW total_volume_changes_5_min WITH
+(timescaledb.continuous, timescaledb.refresh_lag = "0", timescaledb.refresh_interval = '10 min')
+AS
+SELECT time_bucket('5 minute', time) AS time,
+    SUM(CASE WHEN rune_amount > 0 AND event_type = 'swap' THEN rune_amount ELSE 0 END) AS buy_volume,
+    SUM(CASE WHEN rune_amount < 0 AND event_type = 'swap' THEN -rune_amount ELSE 0 END) AS sell_volume
+FROM pools_history
+GROUP BY time_bucket('5 minute', time);
+
+CREATE VIEW total_volume_changes_hourly WITH
+(timescaledb.continuous, timescaledb.refresh_lag = "0", timescaledb.refresh_interval = '10 min')
+AS
+SELECT time_bucket('1 hour', time) AS time,
+    SUM(CASE WHEN rune_amount > 0 AND event_type = 'swap' THEN rune_amount ELSE 0 END) AS buy_volume,
+    SUM(CASE WHEN rune_amount < 0 AND event_type = 'swap' THEN -rune_amount ELSE 0 END) AS sell_volume
+FROM pools_history
+GROUP BY time_bucket('1 hour', time);
+
+CREATE VIEW total_volume_changes_daily WITH
+(timescaledb.continuous, timescaledb.refresh_lag = "0", timescaledb.refresh_interval = '10 min')
+AS
+SELECT time_bucket('1 day', time) AS time,
+    SUM(CASE WHEN rune_amount > 0 AND event_type = 'swap' THEN rune_amount ELSE 0 END) AS buy_volume,
+    SUM(CASE WHEN rune_amount < 0 AND event_type = 'swap' THEN -rune_amount ELSE 0 END) AS sell_volume
+FROM pools_history
+GROUP BY time_bucket('1 day', time);
@@ -0,0 +1,9 @@
+-- +migrate Up
+
+-- The pending events are counted after each block and listed oldest first, so
+-- they are indexed apart from the completed ones.
+CREATE INDEX events_pending_idx ON events (time, id) WHERE status = 'Pending';
+
+-- +migrate Down
+
+DROP INDEX events_pending_idx;
@@ -25,11 +25,13 @@ require (
 	github.com/openlyinc/pointy v1.1.2
 	github.com/patrickmn/go-cache v2.1.0+incompatible
 	github.com/pkg/errors v0.9.1
+	github.com/prometheus/client_golang v1.5.1
 	github.com/rs/zerolog v1.17.2
 	github.com/rubenv/sql-migrate v0.0.0-20191116071645-ce2300be8dc8
 	github.com/spf13/pflag v1.0.5
 	github.com/spf13/viper v1.6.3
 	github.com/stumble/gorocksdb v0.0.3 // indirect
+	github.com/tendermint/go-amino v0.15.1
 	github.com/tendermint/tendermint v0.33.4
 	github.com/yhat/wsutil v0.0.0-20170731153501-1d66fa95c997
 	github.com/ziflex/lecho/v2 v2.0.0
@@ -20,6 +20,18 @@ type Configuration struct {
 	ThorChain       ThorChainConfiguration `json:"thorchain" mapstructure:"thorchain"`
 	LogLevel        string                 `json:"log_level" mapstructure:"log_level"`
 	NodeProxy       NodeProxyConfiguration `json:"node_proxy" mapstructure:"node_proxy"`
+	PriceFeed       PriceFeedConfiguration `json:"price_feed" mapstructure:"price_feed"`
+	// QueryTimeout is the deadline of the api requests. QueryTimeouts overrides
+	// it for the routes with the given paths, e.g. "/v1/history/pools".
+	QueryTimeout  time.Duration              `json:"query_timeout" mapstructure:"query_timeout"`
+	QueryTimeouts map[string]time.Duration   `json:"query_timeouts" mapstructure:"query_timeouts"`
+	ResponseCache ResponseCacheConfiguration `json:"response_cache" mapstructure:"response_cache"`
+	APIKeys       APIKeysConfiguration       `json:"api_keys" mapstructure:"api_keys"`
+	RateLimiter   RateLimiterConfiguration   `json:"rate_limiter" mapstructure:"rate_limiter"`
+	Admin         AdminConfiguration         `json:"admin" mapstructure:"admin"`
+	// TrustedProxies are the ips or CIDR ranges of the proxies whose
+	// X-Forwarded-For and X-Real-IP headers tell the ip of the clients.
+	TrustedProxies []string `json:"trusted_proxies" mapstructure:"trusted_proxies"`
 }
 
 type TimeScaleConfiguration struct {
@@ -44,32 +56,285 @@ type ThorChainConfiguration struct {
 	ProxiedWhitelistedEndpoints []string      `json:"proxied_whitelisted_endpoints" mapstructure:"proxied_whitelisted_endpoints"`
 	CacheTTL                    time.Duration `json:"cache_ttl" mapstructure:"cache_ttl"`
 	CacheCleanup                time.Duration `json:"cache_cleanup" mapstructure:"cache_cleanup"`
+	ReserveMaxBlockAge          time.Duration `json:"reserve_max_block_age" mapstructure:"reserve_max_block_age"`
+	StuckTxThreshold            time.Duration `json:"stuck_tx_threshold" mapstructure:"stuck_tx_threshold"`
+	PendingMaxBlockAge          time.Duration `json:"pending_max_block_age" mapstructure:"pending_max_block_age"`
+	// Hosts and RPCHosts are the addresses of additional nodes. Requests are routed
+	// to the healthiest node and fail over to the others.
+	Hosts               []string      `json:"hosts" mapstructure:"hosts"`
+	RPCHosts            []string      `json:"rpc_hosts" mapstructure:"rpc_hosts"`
+	HealthCheckInterval time.Duration `json:"health_check_interval" mapstructure:"health_check_interval"`
+	// MaxHeightLag is the number of blocks a node can be behind the highest one
+	// before it's refused.
+	MaxHeightLag int64 `json:"max_height_lag" mapstructure:"max_height_lag"`
+	// MaxRetries is the number of times a request failing for a transient reason
+	// is retried with a backoff doubling from RetryBackoff up to RetryMaxBackoff.
+	MaxRetries      int           `json:"max_retries" mapstructure:"max_retries"`
+	RetryBackoff    time.Duration `json:"retry_backoff" mapstructure:"retry_backoff"`
+	RetryMaxBackoff time.Duration `json:"retry_max_backoff" mapstructure:"retry_max_backoff"`
+	// A node isn't called for BreakerCooldown after BreakerThreshold consecutive failures.
+	BreakerThreshold int           `json:"breaker_threshold" mapstructure:"breaker_threshold"`
+	BreakerCooldown  time.Duration `json:"breaker_cooldown" mapstructure:"breaker_cooldown"`
+	// CacheTTLs overrides CacheTTL for the endpoints starting with the given
+	// paths, e.g. "constants" or "pool".
+	CacheTTLs map[string]time.Duration `json:"cache_ttls" mapstructure:"cache_ttls"`
+	// CacheStaleTTL is how long after expiry a cached response is still served
+	// while it's refreshed in background.
+	CacheStaleTTL time.Duration `json:"cache_stale_ttl" mapstructure:"cache_stale_ttl"`
+}
+
+// AllHosts returns the addresses of all the thorchain nodes, Host first.
+func (cfg ThorChainConfiguration) AllHosts() []string {
+	return mergeHosts(cfg.Host, cfg.Hosts)
+}
+
+// AllRPCHosts returns the addresses of all the tendermint rpc nodes, RPCHost first.
+func (cfg ThorChainConfiguration) AllRPCHosts() []string {
+	return mergeHosts(cfg.RPCHost, cfg.RPCHosts)
+}
+
+func mergeHosts(host string, hosts []string) []string {
+	var all []string
+	seen := map[string]bool{}
+	for _, h := range append([]string{host}, hosts...) {
+		if h == "" || seen[h] {
+			continue
+		}
+		seen[h] = true
+		all = append(all, h)
+	}
+	return all
 }
 
 type NodeProxy struct {
-	Chain         string `json:"chain" mapstructure:"chain"`
-	Target        string `json:"target" mapstructure:"target"`
-	WebsocketPath string `json:"websocket_path" mapstructure:"websocket_path"`
+	Chain string `json:"chain" mapstructure:"chain"`
+	// Target is the url of the node. The requests are balanced between it and
+	// the nodes of Targets.
+	Target        string   `json:"target" mapstructure:"target"`
+	Targets       []string `json:"targets" mapstructure:"targets"`
+	WebsocketPath string   `json:"websocket_path" mapstructure:"websocket_path"`
+	// Balancing is either "round_robin" or "least_connections".
+	Balancing string `json:"balancing" mapstructure:"balancing"`
+	// HealthCheckPath is requested to check the health of the nodes, which must
+	// respond with a 2xx status. The nodes are only dialed if it's empty.
+	HealthCheckPath string `json:"health_check_path" mapstructure:"health_check_path"`
+	// AllowedPaths and AllowedMethods are the paths and JSON-RPC methods
+	// proxied to the nodes, all if empty. Patterns ending with "*" match the
+	// values starting with the rest of them.
+	AllowedPaths   []string `json:"allowed_paths" mapstructure:"allowed_paths"`
+	AllowedMethods []string `json:"allowed_methods" mapstructure:"allowed_methods"`
+	// DeniedMethods are refused along with the DeniedMethods of the
+	// NodeProxyConfiguration.
+	DeniedMethods []string `json:"denied_methods" mapstructure:"denied_methods"`
+	// MaxBodySize overrides the MaxBodySize of the NodeProxyConfiguration if
+	// positive.
+	MaxBodySize int64 `json:"max_body_size" mapstructure:"max_body_size"`
+	// CacheRules are the requests whose responses are cached. The first
+	// matching rule applies.
+	CacheRules []NodeProxyCacheRule `json:"cache_rules" mapstructure:"cache_rules"`
+}
+
+// NodeProxyCacheRule caches the responses of the requests for finalized data,
+// e.g. a block by height or a tx by hash.
+type NodeProxyCacheRule struct {
+	// Path and Method are patterns of the path and JSON-RPC method of the
+	// requests, matching all of them if empty.
+	Path   string `json:"path" mapstructure:"path"`
+	Method string `json:"method" mapstructure:"method"`
+	// Param is the param identifying the finalized data, e.g. the height or
+	// hash of a block: the name of a query param or of a field of the JSON-RPC
+	// params, or the position of a JSON-RPC param. Requests without a concrete
+	// value of it aren't cached.
+	Param string        `json:"param" mapstructure:"param"`
+	TTL   time.Duration `json:"ttl" mapstructure:"ttl"`
+}
+
+// AllTargets returns the urls of all the nodes of the chain, Target first.
+func (n NodeProxy) AllTargets() []string {
+	return mergeHosts(n.Target, n.Targets)
 }
 
 type NodeProxyConfiguration struct {
-	RateLimit  float64     `json:"rate_limit" mapstructure:"rate_limit"`
-	BurstLimit int         `json:"burst_limit" mapstructure:"burst_limit"`
-	FullNodes  []NodeProxy `json:"full_nodes" mapstructure:"full_nodes"`
+	RateLimit           float64       `json:"rate_limit" mapstructure:"rate_limit"`
+	BurstLimit          int           `json:"burst_limit" mapstructure:"burst_limit"`
+	FullNodes           []NodeProxy   `json:"full_nodes" mapstructure:"full_nodes"`
+	HealthCheckInterval time.Duration `json:"health_check_interval" mapstructure:"health_check_interval"`
+	HealthCheckTimeout  time.Duration `json:"health_check_timeout" mapstructure:"health_check_timeout"`
+	// A node isn't used for EjectionDuration after EjectionThreshold
+	// consecutive failed requests.
+	EjectionThreshold int           `json:"ejection_threshold" mapstructure:"ejection_threshold"`
+	EjectionDuration  time.Duration `json:"ejection_duration" mapstructure:"ejection_duration"`
+	// DeniedMethods are the JSON-RPC methods refused for all the chains, e.g.
+	// the methods sending transactions or administrating the nodes.
+	DeniedMethods []string `json:"denied_methods" mapstructure:"denied_methods"`
+	// MaxBodySize is the maximum size in bytes of the request bodies.
+	MaxBodySize int64 `json:"max_body_size" mapstructure:"max_body_size"`
+	// Timeout is the deadline of the proxied requests instead of
+	// QueryTimeout. Zero means no deadline, e.g. for websocket connections.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+	// CacheMaxSize is the maximum size in bytes of the cached responses of
+	// all the chains.
+	CacheMaxSize int64 `json:"cache_max_size" mapstructure:"cache_max_size"`
+}
+
+type ResponseCacheConfiguration struct {
+	// Routes are the paths of the routes whose responses are cached until the
+	// scanner processes a new block, e.g. "/v1/pools/detail".
+	Routes []string `json:"routes" mapstructure:"routes"`
+	// MaxAge is the max-age of the Cache-Control header of the cached routes.
+	MaxAge     time.Duration `json:"max_age" mapstructure:"max_age"`
+	MaxEntries int           `json:"max_entries" mapstructure:"max_entries"`
+}
+
+type APIKeysConfiguration struct {
+	// Enabled applies the limits of the tiers to all the api routes but
+	// ExemptRoutes.
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+	// Header is the request header carrying the api key.
+	Header string             `json:"header" mapstructure:"header"`
+	Tiers  map[string]APITier `json:"tiers" mapstructure:"tiers"`
+	// AnonymousTier is the tier of the requests without api key, which are
+	// limited per ip. Requests without api key are refused if it's empty.
+	AnonymousTier string `json:"anonymous_tier" mapstructure:"anonymous_tier"`
+	// Keys are the api keys in addition to the ones of the api_keys table.
+	Keys         []APIKey `json:"keys" mapstructure:"keys"`
+	ExemptRoutes []string `json:"exempt_routes" mapstructure:"exempt_routes"`
+	// ReloadInterval is how often the api keys are reloaded from the database.
+	ReloadInterval time.Duration `json:"reload_interval" mapstructure:"reload_interval"`
+	// UsageFlushInterval is how often the usage of the api keys is saved.
+	UsageFlushInterval time.Duration `json:"usage_flush_interval" mapstructure:"usage_flush_interval"`
+}
+
+// APITier is the limits of a class of api clients. RateLimit is the number of
+// requests per second with bursts of up to BurstLimit requests and DailyQuota
+// is the number of requests per UTC day, unlimited if zero.
+type APITier struct {
+	RateLimit  float64 `json:"rate_limit" mapstructure:"rate_limit"`
+	BurstLimit int     `json:"burst_limit" mapstructure:"burst_limit"`
+	DailyQuota int64   `json:"daily_quota" mapstructure:"daily_quota"`
+}
+
+type APIKey struct {
+	Key  string `json:"key" mapstructure:"key"`
+	Name string `json:"name" mapstructure:"name"`
+	Tier string `json:"tier" mapstructure:"tier"`
+}
+
+type AdminConfiguration struct {
+	// Key is the bearer token of the requests to the admin routes. The admin
+	// routes are disabled if it's empty.
+	Key string `json:"key" mapstructure:"key"`
+	// Routes are the paths of the admin routes, e.g. "/v1/rebuild".
+	Routes []string `json:"routes" mapstructure:"routes"`
+}
+
+type RateLimiterConfiguration struct {
+	// Backend keeps the request counters of the rate limits: "memory" to keep
+	// them in this process or "redis" to share them between replicas.
+	Backend string             `json:"backend" mapstructure:"backend"`
+	Redis   RedisConfiguration `json:"redis" mapstructure:"redis"`
+}
+
+type RedisConfiguration struct {
+	Addr     string `json:"addr" mapstructure:"addr"`
+	Password string `json:"password" mapstructure:"password"`
+	DB       int    `json:"db" mapstructure:"db"`
+	// Prefix is prepended to all the keys.
+	Prefix  string        `json:"prefix" mapstructure:"prefix"`
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+	// PoolSize is the maximum number of open connections.
+	PoolSize int `json:"pool_size" mapstructure:"pool_size"`
+}
+
+type PriceFeedConfiguration struct {
+	// Source is either the url of a http(s) endpoint or the path of a local file
+	// serving a JSON object of asset USD prices. Deviation detection is disabled if empty.
+	Source      string        `json:"source" mapstructure:"source"`
+	ReadTimeout time.Duration `json:"read_timeout" mapstructure:"read_timeout"`
+	// Threshold is the relative deviation of pool price from the reference price
+	// which raises an alert.
+	Threshold float64 `json:"threshold" mapstructure:"threshold"`
+	// MaxBlockAge is the maximum age of blocks to be checked for deviation so
+	// historical blocks are skipped while syncing.
+	MaxBlockAge time.Duration `json:"max_block_age" mapstructure:"max_block_age"`
 }
 
 func applyDefaultConfig() {
 	viper.SetDefault("read_timeout", "30s")
 	viper.SetDefault("write_timeout", "30s")
+	viper.SetDefault("query_timeout", "10s")
+	viper.SetDefault("response_cache.routes", []string{
+		"/v1/assets",
+		"/v1/network",
+		"/v1/pools",
+		"/v1/pools/detail",
+		"/v1/stakers",
+		"/v1/stakers/:address",
+		"/v1/stakers/:address/pools",
+		"/v1/stats",
+		"/v1/txs",
+	})
+	viper.SetDefault("response_cache.max_age", "5s")
+	viper.SetDefault("api_keys.header", "X-API-Key")
+	viper.SetDefault("api_keys.tiers", map[string]interface{}{
+		"anonymous": map[string]interface{}{"rate_limit": 5, "burst_limit": 10, "daily_quota": 20000},
+		"standard":  map[string]interface{}{"rate_limit": 20, "burst_limit": 40, "daily_quota": 500000},
+	})
+	viper.SetDefault("api_keys.anonymous_tier", "anonymous")
+	viper.SetDefault("api_keys.exempt_routes", []string{"/v1/health", "/metrics"})
+	viper.SetDefault("api_keys.reload_interval", "1m")
+	viper.SetDefault("api_keys.usage_flush_interval", "10s")
+	viper.SetDefault("admin.routes", []string{"/v1/rebuild", "/v1/events/quarantine/reprocess", "/metrics"})
+	viper.SetDefault("rate_limiter.backend", "memory")
+	viper.SetDefault("rate_limiter.redis.prefix", "midgard:ratelimit:")
+	viper.SetDefault("rate_limiter.redis.timeout", "100ms")
+	viper.SetDefault("rate_limiter.redis.pool_size", 10)
+	viper.SetDefault("response_cache.max_entries", 10000)
 	viper.SetDefault("thorchain.read_timeout", "10s")
 	viper.SetDefault("thorchain.no_events_backoff", "5s")
 	viper.SetDefault("thorchain.cache_ttl", "5s")
 	viper.SetDefault("thorchain.cache_cleanup", "10s")
 	viper.SetDefault("thorchain.scan_start_pos", 1)
+	viper.SetDefault("thorchain.reserve_max_block_age", "1m")
+	viper.SetDefault("thorchain.stuck_tx_threshold", "15m")
+	viper.SetDefault("thorchain.pending_max_block_age", "1m")
+	viper.SetDefault("thorchain.health_check_interval", "10s")
+	viper.SetDefault("thorchain.max_height_lag", 10)
+	viper.SetDefault("thorchain.max_retries", 3)
+	viper.SetDefault("thorchain.retry_backoff", "100ms")
+	viper.SetDefault("thorchain.retry_max_backoff", "2s")
+	viper.SetDefault("thorchain.breaker_threshold", 5)
+	viper.SetDefault("thorchain.breaker_cooldown", "30s")
+	viper.SetDefault("thorchain.cache_ttls", map[string]string{
+		"constants":    "1h",
+		"mimir":        "1m",
+		"nodeaccounts": "1m",
+	})
+	viper.SetDefault("thorchain.cache_stale_ttl", "1m")
 	viper.SetDefault("timescale.max_connections", 25)
 	viper.SetDefault("timescale.connection_max_lifetime", time.Minute*5)
 	viper.SetDefault("node_proxy.rate_limit", 3)
 	viper.SetDefault("node_proxy.burst_limit", 3)
+	viper.SetDefault("node_proxy.health_check_interval", "10s")
+	viper.SetDefault("node_proxy.health_check_timeout", "2s")
+	viper.SetDefault("node_proxy.ejection_threshold", 3)
+	viper.SetDefault("node_proxy.ejection_duration", "30s")
+	viper.SetDefault("node_proxy.denied_methods", []string{
+		// Tendermint
+		"broadcast_*", "unsafe_*", "dial_*",
+		// Ethereum
+		"admin_*", "debug_*", "miner_*", "personal_*", "txpool_*",
+		"eth_sendTransaction", "eth_sendRawTransaction", "eth_sign*",
+		// Bitcoin
+		"sendrawtransaction", "sendtoaddress", "sendmany", "stop",
+		"importprivkey", "dumpprivkey", "dumpwallet", "walletpassphrase",
+		"generate*", "addnode", "setban",
+	})
+	viper.SetDefault("node_proxy.max_body_size", 1<<20)
+	viper.SetDefault("node_proxy.cache_max_size", 64<<20)
+	viper.SetDefault("price_feed.read_timeout", "10s")
+	viper.SetDefault("price_feed.threshold", 0.05)
+	viper.SetDefault("price_feed.max_block_age", "1m")
 }
 
 func LoadConfiguration(file string) (*Configuration, error) {
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// APIKey identifies a client of the api. The limits of the client are the ones
+// of its tier. Only the hex encoded SHA-256 hash of the key is kept.
+type APIKey struct {
+	KeyHash string
+	Name    string
+	Tier    string
+}
+
+// APIKeyUsage is the number of requests made with an api key during a day.
+type APIKeyUsage struct {
+	KeyHash  string
+	Day      time.Time
+	Requests int64
+}
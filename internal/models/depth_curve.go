@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// DepthCurve contains the expected slip and fee of trades with different
+// sizes on a pool alongside the daily history of its usable liquidity.
+type DepthCurve struct {
+	AssetDepth int64
+	RuneDepth  int64
+	Price      float64
+	Points     []DepthCurvePoint
+	History    []SlipDepth
+}
+
+// DepthCurvePoint contains the expected result of a trade with a specific size.
+// Since both sides of the pool have the same value, the slip and fee of a trade
+// only depend on its value and are the same for buys and sells.
+type DepthCurvePoint struct {
+	TradeSize int64 // In rune
+	Slip      float64
+	Fee       int64 // In rune
+	Output    int64 // In rune
+}
+
+// SlipDepth contains the size of trades which would cause a specific slip on
+// the pool at the end of a time bucket.
+type SlipDepth struct {
+	Time       time.Time
+	AssetDepth int64
+	RuneDepth  int64
+	AssetSize  int64
+	RuneSize   int64
+}
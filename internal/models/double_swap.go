@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"gitlab.com/thorchain/midgard/internal/common"
+)
+
+// DoubleSwap links the two legs of a swap between two non-rune assets. The first
+// leg swaps the inbound asset to rune which the second leg swaps to the target asset.
+type DoubleSwap struct {
+	Time          time.Time
+	TxID          common.TxID
+	FirstEventID  int64
+	SecondEventID int64
+	// RuneAmount is the intermediate rune received by the first leg and spent
+	// by the second one.
+	RuneAmount int64
+}
+
+// SwapLeg contains the details of a single leg of a double swap.
+type SwapLeg struct {
+	Pool common.Asset
+	Fee  uint64
+	Slip float64
+}
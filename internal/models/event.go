@@ -26,4 +26,7 @@ type Event struct {
 	InTx   common.Tx
 	OutTxs common.Txs
 	Fee    common.Fee `json:"fee"`
+	// ExpectedOutbounds is the number of outbound txs required to complete the
+	// event. Zero means the event is completed by its first outbound.
+	ExpectedOutbounds int64 `json:"expected_outbounds" db:"expected_outbounds"`
 }
@@ -6,6 +6,7 @@ import (
 
 type EventReward struct {
 	Event
+	BondReward  int64        `json:"bond_reward" mapstructure:"bond_reward"`
 	PoolRewards []PoolAmount `json:"pool_rewards" mapstructure:"pool_rewards"`
 }
 
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"gitlab.com/thorchain/midgard/internal/common"
+)
+
+// GasChanges contains the gas spent by outbound transactions of a chain and the
+// rune paid by the network to reimburse it during a specific time bucket.
+type GasChanges struct {
+	Time           time.Time
+	Chain          common.Chain
+	GasUsed        int64 // In chain's gas asset
+	GasUsedInRune  int64
+	GasReplenished int64 // In rune
+	OutboundCount  int64
+	AverageGas     float64 // GasUsed / OutboundCount
+	Coverage       float64 // GasReplenished / GasUsedInRune
+}
@@ -5,4 +5,8 @@ type HealthStatus struct {
 	Database      bool  `json:"database"`
 	ScannerHeight int64 `json:"scannerHeight"`
 	CatchingUp    bool  `json:"catching_up"`
+	PendingTxs    int64 `json:"pendingTxs"`
+	StuckTxs      int64 `json:"stuckTxs"`
+	// Rebuild is the status of the latest rebuild of the derived tables if any.
+	Rebuild *RebuildStatus `json:"rebuild,omitempty"`
 }
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"gitlab.com/thorchain/midgard/internal/common"
+)
+
+// PendingTx is an event which is still waiting for its outbound tx.
+type PendingTx struct {
+	EventID int64
+	Type    string
+	Height  int64
+	Time    time.Time
+	Pool    common.Asset
+	In      TxData
+	// Outbounds is the number of outbound txs observed so far out of ExpectedOutbounds.
+	Outbounds         int64
+	ExpectedOutbounds int64
+	// OutboundChain is the chain on which the outbound tx is expected.
+	OutboundChain common.Chain
+	Age           time.Duration
+	// Stuck is true if the event has been pending for longer than the stuck threshold.
+	Stuck bool
+}
@@ -19,6 +19,8 @@ type PoolBasics struct {
 	AssetAdded     int64
 	RuneAdded      int64
 	Reward         int64
+	AssetSlashed   int64
+	RuneSlashed    int64
 	Units          int64
 	Status         PoolStatus
 	BuyVolume      int64
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"gitlab.com/thorchain/midgard/internal/common"
+)
+
+// PoolStaker contains the units of a staker in a pool and its share of the pool.
+type PoolStaker struct {
+	Address common.Address
+	Units   int64
+	Share   float64
+}
+
+// PoolStakerChange contains the change of the units of a staker in a pool summed
+// over the period ending at a point of a history.
+type PoolStakerChange struct {
+	Address common.Address
+	Point   int64
+	Units   int64
+}
+
+// PoolConcentration contains the metrics of a pool ownership concentration at a specific time.
+type PoolConcentration struct {
+	Time         time.Time
+	StakersCount int64
+	Units        int64
+	Gini         float64
+	Top10Share   float64
+	Herfindahl   float64
+}
+
+// PoolStakers contains a page of a pool stakers ranked by units alongside the
+// current concentration of the pool ownership.
+type PoolStakers struct {
+	Stakers       []PoolStaker
+	TotalCount    int64
+	Concentration PoolConcentration
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// PoolYieldChanges contains earnings of a specific pool during a specific
+// time bucket and the APY it would yield if it was repeated for a year.
+type PoolYieldChanges struct {
+	Time           time.Time
+	AssetDepth     int64
+	RuneDepth      int64
+	BuyFees        int64 // In asset
+	SellFees       int64 // In rune
+	Reward         int64
+	GasUsed        int64
+	GasReplenished int64
+	Price          float64
+	FeesEarned     int64 // In rune
+	PoolEarned     int64 // In rune
+	PoolAPY        float64
+}
+
+// PoolYield contains yield history of a pool alongside its trailing APYs.
+type PoolYield struct {
+	Intervals []PoolYieldChanges
+	APY7d     float64
+	APY30d    float64
+	APY90d    float64
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"gitlab.com/thorchain/midgard/internal/common"
+)
+
+// PriceDeviation represents the difference between the USD price implied by a pool
+// and the reference USD price of its asset at a specific block.
+type PriceDeviation struct {
+	Time           time.Time
+	Height         int64
+	Pool           common.Asset
+	PoolPrice      float64 // In USD
+	ReferencePrice float64 // In USD
+	Deviation      float64 // (PoolPrice - ReferencePrice) / ReferencePrice
+}
+
+// DeviationAlert represents a pool whose price has deviated more than the
+// threshold from the reference price since a specific time.
+type DeviationAlert struct {
+	PriceDeviation
+	Since time.Time
+}
+
+// PoolDeviation contains price deviation history of a pool and its current alert if any.
+type PoolDeviation struct {
+	History []PriceDeviation
+	Alert   *DeviationAlert
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// QuarantinedEvent is an event which could not be processed because its type
+// is unknown or its attributes don't match the schema of its type.
+type QuarantinedEvent struct {
+	ID         int64
+	Time       time.Time
+	Height     int64
+	Type       string
+	Attributes map[string]string
+	Reason     string
+}
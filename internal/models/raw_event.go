@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Stages of the block in which a raw event is emitted.
+const (
+	RawEventStageBegin = "begin"
+	RawEventStageTx    = "tx"
+	RawEventStageEnd   = "end"
+)
+
+// RawEvent is an event as emitted by THORChain before being decoded. Raw events
+// are archived so the derived data can be rebuilt without the node.
+type RawEvent struct {
+	Height int64
+	Time   time.Time
+	Stage  string
+	// TxIndex is the index of the tx within the block for tx events.
+	TxIndex    int
+	EventIndex int
+	Type       string
+	Attributes map[string]string
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// States of a rebuild of the derived tables.
+const (
+	RebuildStateRunning = "running"
+	RebuildStateDone    = "done"
+	RebuildStateFailed  = "failed"
+)
+
+// RebuildStatus contains the progress of the latest rebuild of the derived tables.
+type RebuildStatus struct {
+	State string `json:"state"`
+	// StartHeight is the first archived block, from which the replay starts.
+	StartHeight int64 `json:"startHeight"`
+	// Height is the last block replayed into the new tables.
+	Height       int64 `json:"height"`
+	TargetHeight int64 `json:"targetHeight"`
+	// Progress is the ratio of the blocks replayed from StartHeight to
+	// TargetHeight between 0 and 1.
+	Progress   float64    `json:"progress"`
+	StartedAt  time.Time  `json:"startedAt"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	// Quarantined is the number of events quarantined in the new tables.
+	Quarantined int64 `json:"quarantined"`
+}
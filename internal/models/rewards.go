@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// RewardsChanges contains rewards emitted from the reserve during a specific
+// time bucket.
+type RewardsChanges struct {
+	Time        time.Time
+	BondReward  int64
+	PoolRewards int64 // Sum of per-pool liquidity rewards, may be negative
+	TotalReward int64
+	Blocks      int64
+	// ExpectedEmission is the emission of the bucket blocks according to the
+	// emission schedule and the reserve balance. It's zero if the reserve
+	// balance of the bucket is not known.
+	ExpectedEmission int64
+}
+
+// Reserve contains balance of the protocol reserve at a specific block.
+type Reserve struct {
+	Time         time.Time
+	Height       int64
+	TotalReserve int64
+}
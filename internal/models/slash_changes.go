@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"gitlab.com/thorchain/midgard/internal/common"
+)
+
+// SlashChanges contains the amounts slashed from a pool during a specific time bucket.
+type SlashChanges struct {
+	Time        time.Time
+	Pool        common.Asset
+	AssetAmount int64
+	RuneAmount  int64
+	Count       int64
+}
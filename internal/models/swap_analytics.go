@@ -0,0 +1,52 @@
+package models
+
+import "gitlab.com/thorchain/midgard/internal/common"
+
+// SwapMetric specifies a measurable property of swaps.
+type SwapMetric string
+
+// SwapMetric options
+const (
+	SwapMetricTradeSize SwapMetric = "trade_size" // In rune
+	SwapMetricSlip      SwapMetric = "slip"
+	SwapMetricFee       SwapMetric = "fee" // In rune
+)
+
+// Distribution describes how a swap metric is distributed among swaps.
+type Distribution struct {
+	Average   float64
+	P50       float64
+	P90       float64
+	P99       float64
+	Histogram []HistogramBucket
+}
+
+// HistogramBucket contains number of swaps with a metric value in [From, To).
+// To is zero for the last unbounded bucket.
+type HistogramBucket struct {
+	From  float64
+	To    float64
+	Count int64
+}
+
+// SwapsStats contains the distribution of swap metrics.
+type SwapsStats struct {
+	SwapCount int64
+	TradeSize Distribution
+	Slip      Distribution
+	Fee       Distribution
+}
+
+// Swapper contains swap activity of an address.
+type Swapper struct {
+	Address   common.Address
+	SwapCount int64
+	Volume    int64 // In rune
+	Fees      int64 // In rune
+}
+
+// SwapAnalytics contains the swap metrics distribution alongside top swappers.
+type SwapAnalytics struct {
+	SwapsStats
+	TopSwappers []Swapper
+}
@@ -8,6 +8,10 @@ type Events struct {
 	Fee        uint64
 	Slip       float64
 	StakeUnits int64
+	Slash      common.Coins
+	// IntermediateRune and Legs are only set for double swaps.
+	IntermediateRune int64
+	Legs             []SwapLeg
 }
 
 type Options struct {
@@ -38,6 +42,7 @@ type TxDetails struct {
 	Gas     TxGas
 	Options Options
 	Events  Events
+	Fees    FeeBreakdown
 	Date    uint64
 	Height  uint64
 }
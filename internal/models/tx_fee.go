@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"gitlab.com/thorchain/midgard/internal/common"
+)
+
+// TxFee is the network fee charged from the outbound of an event.
+type TxFee struct {
+	Time        time.Time
+	Height      int64
+	EventID     int64
+	Pool        common.Asset
+	AssetAmount int64 // Fee charged in asset
+	RuneAmount  int64 // Fee charged in rune
+	PoolDeduct  int64 // Rune deducted from the pool to the reserve
+}
+
+// FeeBreakdown contains every fee paid by a tx. NetworkFee is charged from the
+// outbound to cover its gas.
+type FeeBreakdown struct {
+	NetworkFee       common.Coins
+	NetworkFeeInRune int64
+	LiquidityFee     uint64
+	Slip             float64
+}
+
+// FeeChanges contains the network fees charged from events of a specific type
+// and pool during a specific time bucket.
+type FeeChanges struct {
+	Time             time.Time
+	Pool             common.Asset
+	Type             string
+	AssetAmount      int64
+	RuneAmount       int64
+	PoolDeduct       int64
+	NetworkFeeInRune int64
+	Count            int64
+}
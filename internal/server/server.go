@@ -6,22 +6,24 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"net/http/httputil"
-	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/ziflex/lecho/v2"
 
-	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
 	"gitlab.com/thorchain/midgard/internal/config"
 	"gitlab.com/thorchain/midgard/internal/store/timescale"
 	"gitlab.com/thorchain/midgard/internal/usecase"
+	"gitlab.com/thorchain/midgard/pkg/clients/pricefeed"
+	"gitlab.com/thorchain/midgard/pkg/clients/redis"
 	"gitlab.com/thorchain/midgard/pkg/clients/thorchain"
 	httpdelivery "gitlab.com/thorchain/midgard/pkg/delivery/http"
 )
@@ -32,8 +34,10 @@ type Server struct {
 	srv             *http.Server
 	logger          zerolog.Logger
 	echoEngine      *echo.Echo
-	thorchainClient thorchain.Thorchain
+	thorchainClient *thorchain.Client
 	uc              *usecase.Usecase
+	apiKeys         *httpdelivery.APIKeyLimiter
+	proxy           *httpdelivery.ProxyHandler
 }
 
 func initLog(level string, pretty bool) zerolog.Logger {
@@ -75,8 +79,7 @@ func New(cfgFile *string) (*Server, error) {
 	}
 
 	// Setup Tendermint rpc client
-	tendermintAddr := fmt.Sprintf("%s://%s", cfg.ThorChain.Scheme, cfg.ThorChain.RPCHost)
-	tendermintClient, err := rpchttp.New(tendermintAddr, "/websocket")
+	tendermintClient, err := thorchain.NewTendermintClient(cfg.ThorChain)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create tendermint rpc client instance")
 	}
@@ -85,6 +88,18 @@ func New(cfgFile *string) (*Server, error) {
 	usecaseConf := &usecase.Config{
 		ScanInterval:         cfg.ThorChain.NoEventsBackoff,
 		UseThorchainBalances: true,
+		DeviationThreshold:   cfg.PriceFeed.Threshold,
+		DeviationMaxBlockAge: cfg.PriceFeed.MaxBlockAge,
+		ReserveMaxBlockAge:   cfg.ThorChain.ReserveMaxBlockAge,
+		StuckTxThreshold:     cfg.ThorChain.StuckTxThreshold,
+		PendingMaxBlockAge:   cfg.ThorChain.PendingMaxBlockAge,
+	}
+	if cfg.PriceFeed.Source != "" {
+		priceSource, err := pricefeed.NewJSONSource(cfg.PriceFeed)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create price feed instance")
+		}
+		usecaseConf.PriceSource = priceSource
 	}
 	uc, err := usecase.NewUsecase(thorchainClient, tendermintClient, tendermintBatch, timescale, usecaseConf)
 	if err != nil {
@@ -96,7 +111,17 @@ func New(cfgFile *string) (*Server, error) {
 	// Setup echo
 	echoEngine := echo.New()
 	echoEngine.Use(middleware.Recover())
-	proxy, err := httpdelivery.NewProxyHandler(cfg.NodeProxy, "/v1/nodes")
+	clientIP, err := httpdelivery.ClientIP(cfg.TrustedProxies)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create client ip middleware")
+	}
+	echoEngine.Use(clientIP)
+	limits, err := newLimiterStore(cfg.RateLimiter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create rate limiter store")
+	}
+	proxyLogger := log.With().Str("module", "node_proxy").Logger()
+	proxy, err := httpdelivery.NewProxyHandler(cfg.NodeProxy, "/v1/nodes", limits, proxyLogger)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create proxy")
 	}
@@ -105,6 +130,22 @@ func New(cfgFile *string) (*Server, error) {
 	// CORS default
 	// Allows requests from any origin wth GET, HEAD, PUT, POST or DELETE method.
 	echoEngine.Use(middleware.CORS())
+	echoEngine.Use(httpdelivery.AdminAuth(cfg.Admin))
+	var apiKeys *httpdelivery.APIKeyLimiter
+	if cfg.APIKeys.Enabled {
+		apiKeys, err = httpdelivery.NewAPIKeyLimiter(cfg.APIKeys, uc, limits, log.With().Str("module", "api_keys").Logger())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create api key limiter")
+		}
+		echoEngine.Use(apiKeys.Middleware)
+	}
+	echoEngine.Use(httpdelivery.ResponseCache(uc.GetScannerHeight, uc.GetDataGeneration, cfg.ResponseCache))
+	// The node proxy has its own timeout since it serves long lived websocket connections.
+	timeouts := map[string]time.Duration{proxy.Route(): cfg.NodeProxy.Timeout}
+	for route, timeout := range cfg.QueryTimeouts {
+		timeouts[route] = timeout
+	}
+	echoEngine.Use(httpdelivery.Timeout(cfg.QueryTimeout, timeouts))
 
 	logger := log.With().Str("module", "httpServer").Logger()
 
@@ -113,6 +154,11 @@ func New(cfgFile *string) (*Server, error) {
 
 	// Register handlers
 	httpdelivery.RegisterHandlers(echoEngine, h)
+	err = usecase.RegisterMetrics(prometheus.DefaultRegisterer)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to register usecase metrics")
+	}
+	echoEngine.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
 
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%v", cfg.ListenPort),
@@ -133,6 +179,8 @@ func New(cfgFile *string) (*Server, error) {
 		logger:          logger,
 		thorchainClient: thorchainClient,
 		uc:              uc,
+		apiKeys:         apiKeys,
+		proxy:           proxy,
 	}, nil
 }
 
@@ -152,7 +200,45 @@ func (s *Server) Stop() error {
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
 	defer cancel()
-	return s.echoEngine.Shutdown(ctx)
+	err := s.echoEngine.Shutdown(ctx)
+	s.proxy.Stop()
+	if s.apiKeys != nil {
+		// Save the usage of the last requests.
+		s.apiKeys.Stop()
+	}
+	return err
+}
+
+// newLimiterStore creates the store of the rate limit counters of the backend
+// of the configuration.
+func newLimiterStore(cfg config.RateLimiterConfiguration) (httpdelivery.LimiterStore, error) {
+	switch cfg.Backend {
+	case "", "memory":
+		return httpdelivery.NewMemoryLimiterStore(), nil
+	case "redis":
+		client, err := redis.NewClient(cfg.Redis)
+		if err != nil {
+			return nil, err
+		}
+		return httpdelivery.NewRedisLimiterStore(client, cfg.Redis.Prefix), nil
+	}
+	return nil, errors.Errorf("unknown rate limiter backend %s", cfg.Backend)
+}
+
+// Reindex rebuilds the data derived from the events between the given heights.
+// Only the store is created, so it runs without the node.
+func Reindex(cfgFile string, from, to int64) error {
+	cfg, err := config.LoadConfiguration(cfgFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to load chain service config")
+	}
+	initLog(cfg.LogLevel, false)
+
+	timescale, err := timescale.NewClient(cfg.TimeScale)
+	if err != nil {
+		return errors.Wrap(err, "failed to create timescale client instance")
+	}
+	return usecase.Reindex(timescale, from, to)
 }
 
 func (s *Server) Log() *zerolog.Logger {
@@ -174,45 +260,31 @@ func (s *Server) registerWhiteListedProxiedRoutes() {
 				// delete duplicate header
 				res.Header().Del("Access-Control-Allow-Origin")
 
-				var u *url.URL
-				var err error
 				// Handle endpoints without any path parameters
-				if len(endpointParts) == 1 {
-					u, err = url.Parse(s.cfg.ThorChain.Scheme + "://" + s.cfg.ThorChain.Host + "/thorchain/" + endpointParts[0])
-					if err != nil {
-						log.Error().Err(err).Msg("Failed to Parse url")
-						return err
-					}
-					// Handle endpoints with path parameters
-				} else {
+				target := "/" + endpointParts[0]
+				// Handle endpoints with path parameters
+				if len(endpointParts) > 1 {
 					reqUrlParts := strings.Split(req.URL.EscapedPath(), "/")
-					u, err = url.Parse(s.cfg.ThorChain.Scheme + "://" + s.cfg.ThorChain.Host + "/thorchain/" + endpointParts[0] + reqUrlParts[len(reqUrlParts)-1])
-					if err != nil {
-						log.Error().Err(err).Msg("Failed to Parse url")
-						return err
-					}
+					target += reqUrlParts[len(reqUrlParts)-1]
 				}
 
-				log.Info().Str("url", u.String()).Msg("Proxied url")
-				proxyHTTP(u).ServeHTTP(res, req)
+				log.Info().Str("path", target).Msg("Proxied path")
+				// The request is routed to the healthiest node and fails over
+				// to the others.
+				err := s.thorchainClient.Proxy(res, req, target)
+				if err != nil {
+					log.Error().Err(err).Str("path", target).Msg("Failed to proxy request")
+					if res.Committed {
+						return nil
+					}
+					return echo.NewHTTPError(http.StatusBadGateway, httpdelivery.GeneralErrorResponse{Error: "thorchain unavailable"})
+				}
 				return nil
 			}
 		})
 	}
 }
 
-func proxyHTTP(target *url.URL) http.Handler {
-	proxy := httputil.NewSingleHostReverseProxy(target)
-	proxy.Director = func(req *http.Request) {
-		req.Header.Add("X-Forwarded-Host", req.Host)
-		req.Header.Add("X-Origin-Host", target.Host)
-		req.URL.Scheme = target.Scheme
-		req.URL.Host = target.Host
-		req.URL.Path = target.Path
-	}
-	return proxy
-}
-
 func (s *Server) registerEchoWithLogger() {
 	l := lecho.New(s.logger)
 	s.echoEngine.Use(lecho.Middleware(lecho.Config{Logger: l}))
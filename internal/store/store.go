@@ -1,6 +1,7 @@
 package store
 
 import (
+	"context"
 	"time"
 
 	"gitlab.com/thorchain/midgard/internal/common"
@@ -20,32 +21,32 @@ type Store interface {
 	CreateRefundedEvent(record *models.Event, pool common.Asset) error
 	CreateSlashRecord(record *models.EventSlash) error
 	CreateErrataRecord(record *models.EventErrata) error
-	Ping() error
-	GetTxDetails(address common.Address, txID common.TxID, asset common.Asset, eventTypes []string, offset, limit int64) ([]models.TxDetails, int64, error)
-	GetPools() ([]common.Asset, error)
-	GetPool(asset common.Asset) (common.Asset, error)
-	GetAssetDepth(asset common.Asset) (uint64, error)
-	GetRuneDepth(asset common.Asset) (uint64, error)
-	GetPoolBasics(asset common.Asset) (models.PoolBasics, error)
-	GetPoolVolume(asset common.Asset, from, to time.Time) (int64, error)
-	GetPoolStatus(asset common.Asset) (models.PoolStatus, error)
-	GetDateCreated(asset common.Asset) (uint64, error)
-	GetTotalDepth() (uint64, error)
-	GetUsersCount(from, to *time.Time) (uint64, error)
-	GetTxsCount(from, to *time.Time) (uint64, error)
-	GetTotalVolume(from, to *time.Time) (uint64, error)
-	TotalStaked() (uint64, error)
-	PoolCount() (uint64, error)
-	TotalAssetBuys() (uint64, error)
-	TotalAssetSells() (uint64, error)
-	TotalStakeTx() (uint64, error)
-	TotalWithdrawTx() (uint64, error)
-	GetPoolSwapStats(asset common.Asset) (models.PoolSwapStats, error)
-	GetStakerAddresses() ([]common.Address, error)
-	GetStakerAddressDetails(address common.Address) (models.StakerAddressDetails, error)
-	GetStakersAddressAndAssetDetails(address common.Address, asset common.Asset) (models.StakerAddressAndAssetDetails, error)
-	TotalEarned() (int64, error)
-	GetEventsByTxID(txID common.TxID) ([]models.Event, error)
+	Ping(ctx context.Context) error
+	GetTxDetails(ctx context.Context, address common.Address, txID common.TxID, asset common.Asset, eventTypes []string, offset, limit int64) ([]models.TxDetails, int64, error)
+	GetPools(ctx context.Context) ([]common.Asset, error)
+	GetPool(ctx context.Context, asset common.Asset) (common.Asset, error)
+	GetAssetDepth(ctx context.Context, asset common.Asset) (uint64, error)
+	GetRuneDepth(ctx context.Context, asset common.Asset) (uint64, error)
+	GetPoolBasics(ctx context.Context, asset common.Asset) (models.PoolBasics, error)
+	GetPoolVolume(ctx context.Context, asset common.Asset, from, to time.Time) (int64, error)
+	GetPoolStatus(ctx context.Context, asset common.Asset) (models.PoolStatus, error)
+	GetDateCreated(ctx context.Context, asset common.Asset) (uint64, error)
+	GetTotalDepth(ctx context.Context) (uint64, error)
+	GetUsersCount(ctx context.Context, from, to *time.Time) (uint64, error)
+	GetTxsCount(ctx context.Context, from, to *time.Time) (uint64, error)
+	GetTotalVolume(ctx context.Context, from, to *time.Time) (uint64, error)
+	TotalStaked(ctx context.Context) (uint64, error)
+	PoolCount(ctx context.Context) (uint64, error)
+	TotalAssetBuys(ctx context.Context) (uint64, error)
+	TotalAssetSells(ctx context.Context) (uint64, error)
+	TotalStakeTx(ctx context.Context) (uint64, error)
+	TotalWithdrawTx(ctx context.Context) (uint64, error)
+	GetPoolSwapStats(ctx context.Context, asset common.Asset) (models.PoolSwapStats, error)
+	GetStakerAddresses(ctx context.Context) ([]common.Address, error)
+	GetStakerAddressDetails(ctx context.Context, address common.Address) (models.StakerAddressDetails, error)
+	GetStakersAddressAndAssetDetails(ctx context.Context, address common.Address, asset common.Asset) (models.StakerAddressAndAssetDetails, error)
+	TotalEarned(ctx context.Context) (int64, error)
+	GetEventsByTxID(ctx context.Context, txID common.TxID) ([]models.Event, error)
 	ProcessTxRecord(direction string, parent models.Event, record common.Tx) error
 	CreateFeeRecord(event models.Event, pool common.Asset) error
 	UpdateUnStakesRecord(record models.EventUnstake) error
@@ -53,13 +54,52 @@ type Store interface {
 	UpdatePoolUnits(pool common.Asset, units int64)
 	GetLastHeight() (int64, error)
 	UpdateEventStatus(eventID int64, status string) error
-	GetTotalVolChanges(interval models.Interval, from, to time.Time) ([]models.TotalVolChanges, error)
-	GetPoolAggChanges(pool common.Asset, inv models.Interval, from, to time.Time) ([]models.PoolAggChanges, error)
+	GetOutboundsCount(ctx context.Context, eventID int64) (int64, error)
+	UpdateExpectedOutbounds(eventID int64, expected int64) error
+	GetTotalVolChanges(ctx context.Context, interval models.Interval, from, to time.Time) ([]models.TotalVolChanges, error)
+	GetPoolAggChanges(ctx context.Context, pool common.Asset, inv models.Interval, from, to time.Time) ([]models.PoolAggChanges, error)
+	GetPoolYieldChanges(ctx context.Context, pool common.Asset, inv models.Interval, from, to time.Time) ([]models.PoolYieldChanges, error)
 	DeleteBlock(height int64) error
-	GetPoolROI12(asset common.Asset) (float64, error)
-	GetStakersCount(asset common.Asset) (uint64, error)
-	GetSwappersCount(asset common.Asset) (uint64, error)
-	GetPoolEarned(asset common.Asset, from time.Time) (int64, error)
-	GetPoolLastEnabledDate(asset common.Asset) (time.Time, error)
-	GetEventPool(id int64) (common.Asset, error)
+	GetPoolROI12(ctx context.Context, asset common.Asset) (float64, error)
+	GetStakersCount(ctx context.Context, asset common.Asset) (uint64, error)
+	GetPoolStakers(ctx context.Context, asset common.Asset, at time.Time) ([]models.PoolStaker, error)
+	GetPoolStakersChanges(ctx context.Context, asset common.Asset, from, to time.Time, step time.Duration) ([]models.PoolStakerChange, error)
+	GetSwappersCount(ctx context.Context, asset common.Asset) (uint64, error)
+	GetPoolEarned(ctx context.Context, asset common.Asset, from time.Time) (int64, error)
+	GetPoolLastEnabledDate(ctx context.Context, asset common.Asset) (time.Time, error)
+	GetEventPool(ctx context.Context, id int64) (common.Asset, error)
+	CreatePriceDeviationRecord(record *models.PriceDeviation) error
+	GetPriceDeviations(ctx context.Context, pool common.Asset, from, to time.Time) ([]models.PriceDeviation, error)
+	SaveDeviationAlert(record *models.PriceDeviation) error
+	DeleteDeviationAlert(pool common.Asset) error
+	GetDeviationAlerts(ctx context.Context) ([]models.DeviationAlert, error)
+	GetSwapsStats(ctx context.Context, asset common.Asset, from, to time.Time) (models.SwapsStats, error)
+	GetSwapsHistogram(ctx context.Context, asset common.Asset, metric models.SwapMetric, bounds []float64, from, to time.Time) ([]int64, error)
+	GetTopSwappers(ctx context.Context, asset common.Asset, from, to time.Time, limit int64) ([]models.Swapper, error)
+	CreateReserveRecord(record *models.Reserve) error
+	GetRewardsChanges(ctx context.Context, inv models.Interval, from, to time.Time) ([]models.RewardsChanges, error)
+	GetReserveChanges(ctx context.Context, inv models.Interval, from, to time.Time) ([]models.Reserve, error)
+	GetSlashChanges(ctx context.Context, pool common.Asset, inv models.Interval, from, to time.Time) ([]models.SlashChanges, error)
+	GetGasChanges(ctx context.Context, chain common.Chain, inv models.Interval, from, to time.Time) ([]models.GasChanges, error)
+	CreateTxFeeRecord(record *models.TxFee) error
+	GetFeeChanges(ctx context.Context, pool common.Asset, inv models.Interval, from, to time.Time) ([]models.FeeChanges, error)
+	GetPendingTxs(ctx context.Context, offset, limit int64) ([]models.PendingTx, int64, error)
+	GetPendingTxsCount(ctx context.Context, before time.Time) (int64, error)
+	CreateDoubleSwapRecord(record *models.DoubleSwap) error
+	GetDoubleSwap(ctx context.Context, eventID int64) (*models.DoubleSwap, error)
+	CreateQuarantinedEvent(record *models.QuarantinedEvent) error
+	GetQuarantinedEvents(ctx context.Context, offset, limit int64) ([]models.QuarantinedEvent, int64, error)
+	CreateRawEvents(records []models.RawEvent) error
+	GetRawEvents(from, to int64) ([]models.RawEvent, error)
+	GetRawEventsRange() (int64, int64, error)
+	GetLinkedRange(from, to int64) (int64, int64, error)
+	DeleteHeightRange(from, to int64) error
+	UpdatePoolDepths(from int64) error
+	GetStakeTxs(from, to int64) ([]common.Tx, error)
+	GetFirstHeight() (int64, error)
+	GetAPIKeys(ctx context.Context) ([]models.APIKey, error)
+	GetAPIKeysUsage(ctx context.Context, day time.Time) ([]models.APIKeyUsage, error)
+	AddAPIKeysUsage(usage []models.APIKeyUsage) error
+	CreateShadow() (Store, error)
+	SwapShadow(shadow Store) error
 }
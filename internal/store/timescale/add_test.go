@@ -1,6 +1,8 @@
 package timescale
 
 import (
+	"context"
+
 	"gitlab.com/thorchain/midgard/internal/common"
 	. "gopkg.in/check.v1"
 )
@@ -9,10 +11,10 @@ func (s *TimeScaleSuite) TestAssetAdded(c *C) {
 	assetBolt, _ := common.NewAsset("BOLT-014")
 
 	// Zero pool depth
-	depth, err := s.Store.poolDepth(assetBolt)
+	depth, err := s.Store.poolDepth(context.Background(), assetBolt)
 	c.Assert(err, IsNil)
 	c.Assert(depth, Equals, uint64(0))
-	depth, err = s.Store.GetAssetDepth(assetBolt)
+	depth, err = s.Store.GetAssetDepth(context.Background(), assetBolt)
 	c.Assert(err, IsNil)
 	c.Assert(depth, Equals, uint64(0))
 
@@ -20,10 +22,10 @@ func (s *TimeScaleSuite) TestAssetAdded(c *C) {
 	err = s.Store.CreateAddRecord(&addBnbEvent0)
 	c.Assert(err, IsNil)
 
-	depth, err = s.Store.poolDepth(assetBolt)
+	depth, err = s.Store.poolDepth(context.Background(), assetBolt)
 	c.Assert(err, IsNil)
 	c.Assert(depth, Equals, uint64(0))
-	depth, err = s.Store.GetAssetDepth(assetBolt)
+	depth, err = s.Store.GetAssetDepth(context.Background(), assetBolt)
 	c.Assert(err, IsNil)
 	c.Assert(depth, Equals, uint64(1000))
 
@@ -32,10 +34,10 @@ func (s *TimeScaleSuite) TestAssetAdded(c *C) {
 	err = s.Store.CreateAddRecord(&addTomlEvent1)
 	c.Assert(err, IsNil)
 
-	depth, err = s.Store.poolDepth(assetToml)
+	depth, err = s.Store.poolDepth(context.Background(), assetToml)
 	c.Assert(err, IsNil)
 	c.Assert(depth, Equals, uint64(0))
-	depth, err = s.Store.GetAssetDepth(assetToml)
+	depth, err = s.Store.GetAssetDepth(context.Background(), assetToml)
 	c.Assert(err, IsNil)
 	c.Assert(depth, Equals, uint64(1000))
 }
@@ -44,16 +46,16 @@ func (s *TimeScaleSuite) TestRuneAdded(c *C) {
 	asset, _ := common.NewAsset("RUNE-B1A")
 
 	// Zero pool depth
-	depth, err := s.Store.poolDepth(asset)
+	depth, err := s.Store.poolDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(depth, Equals, uint64(0))
-	depth, err = s.Store.poolDepth(asset)
+	depth, err = s.Store.poolDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(depth, Equals, uint64(0))
-	depth, err = s.Store.GetAssetDepth(asset)
+	depth, err = s.Store.GetAssetDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(depth, Equals, uint64(0))
-	depth, err = s.Store.GetAssetDepth(asset)
+	depth, err = s.Store.GetAssetDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(depth, Equals, uint64(0))
 
@@ -61,10 +63,10 @@ func (s *TimeScaleSuite) TestRuneAdded(c *C) {
 	err = s.Store.CreateAddRecord(&addRuneEvent0)
 	c.Assert(err, IsNil)
 
-	depth, err = s.Store.poolDepth(asset)
+	depth, err = s.Store.poolDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(depth, Equals, uint64(2000))
-	depth, err = s.Store.GetAssetDepth(asset)
+	depth, err = s.Store.GetAssetDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(depth, Equals, uint64(0))
 
@@ -72,10 +74,10 @@ func (s *TimeScaleSuite) TestRuneAdded(c *C) {
 	err = s.Store.CreateAddRecord(&addRuneEvent1)
 	c.Assert(err, IsNil)
 
-	depth, err = s.Store.poolDepth(asset)
+	depth, err = s.Store.poolDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(depth, Equals, uint64(6000))
-	depth, err = s.Store.GetAssetDepth(asset)
+	depth, err = s.Store.GetAssetDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(depth, Equals, uint64(0))
 }
@@ -0,0 +1,70 @@
+package timescale
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gitlab.com/thorchain/midgard/internal/models"
+)
+
+// GetAPIKeys returns all the api keys.
+func (s *Client) GetAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	q := `SELECT key_hash, name, tier FROM api_keys ORDER BY key_hash`
+	rows, err := s.db().QueryContext(ctx, q)
+	if err != nil {
+		return nil, errors.Wrap(err, "query failed")
+	}
+	defer rows.Close()
+
+	keys := []models.APIKey{}
+	for rows.Next() {
+		var key models.APIKey
+		err := rows.Scan(&key.KeyHash, &key.Name, &key.Tier)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan failed")
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// GetAPIKeysUsage returns the usage of the api keys used during the given day.
+func (s *Client) GetAPIKeysUsage(ctx context.Context, day time.Time) ([]models.APIKeyUsage, error) {
+	q := `SELECT key_hash, day, requests FROM api_key_usage WHERE day = $1 ORDER BY key_hash`
+	rows, err := s.db().QueryContext(ctx, q, day)
+	if err != nil {
+		return nil, errors.Wrap(err, "query failed")
+	}
+	defer rows.Close()
+
+	usage := []models.APIKeyUsage{}
+	for rows.Next() {
+		var u models.APIKeyUsage
+		err := rows.Scan(&u.KeyHash, &u.Day, &u.Requests)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan failed")
+		}
+		usage = append(usage, u)
+	}
+	return usage, rows.Err()
+}
+
+// AddAPIKeysUsage adds the given requests to the usage of the api keys. The
+// usage is added at once, so that it can be retried on failure.
+func (s *Client) AddAPIKeysUsage(usage []models.APIKeyUsage) error {
+	tx, err := s.db().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "could not begin transaction")
+	}
+	q := `INSERT INTO api_key_usage (key_hash, day, requests) VALUES ($1, $2, $3)
+		ON CONFLICT (key_hash, day) DO UPDATE SET requests = api_key_usage.requests + EXCLUDED.requests`
+	for _, u := range usage {
+		_, err := tx.Exec(q, u.KeyHash, u.Day, u.Requests)
+		if err != nil {
+			tx.Rollback()
+			return errors.Wrap(err, "could not add api keys usage")
+		}
+	}
+	return errors.Wrap(tx.Commit(), "could not commit api keys usage")
+}
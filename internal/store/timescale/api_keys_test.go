@@ -0,0 +1,45 @@
+package timescale
+
+import (
+	"context"
+	"time"
+
+	"gitlab.com/thorchain/midgard/internal/models"
+	. "gopkg.in/check.v1"
+)
+
+func (s *TimeScaleSuite) TestAPIKeys(c *C) {
+	_, err := s.Store.db().Exec(`INSERT INTO api_keys (key_hash, name, tier) VALUES ('key-2', 'wallet', 'standard'), ('key-1', 'explorer', 'premium')`)
+	c.Assert(err, IsNil)
+
+	keys, err := s.Store.GetAPIKeys(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(keys, DeepEquals, []models.APIKey{
+		{KeyHash: "key-1", Name: "explorer", Tier: "premium"},
+		{KeyHash: "key-2", Name: "wallet", Tier: "standard"},
+	})
+}
+
+func (s *TimeScaleSuite) TestAPIKeysUsage(c *C) {
+	today := time.Date(2020, 8, 27, 0, 0, 0, 0, time.UTC)
+	yesterday := today.Add(-time.Hour * 24)
+	err := s.Store.AddAPIKeysUsage([]models.APIKeyUsage{
+		{KeyHash: "key-1", Day: yesterday, Requests: 10},
+		{KeyHash: "key-1", Day: today, Requests: 5},
+		{KeyHash: "key-2", Day: today, Requests: 1},
+	})
+	c.Assert(err, IsNil)
+	err = s.Store.AddAPIKeysUsage([]models.APIKeyUsage{
+		{KeyHash: "key-1", Day: today, Requests: 3},
+	})
+	c.Assert(err, IsNil)
+
+	usage, err := s.Store.GetAPIKeysUsage(context.Background(), today)
+	c.Assert(err, IsNil)
+	c.Assert(usage, HasLen, 2)
+	c.Assert(usage[0].KeyHash, Equals, "key-1")
+	c.Assert(usage[0].Requests, Equals, int64(8))
+	c.Assert(usage[0].Day.Equal(today), Equals, true)
+	c.Assert(usage[1].KeyHash, Equals, "key-2")
+	c.Assert(usage[1].Requests, Equals, int64(1))
+}
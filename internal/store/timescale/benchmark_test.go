@@ -1,6 +1,7 @@
 package timescale
 
 import (
+	"context"
 	"math/rand"
 
 	"gitlab.com/thorchain/midgard/internal/store"
@@ -47,7 +48,7 @@ func (s *BenchmarkSuite) TearDownSuite(c *C) {
 func (s *BenchmarkSuite) BenchmarkGetPoolBasics(c *C) {
 	for i := 0; i < c.N; i++ {
 		pool := s.generator.Pools[i%len(s.generator.Pools)]
-		_, err := s.Store.GetPoolBasics(pool)
+		_, err := s.Store.GetPoolBasics(context.Background(), pool)
 		c.Assert(err, IsNil)
 	}
 }
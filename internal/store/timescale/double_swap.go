@@ -0,0 +1,53 @@
+package timescale
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/models"
+)
+
+// CreateDoubleSwapRecord links the two legs of a double swap.
+func (s *Client) CreateDoubleSwapRecord(record *models.DoubleSwap) error {
+	q := `INSERT INTO double_swaps (time, tx_hash, first_event_id, second_event_id, rune_amount)
+		VALUES ($1, $2, $3, $4, $5)`
+	_, err := s.db().Exec(q,
+		record.Time,
+		record.TxID.String(),
+		record.FirstEventID,
+		record.SecondEventID,
+		record.RuneAmount)
+	return err
+}
+
+// GetDoubleSwap returns the double swap which the given event is a leg of. It
+// returns nil if the event is not part of a double swap.
+func (s *Client) GetDoubleSwap(ctx context.Context, eventID int64) (*models.DoubleSwap, error) {
+	q := `SELECT time, tx_hash, first_event_id, second_event_id, rune_amount
+		FROM double_swaps
+		WHERE first_event_id = $1 OR second_event_id = $1`
+	var (
+		record models.DoubleSwap
+		txID   string
+	)
+	err := s.db().QueryRowContext(ctx, q, eventID).Scan(&record.Time, &txID, &record.FirstEventID, &record.SecondEventID, &record.RuneAmount)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "query failed")
+	}
+	record.TxID, err = common.NewTxID(txID)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid tx id")
+	}
+	return &record, nil
+}
+
+func (s *Client) deleteDoubleSwapsInRange(from, to int64) error {
+	q := `DELETE FROM double_swaps USING events WHERE double_swaps.second_event_id = events.id AND events.height BETWEEN $1 AND $2`
+	_, err := s.db().Exec(q, from, to)
+	return err
+}
@@ -0,0 +1,76 @@
+package timescale
+
+import (
+	"context"
+
+	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/models"
+	. "gopkg.in/check.v1"
+)
+
+func (s *TimeScaleSuite) TestDoubleSwap(c *C) {
+	firstLeg := swapBNB2Tusdb0
+	firstLeg.Type = "doubleSwap"
+	firstLeg.OutTxs = common.Txs{
+		{
+			ID:    common.BlankTxID,
+			Chain: common.BNBChain,
+			Coins: common.Coins{{Asset: common.RuneAsset(), Amount: 50}},
+		},
+	}
+	err := s.Store.CreateSwapRecord(&firstLeg)
+	c.Assert(err, IsNil)
+	secondLeg := swapBNB2Tusdb1
+	secondLeg.Type = ""
+	secondLeg.InTx.Coins = common.Coins{{Asset: common.RuneAsset(), Amount: 50}}
+	err = s.Store.CreateSwapRecord(&secondLeg)
+	c.Assert(err, IsNil)
+
+	doubleSwap, err := s.Store.GetDoubleSwap(context.Background(), firstLeg.ID)
+	c.Assert(err, IsNil)
+	c.Assert(doubleSwap, IsNil)
+	vol, err := s.Store.GetTotalVolume(context.Background(), nil, nil)
+	c.Assert(err, IsNil)
+	c.Assert(vol, Equals, uint64(100))
+	sells, err := s.Store.TotalAssetSells(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(sells, Equals, uint64(1))
+
+	record := models.DoubleSwap{
+		Time:          firstLeg.Time,
+		TxID:          firstLeg.InTx.ID,
+		FirstEventID:  firstLeg.ID,
+		SecondEventID: secondLeg.ID,
+		RuneAmount:    50,
+	}
+	err = s.Store.CreateDoubleSwapRecord(&record)
+	c.Assert(err, IsNil)
+
+	// Either leg should resolve to the double swap.
+	doubleSwap, err = s.Store.GetDoubleSwap(context.Background(), firstLeg.ID)
+	c.Assert(err, IsNil)
+	c.Assert(doubleSwap.FirstEventID, Equals, firstLeg.ID)
+	c.Assert(doubleSwap.SecondEventID, Equals, secondLeg.ID)
+	c.Assert(doubleSwap.TxID, Equals, firstLeg.InTx.ID)
+	c.Assert(doubleSwap.RuneAmount, Equals, int64(50))
+	doubleSwap, err = s.Store.GetDoubleSwap(context.Background(), secondLeg.ID)
+	c.Assert(err, IsNil)
+	c.Assert(doubleSwap.FirstEventID, Equals, firstLeg.ID)
+
+	// Double swap should be counted once in volume.
+	vol, err = s.Store.GetTotalVolume(context.Background(), nil, nil)
+	c.Assert(err, IsNil)
+	c.Assert(vol, Equals, uint64(50))
+	buys, err := s.Store.TotalAssetBuys(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(buys, Equals, uint64(1))
+	sells, err = s.Store.TotalAssetSells(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(sells, Equals, uint64(0))
+
+	err = s.Store.DeleteBlock(secondLeg.Height)
+	c.Assert(err, IsNil)
+	doubleSwap, err = s.Store.GetDoubleSwap(context.Background(), firstLeg.ID)
+	c.Assert(err, IsNil)
+	c.Assert(doubleSwap, IsNil)
+}
@@ -1,6 +1,7 @@
 package timescale
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -15,13 +16,23 @@ func (s *Client) GetLastHeight() (int64, error) {
 		SELECT Max(height) 
 		FROM   %s`, models.ModelPoolsHistoryTable)
 	var maxHeight sql.NullInt64
-	err := s.db.Get(&maxHeight, query)
+	err := s.db().Get(&maxHeight, query)
 	if err != nil {
 		return 0, errors.Wrap(err, "maxID query return null or failed")
 	}
 	return maxHeight.Int64, nil
 }
 
+// GetFirstHeight returns the height of the first indexed event.
+func (s *Client) GetFirstHeight() (int64, error) {
+	var height sql.NullInt64
+	err := s.db().Get(&height, `SELECT MIN(height) FROM events`)
+	if err != nil {
+		return 0, errors.Wrap(err, "query failed")
+	}
+	return height.Int64, nil
+}
+
 func (s *Client) CreateEventRecord(record *models.Event) error {
 	if record.Height == 0 {
 		return nil
@@ -102,7 +113,7 @@ func (s *Client) createCoinRecord(parent models.Event, record common.Tx, coin co
 			amount
 		)  VALUES ( $1, $2, $3, $4, $5, $6, $7 ) RETURNING event_id`, models.ModelCoinsTable)
 
-	results, err := s.db.Exec(query,
+	results, err := s.db().Exec(query,
 		parent.Time,
 		record.ID,
 		parent.ID,
@@ -131,7 +142,7 @@ func (s *Client) createTxRecord(parent models.Event, record common.Tx, direction
 			memo
 		) VALUES ( $1, $2, $3, $4, $5, $6, $7, $8) RETURNING event_id`, models.ModelTxsTable)
 
-	results, err := s.db.Exec(query,
+	results, err := s.db().Exec(query,
 		parent.Time,
 		record.ID,
 		parent.ID,
@@ -154,22 +165,24 @@ func (s *Client) createEventRecord(record *models.Event) error {
 				time,
 				height,
 				status,
-				type
+				type,
+				expected_outbounds
 			) VALUES (
 				:time,
 				:height,
 				:status,
-				:type
+				:type,
+				:expected_outbounds
 			) RETURNING id`, models.ModelEventsTable)
 
-	stmt, err := s.db.PrepareNamed(query)
+	stmt, err := s.db().PrepareNamed(query)
 	if err != nil {
 		return errors.Wrap(err, "Failed to prepareNamed query for event")
 	}
 	return stmt.QueryRowx(record).Scan(&record.ID)
 }
 
-func (s *Client) GetEventsByTxID(txID common.TxID) ([]models.Event, error) {
+func (s *Client) GetEventsByTxID(ctx context.Context, txID common.TxID) ([]models.Event, error) {
 	query := `
 		SELECT     events.* 
 		FROM       events 
@@ -179,7 +192,7 @@ func (s *Client) GetEventsByTxID(txID common.TxID) ([]models.Event, error) {
 		ORDER  BY events.id`
 	var events []models.Event
 	var err error
-	rows, err := s.db.Queryx(query, txID.String())
+	rows, err := s.db().QueryxContext(ctx, query, txID.String())
 	if err != nil {
 		return nil, err
 	}
@@ -195,11 +208,34 @@ func (s *Client) GetEventsByTxID(txID common.TxID) ([]models.Event, error) {
 	return events, nil
 }
 
+// GetOutboundsCount returns the number of distinct outbound txs observed for the event.
+func (s *Client) GetOutboundsCount(ctx context.Context, eventID int64) (int64, error) {
+	query := `
+		SELECT COUNT(DISTINCT(tx_hash))
+		FROM   txs
+		WHERE  event_id = $1
+		AND    direction = 'out'`
+	var count int64
+	err := s.db().QueryRowContext(ctx, query, eventID).Scan(&count)
+	return count, err
+}
+
+// UpdateExpectedOutbounds sets the number of outbound txs required to complete
+// the event.
+func (s *Client) UpdateExpectedOutbounds(eventID int64, expected int64) error {
+	query := `
+		UPDATE events
+		SET    expected_outbounds = $1
+		WHERE  events.id = $2`
+	_, err := s.db().Exec(query, expected, eventID)
+	return err
+}
+
 func (s *Client) UpdateEventStatus(eventID int64, status string) error {
 	query := `
 		UPDATE events 
 		SET    status = $1 
 		WHERE  events.id = $2`
-	_, err := s.db.Exec(query, status, eventID)
+	_, err := s.db().Exec(query, status, eventID)
 	return err
 }
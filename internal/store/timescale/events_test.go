@@ -1,13 +1,18 @@
 package timescale
 
 import (
+	"context"
+	"time"
+
+	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/models"
 	. "gopkg.in/check.v1"
 )
 
 func (s *TimeScaleSuite) TestGetEventsByTxID(c *C) {
 	err := s.Store.CreateEventRecord(&stakeBnbEvent0.Event)
 	c.Assert(err, IsNil)
-	event, err := s.Store.GetEventsByTxID(stakeBnbEvent0.InTx.ID)
+	event, err := s.Store.GetEventsByTxID(context.Background(), stakeBnbEvent0.InTx.ID)
 	c.Assert(err, IsNil)
 	c.Assert(len(event), Equals, 1)
 	c.Assert(event[0].Status, Equals, stakeBnbEvent0.Event.Status)
@@ -16,7 +21,7 @@ func (s *TimeScaleSuite) TestGetEventsByTxID(c *C) {
 
 	err = s.Store.CreateSwapRecord(&swapBuyRune2BoltEvent1)
 	c.Assert(err, IsNil)
-	event, err = s.Store.GetEventsByTxID(swapBuyRune2BoltEvent1.InTx.ID)
+	event, err = s.Store.GetEventsByTxID(context.Background(), swapBuyRune2BoltEvent1.InTx.ID)
 	c.Assert(err, IsNil)
 	c.Assert(len(event), Equals, 1)
 	c.Assert(event[0].Status, Equals, swapBuyRune2BoltEvent1.Event.Status)
@@ -27,7 +32,7 @@ func (s *TimeScaleSuite) TestGetEventsByTxID(c *C) {
 	evt.InTx = swapBuyRune2BnbEvent3.InTx
 	err = s.Store.CreateSwapRecord(&evt)
 	c.Assert(err, IsNil)
-	event, err = s.Store.GetEventsByTxID(evt.InTx.ID)
+	event, err = s.Store.GetEventsByTxID(context.Background(), evt.InTx.ID)
 	c.Assert(err, IsNil)
 	c.Assert(len(event), Equals, 2)
 	c.Assert(event[0].Status, Equals, swapBuyRune2BoltEvent1.Event.Status)
@@ -37,3 +42,82 @@ func (s *TimeScaleSuite) TestGetEventsByTxID(c *C) {
 	c.Assert(event[1].Height, Equals, swapSellBnb2RuneEvent4.Event.Height)
 	c.Assert(event[1].Type, Equals, swapSellBnb2RuneEvent4.Event.Type)
 }
+
+func (s *TimeScaleSuite) TestGetOutboundsCount(c *C) {
+	outTx := func(id common.TxID, coin common.Coin) common.Tx {
+		return common.Tx{
+			ID:          id,
+			Chain:       common.BNBChain,
+			FromAddress: "bnb1llvmhawaxxjchwmfmj8fjzftvwz4jpdhapp5hr",
+			ToAddress:   "bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38",
+			Coins:       common.Coins{coin},
+		}
+	}
+	evt := models.Event{
+		Time:   time.Now(),
+		Height: 1,
+		Type:   "refund",
+		Status: "Pending",
+		InTx: common.Tx{
+			ID:          "2F624637DE179665BA3322B864DB9F30001FD37B4E0D22A0B6ECE6A5B078DAB4",
+			Chain:       common.BNBChain,
+			FromAddress: "bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38",
+			ToAddress:   "bnb1llvmhawaxxjchwmfmj8fjzftvwz4jpdhapp5hr",
+			Coins: common.Coins{
+				{Asset: common.BNBAsset, Amount: 10},
+				{Asset: common.RuneAsset(), Amount: 20},
+			},
+		},
+		ExpectedOutbounds: 2,
+	}
+	err := s.Store.CreateEventRecord(&evt)
+	c.Assert(err, IsNil)
+	events, err := s.Store.GetEventsByTxID(context.Background(), evt.InTx.ID)
+	c.Assert(err, IsNil)
+	c.Assert(events, HasLen, 1)
+	c.Assert(events[0].ExpectedOutbounds, Equals, int64(2))
+
+	count, err := s.Store.GetOutboundsCount(context.Background(), evt.ID)
+	c.Assert(err, IsNil)
+	c.Assert(count, Equals, int64(0))
+
+	first := outTx("04AE4EC733CA6366D431376DA600C1E4E091982D06F25B13028C99EC11A4C1E4", common.Coin{Asset: common.BNBAsset, Amount: 9})
+	err = s.Store.ProcessTxRecord("out", evt, first)
+	c.Assert(err, IsNil)
+	count, err = s.Store.GetOutboundsCount(context.Background(), evt.ID)
+	c.Assert(err, IsNil)
+	c.Assert(count, Equals, int64(1))
+
+	txs, _, err := s.Store.GetPendingTxs(context.Background(), 0, 10)
+	c.Assert(err, IsNil)
+	c.Assert(txs, HasLen, 1)
+	c.Assert(txs[0].Outbounds, Equals, int64(1))
+	c.Assert(txs[0].ExpectedOutbounds, Equals, int64(2))
+
+	err = s.Store.UpdateExpectedOutbounds(evt.ID, 3)
+	c.Assert(err, IsNil)
+	events, err = s.Store.GetEventsByTxID(context.Background(), evt.InTx.ID)
+	c.Assert(err, IsNil)
+	c.Assert(events[0].ExpectedOutbounds, Equals, int64(3))
+
+	second := outTx("AA578D052B0EC26F2E4E50901512AC3145F5D5614D24231179C7E86892D42B4D", common.Coin{Asset: common.RuneAsset(), Amount: 19})
+	err = s.Store.ProcessTxRecord("out", evt, second)
+	c.Assert(err, IsNil)
+	count, err = s.Store.GetOutboundsCount(context.Background(), evt.ID)
+	c.Assert(err, IsNil)
+	c.Assert(count, Equals, int64(2))
+}
+
+func (s *TimeScaleSuite) TestGetFirstHeight(c *C) {
+	height, err := s.Store.GetFirstHeight()
+	c.Assert(err, IsNil)
+	c.Assert(height, Equals, int64(0))
+
+	err = s.Store.CreateStakeRecord(&stakeTomlEvent1)
+	c.Assert(err, IsNil)
+	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
+	c.Assert(err, IsNil)
+	height, err = s.Store.GetFirstHeight()
+	c.Assert(err, IsNil)
+	c.Assert(height, Equals, int64(1))
+}
@@ -1,12 +1,33 @@
 package timescale
 
 import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
 	"github.com/pkg/errors"
 	"gitlab.com/thorchain/midgard/internal/common"
 	"gitlab.com/thorchain/midgard/internal/models"
 )
 
 func (s *Client) CreateFeeRecord(event models.Event, pool common.Asset) error {
+	// Fees of fee events are linked to their events separately.
+	if event.ID != 0 && (len(event.Fee.Coins) > 0 || event.Fee.PoolDeduct != 0) {
+		err := s.CreateTxFeeRecord(&models.TxFee{
+			Time:        event.Time,
+			Height:      event.Height,
+			EventID:     event.ID,
+			Pool:        pool,
+			AssetAmount: event.Fee.AssetFee(),
+			RuneAmount:  event.Fee.RuneFee(),
+			PoolDeduct:  event.Fee.PoolDeduct,
+		})
+		if err != nil {
+			return errors.Wrap(err, "could not create tx fee record")
+		}
+	}
+
 	runeAmt := -event.Fee.PoolDeduct
 	assetAmt := event.Fee.AssetFee()
 	if runeAmt == 0 && assetAmt == 0 {
@@ -25,3 +46,135 @@ func (s *Client) CreateFeeRecord(event models.Event, pool common.Asset) error {
 	err := s.UpdatePoolsHistory(change)
 	return errors.Wrap(err, "could not update pool history")
 }
+
+// CreateTxFeeRecord stores the network fee charged from the outbound of an event.
+func (s *Client) CreateTxFeeRecord(record *models.TxFee) error {
+	q := `INSERT INTO tx_fees (time, height, event_id, pool, asset_amount, rune_amount, pool_deduct)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err := s.db().Exec(q,
+		record.Time,
+		record.Height,
+		record.EventID,
+		record.Pool.String(),
+		record.AssetAmount,
+		record.RuneAmount,
+		record.PoolDeduct)
+	return err
+}
+
+type feeChanges struct {
+	Time        time.Time     `db:"time"`
+	Pool        string        `db:"pool"`
+	Type        string        `db:"type"`
+	AssetAmount sql.NullInt64 `db:"asset_amount"`
+	RuneAmount  sql.NullInt64 `db:"rune_amount"`
+	PoolDeduct  sql.NullInt64 `db:"pool_deduct"`
+	InRune      sql.NullInt64 `db:"in_rune"`
+	Count       sql.NullInt64 `db:"count"`
+}
+
+// GetFeeChanges returns the network fees charged from events of each pool and
+// type per time bucket between from and to. Fees of all pools are returned if
+// pool is empty.
+func (s *Client) GetFeeChanges(ctx context.Context, pool common.Asset, inv models.Interval, from, to time.Time) ([]models.FeeChanges, error) {
+	timeBucket := getRawTimeBucket(inv)
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(
+		sb.As(timeBucket, "time"),
+		"pool",
+		"type",
+		sb.As("SUM(asset_amount)", "asset_amount"),
+		sb.As("SUM(rune_amount)", "rune_amount"),
+		sb.As("SUM(pool_deduct)", "pool_deduct"),
+		sb.As("SUM(CASE WHEN rune_amount > 0 THEN rune_amount ELSE pool_deduct END)", "in_rune"),
+		sb.As("COUNT(*)", "count"),
+	)
+	sb.From("(SELECT tx_fees.*, events.type FROM tx_fees JOIN events ON events.id = tx_fees.event_id) AS fees")
+	if !pool.IsEmpty() {
+		sb.Where(sb.Equal("pool", pool.String()))
+	}
+	sb.Where(sb.Between(timeBucket, from, to))
+	sb.GroupBy(timeBucket, "pool", "type")
+	sb.OrderBy("time", "pool", "type")
+
+	q, args := sb.Build()
+	rows, err := s.db().QueryxContext(ctx, q, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "query failed")
+	}
+	defer rows.Close()
+
+	result := []models.FeeChanges{}
+	for rows.Next() {
+		var changes feeChanges
+		err := rows.StructScan(&changes)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan failed")
+		}
+		asset, _ := common.NewAsset(changes.Pool)
+		result = append(result, models.FeeChanges{
+			Time:             changes.Time,
+			Pool:             asset,
+			Type:             changes.Type,
+			AssetAmount:      changes.AssetAmount.Int64,
+			RuneAmount:       changes.RuneAmount.Int64,
+			PoolDeduct:       changes.PoolDeduct.Int64,
+			NetworkFeeInRune: changes.InRune.Int64,
+			Count:            changes.Count.Int64,
+		})
+	}
+	return result, nil
+}
+
+// networkFee returns the network fees charged from the given events.
+func (s *Client) networkFee(ctx context.Context, eventIDs ...uint64) (common.Coins, int64) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select("pool", "asset_amount", "rune_amount", "pool_deduct")
+	sb.From("tx_fees")
+	ids := make([]interface{}, len(eventIDs))
+	for i, id := range eventIDs {
+		ids[i] = id
+	}
+	sb.Where(sb.In("event_id", ids...))
+	sb.OrderBy("event_id")
+
+	q, args := sb.Build()
+	rows, err := s.db().QueryxContext(ctx, q, args...)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed")
+		return nil, 0
+	}
+	defer rows.Close()
+
+	var (
+		coins  common.Coins
+		inRune int64
+	)
+	for rows.Next() {
+		var (
+			pool                                string
+			assetAmount, runeAmount, poolDeduct int64
+		)
+		if err := rows.Scan(&pool, &assetAmount, &runeAmount, &poolDeduct); err != nil {
+			s.logger.Err(err).Msg("Scan error")
+			continue
+		}
+		if assetAmount != 0 {
+			asset, _ := common.NewAsset(pool)
+			coins = append(coins, common.Coin{Asset: asset, Amount: assetAmount})
+		}
+		if runeAmount != 0 {
+			coins = append(coins, common.Coin{Asset: common.RuneAsset(), Amount: runeAmount})
+			inRune += runeAmount
+		} else {
+			inRune += poolDeduct
+		}
+	}
+	return coins, inRune
+}
+
+func (s *Client) deleteTxFeesInRange(from, to int64) error {
+	q := `DELETE FROM tx_fees WHERE height BETWEEN $1 AND $2`
+	_, err := s.db().Exec(q, from, to)
+	return err
+}
@@ -0,0 +1,81 @@
+package timescale
+
+import (
+	"context"
+	"time"
+
+	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/models"
+	"gitlab.com/thorchain/midgard/pkg/helpers"
+	. "gopkg.in/check.v1"
+)
+
+func (s *TimeScaleSuite) TestGetFeeChanges(c *C) {
+	today := time.Date(2020, 8, 14, 0, 0, 0, 0, time.UTC)
+	tomorrow := today.Add(time.Hour * 24)
+
+	events := []models.Event{
+		{Time: today, Height: 1, Type: "swap", Status: "Success"},
+		{Time: today, Height: 2, Type: "swap", Status: "Success"},
+		{Time: today, Height: 3, Type: "unstake", Status: "Success"},
+		{Time: tomorrow, Height: 4, Type: "swap", Status: "Success"},
+	}
+	for i := range events {
+		err := s.Store.CreateEventRecord(&events[i])
+		c.Assert(err, IsNil)
+	}
+	fees := []models.TxFee{
+		{Time: today, Height: 1, EventID: events[0].ID, Pool: common.BNBAsset, AssetAmount: 10, PoolDeduct: 20},
+		{Time: today, Height: 2, EventID: events[1].ID, Pool: common.BNBAsset, RuneAmount: 30},
+		{Time: today, Height: 3, EventID: events[2].ID, Pool: common.BNBAsset, AssetAmount: 5, PoolDeduct: 10},
+		{Time: tomorrow, Height: 4, EventID: events[3].ID, Pool: common.BTCAsset, AssetAmount: 1, PoolDeduct: 50},
+	}
+	for i := range fees {
+		err := s.Store.CreateTxFeeRecord(&fees[i])
+		c.Assert(err, IsNil)
+	}
+
+	changes, err := s.Store.GetFeeChanges(context.Background(), common.EmptyAsset, models.DailyInterval, today, tomorrow)
+	c.Assert(err, IsNil)
+	c.Assert(changes, helpers.DeepEquals, []models.FeeChanges{
+		{
+			Time:             today,
+			Pool:             common.BNBAsset,
+			Type:             "swap",
+			AssetAmount:      10,
+			RuneAmount:       30,
+			PoolDeduct:       20,
+			NetworkFeeInRune: 50,
+			Count:            2,
+		},
+		{
+			Time:             today,
+			Pool:             common.BNBAsset,
+			Type:             "unstake",
+			AssetAmount:      5,
+			PoolDeduct:       10,
+			NetworkFeeInRune: 10,
+			Count:            1,
+		},
+		{
+			Time:             tomorrow,
+			Pool:             common.BTCAsset,
+			Type:             "swap",
+			AssetAmount:      1,
+			PoolDeduct:       50,
+			NetworkFeeInRune: 50,
+			Count:            1,
+		},
+	})
+
+	changes, err = s.Store.GetFeeChanges(context.Background(), common.BTCAsset, models.DailyInterval, today, tomorrow)
+	c.Assert(err, IsNil)
+	c.Assert(changes, HasLen, 1)
+
+	coins, inRune := s.Store.networkFee(context.Background(), uint64(events[0].ID), uint64(events[1].ID))
+	c.Assert(coins, DeepEquals, common.Coins{
+		{Asset: common.BNBAsset, Amount: 10},
+		{Asset: common.RuneAsset(), Amount: 30},
+	})
+	c.Assert(inRune, Equals, int64(50))
+}
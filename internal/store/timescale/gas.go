@@ -1,6 +1,11 @@
 package timescale
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
 	"github.com/pkg/errors"
 	"gitlab.com/thorchain/midgard/internal/common"
 	"gitlab.com/thorchain/midgard/internal/models"
@@ -32,3 +37,68 @@ func (s *Client) CreateGasRecord(record *models.EventGas) error {
 	}
 	return nil
 }
+
+type gasChanges struct {
+	Time           time.Time     `db:"time"`
+	Chain          string        `db:"chain"`
+	GasUsed        sql.NullInt64 `db:"gas_used"`
+	GasUsedInRune  sql.NullInt64 `db:"gas_used_in_rune"`
+	GasReplenished sql.NullInt64 `db:"gas_replenished"`
+	OutboundCount  sql.NullInt64 `db:"outbound_count"`
+}
+
+// GetGasChanges returns the gas spent and replenished on the specified chain, or
+// all chains if it's empty, alongside the number of outbound txs per time bucket.
+func (s *Client) GetGasChanges(ctx context.Context, chain common.Chain, inv models.Interval, from, to time.Time) ([]models.GasChanges, error) {
+	timeBucket := getRawTimeBucket(inv)
+	gasFilter, outFilter := "", ""
+	args := []interface{}{from, to}
+	if !chain.IsEmpty() {
+		gasFilter = "AND split_part(pool, '.', 1) = $3"
+		outFilter = "AND chain = $3"
+		args = append(args, chain.String())
+	}
+	q := fmt.Sprintf(`WITH gas AS (
+			SELECT %[1]s AS time, split_part(pool, '.', 1) AS chain,
+				SUM(-asset_amount) AS gas_used,
+				SUM(CASE WHEN asset_depth > 0 THEN -asset_amount::FLOAT * rune_depth / asset_depth ELSE 0 END)::BIGINT AS gas_used_in_rune,
+				SUM(rune_amount) AS gas_replenished
+			FROM pools_history
+			WHERE event_type = 'gas' AND %[1]s BETWEEN $1 AND $2 %[2]s
+			GROUP BY 1, 2
+		), outbounds AS (
+			SELECT %[1]s AS time, chain, COUNT(DISTINCT(tx_hash)) AS outbound_count
+			FROM txs
+			WHERE direction = 'out' AND %[1]s BETWEEN $1 AND $2 %[3]s
+			GROUP BY 1, 2
+		)
+		SELECT COALESCE(gas.time, outbounds.time) AS time,
+			COALESCE(gas.chain, outbounds.chain) AS chain,
+			gas_used, gas_used_in_rune, gas_replenished, outbound_count
+		FROM gas
+		FULL OUTER JOIN outbounds ON gas.time = outbounds.time AND gas.chain = outbounds.chain
+		ORDER BY time, chain`, timeBucket, gasFilter, outFilter)
+	rows, err := s.db().QueryxContext(ctx, q, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "query failed")
+	}
+	defer rows.Close()
+
+	result := []models.GasChanges{}
+	for rows.Next() {
+		var changes gasChanges
+		err := rows.StructScan(&changes)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan failed")
+		}
+		result = append(result, models.GasChanges{
+			Time:           changes.Time,
+			Chain:          common.Chain(changes.Chain),
+			GasUsed:        changes.GasUsed.Int64,
+			GasUsedInRune:  changes.GasUsedInRune.Int64,
+			GasReplenished: changes.GasReplenished.Int64,
+			OutboundCount:  changes.OutboundCount.Int64,
+		})
+	}
+	return result, nil
+}
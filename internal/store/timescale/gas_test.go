@@ -1,7 +1,12 @@
 package timescale
 
 import (
+	"context"
+	"time"
+
 	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/models"
+	"gitlab.com/thorchain/midgard/pkg/helpers"
 	. "gopkg.in/check.v1"
 )
 
@@ -33,3 +38,96 @@ func (s *TimeScaleSuite) TestGasSpend(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(assetDepth, Equals, int64(0))
 }
+
+func (s *TimeScaleSuite) TestGetGasChanges(c *C) {
+	today := time.Date(2020, 8, 14, 0, 0, 0, 0, time.UTC)
+	tomorrow := today.Add(time.Hour * 24)
+
+	err := s.Store.UpdatePoolsHistory(&models.PoolChange{
+		Time:        today,
+		EventID:     1,
+		EventType:   "stake",
+		Pool:        common.BNBAsset,
+		AssetAmount: 1010,
+		RuneAmount:  2000,
+		Height:      1,
+	})
+	c.Assert(err, IsNil)
+	outbound := models.Event{
+		Time:   today,
+		Height: 2,
+		Type:   "unstake",
+		Status: "Success",
+		OutTxs: common.Txs{
+			{
+				ID:          "E5869F3E93A4B0C0C63D79130ACBFA8A40590F0B54F82343E7F3C334C23F55B4",
+				Chain:       common.BNBChain,
+				FromAddress: "bnb1llvmhawaxxjchwmfmj8fjzftvwz4jpdhapp5hr",
+				ToAddress:   "bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38",
+				Coins: common.Coins{
+					{Asset: common.BNBAsset, Amount: 40},
+				},
+			},
+			{
+				ID:          "4B074E4B83156A4E69A565B7E5AA8E106FC62F3390D9A947AA68BFEF2B092021",
+				Chain:       common.BNBChain,
+				FromAddress: "bnb1llvmhawaxxjchwmfmj8fjzftvwz4jpdhapp5hr",
+				ToAddress:   "bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38",
+				Coins: common.Coins{
+					{Asset: common.BNBAsset, Amount: 10},
+				},
+			},
+		},
+	}
+	err = s.Store.CreateEventRecord(&outbound)
+	c.Assert(err, IsNil)
+	gas := models.EventGas{
+		Event: models.Event{
+			Time:   today,
+			Height: 3,
+			Type:   "gas",
+			Status: "Success",
+		},
+		Pools: []models.GasPool{
+			{Asset: common.BNBAsset, AssetAmt: 10, RuneAmt: 20},
+		},
+	}
+	err = s.Store.CreateGasRecord(&gas)
+	c.Assert(err, IsNil)
+	gas = models.EventGas{
+		Event: models.Event{
+			Time:   tomorrow,
+			Height: 4,
+			Type:   "gas",
+			Status: "Success",
+		},
+		Pools: []models.GasPool{
+			{Asset: common.BTCAsset, AssetAmt: 5},
+		},
+	}
+	err = s.Store.CreateGasRecord(&gas)
+	c.Assert(err, IsNil)
+
+	changes, err := s.Store.GetGasChanges(context.Background(), "", models.DailyInterval, today, tomorrow)
+	c.Assert(err, IsNil)
+	c.Assert(changes, helpers.DeepEquals, []models.GasChanges{
+		{
+			Time:           today,
+			Chain:          common.BNBChain,
+			GasUsed:        10,
+			GasUsedInRune:  20,
+			GasReplenished: 20,
+			OutboundCount:  2,
+		},
+		{
+			Time:    tomorrow,
+			Chain:   common.BTCChain,
+			GasUsed: 5,
+		},
+	})
+
+	changes, err = s.Store.GetGasChanges(context.Background(), common.BTCChain, models.DailyInterval, today, tomorrow)
+	c.Assert(err, IsNil)
+	c.Assert(changes, HasLen, 1)
+	c.Assert(changes[0].Chain, Equals, common.BTCChain)
+}
@@ -0,0 +1,74 @@
+package timescale
+
+import (
+	"context"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/pkg/errors"
+	"gitlab.com/thorchain/midgard/internal/models"
+)
+
+// pendingEventTypes are types of events which are pending until their outbound
+// tx is observed.
+var pendingEventTypes = []interface{}{"swap", "doubleSwap", "unstake", "refund"}
+
+// GetPendingTxs returns the events still waiting for their outbound tx ordered
+// from the oldest alongside the total number of them.
+func (s *Client) GetPendingTxs(ctx context.Context, offset, limit int64) ([]models.PendingTx, int64, error) {
+	count, err := s.GetPendingTxsCount(ctx, time.Time{})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select("id", "type", "height", "time", "expected_outbounds",
+		"(SELECT COUNT(DISTINCT(tx_hash)) FROM txs WHERE txs.event_id = events.id AND txs.direction = 'out')")
+	sb.From("events")
+	sb.Where(sb.Equal("status", "Pending"), sb.In("type", pendingEventTypes...))
+	sb.OrderBy("time", "id")
+	sb.Offset(int(offset))
+	sb.Limit(int(limit))
+
+	q, args := sb.Build()
+	rows, err := s.db().QueryxContext(ctx, q, args...)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "query failed")
+	}
+	defer rows.Close()
+
+	txs := []models.PendingTx{}
+	for rows.Next() {
+		var tx models.PendingTx
+		err := rows.Scan(&tx.EventID, &tx.Type, &tx.Height, &tx.Time, &tx.ExpectedOutbounds, &tx.Outbounds)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "scan failed")
+		}
+		txs = append(txs, tx)
+	}
+	for i, tx := range txs {
+		txs[i].Pool = s.eventPool(ctx, uint64(tx.EventID))
+		txs[i].In = s.inTx(ctx, uint64(tx.EventID))
+	}
+	return txs, count, nil
+}
+
+// GetPendingTxsCount returns the number of events waiting for their outbound tx
+// since before the given time. All pending events are counted if before is zero.
+func (s *Client) GetPendingTxsCount(ctx context.Context, before time.Time) (int64, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select("COUNT(*)")
+	sb.From("events")
+	sb.Where(sb.Equal("status", "Pending"), sb.In("type", pendingEventTypes...))
+	if !before.IsZero() {
+		sb.Where(sb.LessThan("time", before))
+	}
+
+	q, args := sb.Build()
+	var count int64
+	err := s.db().GetContext(ctx, &count, q, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "query failed")
+	}
+	return count, nil
+}
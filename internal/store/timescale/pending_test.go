@@ -0,0 +1,65 @@
+package timescale
+
+import (
+	"context"
+	"time"
+
+	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/models"
+	"gitlab.com/thorchain/midgard/pkg/helpers"
+	. "gopkg.in/check.v1"
+)
+
+func (s *TimeScaleSuite) TestGetPendingTxs(c *C) {
+	now := time.Date(2020, 8, 15, 12, 0, 0, 0, time.UTC)
+	inTx := common.Tx{
+		ID:          "2F624637DE179665BA3322B864DB9F30001FD37B4E0D22A0B6ECE6A5B078DAB4",
+		Chain:       common.BNBChain,
+		FromAddress: "bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38",
+		ToAddress:   "bnb1llvmhawaxxjchwmfmj8fjzftvwz4jpdhapp5hr",
+		Coins:       common.Coins{{Asset: common.RuneAsset(), Amount: 100}},
+		Memo:        "SWAP:BNB.BNB",
+	}
+	events := []models.Event{
+		{Time: now.Add(-time.Hour), Height: 1, Type: "swap", Status: "Pending", InTx: inTx},
+		{Time: now.Add(-time.Minute), Height: 2, Type: "unstake", Status: "Pending"},
+		{Time: now.Add(-time.Minute), Height: 2, Type: "swap", Status: "Success"},
+		{Time: now, Height: 3, Type: "stake", Status: "Pending"},
+		{Time: now, Height: 3, Type: "refund", Status: "Pending"},
+	}
+	for i := range events {
+		err := s.Store.CreateEventRecord(&events[i])
+		c.Assert(err, IsNil)
+	}
+
+	count, err := s.Store.GetPendingTxsCount(context.Background(), time.Time{})
+	c.Assert(err, IsNil)
+	c.Assert(count, Equals, int64(3))
+	count, err = s.Store.GetPendingTxsCount(context.Background(), now.Add(-time.Minute*15))
+	c.Assert(err, IsNil)
+	c.Assert(count, Equals, int64(1))
+
+	txs, count, err := s.Store.GetPendingTxs(context.Background(), 0, 2)
+	c.Assert(err, IsNil)
+	c.Assert(count, Equals, int64(3))
+	c.Assert(txs, helpers.DeepEquals, []models.PendingTx{
+		{
+			EventID: events[0].ID,
+			Type:    "swap",
+			Height:  1,
+			Time:    now.Add(-time.Hour),
+			In: models.TxData{
+				Address: "bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38",
+				Coin:    common.Coins{{Asset: common.RuneAsset(), Amount: 100}},
+				Memo:    "SWAP:BNB.BNB",
+				TxID:    "2F624637DE179665BA3322B864DB9F30001FD37B4E0D22A0B6ECE6A5B078DAB4",
+			},
+		},
+		{
+			EventID: events[1].ID,
+			Type:    "unstake",
+			Height:  2,
+			Time:    now.Add(-time.Minute),
+		},
+	})
+}
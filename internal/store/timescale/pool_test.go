@@ -1,6 +1,8 @@
 package timescale
 
 import (
+	"context"
+
 	"gitlab.com/thorchain/midgard/internal/common"
 	"gitlab.com/thorchain/midgard/internal/models"
 	. "gopkg.in/check.v1"
@@ -11,26 +13,26 @@ func (s *TimeScaleSuite) TestPool(c *C) {
 	assetTcan, _ := common.NewAsset("TCAN-014")
 
 	// No pool status (default value)
-	poolStatus, err := s.Store.GetPoolStatus(assetBolt)
+	poolStatus, err := s.Store.GetPoolStatus(context.Background(), assetBolt)
 	c.Assert(err, IsNil)
 	c.Assert(poolStatus, Equals, models.Unknown)
 
 	// First pool status
 	err = s.Store.CreatePoolRecord(&poolStatusEvent0)
 	c.Assert(err, IsNil)
-	poolStatus, err = s.Store.GetPoolStatus(assetBolt)
+	poolStatus, err = s.Store.GetPoolStatus(context.Background(), assetBolt)
 	c.Assert(err, IsNil)
 	c.Assert(poolStatus, Equals, models.Bootstrap)
 
 	// Unchanged pool status
-	poolStatus, err = s.Store.GetPoolStatus(assetTcan)
+	poolStatus, err = s.Store.GetPoolStatus(context.Background(), assetTcan)
 	c.Assert(err, IsNil)
 	c.Assert(poolStatus, Equals, models.Unknown)
 
 	// Second pool status
 	err = s.Store.CreatePoolRecord(&poolStatusEvent1)
 	c.Assert(err, IsNil)
-	poolStatus, err = s.Store.GetPoolStatus(assetBolt)
+	poolStatus, err = s.Store.GetPoolStatus(context.Background(), assetBolt)
 	c.Assert(err, IsNil)
 	c.Assert(poolStatus, Equals, models.Enabled)
 }
@@ -1,6 +1,7 @@
 package timescale
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
@@ -11,7 +12,7 @@ import (
 	"gitlab.com/thorchain/midgard/internal/models"
 )
 
-func (s *Client) GetPool(asset common.Asset) (common.Asset, error) {
+func (s *Client) GetPool(ctx context.Context, asset common.Asset) (common.Asset, error) {
 	pool, ok := s.pools[asset.String()]
 	if ok && pool.Units > 0 {
 		return pool.Asset, nil
@@ -20,7 +21,7 @@ func (s *Client) GetPool(asset common.Asset) (common.Asset, error) {
 }
 
 // GetPoolBasics returns the basics of pool like asset and rune depths, units and status.
-func (s *Client) GetPoolBasics(pool common.Asset) (models.PoolBasics, error) {
+func (s *Client) GetPoolBasics(ctx context.Context, pool common.Asset) (models.PoolBasics, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -30,7 +31,7 @@ func (s *Client) GetPoolBasics(pool common.Asset) (models.PoolBasics, error) {
 	return models.PoolBasics{}, errors.New("pool doesn't exist")
 }
 
-func (s *Client) GetPools() ([]common.Asset, error) {
+func (s *Client) GetPools(ctx context.Context) ([]common.Asset, error) {
 	var pools []common.Asset
 	for _, pool := range s.pools {
 		if pool.Units > 0 && !pool.Asset.Symbol.IsMiniToken() {
@@ -41,7 +42,7 @@ func (s *Client) GetPools() ([]common.Asset, error) {
 	return pools, nil
 }
 
-func (s *Client) GetPoolSwapStats(asset common.Asset) (models.PoolSwapStats, error) {
+func (s *Client) GetPoolSwapStats(ctx context.Context, asset common.Asset) (models.PoolSwapStats, error) {
 	stmnt := `
 		SELECT AVG(ABS(runeAmt)), AVG(trade_slip), COUNT(*)
 		FROM swaps
@@ -50,7 +51,7 @@ func (s *Client) GetPoolSwapStats(asset common.Asset) (models.PoolSwapStats, err
 
 	var txAverge, slipAverage sql.NullFloat64
 	var count sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 	if err := row.Scan(&txAverge, &slipAverage, &count); err != nil {
 		return models.PoolSwapStats{}, errors.Wrap(err, "poolTxAverage failed")
 	}
@@ -62,13 +63,13 @@ func (s *Client) GetPoolSwapStats(asset common.Asset) (models.PoolSwapStats, err
 	}, nil
 }
 
-func (s *Client) getPriceInRune(asset common.Asset) (float64, error) {
-	assetDepth, err := s.GetAssetDepth(asset)
+func (s *Client) getPriceInRune(ctx context.Context, asset common.Asset) (float64, error) {
+	assetDepth, err := s.GetAssetDepth(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "getPriceInRune failed")
 	}
 	if assetDepth > 0 {
-		runeDepth, err := s.GetRuneDepth(asset)
+		runeDepth, err := s.GetRuneDepth(ctx, asset)
 		if err != nil {
 			return 0, errors.Wrap(err, "getPriceInRune failed")
 		}
@@ -78,7 +79,7 @@ func (s *Client) getPriceInRune(asset common.Asset) (float64, error) {
 	return 0, nil
 }
 
-func (s *Client) GetDateCreated(asset common.Asset) (uint64, error) {
+func (s *Client) GetDateCreated(ctx context.Context, asset common.Asset) (uint64, error) {
 	pool, ok := s.pools[asset.String()]
 	if !ok {
 		return 0, store.ErrPoolNotFound
@@ -86,8 +87,8 @@ func (s *Client) GetDateCreated(asset common.Asset) (uint64, error) {
 	return uint64(pool.DateCreated.Unix()), nil
 }
 
-func (s *Client) exists(asset common.Asset) (bool, error) {
-	staked, err := s.stakeTxCount(asset)
+func (s *Client) exists(ctx context.Context, asset common.Asset) (bool, error) {
+	staked, err := s.stakeTxCount(ctx, asset)
 	if err != nil {
 		return false, errors.Wrap(err, "exists failed")
 	}
@@ -120,7 +121,7 @@ func (s *Client) assetStakedTotal(asset common.Asset) (uint64, error) {
 	return 0, nil
 }
 
-func (s *Client) assetStaked12m(asset common.Asset) (int64, error) {
+func (s *Client) assetStaked12m(ctx context.Context, asset common.Asset) (int64, error) {
 	stmnt := `
 		SELECT SUM(asset_amount)
 		FROM pools_history
@@ -130,7 +131,7 @@ func (s *Client) assetStaked12m(asset common.Asset) (int64, error) {
 		AND pools_history.time BETWEEN NOW() - INTERVAL '12 MONTHS' AND NOW()`
 
 	var assetStakedTotal sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&assetStakedTotal); err != nil {
 		return 0, errors.Wrap(err, "assetStaked12m failed")
@@ -172,7 +173,7 @@ func (s *Client) runeStaked(asset common.Asset) (int64, error) {
 	return 0, nil
 }
 
-func (s *Client) runeStaked12m(asset common.Asset) (int64, error) {
+func (s *Client) runeStaked12m(ctx context.Context, asset common.Asset) (int64, error) {
 	stmnt := `
 		SELECT SUM(rune_amount)
 		FROM pools_history
@@ -182,7 +183,7 @@ func (s *Client) runeStaked12m(asset common.Asset) (int64, error) {
 		AND pools_history.time BETWEEN NOW() - INTERVAL '12 MONTHS' AND NOW()`
 
 	var runeStaked12m sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&runeStaked12m); err != nil {
 		return 0, errors.Wrap(err, "runeStaked12m failed")
@@ -191,7 +192,7 @@ func (s *Client) runeStaked12m(asset common.Asset) (int64, error) {
 	return runeStaked12m.Int64, nil
 }
 
-func (s *Client) poolStakedTotal(asset common.Asset) (uint64, error) {
+func (s *Client) poolStakedTotal(ctx context.Context, asset common.Asset) (uint64, error) {
 	assetTotal, err := s.assetStakedTotal(asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "poolStakedTotal failed")
@@ -200,7 +201,7 @@ func (s *Client) poolStakedTotal(asset common.Asset) (uint64, error) {
 	if err != nil {
 		return 0, nil
 	}
-	priceInRune, err := s.getPriceInRune(asset)
+	priceInRune, err := s.getPriceInRune(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "poolStakedTotal failed")
 	}
@@ -211,8 +212,8 @@ func (s *Client) poolStakedTotal(asset common.Asset) (uint64, error) {
 	return stakedTotal, nil
 }
 
-func (s *Client) poolAddedTotal(asset common.Asset) (uint64, error) {
-	priceInRune, err := s.getPriceInRune(asset)
+func (s *Client) poolAddedTotal(ctx context.Context, asset common.Asset) (uint64, error) {
+	priceInRune, err := s.getPriceInRune(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "poolStakedTotal failed")
 	}
@@ -224,7 +225,7 @@ func (s *Client) poolAddedTotal(asset common.Asset) (uint64, error) {
 	return 0, nil
 }
 
-func (s *Client) GetAssetDepth(asset common.Asset) (uint64, error) {
+func (s *Client) GetAssetDepth(ctx context.Context, asset common.Asset) (uint64, error) {
 	depth, err := s.getAssetDepth(asset)
 	return uint64(depth), err
 }
@@ -239,12 +240,12 @@ func (s *Client) getAssetDepth(asset common.Asset) (int64, error) {
 	return 0, nil
 }
 
-func (s *Client) assetDepth12m(asset common.Asset) (uint64, error) {
+func (s *Client) assetDepth12m(ctx context.Context, asset common.Asset) (uint64, error) {
 	stmnt := `SELECT SUM(asset_amount) FROM pools_history WHERE pool = $1 
 		AND time BETWEEN NOW() - INTERVAL '12 MONTHS' AND NOW()`
 
 	var depth sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&depth); err != nil {
 		return 0, errors.Wrap(err, "assetDepth12m failed")
@@ -253,7 +254,7 @@ func (s *Client) assetDepth12m(asset common.Asset) (uint64, error) {
 	return uint64(depth.Int64), nil
 }
 
-func (s *Client) GetRuneDepth(asset common.Asset) (uint64, error) {
+func (s *Client) GetRuneDepth(ctx context.Context, asset common.Asset) (uint64, error) {
 	depth, err := s.getRuneDepth(asset)
 	return uint64(depth), err
 }
@@ -268,12 +269,12 @@ func (s *Client) getRuneDepth(asset common.Asset) (int64, error) {
 	return 0, nil
 }
 
-func (s *Client) runeDepth12m(asset common.Asset) (uint64, error) {
+func (s *Client) runeDepth12m(ctx context.Context, asset common.Asset) (uint64, error) {
 	stmnt := `SELECT SUM(rune_amount) FROM pools_history WHERE pool = $1 
 		AND time BETWEEN NOW() - INTERVAL '12 MONTHS' AND NOW()`
 
 	var depth sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&depth); err != nil {
 		return 0, errors.Wrap(err, "runeDepth12m failed")
@@ -283,7 +284,7 @@ func (s *Client) runeDepth12m(asset common.Asset) (uint64, error) {
 }
 
 // runeSwapped - amount rune swapped through the pool
-func (s *Client) runeSwapped(asset common.Asset) (int64, error) {
+func (s *Client) runeSwapped(ctx context.Context, asset common.Asset) (int64, error) {
 	stmnt := `
 		SELECT SUM(runeAmt)
 		FROM swaps
@@ -291,7 +292,7 @@ func (s *Client) runeSwapped(asset common.Asset) (int64, error) {
 	`
 
 	var total sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&total); err != nil {
 		return 0, errors.Wrap(err, "runeSwapTotal failed")
@@ -302,7 +303,7 @@ func (s *Client) runeSwapped(asset common.Asset) (int64, error) {
 
 // runeSwap12m - amount rune swapped through the pool in the last 12
 // months
-func (s *Client) runeSwap12m(asset common.Asset) (int64, error) {
+func (s *Client) runeSwap12m(ctx context.Context, asset common.Asset) (int64, error) {
 	stmnt := `
 		SELECT SUM(runeAmt)
 		FROM swaps
@@ -311,7 +312,7 @@ func (s *Client) runeSwap12m(asset common.Asset) (int64, error) {
 	`
 
 	var runeSwap12m sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&runeSwap12m); err != nil {
 		return 0, errors.Wrap(err, "runeSwap12m failed")
@@ -321,7 +322,7 @@ func (s *Client) runeSwap12m(asset common.Asset) (int64, error) {
 }
 
 // assetSwap returns the sum of assets swapped
-func (s *Client) assetSwap(asset common.Asset) (int64, error) {
+func (s *Client) assetSwap(ctx context.Context, asset common.Asset) (int64, error) {
 	stmnt := `
 		SELECT SUM(assetAmt)
 		FROM swaps
@@ -329,7 +330,7 @@ func (s *Client) assetSwap(asset common.Asset) (int64, error) {
 	`
 
 	var total sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&total); err != nil {
 		return 0, errors.Wrap(err, "assetSwapTotal failed")
@@ -338,7 +339,7 @@ func (s *Client) assetSwap(asset common.Asset) (int64, error) {
 	return total.Int64, nil
 }
 
-func (s *Client) assetSwapped12m(asset common.Asset) (int64, error) {
+func (s *Client) assetSwapped12m(ctx context.Context, asset common.Asset) (int64, error) {
 	stmnt := `
 		SELECT SUM(assetAmt)
 		FROM swaps
@@ -347,7 +348,7 @@ func (s *Client) assetSwapped12m(asset common.Asset) (int64, error) {
 	`
 
 	var total sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&total); err != nil {
 		return 0, errors.Wrap(err, "assetSwapped12m failed")
@@ -356,8 +357,8 @@ func (s *Client) assetSwapped12m(asset common.Asset) (int64, error) {
 	return total.Int64, nil
 }
 
-func (s *Client) poolDepth(asset common.Asset) (uint64, error) {
-	runeDepth, err := s.GetRuneDepth(asset)
+func (s *Client) poolDepth(ctx context.Context, asset common.Asset) (uint64, error) {
+	runeDepth, err := s.GetRuneDepth(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "poolDepth failed")
 	}
@@ -374,7 +375,7 @@ func (s *Client) poolUnits(asset common.Asset) (uint64, error) {
 	return 0, nil
 }
 
-func (s *Client) sellVolume(asset common.Asset) (uint64, error) {
+func (s *Client) sellVolume(ctx context.Context, asset common.Asset) (uint64, error) {
 	stmnt := `
 		SELECT SUM(runeAmt)
 		FROM swaps
@@ -383,7 +384,7 @@ func (s *Client) sellVolume(asset common.Asset) (uint64, error) {
 	`
 
 	var sellVolume sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&sellVolume); err != nil {
 		return 0, errors.Wrap(err, "sellVolume failed")
@@ -392,7 +393,7 @@ func (s *Client) sellVolume(asset common.Asset) (uint64, error) {
 	return uint64(float64(-sellVolume.Int64)), nil
 }
 
-func (s *Client) sellVolume24hr(asset common.Asset) (uint64, error) {
+func (s *Client) sellVolume24hr(ctx context.Context, asset common.Asset) (uint64, error) {
 	stmnt := `
 		SELECT SUM(runeAmt)
 		FROM swaps
@@ -402,7 +403,7 @@ func (s *Client) sellVolume24hr(asset common.Asset) (uint64, error) {
 	`
 
 	var sellVolume sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&sellVolume); err != nil {
 		return 0, errors.Wrap(err, "sellVolume24hr failed")
@@ -411,7 +412,7 @@ func (s *Client) sellVolume24hr(asset common.Asset) (uint64, error) {
 	return uint64(-sellVolume.Int64), nil
 }
 
-func (s *Client) buyVolume(asset common.Asset) (uint64, error) {
+func (s *Client) buyVolume(ctx context.Context, asset common.Asset) (uint64, error) {
 	stmnt := `
 		SELECT SUM(assetAmt)
 		FROM swaps
@@ -420,20 +421,20 @@ func (s *Client) buyVolume(asset common.Asset) (uint64, error) {
 	`
 
 	var buyVolume sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&buyVolume); err != nil {
 		return 0, errors.Wrap(err, "buyVolume failed")
 	}
 
-	priceInRune, err := s.getPriceInRune(asset)
+	priceInRune, err := s.getPriceInRune(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "buyVolume failed")
 	}
 	return uint64(float64(-buyVolume.Int64) * priceInRune), nil
 }
 
-func (s *Client) buyVolume24hr(asset common.Asset) (uint64, error) {
+func (s *Client) buyVolume24hr(ctx context.Context, asset common.Asset) (uint64, error) {
 	stmnt := `
 		SELECT SUM(assetAmt)
 		FROM swaps
@@ -443,26 +444,26 @@ func (s *Client) buyVolume24hr(asset common.Asset) (uint64, error) {
 	`
 
 	var buyVolume sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&buyVolume); err != nil {
 		return 0, errors.Wrap(err, "buyVolume24hr failed")
 	}
 
-	priceInRune, err := s.getPriceInRune(asset)
+	priceInRune, err := s.getPriceInRune(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "buyVolume24hr failed")
 	}
 	return uint64(float64(-buyVolume.Int64) * priceInRune), nil
 }
 
-func (s *Client) poolVolume(asset common.Asset) (uint64, error) {
-	sellVolume, err := s.sellVolume(asset)
+func (s *Client) poolVolume(ctx context.Context, asset common.Asset) (uint64, error) {
+	sellVolume, err := s.sellVolume(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "poolVolume failed")
 	}
 
-	buyVolume, err := s.buyVolume(asset)
+	buyVolume, err := s.buyVolume(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "poolVolume failed")
 	}
@@ -470,7 +471,7 @@ func (s *Client) poolVolume(asset common.Asset) (uint64, error) {
 	return buyVolume + sellVolume, nil
 }
 
-func (s *Client) GetPoolVolume(asset common.Asset, from, to time.Time) (int64, error) {
+func (s *Client) GetPoolVolume(ctx context.Context, asset common.Asset, from, to time.Time) (int64, error) {
 	stmnt := `
 		SELECT SUM(ABS(rune_amount))
 		FROM pools_history
@@ -480,7 +481,7 @@ func (s *Client) GetPoolVolume(asset common.Asset, from, to time.Time) (int64, e
 	`
 
 	var vol sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String(), from, to)
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String(), from, to)
 
 	if err := row.Scan(&vol); err != nil {
 		return 0, errors.Wrap(err, "GetPoolVolume failed")
@@ -489,7 +490,7 @@ func (s *Client) GetPoolVolume(asset common.Asset, from, to time.Time) (int64, e
 	return vol.Int64, nil
 }
 
-func (s *Client) sellTxAverage(asset common.Asset) (float64, error) {
+func (s *Client) sellTxAverage(ctx context.Context, asset common.Asset) (float64, error) {
 	stmnt := `
 		SELECT AVG(assetAmt)
 		FROM swaps
@@ -498,20 +499,20 @@ func (s *Client) sellTxAverage(asset common.Asset) (float64, error) {
 	`
 
 	var avg sql.NullFloat64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&avg); err != nil {
 		return 0, errors.Wrap(err, "sellTxAverage failed")
 	}
 
-	priceInRune, err := s.getPriceInRune(asset)
+	priceInRune, err := s.getPriceInRune(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "sellTxAverage failed")
 	}
 	return avg.Float64 * priceInRune, nil
 }
 
-func (s *Client) buyTxAverage(asset common.Asset) (float64, error) {
+func (s *Client) buyTxAverage(ctx context.Context, asset common.Asset) (float64, error) {
 	stmnt := `
 		SELECT AVG(assetAmt)
 		FROM swaps
@@ -520,13 +521,13 @@ func (s *Client) buyTxAverage(asset common.Asset) (float64, error) {
 	`
 
 	var avg sql.NullFloat64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&avg); err != nil {
 		return 0, errors.Wrap(err, "buyTxAverage failed")
 	}
 
-	priceInRune, err := s.getPriceInRune(asset)
+	priceInRune, err := s.getPriceInRune(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "buyTxAverage failed")
 	}
@@ -534,7 +535,7 @@ func (s *Client) buyTxAverage(asset common.Asset) (float64, error) {
 	return -avg.Float64 * priceInRune, nil
 }
 
-func (s *Client) poolTxAverage(asset common.Asset) (float64, error) {
+func (s *Client) poolTxAverage(ctx context.Context, asset common.Asset) (float64, error) {
 	stmnt := `
 		SELECT AVG(ABS(assetAmt))
 		FROM swaps
@@ -542,13 +543,13 @@ func (s *Client) poolTxAverage(asset common.Asset) (float64, error) {
 	`
 
 	var avg sql.NullFloat64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&avg); err != nil {
 		return 0, errors.Wrap(err, "poolTxAverage failed")
 	}
 
-	priceInRune, err := s.getPriceInRune(asset)
+	priceInRune, err := s.getPriceInRune(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "poolTxAverage failed")
 	}
@@ -556,7 +557,7 @@ func (s *Client) poolTxAverage(asset common.Asset) (float64, error) {
 	return avg.Float64 * priceInRune, nil
 }
 
-func (s *Client) sellSlipAverage(asset common.Asset) (float64, error) {
+func (s *Client) sellSlipAverage(ctx context.Context, asset common.Asset) (float64, error) {
 	stmnt := `
 		SELECT AVG(trade_slip)
 		FROM swaps
@@ -565,7 +566,7 @@ func (s *Client) sellSlipAverage(asset common.Asset) (float64, error) {
 	`
 
 	var sellSlipAverage sql.NullFloat64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&sellSlipAverage); err != nil {
 		return 0, errors.Wrap(err, "sellSlipAverage failed")
@@ -574,7 +575,7 @@ func (s *Client) sellSlipAverage(asset common.Asset) (float64, error) {
 	return sellSlipAverage.Float64, nil
 }
 
-func (s *Client) buySlipAverage(asset common.Asset) (float64, error) {
+func (s *Client) buySlipAverage(ctx context.Context, asset common.Asset) (float64, error) {
 	stmnt := `
 		SELECT AVG(trade_slip)
 		FROM swaps
@@ -583,7 +584,7 @@ func (s *Client) buySlipAverage(asset common.Asset) (float64, error) {
 	`
 
 	var buySlipAverage sql.NullFloat64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&buySlipAverage); err != nil {
 		return 0, errors.Wrap(err, "buySlipAverage failed")
@@ -592,7 +593,7 @@ func (s *Client) buySlipAverage(asset common.Asset) (float64, error) {
 	return buySlipAverage.Float64, nil
 }
 
-func (s *Client) poolSlipAverage(asset common.Asset) (float64, error) {
+func (s *Client) poolSlipAverage(ctx context.Context, asset common.Asset) (float64, error) {
 	stmnt := `
 		SELECT AVG(trade_slip)
 		FROM swaps
@@ -600,7 +601,7 @@ func (s *Client) poolSlipAverage(asset common.Asset) (float64, error) {
 	`
 
 	var poolSlipAverage sql.NullFloat64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&poolSlipAverage); err != nil {
 		return 0, errors.Wrap(err, "poolSlipAverage failed")
@@ -608,7 +609,7 @@ func (s *Client) poolSlipAverage(asset common.Asset) (float64, error) {
 	return poolSlipAverage.Float64, nil
 }
 
-func (s *Client) sellFeeAverage(asset common.Asset) (float64, error) {
+func (s *Client) sellFeeAverage(ctx context.Context, asset common.Asset) (float64, error) {
 	stmnt := `
 		SELECT AVG(liquidity_fee)
 		FROM swaps
@@ -617,7 +618,7 @@ func (s *Client) sellFeeAverage(asset common.Asset) (float64, error) {
 	`
 
 	var sellFeeAverage sql.NullFloat64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&sellFeeAverage); err != nil {
 		return 0, errors.Wrap(err, "sellFeeAverage failed")
@@ -626,7 +627,7 @@ func (s *Client) sellFeeAverage(asset common.Asset) (float64, error) {
 	return sellFeeAverage.Float64, nil
 }
 
-func (s *Client) buyFeeAverage(asset common.Asset) (float64, error) {
+func (s *Client) buyFeeAverage(ctx context.Context, asset common.Asset) (float64, error) {
 	stmnt := `
 		SELECT AVG(liquidity_fee)
 		FROM swaps
@@ -635,36 +636,36 @@ func (s *Client) buyFeeAverage(asset common.Asset) (float64, error) {
 	`
 
 	var buyFeeAverage sql.NullFloat64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&buyFeeAverage); err != nil {
 		return 0, errors.Wrap(err, "buyFeeAverage failed")
 	}
 
-	priceInRune, err := s.getPriceInRune(asset)
+	priceInRune, err := s.getPriceInRune(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "buyFeeAverage failed")
 	}
 	return buyFeeAverage.Float64 * priceInRune, nil
 }
 
-func (s *Client) poolFeeAverage(asset common.Asset) (float64, error) {
-	sellFeesTotal, err := s.sellFeesTotal(asset)
+func (s *Client) poolFeeAverage(ctx context.Context, asset common.Asset) (float64, error) {
+	sellFeesTotal, err := s.sellFeesTotal(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "poolFeeAverage failed")
 	}
 
-	buyFeesTotal, err := s.buyFeesTotal(asset)
+	buyFeesTotal, err := s.buyFeesTotal(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "poolFeeAverage failed")
 	}
 
-	priceInRune, err := s.getPriceInRune(asset)
+	priceInRune, err := s.getPriceInRune(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "buyFeesTotal failed")
 	}
 
-	swappingTxCount, err := s.swappingTxCount(asset)
+	swappingTxCount, err := s.swappingTxCount(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "poolFeeAverage failed")
 	}
@@ -674,7 +675,7 @@ func (s *Client) poolFeeAverage(asset common.Asset) (float64, error) {
 	return (float64(sellFeesTotal) + (float64(buyFeesTotal) * priceInRune)) / float64(swappingTxCount), nil
 }
 
-func (s *Client) sellFeesTotal(asset common.Asset) (uint64, error) {
+func (s *Client) sellFeesTotal(ctx context.Context, asset common.Asset) (uint64, error) {
 	stmnt := `
 		SELECT SUM(liquidity_fee)
 		FROM swaps
@@ -683,7 +684,7 @@ func (s *Client) sellFeesTotal(asset common.Asset) (uint64, error) {
 	`
 
 	var sellFeesTotal sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&sellFeesTotal); err != nil {
 		return 0, errors.Wrap(err, "sellFeesTotal failed")
@@ -692,7 +693,7 @@ func (s *Client) sellFeesTotal(asset common.Asset) (uint64, error) {
 	return uint64(sellFeesTotal.Int64), nil
 }
 
-func (s *Client) buyFeesTotal(asset common.Asset) (uint64, error) {
+func (s *Client) buyFeesTotal(ctx context.Context, asset common.Asset) (uint64, error) {
 	stmnt := `
 		SELECT SUM(liquidity_fee)
 		FROM swaps
@@ -701,7 +702,7 @@ func (s *Client) buyFeesTotal(asset common.Asset) (uint64, error) {
 	`
 
 	var buyFeesTotal sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&buyFeesTotal); err != nil {
 		return 0, errors.Wrap(err, "buyFeesTotal failed")
@@ -710,18 +711,18 @@ func (s *Client) buyFeesTotal(asset common.Asset) (uint64, error) {
 	return uint64(buyFeesTotal.Int64), nil
 }
 
-func (s *Client) poolFeesTotal(asset common.Asset) (uint64, error) {
-	buyFeesTotal, err := s.buyFeesTotal(asset)
+func (s *Client) poolFeesTotal(ctx context.Context, asset common.Asset) (uint64, error) {
+	buyFeesTotal, err := s.buyFeesTotal(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "poolFeesTotal failed")
 	}
 
-	sellFeesTotal, err := s.sellFeesTotal(asset)
+	sellFeesTotal, err := s.sellFeesTotal(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "poolFeesTotal failed")
 	}
 
-	priceInRune, err := s.getPriceInRune(asset)
+	priceInRune, err := s.getPriceInRune(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "buyFeesTotal failed")
 	}
@@ -729,7 +730,7 @@ func (s *Client) poolFeesTotal(asset common.Asset) (uint64, error) {
 	return uint64(float64(buyFeesTotal)*priceInRune) + sellFeesTotal, nil
 }
 
-func (s *Client) sellAssetCount(asset common.Asset) (uint64, error) {
+func (s *Client) sellAssetCount(ctx context.Context, asset common.Asset) (uint64, error) {
 	stmnt := `
 		SELECT COUNT(assetAmt)
 		FROM swaps
@@ -738,7 +739,7 @@ func (s *Client) sellAssetCount(asset common.Asset) (uint64, error) {
 	`
 
 	var sellAssetCount sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&sellAssetCount); err != nil {
 		return 0, errors.Wrap(err, "sellAssetCount failed")
@@ -747,7 +748,7 @@ func (s *Client) sellAssetCount(asset common.Asset) (uint64, error) {
 	return uint64(sellAssetCount.Int64), nil
 }
 
-func (s *Client) buyAssetCount(asset common.Asset) (uint64, error) {
+func (s *Client) buyAssetCount(ctx context.Context, asset common.Asset) (uint64, error) {
 	stmnt := `
 		SELECT COUNT(liquidity_fee)
 		FROM swaps
@@ -756,7 +757,7 @@ func (s *Client) buyAssetCount(asset common.Asset) (uint64, error) {
 	`
 
 	var buyAssetCount sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&buyAssetCount); err != nil {
 		return 0, errors.Wrap(err, "buyAssetCount failed")
@@ -765,13 +766,13 @@ func (s *Client) buyAssetCount(asset common.Asset) (uint64, error) {
 	return uint64(buyAssetCount.Int64), nil
 }
 
-func (s *Client) swappingTxCount(asset common.Asset) (uint64, error) {
+func (s *Client) swappingTxCount(ctx context.Context, asset common.Asset) (uint64, error) {
 	stmnt := `
 		SELECT COUNT(event_id) FROM swaps WHERE pool = $1
 	`
 
 	var swappingTxCount sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&swappingTxCount); err != nil {
 		if err == sql.ErrNoRows {
@@ -784,7 +785,7 @@ func (s *Client) swappingTxCount(asset common.Asset) (uint64, error) {
 }
 
 // GetSwappersCount - number of unique swappers on the network
-func (s *Client) GetSwappersCount(asset common.Asset) (uint64, error) {
+func (s *Client) GetSwappersCount(ctx context.Context, asset common.Asset) (uint64, error) {
 	stmnt := `
 		SELECT COUNT(DISTINCT(from_address))
 		FROM swaps
@@ -792,7 +793,7 @@ func (s *Client) GetSwappersCount(asset common.Asset) (uint64, error) {
 	`
 
 	var swappersCount sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&swappersCount); err != nil {
 		if err != nil {
@@ -805,7 +806,7 @@ func (s *Client) GetSwappersCount(asset common.Asset) (uint64, error) {
 }
 
 // stakeTxCount - number of stakes that occurred on a given pool
-func (s *Client) stakeTxCount(asset common.Asset) (uint64, error) {
+func (s *Client) stakeTxCount(ctx context.Context, asset common.Asset) (uint64, error) {
 	stmnt := `
 		SELECT COUNT(id)
 		FROM pools_history
@@ -814,7 +815,7 @@ func (s *Client) stakeTxCount(asset common.Asset) (uint64, error) {
 	`
 
 	var stateTxCount sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&stateTxCount); err != nil {
 		return 0, errors.Wrap(err, "stakeTxCount failed")
@@ -824,7 +825,7 @@ func (s *Client) stakeTxCount(asset common.Asset) (uint64, error) {
 }
 
 // withdrawTxCount - number of unstakes that occurred on a given pool
-func (s *Client) withdrawTxCount(asset common.Asset) (uint64, error) {
+func (s *Client) withdrawTxCount(ctx context.Context, asset common.Asset) (uint64, error) {
 	stmnt := `
 		SELECT COUNT(id)
 		FROM pools_history
@@ -833,7 +834,7 @@ func (s *Client) withdrawTxCount(asset common.Asset) (uint64, error) {
 	`
 
 	var withdrawTxCount sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	if err := row.Scan(&withdrawTxCount); err != nil {
 		return 0, errors.Wrap(err, "withdrawTxCount failed")
@@ -842,12 +843,12 @@ func (s *Client) withdrawTxCount(asset common.Asset) (uint64, error) {
 	return uint64(withdrawTxCount.Int64), nil
 }
 
-func (s *Client) stakingTxCount(asset common.Asset) (uint64, error) {
-	stakeTxCount, err := s.stakeTxCount(asset)
+func (s *Client) stakingTxCount(ctx context.Context, asset common.Asset) (uint64, error) {
+	stakeTxCount, err := s.stakeTxCount(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "stakingTxCount failed")
 	}
-	withdrawTxCount, err := s.withdrawTxCount(asset)
+	withdrawTxCount, err := s.withdrawTxCount(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "stakingTxCount failed")
 	}
@@ -857,7 +858,7 @@ func (s *Client) stakingTxCount(asset common.Asset) (uint64, error) {
 }
 
 // GetStakersCount - number of addresses staking on a given pool
-func (s *Client) GetStakersCount(asset common.Asset) (uint64, error) {
+func (s *Client) GetStakersCount(ctx context.Context, asset common.Asset) (uint64, error) {
 	stmnt := `
 		SELECT COUNT(from_address)
 		FROM (
@@ -871,7 +872,7 @@ func (s *Client) GetStakersCount(asset common.Asset) (uint64, error) {
 			) t`
 
 	var stakersCount sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 
 	err := row.Scan(&stakersCount)
 	if err == sql.ErrNoRows {
@@ -884,8 +885,8 @@ func (s *Client) GetStakersCount(asset common.Asset) (uint64, error) {
 	return uint64(stakersCount.Int64), nil
 }
 
-func (s *Client) assetROI(asset common.Asset) (float64, error) {
-	assetDepth, err := s.GetAssetDepth(asset)
+func (s *Client) assetROI(ctx context.Context, asset common.Asset) (float64, error) {
+	assetDepth, err := s.GetAssetDepth(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "assetROI failed")
 	}
@@ -905,12 +906,12 @@ func (s *Client) assetROI(asset common.Asset) (float64, error) {
 	return roi, nil
 }
 
-func (s *Client) assetROI12(asset common.Asset) (float64, error) {
-	assetDepth12m, err := s.assetDepth12m(asset)
+func (s *Client) assetROI12(ctx context.Context, asset common.Asset) (float64, error) {
+	assetDepth12m, err := s.assetDepth12m(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "assetROI12 failed")
 	}
-	assetStaked12m, err := s.assetStaked12m(asset)
+	assetStaked12m, err := s.assetStaked12m(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "assetROI12 failed")
 	}
@@ -926,8 +927,8 @@ func (s *Client) assetROI12(asset common.Asset) (float64, error) {
 	return roi, nil
 }
 
-func (s *Client) runeROI(asset common.Asset) (float64, error) {
-	runeDepth, err := s.GetRuneDepth(asset)
+func (s *Client) runeROI(ctx context.Context, asset common.Asset) (float64, error) {
+	runeDepth, err := s.GetRuneDepth(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "runeROI failed")
 	}
@@ -946,12 +947,12 @@ func (s *Client) runeROI(asset common.Asset) (float64, error) {
 	return roi, nil
 }
 
-func (s *Client) runeROI12(asset common.Asset) (float64, error) {
-	runeDepth12m, err := s.runeDepth12m(asset)
+func (s *Client) runeROI12(ctx context.Context, asset common.Asset) (float64, error) {
+	runeDepth12m, err := s.runeDepth12m(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "runeROI12 failed")
 	}
-	runeStaked12m, err := s.runeStaked12m(asset)
+	runeStaked12m, err := s.runeStaked12m(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "runeROI12 failed")
 	}
@@ -966,12 +967,12 @@ func (s *Client) runeROI12(asset common.Asset) (float64, error) {
 	return roi, nil
 }
 
-func (s *Client) PoolROI(asset common.Asset) (float64, error) {
-	assetROI, err := s.assetROI(asset)
+func (s *Client) PoolROI(ctx context.Context, asset common.Asset) (float64, error) {
+	assetROI, err := s.assetROI(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "PoolROI failed")
 	}
-	runeROI, err := s.runeROI(asset)
+	runeROI, err := s.runeROI(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "PoolROI failed")
 	}
@@ -982,7 +983,7 @@ func (s *Client) PoolROI(asset common.Asset) (float64, error) {
 	return roi, errors.Wrap(err, "PoolROI failed")
 }
 
-func (s *Client) getStakes12(asset common.Asset) (int64, int64, error) {
+func (s *Client) getStakes12(ctx context.Context, asset common.Asset) (int64, int64, error) {
 	stmnt := `
 		SELECT
 		SUM(asset_amount),
@@ -999,12 +1000,12 @@ func (s *Client) getStakes12(asset common.Asset) (int64, int64, error) {
 		assetStaked sql.NullInt64
 		runeStaked  sql.NullInt64
 	)
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 	err := row.Scan(&assetStaked, &runeStaked)
 	return assetStaked.Int64, runeStaked.Int64, errors.Wrap(err, "getStakes12 failed")
 }
 
-func (s *Client) getDepth12(asset common.Asset) (int64, int64, error) {
+func (s *Client) getDepth12(ctx context.Context, asset common.Asset) (int64, int64, error) {
 	stmnt := `
 		SELECT
 		asset_depth,
@@ -1019,12 +1020,12 @@ func (s *Client) getDepth12(asset common.Asset) (int64, int64, error) {
 		assetDepthLastYear sql.NullInt64
 		runeDepthLastYear  sql.NullInt64
 	)
-	row := s.db.QueryRow(stmnt, asset.String())
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String())
 	err := row.Scan(&assetDepthLastYear, &runeDepthLastYear)
 	if err != sql.ErrNoRows && err != nil {
 		return 0, 0, errors.Wrap(err, "getDepth12 failed")
 	}
-	basics, err := s.GetPoolBasics(asset)
+	basics, err := s.GetPoolBasics(ctx, asset)
 	if err != nil {
 		return 0, 0, errors.Wrap(err, "getDepth12 failed")
 	}
@@ -1033,12 +1034,12 @@ func (s *Client) getDepth12(asset common.Asset) (int64, int64, error) {
 	return assetDepth12, runeDepth12, nil
 }
 
-func (s *Client) GetPoolROI12(asset common.Asset) (float64, error) {
-	assetDepth12, runeDepth12, err := s.getDepth12(asset)
+func (s *Client) GetPoolROI12(ctx context.Context, asset common.Asset) (float64, error) {
+	assetDepth12, runeDepth12, err := s.getDepth12(ctx, asset)
 	if err != nil {
 		return 0, err
 	}
-	assetStaked, runeStaked, err := s.getStakes12(asset)
+	assetStaked, runeStaked, err := s.getStakes12(ctx, asset)
 	if err != nil {
 		return 0, err
 	}
@@ -1055,7 +1056,7 @@ func (s *Client) GetPoolROI12(asset common.Asset) (float64, error) {
 }
 
 // GetPoolStatus - latest pool status
-func (s *Client) GetPoolStatus(asset common.Asset) (models.PoolStatus, error) {
+func (s *Client) GetPoolStatus(ctx context.Context, asset common.Asset) (models.PoolStatus, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -1066,7 +1067,7 @@ func (s *Client) GetPoolStatus(asset common.Asset) (models.PoolStatus, error) {
 }
 
 // Get the first time when pool status changed to enabled
-func (s *Client) GetPoolLastEnabledDate(asset common.Asset) (time.Time, error) {
+func (s *Client) GetPoolLastEnabledDate(ctx context.Context, asset common.Asset) (time.Time, error) {
 	stmnt := `
 		SELECT time 
 		FROM   pools_history 
@@ -1076,7 +1077,7 @@ func (s *Client) GetPoolLastEnabledDate(asset common.Asset) (time.Time, error) {
 		LIMIT  1 `
 
 	var inactiveTime sql.NullTime
-	row := s.db.QueryRow(stmnt, asset.String(), models.Enabled)
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String(), models.Enabled)
 
 	if err := row.Scan(&inactiveTime); err != nil {
 		return time.Time{}, errors.Wrap(err, "GetPoolLastEnabledDate failed")
@@ -1085,7 +1086,7 @@ func (s *Client) GetPoolLastEnabledDate(asset common.Asset) (time.Time, error) {
 }
 
 // Calculate buy and sell liquidity fee for an asset from a specific date till now
-func (s *Client) getPoolLiquidityFee(asset common.Asset, from time.Time) (int64, int64, error) {
+func (s *Client) getPoolLiquidityFee(ctx context.Context, asset common.Asset, from time.Time) (int64, int64, error) {
 	q := `
 		SELECT 
 		SUM(liquidity_fee) FILTER (WHERE runeAmt > 0 or assetAmt < 0),
@@ -1094,7 +1095,7 @@ func (s *Client) getPoolLiquidityFee(asset common.Asset, from time.Time) (int64,
 		WHERE pool = $1
 		AND time > $2`
 
-	row := s.db.QueryRow(q, asset.String(), from)
+	row := s.db().QueryRowContext(ctx, q, asset.String(), from)
 	var buyFee, sellFee sql.NullInt64
 
 	if err := row.Scan(&buyFee, &sellFee); err != nil {
@@ -1107,7 +1108,7 @@ func (s *Client) getPoolLiquidityFee(asset common.Asset, from time.Time) (int64,
 // runeEarned  = gasUsed + buyFee
 // assetEarned = gasReplenished + reward + sellFee
 // poolEarned = assetEarned * Price + runeEarned
-func (s *Client) GetPoolEarned(asset common.Asset, from time.Time) (int64, error) {
+func (s *Client) GetPoolEarned(ctx context.Context, asset common.Asset, from time.Time) (int64, error) {
 	stmnt := `
 		SELECT Sum(reward), 
        	Sum(gas_used), 
@@ -1117,16 +1118,16 @@ func (s *Client) GetPoolEarned(asset common.Asset, from time.Time) (int64, error
 		AND    time >= $2`
 
 	var reward, gasUsed, gasReplenished sql.NullInt64
-	row := s.db.QueryRow(stmnt, asset.String(), from)
+	row := s.db().QueryRowContext(ctx, stmnt, asset.String(), from)
 
 	if err := row.Scan(&reward, &gasUsed, &gasReplenished); err != nil {
 		return 0, errors.Wrap(err, "GetPoolEarned failed")
 	}
-	buyFee, sellFee, err := s.getPoolLiquidityFee(asset, from)
+	buyFee, sellFee, err := s.getPoolLiquidityFee(ctx, asset, from)
 	if err != nil {
 		return 0, errors.Wrap(err, "GetPoolEarned30d failed")
 	}
-	priceInRune, err := s.getPriceInRune(asset)
+	priceInRune, err := s.getPriceInRune(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "GetPoolEarned30d failed")
 	}
@@ -1,6 +1,7 @@
 package timescale
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
@@ -12,7 +13,7 @@ import (
 
 func (s *Client) UpdatePoolsHistory(change *models.PoolChange) error {
 	pool := change.Pool.String()
-	basics, _ := s.GetPoolBasics(change.Pool)
+	basics, _ := s.GetPoolBasics(context.Background(), change.Pool)
 	assetDepth := basics.AssetDepth + change.AssetAmount
 	runeDepth := basics.RuneDepth + change.RuneAmount
 	units := sql.NullInt64{
@@ -22,7 +23,7 @@ func (s *Client) UpdatePoolsHistory(change *models.PoolChange) error {
 
 	q := `INSERT INTO pools_history (time, height, event_id, event_type, pool, asset_amount, asset_depth, rune_amount, rune_depth, units, status) 
 			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
-	_, err := s.db.Exec(q,
+	_, err := s.db().Exec(q,
 		change.Time,
 		change.Height,
 		change.EventID,
@@ -42,10 +43,10 @@ func (s *Client) UpdatePoolsHistory(change *models.PoolChange) error {
 	return nil
 }
 
-func (s *Client) GetEventPool(id int64) (common.Asset, error) {
+func (s *Client) GetEventPool(ctx context.Context, id int64) (common.Asset, error) {
 	sql := `SELECT pool FROM pools_history WHERE event_id = $1`
 	var poolStr string
-	err := s.db.QueryRowx(sql, id).Scan(&poolStr)
+	err := s.db().QueryRowxContext(ctx, sql, id).Scan(&poolStr)
 	if err != nil {
 		return common.EmptyAsset, err
 	}
@@ -78,7 +79,7 @@ type poolAggChanges struct {
 }
 
 // GetPoolAggChanges returns historical aggregated details of the specified pool.
-func (s *Client) GetPoolAggChanges(pool common.Asset, inv models.Interval, from, to time.Time) ([]models.PoolAggChanges, error) {
+func (s *Client) GetPoolAggChanges(ctx context.Context, pool common.Asset, inv models.Interval, from, to time.Time) ([]models.PoolAggChanges, error) {
 	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
 	colsTemplate := "%s"
 	lastTemplate := "%s"
@@ -117,7 +118,7 @@ func (s *Client) GetPoolAggChanges(pool common.Asset, inv models.Interval, from,
 	sb.OrderBy("time")
 
 	q, args := sb.Build()
-	rows, err := s.db.Queryx(q, args...)
+	rows, err := s.db().QueryxContext(ctx, q, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -157,6 +158,72 @@ func (s *Client) GetPoolAggChanges(pool common.Asset, inv models.Interval, from,
 	return result, nil
 }
 
+type poolYieldChanges struct {
+	Time           time.Time     `db:"time"`
+	AssetDepth     sql.NullInt64 `db:"asset_depth"`
+	RuneDepth      sql.NullInt64 `db:"rune_depth"`
+	BuyFees        sql.NullInt64 `db:"buy_fees"`
+	SellFees       sql.NullInt64 `db:"sell_fees"`
+	Reward         sql.NullInt64 `db:"reward"`
+	GasUsed        sql.NullInt64 `db:"gas_used"`
+	GasReplenished sql.NullInt64 `db:"gas_replenished"`
+}
+
+// GetPoolYieldChanges returns historical fee, reward and gas earnings of the specified pool.
+func (s *Client) GetPoolYieldChanges(ctx context.Context, pool common.Asset, inv models.Interval, from, to time.Time) ([]models.PoolYieldChanges, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	colsTemplate := "%s"
+	lastTemplate := "%s"
+	timeBucket := getTimeBucket(inv)
+	if inv > models.DailyInterval {
+		colsTemplate = "SUM(%s)"
+		lastTemplate = "last(%s, time)"
+		sb.GroupBy(timeBucket, "pool")
+	}
+	suffix := getIntervalTableSuffix(inv)
+	sb.Select(
+		sb.As(timeBucket, "time"),
+		sb.As(fmt.Sprintf(lastTemplate, "asset_depth"), "asset_depth"),
+		sb.As(fmt.Sprintf(lastTemplate, "rune_depth"), "rune_depth"),
+		sb.As(fmt.Sprintf(colsTemplate, "buy_fees"), "buy_fees"),
+		sb.As(fmt.Sprintf(colsTemplate, "sell_fees"), "sell_fees"),
+		sb.As(fmt.Sprintf(colsTemplate, "reward"), "reward"),
+		sb.As(fmt.Sprintf(colsTemplate, "gas_used"), "gas_used"),
+		sb.As(fmt.Sprintf(colsTemplate, "gas_replenished"), "gas_replenished"),
+	)
+	sb.From(fmt.Sprintf("pool_changes%s LEFT JOIN swap_fees%s USING (pool, time)", suffix, suffix))
+	sb.Where(sb.Equal("pool", pool.String()))
+	sb.Where(sb.Between(timeBucket, from, to))
+	sb.OrderBy("time")
+
+	q, args := sb.Build()
+	rows, err := s.db().QueryxContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []models.PoolYieldChanges{}
+	for rows.Next() {
+		var changes poolYieldChanges
+		err := rows.StructScan(&changes)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, models.PoolYieldChanges{
+			Time:           changes.Time,
+			AssetDepth:     changes.AssetDepth.Int64,
+			RuneDepth:      changes.RuneDepth.Int64,
+			BuyFees:        changes.BuyFees.Int64,
+			SellFees:       changes.SellFees.Int64,
+			Reward:         changes.Reward.Int64,
+			GasUsed:        changes.GasUsed.Int64,
+			GasReplenished: changes.GasReplenished.Int64,
+		})
+	}
+	return result, nil
+}
+
 type totalVolChanges struct {
 	Time        time.Time     `db:"time"`
 	BuyVolume   sql.NullInt64 `db:"buy_volume"`
@@ -164,7 +231,7 @@ type totalVolChanges struct {
 	TotalVolume sql.NullInt64 `db:"total_volume"`
 }
 
-func (s *Client) GetTotalVolChanges(interval models.Interval, from, to time.Time) ([]models.TotalVolChanges, error) {
+func (s *Client) GetTotalVolChanges(ctx context.Context, interval models.Interval, from, to time.Time) ([]models.TotalVolChanges, error) {
 	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
 	timeBucket := getTimeBucket(interval)
 	sb.Select(
@@ -179,7 +246,7 @@ func (s *Client) GetTotalVolChanges(interval models.Interval, from, to time.Time
 	sb.OrderBy("time")
 
 	q, args := sb.Build()
-	rows, err := s.db.Queryx(q, args...)
+	rows, err := s.db().QueryxContext(ctx, q, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package timescale
 
 import (
+	"context"
 	"time"
 
 	"gitlab.com/thorchain/midgard/internal/common"
@@ -28,7 +29,7 @@ func (s *TimeScaleSuite) TestUpdatePoolsHistory(c *C) {
 	runeDepth, err := s.Store.getRuneDepth(pool)
 	c.Assert(err, IsNil)
 	c.Assert(runeDepth, Equals, int64(-2000))
-	status, err := s.Store.GetPoolStatus(pool)
+	status, err := s.Store.GetPoolStatus(context.Background(), pool)
 	c.Assert(err, IsNil)
 	c.Assert(status, Equals, models.Enabled)
 
@@ -50,7 +51,7 @@ func (s *TimeScaleSuite) TestUpdatePoolsHistory(c *C) {
 	runeDepth, err = s.Store.getRuneDepth(pool)
 	c.Assert(err, IsNil)
 	c.Assert(runeDepth, Equals, int64(4000))
-	status, err = s.Store.GetPoolStatus(pool)
+	status, err = s.Store.GetPoolStatus(context.Background(), pool)
 	c.Assert(err, IsNil)
 	c.Assert(status, Equals, models.Bootstrap)
 }
@@ -76,11 +77,11 @@ func (s *TimeScaleSuite) TestGetEventPool(c *C) {
 	err = s.Store.UpdatePoolsHistory(change)
 	c.Assert(err, IsNil)
 
-	pool, err := s.Store.GetEventPool(1)
+	pool, err := s.Store.GetEventPool(context.Background(), 1)
 	c.Assert(err, IsNil)
 	c.Assert(pool.String(), Equals, bnbPool.String())
 
-	pool, err = s.Store.GetEventPool(2)
+	pool, err = s.Store.GetEventPool(context.Background(), 2)
 	c.Assert(err, IsNil)
 	c.Assert(pool.String(), Equals, tomobPool.String())
 }
@@ -187,7 +188,7 @@ func (s *TimeScaleSuite) TestGetPoolAggChanges(c *C) {
 	c.Assert(err, IsNil)
 
 	// Test hourly aggrigation
-	changes, err := s.Store.GetPoolAggChanges(bnbAsset, models.HourlyInterval, today, tomorrow.Add(time.Hour))
+	changes, err := s.Store.GetPoolAggChanges(context.Background(), bnbAsset, models.HourlyInterval, today, tomorrow.Add(time.Hour))
 	c.Assert(err, IsNil)
 	c.Assert(changes, HasLen, 4)
 	expected := []models.PoolAggChanges{
@@ -240,7 +241,7 @@ func (s *TimeScaleSuite) TestGetPoolAggChanges(c *C) {
 	c.Assert(changes, helpers.DeepEquals, expected)
 
 	// Test daily aggrigation
-	changes, err = s.Store.GetPoolAggChanges(bnbAsset, models.DailyInterval, today, tomorrow)
+	changes, err = s.Store.GetPoolAggChanges(context.Background(), bnbAsset, models.DailyInterval, today, tomorrow)
 	c.Assert(err, IsNil)
 	c.Assert(changes, HasLen, 2)
 	expected = []models.PoolAggChanges{
@@ -279,7 +280,7 @@ func (s *TimeScaleSuite) TestGetPoolAggChanges(c *C) {
 	c.Assert(changes, helpers.DeepEquals, expected)
 
 	// Test yearly aggrigation
-	changes, err = s.Store.GetPoolAggChanges(bnbAsset, models.YearlyInterval, year, year)
+	changes, err = s.Store.GetPoolAggChanges(context.Background(), bnbAsset, models.YearlyInterval, year, year)
 	c.Assert(err, IsNil)
 	c.Assert(changes, HasLen, 1)
 	exp := models.PoolAggChanges{
@@ -308,6 +309,69 @@ func (s *TimeScaleSuite) TestGetPoolAggChanges(c *C) {
 	c.Assert(changes[0], helpers.DeepEquals, exp)
 }
 
+func (s *TimeScaleSuite) TestGetPoolYieldChanges(c *C) {
+	today := time.Date(2020, 7, 22, 0, 0, 0, 0, time.UTC)
+	tomorrow := today.Add(time.Hour * 24)
+
+	bnbAsset, err := common.NewAsset("BNB.BNB")
+	c.Assert(err, IsNil)
+	change := &models.PoolChange{
+		Time:        today,
+		EventID:     1,
+		EventType:   "stake",
+		Pool:        bnbAsset,
+		AssetAmount: 100,
+		RuneAmount:  200,
+		Units:       1000,
+	}
+	err = s.Store.UpdatePoolsHistory(change)
+	c.Assert(err, IsNil)
+	change = &models.PoolChange{
+		Time:        tomorrow,
+		EventID:     2,
+		EventType:   "gas",
+		Pool:        bnbAsset,
+		AssetAmount: -6,
+		RuneAmount:  12,
+	}
+	err = s.Store.UpdatePoolsHistory(change)
+	c.Assert(err, IsNil)
+	change = &models.PoolChange{
+		Time:       tomorrow,
+		EventID:    3,
+		EventType:  "rewards",
+		Pool:       bnbAsset,
+		RuneAmount: 20,
+	}
+	err = s.Store.UpdatePoolsHistory(change)
+	c.Assert(err, IsNil)
+	swap := swapSellBnb2RuneEvent4
+	swap.Time = tomorrow
+	err = s.Store.CreateSwapRecord(&swap)
+	c.Assert(err, IsNil)
+
+	changes, err := s.Store.GetPoolYieldChanges(context.Background(), bnbAsset, models.DailyInterval, today, tomorrow)
+	c.Assert(err, IsNil)
+	c.Assert(changes, HasLen, 2)
+	expected := []models.PoolYieldChanges{
+		{
+			Time:       today,
+			AssetDepth: 100,
+			RuneDepth:  200,
+		},
+		{
+			Time:           tomorrow,
+			AssetDepth:     94,
+			RuneDepth:      232,
+			SellFees:       swap.LiquidityFee,
+			Reward:         20,
+			GasUsed:        6,
+			GasReplenished: 12,
+		},
+	}
+	c.Assert(changes, helpers.DeepEquals, expected)
+}
+
 func (s *TimeScaleSuite) TestGetTotalVolChanges(c *C) {
 	today := time.Date(2020, 7, 22, 0, 0, 0, 0, time.UTC)
 	tomorrow := today.Add(time.Hour * 24)
@@ -354,7 +418,7 @@ func (s *TimeScaleSuite) TestGetTotalVolChanges(c *C) {
 	c.Assert(err, IsNil)
 
 	// Test daily aggrigation
-	changes, err := s.Store.GetTotalVolChanges(models.DailyInterval, today, tomorrow)
+	changes, err := s.Store.GetTotalVolChanges(context.Background(), models.DailyInterval, today, tomorrow)
 	c.Assert(err, IsNil)
 	expected := []models.TotalVolChanges{
 		{
@@ -373,7 +437,7 @@ func (s *TimeScaleSuite) TestGetTotalVolChanges(c *C) {
 	c.Assert(changes, helpers.DeepEquals, expected)
 
 	// Test 5 minute aggrigation
-	changes, err = s.Store.GetTotalVolChanges(models.FiveMinInterval, today, tomorrow.Add(time.Minute*5))
+	changes, err = s.Store.GetTotalVolChanges(context.Background(), models.FiveMinInterval, today, tomorrow.Add(time.Minute*5))
 	c.Assert(err, IsNil)
 	expected = []models.TotalVolChanges{
 		{
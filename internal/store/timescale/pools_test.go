@@ -1,6 +1,7 @@
 package timescale
 
 import (
+	"context"
 	"time"
 
 	"gitlab.com/thorchain/midgard/internal/common"
@@ -10,7 +11,7 @@ import (
 )
 
 func (s *TimeScaleSuite) TestGetPool(c *C) {
-	pools, err := s.Store.GetPools()
+	pools, err := s.Store.GetPools(context.Background())
 	c.Assert(err, IsNil)
 
 	// Test No stakes
@@ -20,7 +21,7 @@ func (s *TimeScaleSuite) TestGetPool(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	pools, err = s.Store.GetPools()
+	pools, err = s.Store.GetPools(context.Background())
 	c.Assert(err, IsNil)
 	c.Check(len(pools), Equals, 1)
 	c.Assert(pools[0].Symbol.String(), Equals, "BNB")
@@ -31,7 +32,7 @@ func (s *TimeScaleSuite) TestGetPool(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeTomlEvent1)
 	c.Assert(err, IsNil)
 
-	pools, err = s.Store.GetPools()
+	pools, err = s.Store.GetPools(context.Background())
 	c.Assert(err, IsNil)
 	c.Check(len(pools), Equals, 2)
 	expected := map[string]bool{
@@ -47,7 +48,7 @@ func (s *TimeScaleSuite) TestGetPool(c *C) {
 	c.Assert(err, IsNil)
 	s.Store.UpdatePoolUnits(unstakeTomlEvent0.Pool, -unstakeTomlEvent0.StakeUnits)
 
-	pools, err = s.Store.GetPools()
+	pools, err = s.Store.GetPools(context.Background())
 	c.Assert(err, IsNil)
 	c.Check(len(pools), Equals, 1)
 
@@ -57,19 +58,19 @@ func (s *TimeScaleSuite) TestGetPool(c *C) {
 
 	asset, err := common.NewAsset("BNB.BNB")
 	c.Assert(err, IsNil)
-	pool, err := s.Store.GetPool(asset)
+	pool, err := s.Store.GetPool(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Check(pool.Equals(asset), Equals, true)
 
 	asset, err = common.NewAsset("BNB.TUSDB-000")
 	c.Assert(err, IsNil)
-	_, err = s.Store.GetPool(asset)
+	_, err = s.Store.GetPool(context.Background(), asset)
 	c.Assert(err, NotNil)
 	c.Assert(err, Equals, store.ErrPoolNotFound)
 }
 
 func (s *TimeScaleSuite) TestGetPoolByFailedUnstake(c *C) {
-	pools, err := s.Store.GetPools()
+	pools, err := s.Store.GetPools(context.Background())
 	c.Assert(err, IsNil)
 	// Test No pools
 	c.Check(len(pools), Equals, 0)
@@ -77,7 +78,7 @@ func (s *TimeScaleSuite) TestGetPoolByFailedUnstake(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	pools, err = s.Store.GetPools()
+	pools, err = s.Store.GetPools(context.Background())
 	c.Assert(err, IsNil)
 	c.Check(len(pools), Equals, 1)
 	c.Assert(pools[0], DeepEquals, common.BNBAsset)
@@ -88,7 +89,7 @@ func (s *TimeScaleSuite) TestGetPoolByFailedUnstake(c *C) {
 	err = s.Store.CreateUnStakesRecord(&unstakeEvt)
 	c.Assert(err, IsNil)
 
-	pools, err = s.Store.GetPools()
+	pools, err = s.Store.GetPools(context.Background())
 	c.Check(len(pools), Equals, 1)
 	c.Assert(pools[0], DeepEquals, common.BNBAsset)
 }
@@ -106,7 +107,7 @@ func (s *TimeScaleSuite) TestPoolBasicsAddEvent(c *C) {
 	err := s.Store.UpdatePoolsHistory(change)
 	c.Assert(err, IsNil)
 
-	basics, err := s.Store.GetPoolBasics(common.BNBAsset)
+	basics, err := s.Store.GetPoolBasics(context.Background(), common.BNBAsset)
 	c.Assert(err, IsNil)
 	c.Assert(basics, DeepEquals, models.PoolBasics{
 		Asset:       common.BNBAsset,
@@ -128,7 +129,7 @@ func (s *TimeScaleSuite) TestPoolBasicsAddEvent(c *C) {
 	err = s.Store.UpdatePoolsHistory(change)
 	c.Assert(err, IsNil)
 
-	basics, err = s.Store.GetPoolBasics(common.BTCAsset)
+	basics, err = s.Store.GetPoolBasics(context.Background(), common.BTCAsset)
 	c.Assert(err, IsNil)
 	c.Assert(basics, DeepEquals, models.PoolBasics{
 		Asset:       common.BTCAsset,
@@ -139,7 +140,7 @@ func (s *TimeScaleSuite) TestPoolBasicsAddEvent(c *C) {
 		DateCreated: now.UTC(),
 	})
 
-	basics, err = s.Store.GetPoolBasics(common.BNBAsset)
+	basics, err = s.Store.GetPoolBasics(context.Background(), common.BNBAsset)
 	c.Assert(err, IsNil)
 	c.Assert(basics, DeepEquals, models.PoolBasics{
 		Asset:       common.BNBAsset,
@@ -231,7 +232,7 @@ func (s *TimeScaleSuite) TestGetPoolBasics(c *C) {
 	c.Assert(err, IsNil)
 	s.Store.UpdatePoolUnits(common.BTCAsset, 1000)
 
-	basics, err := s.Store.GetPoolBasics(common.BNBAsset)
+	basics, err := s.Store.GetPoolBasics(context.Background(), common.BNBAsset)
 	c.Assert(err, IsNil)
 	c.Assert(basics, DeepEquals, models.PoolBasics{
 		Asset:          common.BNBAsset,
@@ -252,7 +253,7 @@ func (s *TimeScaleSuite) TestGetPoolBasics(c *C) {
 		DateCreated:    today.UTC(),
 	})
 
-	basics, err = s.Store.GetPoolBasics(common.BTCAsset)
+	basics, err = s.Store.GetPoolBasics(context.Background(), common.BTCAsset)
 	c.Assert(err, IsNil)
 	c.Assert(basics, DeepEquals, models.PoolBasics{
 		Asset:         common.BTCAsset,
@@ -271,7 +272,7 @@ func (s *TimeScaleSuite) TestGetPoolBasics(c *C) {
 	})
 
 	ethAsset, _ := common.NewAsset("ETH.ETH")
-	_, err = s.Store.GetPoolBasics(ethAsset)
+	_, err = s.Store.GetPoolBasics(context.Background(), ethAsset)
 	c.Assert(err, NotNil)
 }
 
@@ -295,7 +296,7 @@ func (s *TimeScaleSuite) TestGetPoolSwapStats(c *C) {
 	c.Assert(err, IsNil)
 
 	asset, _ := common.NewAsset("BNB.BNB")
-	stats, err := s.Store.GetPoolSwapStats(asset)
+	stats, err := s.Store.GetPoolSwapStats(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(stats, DeepEquals, models.PoolSwapStats{
 		PoolTxAverage:   1,
@@ -304,7 +305,7 @@ func (s *TimeScaleSuite) TestGetPoolSwapStats(c *C) {
 	})
 
 	asset, _ = common.NewAsset("BNB.BOLT-014")
-	stats, err = s.Store.GetPoolSwapStats(asset)
+	stats, err = s.Store.GetPoolSwapStats(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(stats, DeepEquals, models.PoolSwapStats{
 		PoolTxAverage:   1,
@@ -316,7 +317,7 @@ func (s *TimeScaleSuite) TestGetPoolSwapStats(c *C) {
 func (s *TimeScaleSuite) TestGetPriceInRune(c *C) {
 	// No stakes
 	asset, _ := common.NewAsset("BNB.BNB")
-	priceRune, err := s.Store.getPriceInRune(asset)
+	priceRune, err := s.Store.getPriceInRune(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(priceRune, Equals, 0.0)
 
@@ -324,7 +325,7 @@ func (s *TimeScaleSuite) TestGetPriceInRune(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	priceRune, err = s.Store.getPriceInRune(asset)
+	priceRune, err = s.Store.getPriceInRune(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(priceRune, Equals, 10.0)
 }
@@ -332,7 +333,7 @@ func (s *TimeScaleSuite) TestGetPriceInRune(c *C) {
 func (s *TimeScaleSuite) TestExists(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	exists, err := s.Store.exists(asset)
+	exists, err := s.Store.exists(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(exists, Equals, false)
 
@@ -340,7 +341,7 @@ func (s *TimeScaleSuite) TestExists(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	exists, err = s.Store.exists(asset)
+	exists, err = s.Store.exists(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(exists, Equals, true)
 }
@@ -469,7 +470,7 @@ func (s *TimeScaleSuite) TestRuneStakedTotal(c *C) {
 func (s *TimeScaleSuite) TestPoolStakedTotal(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	poolStakedTotal, err := s.Store.poolStakedTotal(asset)
+	poolStakedTotal, err := s.Store.poolStakedTotal(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(poolStakedTotal, Equals, uint64(0))
 
@@ -477,7 +478,7 @@ func (s *TimeScaleSuite) TestPoolStakedTotal(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	poolStakedTotal, err = s.Store.poolStakedTotal(asset)
+	poolStakedTotal, err = s.Store.poolStakedTotal(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(poolStakedTotal, Equals, uint64(200))
 
@@ -485,7 +486,7 @@ func (s *TimeScaleSuite) TestPoolStakedTotal(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent1)
 	c.Assert(err, IsNil)
 
-	poolStakedTotal, err = s.Store.poolStakedTotal(asset)
+	poolStakedTotal, err = s.Store.poolStakedTotal(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(poolStakedTotal, Equals, uint64(400))
 
@@ -493,7 +494,7 @@ func (s *TimeScaleSuite) TestPoolStakedTotal(c *C) {
 	err = s.Store.CreateUnStakesRecord(&unstakeBnbEvent1)
 	c.Assert(err, IsNil)
 
-	poolStakedTotal, err = s.Store.poolStakedTotal(asset)
+	poolStakedTotal, err = s.Store.poolStakedTotal(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(poolStakedTotal, Equals, uint64(400), Commentf("poolStakedTotal: %v", poolStakedTotal))
 }
@@ -501,7 +502,7 @@ func (s *TimeScaleSuite) TestPoolStakedTotal(c *C) {
 func (s *TimeScaleSuite) TestAssetDepth(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	assetDepth, err := s.Store.GetAssetDepth(asset)
+	assetDepth, err := s.Store.GetAssetDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetDepth, Equals, uint64(0))
 
@@ -509,7 +510,7 @@ func (s *TimeScaleSuite) TestAssetDepth(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	assetDepth, err = s.Store.GetAssetDepth(asset)
+	assetDepth, err = s.Store.GetAssetDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetDepth, Equals, uint64(10), Commentf("%v", assetDepth))
 
@@ -517,7 +518,7 @@ func (s *TimeScaleSuite) TestAssetDepth(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent2)
 	c.Assert(err, IsNil)
 
-	assetDepth, err = s.Store.GetAssetDepth(asset)
+	assetDepth, err = s.Store.GetAssetDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetDepth, Equals, uint64(50000000010), Commentf("%v", assetDepth))
 
@@ -525,7 +526,7 @@ func (s *TimeScaleSuite) TestAssetDepth(c *C) {
 	err = s.Store.CreateUnStakesRecord(&unstakeBnbEvent1)
 	c.Assert(err, IsNil)
 
-	assetDepth, err = s.Store.GetAssetDepth(asset)
+	assetDepth, err = s.Store.GetAssetDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetDepth, Equals, uint64(50000000000), Commentf("%v", assetDepth))
 
@@ -533,7 +534,7 @@ func (s *TimeScaleSuite) TestAssetDepth(c *C) {
 	err = s.Store.CreateSwapRecord(&swapBuyRune2BnbEvent2)
 	c.Assert(err, IsNil)
 
-	assetDepth, err = s.Store.GetAssetDepth(asset)
+	assetDepth, err = s.Store.GetAssetDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetDepth, Equals, uint64(49980000000), Commentf("%v", assetDepth))
 
@@ -541,7 +542,7 @@ func (s *TimeScaleSuite) TestAssetDepth(c *C) {
 	err = s.Store.CreateSwapRecord(&swapSellBnb2RuneEvent4)
 	c.Assert(err, IsNil)
 
-	assetDepth, err = s.Store.GetAssetDepth(asset)
+	assetDepth, err = s.Store.GetAssetDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetDepth, Equals, uint64(50000000000), Commentf("%v", assetDepth))
 }
@@ -549,7 +550,7 @@ func (s *TimeScaleSuite) TestAssetDepth(c *C) {
 func (s *TimeScaleSuite) TestAssetDepth12m(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	assetDepth, err := s.Store.assetDepth12m(asset)
+	assetDepth, err := s.Store.assetDepth12m(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetDepth, Equals, uint64(0))
 
@@ -557,7 +558,7 @@ func (s *TimeScaleSuite) TestAssetDepth12m(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	assetDepth, err = s.Store.GetAssetDepth(asset)
+	assetDepth, err = s.Store.GetAssetDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetDepth, Equals, uint64(10))
 }
@@ -565,7 +566,7 @@ func (s *TimeScaleSuite) TestAssetDepth12m(c *C) {
 func (s *TimeScaleSuite) TestRuneDepth(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	runeDepth, err := s.Store.GetRuneDepth(asset)
+	runeDepth, err := s.Store.GetRuneDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(runeDepth, Equals, uint64(0))
 
@@ -573,7 +574,7 @@ func (s *TimeScaleSuite) TestRuneDepth(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	runeDepth, err = s.Store.GetRuneDepth(asset)
+	runeDepth, err = s.Store.GetRuneDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(runeDepth, Equals, uint64(100), Commentf("%v", runeDepth))
 
@@ -581,7 +582,7 @@ func (s *TimeScaleSuite) TestRuneDepth(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent1)
 	c.Assert(err, IsNil)
 
-	runeDepth, err = s.Store.GetRuneDepth(asset)
+	runeDepth, err = s.Store.GetRuneDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(runeDepth, Equals, uint64(200), Commentf("%v", runeDepth))
 
@@ -589,7 +590,7 @@ func (s *TimeScaleSuite) TestRuneDepth(c *C) {
 	err = s.Store.CreateUnStakesRecord(&unstakeBnbEvent1)
 	c.Assert(err, IsNil)
 
-	runeDepth, err = s.Store.GetRuneDepth(asset)
+	runeDepth, err = s.Store.GetRuneDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(runeDepth, Equals, uint64(100), Commentf("%v", runeDepth))
 
@@ -597,7 +598,7 @@ func (s *TimeScaleSuite) TestRuneDepth(c *C) {
 	err = s.Store.CreateSwapRecord(&swapSellBnb2RuneEvent4)
 	c.Assert(err, IsNil)
 
-	runeDepth, err = s.Store.GetRuneDepth(asset)
+	runeDepth, err = s.Store.GetRuneDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(runeDepth, Equals, uint64(99), Commentf("%v", runeDepth))
 
@@ -605,7 +606,7 @@ func (s *TimeScaleSuite) TestRuneDepth(c *C) {
 	err = s.Store.CreateSwapRecord(&swapBuyRune2BnbEvent2)
 	c.Assert(err, IsNil)
 
-	runeDepth, err = s.Store.GetRuneDepth(asset)
+	runeDepth, err = s.Store.GetRuneDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(runeDepth, Equals, uint64(100), Commentf("%v", runeDepth))
 }
@@ -613,7 +614,7 @@ func (s *TimeScaleSuite) TestRuneDepth(c *C) {
 func (s *TimeScaleSuite) TestRuneDepth12m(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	runeDepth, err := s.Store.runeDepth12m(asset)
+	runeDepth, err := s.Store.runeDepth12m(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(runeDepth, Equals, uint64(0))
 
@@ -621,7 +622,7 @@ func (s *TimeScaleSuite) TestRuneDepth12m(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	runeDepth, err = s.Store.GetAssetDepth(asset)
+	runeDepth, err = s.Store.GetAssetDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(runeDepth, Equals, uint64(10))
 }
@@ -629,7 +630,7 @@ func (s *TimeScaleSuite) TestRuneDepth12m(c *C) {
 func (s *TimeScaleSuite) TestAssetSwap(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	swapTotal, err := s.Store.assetSwap(asset)
+	swapTotal, err := s.Store.assetSwap(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(swapTotal, Equals, int64(0))
 
@@ -642,7 +643,7 @@ func (s *TimeScaleSuite) TestAssetSwap(c *C) {
 	c.Assert(err, IsNil)
 
 	asset, _ = common.NewAsset("BNB.BOLT-014")
-	swapTotal, err = s.Store.assetSwap(asset)
+	swapTotal, err = s.Store.assetSwap(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(swapTotal, Equals, int64(20000000))
 
@@ -652,7 +653,7 @@ func (s *TimeScaleSuite) TestAssetSwap(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	swapTotal, err = s.Store.assetSwap(asset)
+	swapTotal, err = s.Store.assetSwap(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(swapTotal, Equals, int64(0))
 }
@@ -660,7 +661,7 @@ func (s *TimeScaleSuite) TestAssetSwap(c *C) {
 func (s *TimeScaleSuite) TestAssetSwap12m(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	swapTotal, err := s.Store.assetSwapped12m(asset)
+	swapTotal, err := s.Store.assetSwapped12m(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(swapTotal, Equals, int64(0))
 
@@ -673,7 +674,7 @@ func (s *TimeScaleSuite) TestAssetSwap12m(c *C) {
 	c.Assert(err, IsNil)
 
 	asset, _ = common.NewAsset("BNB.BOLT-014")
-	swapTotal, err = s.Store.assetSwapped12m(asset)
+	swapTotal, err = s.Store.assetSwapped12m(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(swapTotal, Equals, int64(20000000))
 
@@ -682,7 +683,7 @@ func (s *TimeScaleSuite) TestAssetSwap12m(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	swapTotal, err = s.Store.assetSwapped12m(asset)
+	swapTotal, err = s.Store.assetSwapped12m(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(swapTotal, Equals, int64(0))
 }
@@ -690,7 +691,7 @@ func (s *TimeScaleSuite) TestAssetSwap12m(c *C) {
 func (s *TimeScaleSuite) TestRuneSwap12m(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BOLT-014")
-	swapTotal, err := s.Store.runeSwap12m(asset)
+	swapTotal, err := s.Store.runeSwap12m(context.Background(), asset)
 	c.Assert(err, IsNil)
 
 	c.Assert(swapTotal, Equals, int64(0))
@@ -704,7 +705,7 @@ func (s *TimeScaleSuite) TestRuneSwap12m(c *C) {
 	c.Assert(err, IsNil)
 
 	asset, _ = common.NewAsset("BNB.BOLT-014")
-	swapTotal, err = s.Store.runeSwap12m(asset)
+	swapTotal, err = s.Store.runeSwap12m(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(swapTotal, Equals, int64(-1))
 }
@@ -712,7 +713,7 @@ func (s *TimeScaleSuite) TestRuneSwap12m(c *C) {
 func (s *TimeScaleSuite) TestPoolDepth(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	poolDepth, err := s.Store.poolDepth(asset)
+	poolDepth, err := s.Store.poolDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(poolDepth, Equals, uint64(0))
 
@@ -720,7 +721,7 @@ func (s *TimeScaleSuite) TestPoolDepth(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	poolDepth, err = s.Store.poolDepth(asset)
+	poolDepth, err = s.Store.poolDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(poolDepth, Equals, uint64(200))
 
@@ -728,7 +729,7 @@ func (s *TimeScaleSuite) TestPoolDepth(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent2)
 	c.Assert(err, IsNil)
 
-	poolDepth, err = s.Store.poolDepth(asset)
+	poolDepth, err = s.Store.poolDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(poolDepth, Equals, uint64(100000200), Commentf("%d", poolDepth))
 
@@ -736,7 +737,7 @@ func (s *TimeScaleSuite) TestPoolDepth(c *C) {
 	err = s.Store.CreateUnStakesRecord(&unstakeBnbEvent1)
 	c.Assert(err, IsNil)
 
-	poolDepth, err = s.Store.poolDepth(asset)
+	poolDepth, err = s.Store.poolDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(poolDepth, Equals, uint64(100000000), Commentf("%d", poolDepth))
 
@@ -744,7 +745,7 @@ func (s *TimeScaleSuite) TestPoolDepth(c *C) {
 	err = s.Store.CreateSwapRecord(&swapSellBnb2RuneEvent4)
 	c.Assert(err, IsNil)
 
-	poolDepth, err = s.Store.poolDepth(asset)
+	poolDepth, err = s.Store.poolDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(poolDepth, Equals, uint64(99999998), Commentf("%d", poolDepth))
 
@@ -752,7 +753,7 @@ func (s *TimeScaleSuite) TestPoolDepth(c *C) {
 	err = s.Store.CreateSwapRecord(&swapBuyRune2BnbEvent2)
 	c.Assert(err, IsNil)
 
-	poolDepth, err = s.Store.poolDepth(asset)
+	poolDepth, err = s.Store.poolDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(poolDepth, Equals, uint64(100000000), Commentf("%d", poolDepth))
 }
@@ -803,7 +804,7 @@ func (s *TimeScaleSuite) TestPoolUnits(c *C) {
 func (s *TimeScaleSuite) TestSellVolume(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	volume, err := s.Store.sellVolume(asset)
+	volume, err := s.Store.sellVolume(context.Background(), asset)
 	c.Assert(err, IsNil)
 
 	c.Assert(volume, Equals, uint64(0))
@@ -817,7 +818,7 @@ func (s *TimeScaleSuite) TestSellVolume(c *C) {
 	c.Assert(err, IsNil)
 
 	asset, _ = common.NewAsset("BNB.BOLT-014")
-	volume, err = s.Store.sellVolume(asset)
+	volume, err = s.Store.sellVolume(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(volume, Equals, uint64(1), Commentf("%d", volume))
 
@@ -827,7 +828,7 @@ func (s *TimeScaleSuite) TestSellVolume(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	volume, err = s.Store.sellVolume(asset)
+	volume, err = s.Store.sellVolume(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(volume, Equals, uint64(1), Commentf("%d", volume))
 }
@@ -835,7 +836,7 @@ func (s *TimeScaleSuite) TestSellVolume(c *C) {
 func (s *TimeScaleSuite) TestSellVolume24hr(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	volume, err := s.Store.sellVolume24hr(asset)
+	volume, err := s.Store.sellVolume24hr(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(volume, Equals, uint64(0))
 
@@ -848,7 +849,7 @@ func (s *TimeScaleSuite) TestSellVolume24hr(c *C) {
 	c.Assert(err, IsNil)
 
 	asset, _ = common.NewAsset("BNB.BOLT-014")
-	volume, err = s.Store.sellVolume24hr(asset)
+	volume, err = s.Store.sellVolume24hr(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(volume, Equals, uint64(0), Commentf("%v", volume))
 }
@@ -856,7 +857,7 @@ func (s *TimeScaleSuite) TestSellVolume24hr(c *C) {
 func (s *TimeScaleSuite) TestBuyVolume(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	volume, err := s.Store.buyVolume(asset)
+	volume, err := s.Store.buyVolume(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(volume, Equals, uint64(0))
 
@@ -869,7 +870,7 @@ func (s *TimeScaleSuite) TestBuyVolume(c *C) {
 	c.Assert(err, IsNil)
 
 	asset, _ = common.NewAsset("BNB.BOLT-014")
-	volume, err = s.Store.buyVolume(asset)
+	volume, err = s.Store.buyVolume(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(volume, Equals, uint64(149245672), Commentf("%v", volume))
 
@@ -879,7 +880,7 @@ func (s *TimeScaleSuite) TestBuyVolume(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	volume, err = s.Store.buyVolume(asset)
+	volume, err = s.Store.buyVolume(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(volume, Equals, uint64(140331491), Commentf("%v", volume))
 }
@@ -887,7 +888,7 @@ func (s *TimeScaleSuite) TestBuyVolume(c *C) {
 func (s *TimeScaleSuite) TestBuyVolume24hr(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	volume, err := s.Store.buyVolume24hr(asset)
+	volume, err := s.Store.buyVolume24hr(context.Background(), asset)
 	c.Assert(err, IsNil)
 
 	c.Assert(volume, Equals, uint64(0))
@@ -901,7 +902,7 @@ func (s *TimeScaleSuite) TestBuyVolume24hr(c *C) {
 	c.Assert(err, IsNil)
 
 	asset, _ = common.NewAsset("BNB.BOLT-014")
-	volume, err = s.Store.buyVolume24hr(asset)
+	volume, err = s.Store.buyVolume24hr(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(volume, Equals, uint64(0))
 }
@@ -909,7 +910,7 @@ func (s *TimeScaleSuite) TestBuyVolume24hr(c *C) {
 func (s *TimeScaleSuite) TestPoolVolume(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	volume, err := s.Store.poolVolume(asset)
+	volume, err := s.Store.poolVolume(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(volume, Equals, uint64(0))
 
@@ -922,7 +923,7 @@ func (s *TimeScaleSuite) TestPoolVolume(c *C) {
 	c.Assert(err, IsNil)
 
 	asset, _ = common.NewAsset("BNB.BOLT-014")
-	volume, err = s.Store.poolVolume(asset)
+	volume, err = s.Store.poolVolume(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(volume, Equals, uint64(1), Commentf("%v", volume))
 
@@ -932,7 +933,7 @@ func (s *TimeScaleSuite) TestPoolVolume(c *C) {
 	err = s.Store.CreateSwapRecord(&swap1)
 	c.Assert(err, IsNil)
 
-	volume, err = s.Store.poolVolume(asset)
+	volume, err = s.Store.poolVolume(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(volume, Equals, uint64(140331492), Commentf("%v", volume))
 
@@ -940,7 +941,7 @@ func (s *TimeScaleSuite) TestPoolVolume(c *C) {
 	err = s.Store.CreateUnStakesRecord(&unstakeBnbEvent1)
 	c.Assert(err, IsNil)
 
-	volume, err = s.Store.poolVolume(asset)
+	volume, err = s.Store.poolVolume(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(volume, Equals, uint64(140331492), Commentf("%v", volume))
 }
@@ -951,7 +952,7 @@ func (s *TimeScaleSuite) TestGetPoolVolume(c *C) {
 
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	volume, err := s.Store.GetPoolVolume(asset, pastDay, now)
+	volume, err := s.Store.GetPoolVolume(context.Background(), asset, pastDay, now)
 	c.Assert(err, IsNil)
 	c.Assert(volume, Equals, int64(0))
 
@@ -964,7 +965,7 @@ func (s *TimeScaleSuite) TestGetPoolVolume(c *C) {
 	c.Assert(err, IsNil)
 
 	asset, _ = common.NewAsset("BNB.BOLT-014")
-	volume, err = s.Store.GetPoolVolume(asset, pastDay, now)
+	volume, err = s.Store.GetPoolVolume(context.Background(), asset, pastDay, now)
 	c.Assert(err, IsNil)
 	c.Assert(volume, Equals, int64(1), Commentf("%v", volume))
 
@@ -974,7 +975,7 @@ func (s *TimeScaleSuite) TestGetPoolVolume(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	volume, err = s.Store.GetPoolVolume(asset, pastDay, now)
+	volume, err = s.Store.GetPoolVolume(context.Background(), asset, pastDay, now)
 	c.Assert(err, IsNil)
 	c.Assert(volume, Equals, int64(2), Commentf("%v", volume))
 }
@@ -982,7 +983,7 @@ func (s *TimeScaleSuite) TestGetPoolVolume(c *C) {
 func (s *TimeScaleSuite) TestSellTxAverage(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BOLT-014")
-	txAverage, err := s.Store.sellTxAverage(asset)
+	txAverage, err := s.Store.sellTxAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(txAverage, Equals, float64(0))
 
@@ -990,7 +991,7 @@ func (s *TimeScaleSuite) TestSellTxAverage(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBoltEvent5)
 	c.Assert(err, IsNil)
 
-	txAverage, err = s.Store.sellTxAverage(asset)
+	txAverage, err = s.Store.sellTxAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(txAverage, Equals, float64(0))
 
@@ -1000,7 +1001,7 @@ func (s *TimeScaleSuite) TestSellTxAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	txAverage, err = s.Store.sellTxAverage(asset)
+	txAverage, err = s.Store.sellTxAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(txAverage, Equals, float64(0))
 
@@ -1010,7 +1011,7 @@ func (s *TimeScaleSuite) TestSellTxAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	txAverage, err = s.Store.sellTxAverage(asset)
+	txAverage, err = s.Store.sellTxAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(txAverage, Equals, float64(1.403314917127072e+08), Commentf("%d", txAverage))
 
@@ -1019,7 +1020,7 @@ func (s *TimeScaleSuite) TestSellTxAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	txAverage, err = s.Store.sellTxAverage(asset)
+	txAverage, err = s.Store.sellTxAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(txAverage, Equals, float64(1.3242215014794984e+08), Commentf("%d", txAverage))
 
@@ -1029,7 +1030,7 @@ func (s *TimeScaleSuite) TestSellTxAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	txAverage, err = s.Store.sellTxAverage(asset)
+	txAverage, err = s.Store.sellTxAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(txAverage, Equals, float64(1.403314917127072e+08), Commentf("%d", txAverage))
 }
@@ -1037,7 +1038,7 @@ func (s *TimeScaleSuite) TestSellTxAverage(c *C) {
 func (s *TimeScaleSuite) TestBuyTxAverage(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BOLT-014")
-	txAverage, err := s.Store.buyTxAverage(asset)
+	txAverage, err := s.Store.buyTxAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(txAverage, Equals, float64(0))
 
@@ -1045,7 +1046,7 @@ func (s *TimeScaleSuite) TestBuyTxAverage(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBoltEvent5)
 	c.Assert(err, IsNil)
 
-	txAverage, err = s.Store.buyTxAverage(asset)
+	txAverage, err = s.Store.buyTxAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(txAverage, Equals, float64(0))
 
@@ -1053,7 +1054,7 @@ func (s *TimeScaleSuite) TestBuyTxAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swapSellBolt2RuneEvent2)
 	c.Assert(err, IsNil)
 
-	txAverage, err = s.Store.buyTxAverage(asset)
+	txAverage, err = s.Store.buyTxAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(txAverage, Equals, float64(0), Commentf("%d", txAverage))
 
@@ -1063,7 +1064,7 @@ func (s *TimeScaleSuite) TestBuyTxAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	txAverage, err = s.Store.buyTxAverage(asset)
+	txAverage, err = s.Store.buyTxAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(txAverage, Equals, float64(1.403314917127072e+08), Commentf("%d", txAverage))
 
@@ -1072,7 +1073,7 @@ func (s *TimeScaleSuite) TestBuyTxAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	txAverage, err = s.Store.buyTxAverage(asset)
+	txAverage, err = s.Store.buyTxAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(txAverage, Equals, float64(1.4924567260600287e+08), Commentf("%d", txAverage))
 }
@@ -1080,7 +1081,7 @@ func (s *TimeScaleSuite) TestBuyTxAverage(c *C) {
 func (s *TimeScaleSuite) TestPoolTxAverage(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.TUSDB-000")
-	txAverage, err := s.Store.poolTxAverage(asset)
+	txAverage, err := s.Store.poolTxAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(txAverage, Equals, float64(0))
 
@@ -1088,7 +1089,7 @@ func (s *TimeScaleSuite) TestPoolTxAverage(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeTusdbEvent0)
 	c.Assert(err, IsNil)
 
-	txAverage, err = s.Store.poolTxAverage(asset)
+	txAverage, err = s.Store.poolTxAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(txAverage, Equals, float64(0))
 
@@ -1096,7 +1097,7 @@ func (s *TimeScaleSuite) TestPoolTxAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swapSellTusdb2RuneEvent0)
 	c.Assert(err, IsNil)
 
-	txAverage, err = s.Store.poolTxAverage(asset)
+	txAverage, err = s.Store.poolTxAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(txAverage, Equals, float64(9.99999998), Commentf("%d", txAverage))
 
@@ -1106,7 +1107,7 @@ func (s *TimeScaleSuite) TestPoolTxAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	txAverage, err = s.Store.poolTxAverage(asset)
+	txAverage, err = s.Store.poolTxAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(txAverage, Equals, float64(9.99999996), Commentf("%d", txAverage))
 
@@ -1114,7 +1115,7 @@ func (s *TimeScaleSuite) TestPoolTxAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swapBuyRune2TusdbEvent0)
 	c.Assert(err, IsNil)
 
-	txAverage, err = s.Store.poolTxAverage(asset)
+	txAverage, err = s.Store.poolTxAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(txAverage, Equals, float64(9.99999998), Commentf("%d", txAverage))
 }
@@ -1122,7 +1123,7 @@ func (s *TimeScaleSuite) TestPoolTxAverage(c *C) {
 func (s *TimeScaleSuite) TestSellSlipAverage(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BOLT-014")
-	slipAverage, err := s.Store.sellSlipAverage(asset)
+	slipAverage, err := s.Store.sellSlipAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(slipAverage, Equals, 0.0)
 
@@ -1130,7 +1131,7 @@ func (s *TimeScaleSuite) TestSellSlipAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swapBuyRune2BoltEvent1)
 	c.Assert(err, IsNil)
 
-	slipAverage, err = s.Store.sellSlipAverage(asset)
+	slipAverage, err = s.Store.sellSlipAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(slipAverage, Equals, 0.0)
 
@@ -1140,7 +1141,7 @@ func (s *TimeScaleSuite) TestSellSlipAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	slipAverage, err = s.Store.sellSlipAverage(asset)
+	slipAverage, err = s.Store.sellSlipAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(slipAverage, Equals, 0.12300000339746475)
 
@@ -1148,7 +1149,7 @@ func (s *TimeScaleSuite) TestSellSlipAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swapSellBolt2RuneEvent2)
 	c.Assert(err, IsNil)
 
-	slipAverage, err = s.Store.sellSlipAverage(asset)
+	slipAverage, err = s.Store.sellSlipAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(slipAverage, Equals, 0.12300000339746475)
 }
@@ -1156,7 +1157,7 @@ func (s *TimeScaleSuite) TestSellSlipAverage(c *C) {
 func (s *TimeScaleSuite) TestBuySlipAverage(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BOLT-014")
-	slipAverage, err := s.Store.buySlipAverage(asset)
+	slipAverage, err := s.Store.buySlipAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(slipAverage, Equals, 0.0)
 
@@ -1164,7 +1165,7 @@ func (s *TimeScaleSuite) TestBuySlipAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swapSellBolt2RuneEvent2)
 	c.Assert(err, IsNil)
 
-	slipAverage, err = s.Store.buySlipAverage(asset)
+	slipAverage, err = s.Store.buySlipAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(slipAverage, Equals, 0.0)
 
@@ -1174,7 +1175,7 @@ func (s *TimeScaleSuite) TestBuySlipAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	slipAverage, err = s.Store.buySlipAverage(asset)
+	slipAverage, err = s.Store.buySlipAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(slipAverage, Equals, 0.12300000339746475)
 
@@ -1182,7 +1183,7 @@ func (s *TimeScaleSuite) TestBuySlipAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swapBuyRune2BoltEvent1)
 	c.Assert(err, IsNil)
 
-	slipAverage, err = s.Store.buySlipAverage(asset)
+	slipAverage, err = s.Store.buySlipAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(slipAverage, Equals, 0.12300000339746475)
 }
@@ -1190,7 +1191,7 @@ func (s *TimeScaleSuite) TestBuySlipAverage(c *C) {
 func (s *TimeScaleSuite) TestPoolSlipAverage(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BOLT-014")
-	slipAverage, err := s.Store.poolSlipAverage(asset)
+	slipAverage, err := s.Store.poolSlipAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(slipAverage, Equals, 0.0)
 
@@ -1198,7 +1199,7 @@ func (s *TimeScaleSuite) TestPoolSlipAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swapSellBolt2RuneEvent1)
 	c.Assert(err, IsNil)
 
-	slipAverage, err = s.Store.poolSlipAverage(asset)
+	slipAverage, err = s.Store.poolSlipAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(slipAverage, Equals, 0.12300000339746475)
 
@@ -1208,7 +1209,7 @@ func (s *TimeScaleSuite) TestPoolSlipAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	slipAverage, err = s.Store.poolSlipAverage(asset)
+	slipAverage, err = s.Store.poolSlipAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(slipAverage, Equals, 0.12300000339746475)
 }
@@ -1216,7 +1217,7 @@ func (s *TimeScaleSuite) TestPoolSlipAverage(c *C) {
 func (s *TimeScaleSuite) TestSellFeeAverage(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BOLT-014")
-	feeAverage, err := s.Store.sellFeeAverage(asset)
+	feeAverage, err := s.Store.sellFeeAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feeAverage, Equals, float64(0))
 
@@ -1224,7 +1225,7 @@ func (s *TimeScaleSuite) TestSellFeeAverage(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBoltEvent5)
 	c.Assert(err, IsNil)
 
-	feeAverage, err = s.Store.sellFeeAverage(asset)
+	feeAverage, err = s.Store.sellFeeAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feeAverage, Equals, float64(0))
 
@@ -1232,7 +1233,7 @@ func (s *TimeScaleSuite) TestSellFeeAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swapBuyRune2BoltEvent1)
 	c.Assert(err, IsNil)
 
-	feeAverage, err = s.Store.sellFeeAverage(asset)
+	feeAverage, err = s.Store.sellFeeAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feeAverage, Equals, float64(0))
 
@@ -1241,7 +1242,7 @@ func (s *TimeScaleSuite) TestSellFeeAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	feeAverage, err = s.Store.sellFeeAverage(asset)
+	feeAverage, err = s.Store.sellFeeAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feeAverage, Equals, float64(7463556), Commentf("feeAverage: %v", feeAverage))
 
@@ -1250,7 +1251,7 @@ func (s *TimeScaleSuite) TestSellFeeAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	feeAverage, err = s.Store.sellFeeAverage(asset)
+	feeAverage, err = s.Store.sellFeeAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feeAverage, Equals, float64(7463556), Commentf("feeAverage: %v", feeAverage))
 }
@@ -1258,7 +1259,7 @@ func (s *TimeScaleSuite) TestSellFeeAverage(c *C) {
 func (s *TimeScaleSuite) TestBuyFeeAverage(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BOLT-014")
-	feeAverage, err := s.Store.buyFeeAverage(asset)
+	feeAverage, err := s.Store.buyFeeAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feeAverage, Equals, float64(0))
 
@@ -1266,7 +1267,7 @@ func (s *TimeScaleSuite) TestBuyFeeAverage(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBoltEvent5)
 	c.Assert(err, IsNil)
 
-	feeAverage, err = s.Store.buyFeeAverage(asset)
+	feeAverage, err = s.Store.buyFeeAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feeAverage, Equals, float64(0))
 
@@ -1274,7 +1275,7 @@ func (s *TimeScaleSuite) TestBuyFeeAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swapSellBolt2RuneEvent2)
 	c.Assert(err, IsNil)
 
-	feeAverage, err = s.Store.buyFeeAverage(asset)
+	feeAverage, err = s.Store.buyFeeAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feeAverage, Equals, float64(0))
 
@@ -1283,7 +1284,7 @@ func (s *TimeScaleSuite) TestBuyFeeAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	feeAverage, err = s.Store.buyFeeAverage(asset)
+	feeAverage, err = s.Store.buyFeeAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feeAverage, Equals, float64(5.23685973480663e+07), Commentf("feeAverage: %v", feeAverage))
 
@@ -1292,7 +1293,7 @@ func (s *TimeScaleSuite) TestBuyFeeAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	feeAverage, err = s.Store.buyFeeAverage(asset)
+	feeAverage, err = s.Store.buyFeeAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feeAverage, Equals, float64(5.5695171762628414e+07), Commentf("feeAverage: %v", feeAverage))
 }
@@ -1300,7 +1301,7 @@ func (s *TimeScaleSuite) TestBuyFeeAverage(c *C) {
 func (s *TimeScaleSuite) TestPoolFeeAverage(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BOLT-014")
-	feeAverage, err := s.Store.poolFeeAverage(asset)
+	feeAverage, err := s.Store.poolFeeAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feeAverage, Equals, float64(0))
 
@@ -1308,7 +1309,7 @@ func (s *TimeScaleSuite) TestPoolFeeAverage(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBoltEvent5)
 	c.Assert(err, IsNil)
 
-	feeAverage, err = s.Store.poolFeeAverage(asset)
+	feeAverage, err = s.Store.poolFeeAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feeAverage, Equals, float64(0))
 
@@ -1316,7 +1317,7 @@ func (s *TimeScaleSuite) TestPoolFeeAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swapSellBolt2RuneEvent2)
 	c.Assert(err, IsNil)
 
-	feeAverage, err = s.Store.poolFeeAverage(asset)
+	feeAverage, err = s.Store.poolFeeAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feeAverage, Equals, float64(7463556), Commentf("feeAverage: %v", feeAverage))
 
@@ -1325,7 +1326,7 @@ func (s *TimeScaleSuite) TestPoolFeeAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	feeAverage, err = s.Store.poolFeeAverage(asset)
+	feeAverage, err = s.Store.poolFeeAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feeAverage, Equals, float64(2.991607667403315e+07), Commentf("feeAverage: %v", feeAverage))
 
@@ -1334,7 +1335,7 @@ func (s *TimeScaleSuite) TestPoolFeeAverage(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	feeAverage, err = s.Store.poolFeeAverage(asset)
+	feeAverage, err = s.Store.poolFeeAverage(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feeAverage, Equals, float64(3.961796650841894e+07), Commentf("feeAverage: %v", feeAverage))
 }
@@ -1342,7 +1343,7 @@ func (s *TimeScaleSuite) TestPoolFeeAverage(c *C) {
 func (s *TimeScaleSuite) TestSellFeesTotal(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	feesTotal, err := s.Store.sellFeesTotal(asset)
+	feesTotal, err := s.Store.sellFeesTotal(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feesTotal, Equals, uint64(0))
 
@@ -1355,7 +1356,7 @@ func (s *TimeScaleSuite) TestSellFeesTotal(c *C) {
 	err = s.Store.CreateSwapRecord(&swapBuyRune2BnbEvent2)
 	c.Assert(err, IsNil)
 
-	feesTotal, err = s.Store.sellFeesTotal(asset)
+	feesTotal, err = s.Store.sellFeesTotal(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feesTotal, Equals, uint64(0))
 
@@ -1363,7 +1364,7 @@ func (s *TimeScaleSuite) TestSellFeesTotal(c *C) {
 	err = s.Store.CreateSwapRecord(&swapSellBnb2RuneEvent4)
 	c.Assert(err, IsNil)
 
-	feesTotal, err = s.Store.sellFeesTotal(asset)
+	feesTotal, err = s.Store.sellFeesTotal(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feesTotal, Equals, uint64(7463556), Commentf("feesTotal: %v", feesTotal))
 
@@ -1373,7 +1374,7 @@ func (s *TimeScaleSuite) TestSellFeesTotal(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	feesTotal, err = s.Store.sellFeesTotal(asset)
+	feesTotal, err = s.Store.sellFeesTotal(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feesTotal, Equals, uint64(14927112), Commentf("feesTotal: %v", feesTotal))
 
@@ -1383,7 +1384,7 @@ func (s *TimeScaleSuite) TestSellFeesTotal(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	feesTotal, err = s.Store.sellFeesTotal(asset)
+	feesTotal, err = s.Store.sellFeesTotal(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feesTotal, Equals, uint64(14927112), Commentf("feesTotal: %v", feesTotal))
 }
@@ -1391,7 +1392,7 @@ func (s *TimeScaleSuite) TestSellFeesTotal(c *C) {
 func (s *TimeScaleSuite) TestBuyFeesTotal(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	feesTotal, err := s.Store.buyFeesTotal(asset)
+	feesTotal, err := s.Store.buyFeesTotal(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feesTotal, Equals, uint64(0))
 
@@ -1403,7 +1404,7 @@ func (s *TimeScaleSuite) TestBuyFeesTotal(c *C) {
 	err = s.Store.CreateSwapRecord(&swapSellBnb2RuneEvent4)
 	c.Assert(err, IsNil)
 
-	feesTotal, err = s.Store.buyFeesTotal(asset)
+	feesTotal, err = s.Store.buyFeesTotal(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feesTotal, Equals, uint64(0), Commentf("feesTotal: %v", feesTotal))
 
@@ -1413,7 +1414,7 @@ func (s *TimeScaleSuite) TestBuyFeesTotal(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	feesTotal, err = s.Store.buyFeesTotal(asset)
+	feesTotal, err = s.Store.buyFeesTotal(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feesTotal, Equals, uint64(7463556), Commentf("feesTotal: %v", feesTotal))
 
@@ -1423,7 +1424,7 @@ func (s *TimeScaleSuite) TestBuyFeesTotal(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	feesTotal, err = s.Store.buyFeesTotal(asset)
+	feesTotal, err = s.Store.buyFeesTotal(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feesTotal, Equals, uint64(7463556), Commentf("feesTotal: %v", feesTotal))
 }
@@ -1431,7 +1432,7 @@ func (s *TimeScaleSuite) TestBuyFeesTotal(c *C) {
 func (s *TimeScaleSuite) TestPoolFeesTotal(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	feesTotal, err := s.Store.poolFeesTotal(asset)
+	feesTotal, err := s.Store.poolFeesTotal(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feesTotal, Equals, uint64(0))
 
@@ -1444,7 +1445,7 @@ func (s *TimeScaleSuite) TestPoolFeesTotal(c *C) {
 	err = s.Store.CreateSwapRecord(&swapBuyRune2BnbEvent2)
 	c.Assert(err, IsNil)
 
-	feesTotal, err = s.Store.poolFeesTotal(asset)
+	feesTotal, err = s.Store.poolFeesTotal(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feesTotal, Equals, uint64(7466), Commentf("feesTotal: %v", feesTotal))
 
@@ -1452,7 +1453,7 @@ func (s *TimeScaleSuite) TestPoolFeesTotal(c *C) {
 	err = s.Store.CreateSwapRecord(&swapSellBnb2RuneEvent4)
 	c.Assert(err, IsNil)
 
-	feesTotal, err = s.Store.poolFeesTotal(asset)
+	feesTotal, err = s.Store.poolFeesTotal(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feesTotal, Equals, uint64(7471019), Commentf("feesTotal: %v", feesTotal))
 
@@ -1462,7 +1463,7 @@ func (s *TimeScaleSuite) TestPoolFeesTotal(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	feesTotal, err = s.Store.poolFeesTotal(asset)
+	feesTotal, err = s.Store.poolFeesTotal(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feesTotal, Equals, uint64(14933081), Commentf("feesTotal: %v", feesTotal))
 
@@ -1472,7 +1473,7 @@ func (s *TimeScaleSuite) TestPoolFeesTotal(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	feesTotal, err = s.Store.poolFeesTotal(asset)
+	feesTotal, err = s.Store.poolFeesTotal(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(feesTotal, Equals, uint64(14939056), Commentf("feesTotal: %v", feesTotal))
 }
@@ -1480,7 +1481,7 @@ func (s *TimeScaleSuite) TestPoolFeesTotal(c *C) {
 func (s *TimeScaleSuite) TestSellAssetCount(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	assetCount, err := s.Store.sellAssetCount(asset)
+	assetCount, err := s.Store.sellAssetCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetCount, Equals, uint64(0))
 
@@ -1489,7 +1490,7 @@ func (s *TimeScaleSuite) TestSellAssetCount(c *C) {
 	c.Assert(err, IsNil)
 
 	asset, _ = common.NewAsset("BNB.BOLT-014")
-	assetCount, err = s.Store.sellAssetCount(asset)
+	assetCount, err = s.Store.sellAssetCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetCount, Equals, uint64(0))
 
@@ -1497,7 +1498,7 @@ func (s *TimeScaleSuite) TestSellAssetCount(c *C) {
 	err = s.Store.CreateSwapRecord(&swapSellBolt2RuneEvent1)
 	c.Assert(err, IsNil)
 
-	assetCount, err = s.Store.sellAssetCount(asset)
+	assetCount, err = s.Store.sellAssetCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetCount, Equals, uint64(1))
 
@@ -1505,7 +1506,7 @@ func (s *TimeScaleSuite) TestSellAssetCount(c *C) {
 	err = s.Store.CreateSwapRecord(&swapSellBolt2RuneEvent2)
 	c.Assert(err, IsNil)
 
-	assetCount, err = s.Store.sellAssetCount(asset)
+	assetCount, err = s.Store.sellAssetCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetCount, Equals, uint64(2), Commentf("assetCount: %v", assetCount))
 
@@ -1515,7 +1516,7 @@ func (s *TimeScaleSuite) TestSellAssetCount(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	assetCount, err = s.Store.sellAssetCount(asset)
+	assetCount, err = s.Store.sellAssetCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetCount, Equals, uint64(2), Commentf("assetCount: %v", assetCount))
 
@@ -1523,7 +1524,7 @@ func (s *TimeScaleSuite) TestSellAssetCount(c *C) {
 	err = s.Store.CreateUnStakesRecord(&unstakeBoltEvent2)
 	c.Assert(err, IsNil)
 
-	assetCount, err = s.Store.sellAssetCount(asset)
+	assetCount, err = s.Store.sellAssetCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetCount, Equals, uint64(2), Commentf("assetCount: %v", assetCount))
 }
@@ -1531,7 +1532,7 @@ func (s *TimeScaleSuite) TestSellAssetCount(c *C) {
 func (s *TimeScaleSuite) TestBuyAssetCount(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	assetCount, err := s.Store.buyAssetCount(asset)
+	assetCount, err := s.Store.buyAssetCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetCount, Equals, uint64(0))
 
@@ -1540,7 +1541,7 @@ func (s *TimeScaleSuite) TestBuyAssetCount(c *C) {
 	c.Assert(err, IsNil)
 
 	asset, _ = common.NewAsset("BNB.BOLT-014")
-	assetCount, err = s.Store.buyAssetCount(asset)
+	assetCount, err = s.Store.buyAssetCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetCount, Equals, uint64(0))
 
@@ -1550,7 +1551,7 @@ func (s *TimeScaleSuite) TestBuyAssetCount(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	assetCount, err = s.Store.buyAssetCount(asset)
+	assetCount, err = s.Store.buyAssetCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetCount, Equals, uint64(1), Commentf("assetCount: %v", assetCount))
 
@@ -1559,7 +1560,7 @@ func (s *TimeScaleSuite) TestBuyAssetCount(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	assetCount, err = s.Store.buyAssetCount(asset)
+	assetCount, err = s.Store.buyAssetCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetCount, Equals, uint64(2), Commentf("assetCount: %v", assetCount))
 
@@ -1569,7 +1570,7 @@ func (s *TimeScaleSuite) TestBuyAssetCount(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	assetCount, err = s.Store.buyAssetCount(asset)
+	assetCount, err = s.Store.buyAssetCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetCount, Equals, uint64(2), Commentf("assetCount: %v", assetCount))
 
@@ -1577,7 +1578,7 @@ func (s *TimeScaleSuite) TestBuyAssetCount(c *C) {
 	err = s.Store.CreateUnStakesRecord(&unstakeBoltEvent2)
 	c.Assert(err, IsNil)
 
-	assetCount, err = s.Store.buyAssetCount(asset)
+	assetCount, err = s.Store.buyAssetCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetCount, Equals, uint64(2), Commentf("assetCount: %v", assetCount))
 }
@@ -1585,7 +1586,7 @@ func (s *TimeScaleSuite) TestBuyAssetCount(c *C) {
 func (s *TimeScaleSuite) TestSwappingTxCount(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	swappingCount, err := s.Store.swappingTxCount(asset)
+	swappingCount, err := s.Store.swappingTxCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(swappingCount, Equals, uint64(0))
 
@@ -1600,7 +1601,7 @@ func (s *TimeScaleSuite) TestSwappingTxCount(c *C) {
 	c.Assert(err, IsNil)
 
 	asset, _ = common.NewAsset("BNB.BOLT-014")
-	swappingCount, err = s.Store.swappingTxCount(asset)
+	swappingCount, err = s.Store.swappingTxCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(swappingCount, Equals, uint64(3))
 }
@@ -1608,7 +1609,7 @@ func (s *TimeScaleSuite) TestSwappingTxCount(c *C) {
 func (s *TimeScaleSuite) TestSwappersCount(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	swappersCount, err := s.Store.GetSwappersCount(asset)
+	swappersCount, err := s.Store.GetSwappersCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(swappersCount, Equals, uint64(0))
 
@@ -1617,7 +1618,7 @@ func (s *TimeScaleSuite) TestSwappersCount(c *C) {
 	c.Assert(err, IsNil)
 
 	asset, _ = common.NewAsset("BNB.BOLT-014")
-	swappersCount, err = s.Store.GetSwappersCount(asset)
+	swappersCount, err = s.Store.GetSwappersCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(swappersCount, Equals, uint64(1))
 
@@ -1627,7 +1628,7 @@ func (s *TimeScaleSuite) TestSwappersCount(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	swappersCount, err = s.Store.GetSwappersCount(asset)
+	swappersCount, err = s.Store.GetSwappersCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(swappersCount, Equals, uint64(1), Commentf("swappersCount: %v", swappersCount))
 
@@ -1637,7 +1638,7 @@ func (s *TimeScaleSuite) TestSwappersCount(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	swappersCount, err = s.Store.GetSwappersCount(asset)
+	swappersCount, err = s.Store.GetSwappersCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(swappersCount, Equals, uint64(2), Commentf("swappersCount: %v", swappersCount))
 }
@@ -1645,7 +1646,7 @@ func (s *TimeScaleSuite) TestSwappersCount(c *C) {
 func (s *TimeScaleSuite) TestStakeTxCount(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	stakeCount, err := s.Store.stakeTxCount(asset)
+	stakeCount, err := s.Store.stakeTxCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(stakeCount, Equals, uint64(0))
 
@@ -1653,7 +1654,7 @@ func (s *TimeScaleSuite) TestStakeTxCount(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	stakeCount, err = s.Store.stakeTxCount(asset)
+	stakeCount, err = s.Store.stakeTxCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(stakeCount, Equals, uint64(1), Commentf("%v", stakeCount))
 
@@ -1661,7 +1662,7 @@ func (s *TimeScaleSuite) TestStakeTxCount(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent2)
 	c.Assert(err, IsNil)
 
-	stakeCount, err = s.Store.stakeTxCount(asset)
+	stakeCount, err = s.Store.stakeTxCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(stakeCount, Equals, uint64(2), Commentf("%v", stakeCount))
 
@@ -1669,7 +1670,7 @@ func (s *TimeScaleSuite) TestStakeTxCount(c *C) {
 	err = s.Store.CreateUnStakesRecord(&unstakeBnbEvent1)
 	c.Assert(err, IsNil)
 
-	stakeCount, err = s.Store.stakeTxCount(asset)
+	stakeCount, err = s.Store.stakeTxCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(stakeCount, Equals, uint64(2), Commentf("%v", stakeCount))
 }
@@ -1677,7 +1678,7 @@ func (s *TimeScaleSuite) TestStakeTxCount(c *C) {
 func (s *TimeScaleSuite) TestWithdrawTxCount(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	withdrawCount, err := s.Store.withdrawTxCount(asset)
+	withdrawCount, err := s.Store.withdrawTxCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(withdrawCount, Equals, uint64(0))
 
@@ -1685,7 +1686,7 @@ func (s *TimeScaleSuite) TestWithdrawTxCount(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	withdrawCount, err = s.Store.withdrawTxCount(asset)
+	withdrawCount, err = s.Store.withdrawTxCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(withdrawCount, Equals, uint64(0))
 
@@ -1693,7 +1694,7 @@ func (s *TimeScaleSuite) TestWithdrawTxCount(c *C) {
 	err = s.Store.CreateUnStakesRecord(&unstakeBnbEvent1)
 	c.Assert(err, IsNil)
 
-	withdrawCount, err = s.Store.withdrawTxCount(asset)
+	withdrawCount, err = s.Store.withdrawTxCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(withdrawCount, Equals, uint64(1), Commentf("withdrawCount: %v", withdrawCount))
 
@@ -1703,7 +1704,7 @@ func (s *TimeScaleSuite) TestWithdrawTxCount(c *C) {
 	err = s.Store.CreateUnStakesRecord(&unstake)
 	c.Assert(err, IsNil)
 
-	withdrawCount, err = s.Store.withdrawTxCount(asset)
+	withdrawCount, err = s.Store.withdrawTxCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(withdrawCount, Equals, uint64(2), Commentf("withdrawCount: %v", withdrawCount))
 }
@@ -1711,7 +1712,7 @@ func (s *TimeScaleSuite) TestWithdrawTxCount(c *C) {
 func (s *TimeScaleSuite) TestStakingTxCount(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	stakingCount, err := s.Store.stakingTxCount(asset)
+	stakingCount, err := s.Store.stakingTxCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(stakingCount, Equals, uint64(0))
 
@@ -1719,7 +1720,7 @@ func (s *TimeScaleSuite) TestStakingTxCount(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	stakingCount, err = s.Store.stakingTxCount(asset)
+	stakingCount, err = s.Store.stakingTxCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(stakingCount, Equals, uint64(1))
 
@@ -1729,7 +1730,7 @@ func (s *TimeScaleSuite) TestStakingTxCount(c *C) {
 	err = s.Store.CreateStakeRecord(&stake)
 	c.Assert(err, IsNil)
 
-	stakingCount, err = s.Store.stakingTxCount(asset)
+	stakingCount, err = s.Store.stakingTxCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(stakingCount, Equals, uint64(2), Commentf("stakingCount: %v", stakingCount))
 
@@ -1737,7 +1738,7 @@ func (s *TimeScaleSuite) TestStakingTxCount(c *C) {
 	err = s.Store.CreateUnStakesRecord(&unstakeBnbEvent1)
 	c.Assert(err, IsNil)
 
-	stakingCount, err = s.Store.stakingTxCount(asset)
+	stakingCount, err = s.Store.stakingTxCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(stakingCount, Equals, uint64(3), Commentf("stakingCount: %v", stakingCount))
 }
@@ -1745,7 +1746,7 @@ func (s *TimeScaleSuite) TestStakingTxCount(c *C) {
 func (s *TimeScaleSuite) TestStakersCount(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	stakersCount, err := s.Store.GetStakersCount(asset)
+	stakersCount, err := s.Store.GetStakersCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 
 	c.Assert(stakersCount, Equals, uint64(0))
@@ -1754,14 +1755,14 @@ func (s *TimeScaleSuite) TestStakersCount(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	stakersCount, err = s.Store.GetStakersCount(asset)
+	stakersCount, err = s.Store.GetStakersCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(stakersCount, Equals, uint64(1))
 
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	stakersCount, err = s.Store.GetStakersCount(asset)
+	stakersCount, err = s.Store.GetStakersCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(stakersCount, Equals, uint64(1))
 
@@ -1769,7 +1770,7 @@ func (s *TimeScaleSuite) TestStakersCount(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent2)
 	c.Assert(err, IsNil)
 
-	stakersCount, err = s.Store.GetStakersCount(asset)
+	stakersCount, err = s.Store.GetStakersCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(stakersCount, Equals, uint64(2))
 
@@ -1779,7 +1780,7 @@ func (s *TimeScaleSuite) TestStakersCount(c *C) {
 	err = s.Store.CreateUnStakesRecord(&unstakeBnbEvent1)
 	c.Assert(err, IsNil)
 
-	stakersCount, err = s.Store.GetStakersCount(asset)
+	stakersCount, err = s.Store.GetStakersCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(stakersCount, Equals, uint64(1))
 
@@ -1787,12 +1788,12 @@ func (s *TimeScaleSuite) TestStakersCount(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeTcanEvent3)
 	c.Assert(err, IsNil)
 
-	stakersCount, err = s.Store.GetStakersCount(asset)
+	stakersCount, err = s.Store.GetStakersCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(stakersCount, Equals, uint64(1))
 
 	asset, _ = common.NewAsset("BNB.TCAN-014")
-	stakersCount, err = s.Store.GetStakersCount(asset)
+	stakersCount, err = s.Store.GetStakersCount(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(stakersCount, Equals, uint64(1))
 }
@@ -1800,7 +1801,7 @@ func (s *TimeScaleSuite) TestStakersCount(c *C) {
 func (s *TimeScaleSuite) TestAssetROI(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	roi, err := s.Store.assetROI(asset)
+	roi, err := s.Store.assetROI(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, 0.0)
 
@@ -1809,7 +1810,7 @@ func (s *TimeScaleSuite) TestAssetROI(c *C) {
 	c.Assert(err, IsNil)
 
 	asset, _ = common.NewAsset("BNB.BOLT-014")
-	roi, err = s.Store.assetROI(asset)
+	roi, err = s.Store.assetROI(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, 0.0)
 
@@ -1819,7 +1820,7 @@ func (s *TimeScaleSuite) TestAssetROI(c *C) {
 	err = s.Store.CreateSwapRecord(&swap0)
 	c.Assert(err, IsNil)
 
-	roi, err = s.Store.assetROI(asset)
+	roi, err = s.Store.assetROI(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, -0.05972823652381663)
 
@@ -1829,7 +1830,7 @@ func (s *TimeScaleSuite) TestAssetROI(c *C) {
 	err = s.Store.CreateSwapRecord(&swap1)
 	c.Assert(err, IsNil)
 
-	roi, err = s.Store.assetROI(asset)
+	roi, err = s.Store.assetROI(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, 0.0)
 }
@@ -1837,7 +1838,7 @@ func (s *TimeScaleSuite) TestAssetROI(c *C) {
 func (s *TimeScaleSuite) TestAssetROI12(c *C) {
 	// No stake
 	asset, _ := common.NewAsset("BNB.BNB")
-	roi, err := s.Store.assetROI12(asset)
+	roi, err := s.Store.assetROI12(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, 0.0)
 
@@ -1856,7 +1857,7 @@ func (s *TimeScaleSuite) TestAssetROI12(c *C) {
 	c.Assert(err, IsNil)
 
 	asset, _ = common.NewAsset("BNB.BOLT-4DC")
-	roi, err = s.Store.assetROI12(asset)
+	roi, err = s.Store.assetROI12(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, 0.0) // because we're always sending asset in (not rune), there is no ROI
 }
@@ -1865,7 +1866,7 @@ func (s *TimeScaleSuite) TestRuneROI(c *C) {
 	asset, _ := common.NewAsset("BNB.BNB")
 
 	// No stake
-	roi, err := s.Store.runeROI(asset)
+	roi, err := s.Store.runeROI(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, 0.0)
 
@@ -1873,7 +1874,7 @@ func (s *TimeScaleSuite) TestRuneROI(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent2)
 	c.Assert(err, IsNil)
 
-	roi, err = s.Store.runeROI(asset)
+	roi, err = s.Store.runeROI(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, 0.0)
 
@@ -1881,7 +1882,7 @@ func (s *TimeScaleSuite) TestRuneROI(c *C) {
 	err = s.Store.CreateSwapRecord(&swapBuyRune2BnbEvent2)
 	c.Assert(err, IsNil)
 
-	roi, err = s.Store.runeROI(asset)
+	roi, err = s.Store.runeROI(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, 0.00000002, Commentf("roi: %d", roi))
 
@@ -1889,7 +1890,7 @@ func (s *TimeScaleSuite) TestRuneROI(c *C) {
 	err = s.Store.CreateSwapRecord(&swapBuyRune2BnbEvent3)
 	c.Assert(err, IsNil)
 
-	roi, err = s.Store.runeROI(asset)
+	roi, err = s.Store.runeROI(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, 4.00000002)
 
@@ -1897,7 +1898,7 @@ func (s *TimeScaleSuite) TestRuneROI(c *C) {
 	err = s.Store.CreateSwapRecord(&swapSellBnb2RuneEvent5)
 	c.Assert(err, IsNil)
 
-	roi, err = s.Store.runeROI(asset)
+	roi, err = s.Store.runeROI(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, 3.80000002)
 }
@@ -1906,7 +1907,7 @@ func (s *TimeScaleSuite) TestRuneROI12(c *C) {
 	asset, _ := common.NewAsset("BNB.BNB")
 
 	// No stake
-	roi, err := s.Store.runeROI12(asset)
+	roi, err := s.Store.runeROI12(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, 0.0)
 
@@ -1914,7 +1915,7 @@ func (s *TimeScaleSuite) TestRuneROI12(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent2)
 	c.Assert(err, IsNil)
 
-	roi, err = s.Store.runeROI12(asset)
+	roi, err = s.Store.runeROI12(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, 0.0)
 
@@ -1922,7 +1923,7 @@ func (s *TimeScaleSuite) TestRuneROI12(c *C) {
 	err = s.Store.CreateSwapRecord(&swapBuyRune2BnbEvent2)
 	c.Assert(err, IsNil)
 
-	roi, err = s.Store.runeROI12(asset)
+	roi, err = s.Store.runeROI12(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, 0.00000002, Commentf("roi: %d", roi))
 
@@ -1930,7 +1931,7 @@ func (s *TimeScaleSuite) TestRuneROI12(c *C) {
 	err = s.Store.CreateSwapRecord(&swapBuyRune2BnbEvent3)
 	c.Assert(err, IsNil)
 
-	roi, err = s.Store.runeROI12(asset)
+	roi, err = s.Store.runeROI12(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, 4.00000002)
 
@@ -1938,7 +1939,7 @@ func (s *TimeScaleSuite) TestRuneROI12(c *C) {
 	err = s.Store.CreateSwapRecord(&swapSellBnb2RuneEvent5)
 	c.Assert(err, IsNil)
 
-	roi, err = s.Store.runeROI12(asset)
+	roi, err = s.Store.runeROI12(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, 3.80000002)
 }
@@ -1947,7 +1948,7 @@ func (s *TimeScaleSuite) TestPoolROI(c *C) {
 	asset, _ := common.NewAsset("BNB.BNB")
 
 	// No stake
-	roi, err := s.Store.PoolROI(asset)
+	roi, err := s.Store.PoolROI(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, 0.0)
 
@@ -1955,7 +1956,7 @@ func (s *TimeScaleSuite) TestPoolROI(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent2)
 	c.Assert(err, IsNil)
 
-	roi, err = s.Store.PoolROI(asset)
+	roi, err = s.Store.PoolROI(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, 0.0)
 
@@ -1963,7 +1964,7 @@ func (s *TimeScaleSuite) TestPoolROI(c *C) {
 	err = s.Store.CreateSwapRecord(&swapSellBnb2RuneEvent5)
 	c.Assert(err, IsNil)
 
-	roi, err = s.Store.PoolROI(asset)
+	roi, err = s.Store.PoolROI(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, -0.0999)
 
@@ -1971,7 +1972,7 @@ func (s *TimeScaleSuite) TestPoolROI(c *C) {
 	err = s.Store.CreateSwapRecord(&swapBuyRune2BnbEvent2)
 	c.Assert(err, IsNil)
 
-	roi, err = s.Store.PoolROI(asset)
+	roi, err = s.Store.PoolROI(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, -0.10009999)
 
@@ -1979,7 +1980,7 @@ func (s *TimeScaleSuite) TestPoolROI(c *C) {
 	err = s.Store.CreateSwapRecord(&swapBuyRune2BnbEvent3)
 	c.Assert(err, IsNil)
 
-	roi, err = s.Store.PoolROI(asset)
+	roi, err = s.Store.PoolROI(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, 1.89970001)
 }
@@ -1988,7 +1989,7 @@ func (s *TimeScaleSuite) TestPoolROI12(c *C) {
 	asset, _ := common.NewAsset("BNB.BNB")
 
 	// No stake
-	roi, err := s.Store.GetPoolROI12(asset)
+	roi, err := s.Store.GetPoolROI12(context.Background(), asset)
 	c.Assert(err, NotNil)
 	c.Assert(roi, Equals, 0.0)
 
@@ -1996,7 +1997,7 @@ func (s *TimeScaleSuite) TestPoolROI12(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent2)
 	c.Assert(err, IsNil)
 
-	roi, err = s.Store.GetPoolROI12(asset)
+	roi, err = s.Store.GetPoolROI12(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, 0.0)
 
@@ -2004,7 +2005,7 @@ func (s *TimeScaleSuite) TestPoolROI12(c *C) {
 	err = s.Store.CreateSwapRecord(&swapSellBnb2RuneEvent5)
 	c.Assert(err, IsNil)
 
-	roi, err = s.Store.GetPoolROI12(asset)
+	roi, err = s.Store.GetPoolROI12(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, -0.0999)
 
@@ -2012,7 +2013,7 @@ func (s *TimeScaleSuite) TestPoolROI12(c *C) {
 	err = s.Store.CreateSwapRecord(&swapBuyRune2BnbEvent2)
 	c.Assert(err, IsNil)
 
-	roi, err = s.Store.GetPoolROI12(asset)
+	roi, err = s.Store.GetPoolROI12(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, -0.10009999)
 
@@ -2020,7 +2021,7 @@ func (s *TimeScaleSuite) TestPoolROI12(c *C) {
 	err = s.Store.CreateSwapRecord(&swapBuyRune2BnbEvent3)
 	c.Assert(err, IsNil)
 
-	roi, err = s.Store.GetPoolROI12(asset)
+	roi, err = s.Store.GetPoolROI12(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(roi, Equals, 1.89970001)
 }
@@ -2031,7 +2032,7 @@ func (s *TimeScaleSuite) TestGetDateCreated(c *C) {
 	c.Assert(err, IsNil)
 
 	asset, _ := common.NewAsset("BNB.BNB")
-	dateCreated, err := s.Store.GetDateCreated(asset)
+	dateCreated, err := s.Store.GetDateCreated(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(dateCreated, Equals, uint64(stakeBnbEvent0.Time.Unix()))
 
@@ -2040,7 +2041,7 @@ func (s *TimeScaleSuite) TestGetDateCreated(c *C) {
 	c.Assert(err, IsNil)
 
 	asset, _ = common.NewAsset("TOML-4BC")
-	dateCreated, err = s.Store.GetDateCreated(asset)
+	dateCreated, err = s.Store.GetDateCreated(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(dateCreated, Equals, uint64(stakeTomlEvent1.Time.Unix()))
 }
@@ -2048,7 +2049,7 @@ func (s *TimeScaleSuite) TestGetDateCreated(c *C) {
 func (s *TimeScaleSuite) TestGetPoolLiquidityFee(c *C) {
 	err := s.Store.CreateSwapRecord(&swapSellBnb2RuneEvent4)
 	c.Assert(err, IsNil)
-	buyFee, sellFee, err := s.Store.getPoolLiquidityFee(common.BNBAsset, time.Now().Add(-24*time.Hour))
+	buyFee, sellFee, err := s.Store.getPoolLiquidityFee(context.Background(), common.BNBAsset, time.Now().Add(-24*time.Hour))
 	c.Assert(err, IsNil)
 	c.Assert(buyFee, Equals, int64(0))
 	c.Assert(sellFee, Equals, int64(7463556))
@@ -2058,12 +2059,12 @@ func (s *TimeScaleSuite) TestGetPoolLiquidityFee(c *C) {
 	swap.Time = time.Now().Add(-30 * 24 * time.Hour)
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
-	buyFee, sellFee, err = s.Store.getPoolLiquidityFee(common.BNBAsset, time.Now().Add(-40*24*time.Hour))
+	buyFee, sellFee, err = s.Store.getPoolLiquidityFee(context.Background(), common.BNBAsset, time.Now().Add(-40*24*time.Hour))
 	c.Assert(err, IsNil)
 	c.Assert(buyFee, Equals, int64(7463556))
 	c.Assert(sellFee, Equals, int64(7463556))
 
-	buyFee, sellFee, err = s.Store.getPoolLiquidityFee(common.BNBAsset, time.Now().Add(-24*time.Hour))
+	buyFee, sellFee, err = s.Store.getPoolLiquidityFee(context.Background(), common.BNBAsset, time.Now().Add(-24*time.Hour))
 	c.Assert(err, IsNil)
 	c.Assert(buyFee, Equals, int64(0))
 	c.Assert(sellFee, Equals, int64(7463556))
@@ -2083,7 +2084,7 @@ func (s *TimeScaleSuite) TestGetPoolLastEnabledDate(c *C) {
 		Pool:   common.BNBAsset,
 	})
 	c.Assert(err, IsNil)
-	lastDate, err := s.Store.GetPoolLastEnabledDate(common.BNBAsset)
+	lastDate, err := s.Store.GetPoolLastEnabledDate(context.Background(), common.BNBAsset)
 	c.Assert(err, IsNil)
 	c.Assert(lastDate.Unix(), Equals, now.Add(-20*24*time.Hour).Unix())
 
@@ -2100,7 +2101,7 @@ func (s *TimeScaleSuite) TestGetPoolLastEnabledDate(c *C) {
 	})
 
 	c.Assert(err, IsNil)
-	lastDate, err = s.Store.GetPoolLastEnabledDate(common.BNBAsset)
+	lastDate, err = s.Store.GetPoolLastEnabledDate(context.Background(), common.BNBAsset)
 	c.Assert(err, IsNil)
 	c.Assert(lastDate.Unix(), Equals, now.Add(-20*24*time.Hour).Unix())
 
@@ -2117,7 +2118,7 @@ func (s *TimeScaleSuite) TestGetPoolLastEnabledDate(c *C) {
 	})
 
 	c.Assert(err, IsNil)
-	lastDate, err = s.Store.GetPoolLastEnabledDate(common.BNBAsset)
+	lastDate, err = s.Store.GetPoolLastEnabledDate(context.Background(), common.BNBAsset)
 	c.Assert(err, IsNil)
 	c.Assert(lastDate.Unix(), Equals, now.Add(-20*24*time.Hour).Unix())
 }
@@ -2131,7 +2132,7 @@ func (s *TimeScaleSuite) TestGetPoolEarned(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	earned, err := s.Store.GetPoolEarned(common.BNBAsset, time.Now().Add(-30*24*time.Hour))
+	earned, err := s.Store.GetPoolEarned(context.Background(), common.BNBAsset, time.Now().Add(-30*24*time.Hour))
 	c.Assert(err, IsNil)
 	c.Assert(earned, Equals, int64(7463556))
 
@@ -2141,7 +2142,7 @@ func (s *TimeScaleSuite) TestGetPoolEarned(c *C) {
 	err = s.Store.CreateSwapRecord(&swap)
 	c.Assert(err, IsNil)
 
-	earned, err = s.Store.GetPoolEarned(common.BNBAsset, time.Now().Add(-30*24*time.Hour))
+	earned, err = s.Store.GetPoolEarned(context.Background(), common.BNBAsset, time.Now().Add(-30*24*time.Hour))
 	c.Assert(err, IsNil)
 	c.Assert(earned, Equals, int64(7471019))
 
@@ -2150,7 +2151,7 @@ func (s *TimeScaleSuite) TestGetPoolEarned(c *C) {
 	err = s.Store.CreateRewardRecord(&reward)
 	c.Assert(err, IsNil)
 
-	earned, err = s.Store.GetPoolEarned(common.BNBAsset, time.Now().Add(-30*24*time.Hour))
+	earned, err = s.Store.GetPoolEarned(context.Background(), common.BNBAsset, time.Now().Add(-30*24*time.Hour))
 	c.Assert(err, IsNil)
 	c.Assert(earned, Equals, int64(7472019))
 
@@ -2160,11 +2161,11 @@ func (s *TimeScaleSuite) TestGetPoolEarned(c *C) {
 	err = s.Store.CreateGasRecord(&gas)
 	c.Assert(err, IsNil)
 
-	earned, err = s.Store.GetPoolEarned(common.BNBAsset, time.Now().Add(-30*24*time.Hour))
+	earned, err = s.Store.GetPoolEarned(context.Background(), common.BNBAsset, time.Now().Add(-30*24*time.Hour))
 	c.Assert(err, IsNil)
 	c.Assert(earned, Equals, int64(7472028))
 
-	earned, err = s.Store.GetPoolEarned(common.BNBAsset, time.Now().Add(-25*24*time.Hour))
+	earned, err = s.Store.GetPoolEarned(context.Background(), common.BNBAsset, time.Now().Add(-25*24*time.Hour))
 	c.Assert(err, IsNil)
 	c.Assert(earned, Equals, int64(7471028))
 }
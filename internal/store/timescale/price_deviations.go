@@ -0,0 +1,133 @@
+package timescale
+
+import (
+	"context"
+	"time"
+
+	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/models"
+)
+
+// CreatePriceDeviationRecord stores the price deviation of a pool at a block.
+func (s *Client) CreatePriceDeviationRecord(record *models.PriceDeviation) error {
+	q := `INSERT INTO price_deviations (time, height, pool, pool_price, reference_price, deviation)
+			VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := s.db().Exec(q,
+		record.Time,
+		record.Height,
+		record.Pool.String(),
+		record.PoolPrice,
+		record.ReferencePrice,
+		record.Deviation)
+	return err
+}
+
+type priceDeviation struct {
+	Time           time.Time `db:"time"`
+	Height         int64     `db:"height"`
+	PoolPrice      float64   `db:"pool_price"`
+	ReferencePrice float64   `db:"reference_price"`
+	Deviation      float64   `db:"deviation"`
+}
+
+// GetPriceDeviations returns price deviations of the specified pool between from and to.
+func (s *Client) GetPriceDeviations(ctx context.Context, pool common.Asset, from, to time.Time) ([]models.PriceDeviation, error) {
+	q := `SELECT time, height, pool_price, reference_price, deviation
+		FROM price_deviations
+		WHERE pool = $1 AND time BETWEEN $2 AND $3
+		ORDER BY time`
+	rows, err := s.db().QueryxContext(ctx, q, pool.String(), from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []models.PriceDeviation{}
+	for rows.Next() {
+		var deviation priceDeviation
+		err := rows.StructScan(&deviation)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, models.PriceDeviation{
+			Time:           deviation.Time,
+			Height:         deviation.Height,
+			Pool:           pool,
+			PoolPrice:      deviation.PoolPrice,
+			ReferencePrice: deviation.ReferencePrice,
+			Deviation:      deviation.Deviation,
+		})
+	}
+	return result, nil
+}
+
+// SaveDeviationAlert sets the deviation of the alert of a pool. The alert is
+// created since the time of the deviation unless it exists already.
+func (s *Client) SaveDeviationAlert(record *models.PriceDeviation) error {
+	q := `INSERT INTO price_deviation_alerts (pool, since, time, height, pool_price, reference_price, deviation)
+			VALUES ($1, $2, $2, $3, $4, $5, $6)
+		ON CONFLICT (pool) DO UPDATE SET
+			time = EXCLUDED.time,
+			height = EXCLUDED.height,
+			pool_price = EXCLUDED.pool_price,
+			reference_price = EXCLUDED.reference_price,
+			deviation = EXCLUDED.deviation`
+	_, err := s.db().Exec(q,
+		record.Pool.String(),
+		record.Time,
+		record.Height,
+		record.PoolPrice,
+		record.ReferencePrice,
+		record.Deviation)
+	return err
+}
+
+// DeleteDeviationAlert removes the alert of a pool if any.
+func (s *Client) DeleteDeviationAlert(pool common.Asset) error {
+	q := `DELETE FROM price_deviation_alerts WHERE pool = $1`
+	_, err := s.db().Exec(q, pool.String())
+	return err
+}
+
+type deviationAlert struct {
+	priceDeviation
+	Pool  string    `db:"pool"`
+	Since time.Time `db:"since"`
+}
+
+// GetDeviationAlerts returns the alerts of all pools sorted by pool.
+func (s *Client) GetDeviationAlerts(ctx context.Context) ([]models.DeviationAlert, error) {
+	q := `SELECT pool, since, time, height, pool_price, reference_price, deviation
+		FROM price_deviation_alerts
+		ORDER BY pool`
+	rows, err := s.db().QueryxContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []models.DeviationAlert{}
+	for rows.Next() {
+		var alert deviationAlert
+		err := rows.StructScan(&alert)
+		if err != nil {
+			return nil, err
+		}
+		pool, err := common.NewAsset(alert.Pool)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, models.DeviationAlert{
+			PriceDeviation: models.PriceDeviation{
+				Time:           alert.Time,
+				Height:         alert.Height,
+				Pool:           pool,
+				PoolPrice:      alert.PoolPrice,
+				ReferencePrice: alert.ReferencePrice,
+				Deviation:      alert.Deviation,
+			},
+			Since: alert.Since,
+		})
+	}
+	return result, rows.Err()
+}
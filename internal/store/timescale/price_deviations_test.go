@@ -0,0 +1,106 @@
+package timescale
+
+import (
+	"context"
+	"time"
+
+	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/models"
+	"gitlab.com/thorchain/midgard/pkg/helpers"
+	. "gopkg.in/check.v1"
+)
+
+func (s *TimeScaleSuite) TestPriceDeviations(c *C) {
+	today := time.Date(2020, 8, 6, 0, 0, 0, 0, time.UTC)
+	records := []models.PriceDeviation{
+		{
+			Time:           today,
+			Height:         1,
+			Pool:           common.BNBAsset,
+			PoolPrice:      17,
+			ReferencePrice: 17,
+		},
+		{
+			Time:           today,
+			Height:         1,
+			Pool:           common.BTCAsset,
+			PoolPrice:      0.25,
+			ReferencePrice: 0.125,
+			Deviation:      1,
+		},
+		{
+			Time:           today.Add(time.Minute),
+			Height:         10,
+			Pool:           common.BNBAsset,
+			PoolPrice:      18,
+			ReferencePrice: 16,
+			Deviation:      0.125,
+		},
+	}
+	for i := range records {
+		err := s.Store.CreatePriceDeviationRecord(&records[i])
+		c.Assert(err, IsNil)
+	}
+
+	deviations, err := s.Store.GetPriceDeviations(context.Background(), common.BNBAsset, today, today.Add(time.Minute))
+	c.Assert(err, IsNil)
+	c.Assert(deviations, helpers.DeepEquals, []models.PriceDeviation{records[0], records[2]})
+
+	deviations, err = s.Store.GetPriceDeviations(context.Background(), common.BNBAsset, today.Add(time.Second), today.Add(time.Minute))
+	c.Assert(err, IsNil)
+	c.Assert(deviations, helpers.DeepEquals, []models.PriceDeviation{records[2]})
+
+	deviations, err = s.Store.GetPriceDeviations(context.Background(), common.BTCAsset, today.Add(time.Second), today.Add(time.Minute))
+	c.Assert(err, IsNil)
+	c.Assert(deviations, HasLen, 0)
+}
+
+func (s *TimeScaleSuite) TestDeviationAlerts(c *C) {
+	today := time.Date(2020, 8, 6, 0, 0, 0, 0, time.UTC)
+	records := []models.PriceDeviation{
+		{
+			Time:           today,
+			Height:         1,
+			Pool:           common.BTCAsset,
+			PoolPrice:      0.25,
+			ReferencePrice: 0.125,
+			Deviation:      1,
+		},
+		{
+			Time:           today,
+			Height:         1,
+			Pool:           common.BNBAsset,
+			PoolPrice:      18,
+			ReferencePrice: 16,
+			Deviation:      0.125,
+		},
+		{
+			Time:           today.Add(time.Minute),
+			Height:         10,
+			Pool:           common.BTCAsset,
+			PoolPrice:      0.2,
+			ReferencePrice: 0.125,
+			Deviation:      0.6,
+		},
+	}
+	for i := range records {
+		err := s.Store.SaveDeviationAlert(&records[i])
+		c.Assert(err, IsNil)
+	}
+
+	// The alerts are kept since their first deviation.
+	alerts, err := s.Store.GetDeviationAlerts(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(alerts, helpers.DeepEquals, []models.DeviationAlert{
+		{PriceDeviation: records[1], Since: today},
+		{PriceDeviation: records[2], Since: today},
+	})
+
+	err = s.Store.DeleteDeviationAlert(common.BNBAsset)
+	c.Assert(err, IsNil)
+	alerts, err = s.Store.GetDeviationAlerts(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(alerts, helpers.DeepEquals, []models.DeviationAlert{
+		{PriceDeviation: records[2], Since: today},
+	})
+}
@@ -0,0 +1,69 @@
+package timescale
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"gitlab.com/thorchain/midgard/internal/models"
+)
+
+// CreateQuarantinedEvent stores an event which could not be processed.
+func (s *Client) CreateQuarantinedEvent(record *models.QuarantinedEvent) error {
+	attrs, err := json.Marshal(record.Attributes)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal attributes")
+	}
+	q := `INSERT INTO quarantined_events (time, height, type, attributes, reason)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	return s.db().QueryRow(q,
+		record.Time,
+		record.Height,
+		record.Type,
+		attrs,
+		record.Reason).Scan(&record.ID)
+}
+
+// GetQuarantinedEvents returns the quarantined events ordered by height alongside
+// the total number of them.
+func (s *Client) GetQuarantinedEvents(ctx context.Context, offset, limit int64) ([]models.QuarantinedEvent, int64, error) {
+	var count int64
+	err := s.db().QueryRowContext(ctx, `SELECT COUNT(*) FROM quarantined_events`).Scan(&count)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "query failed")
+	}
+
+	q := `SELECT id, time, height, type, attributes, reason
+		FROM quarantined_events
+		ORDER BY height, id
+		OFFSET $1 LIMIT $2`
+	rows, err := s.db().QueryContext(ctx, q, offset, limit)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "query failed")
+	}
+	defer rows.Close()
+
+	events := []models.QuarantinedEvent{}
+	for rows.Next() {
+		var (
+			evt   models.QuarantinedEvent
+			attrs []byte
+		)
+		err := rows.Scan(&evt.ID, &evt.Time, &evt.Height, &evt.Type, &attrs, &evt.Reason)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "scan failed")
+		}
+		err = json.Unmarshal(attrs, &evt.Attributes)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "could not unmarshal attributes")
+		}
+		events = append(events, evt)
+	}
+	return events, count, nil
+}
+
+func (s *Client) deleteQuarantinedEventsInRange(from, to int64) error {
+	q := `DELETE FROM quarantined_events WHERE height BETWEEN $1 AND $2`
+	_, err := s.db().Exec(q, from, to)
+	return err
+}
@@ -0,0 +1,56 @@
+package timescale
+
+import (
+	"context"
+	"time"
+
+	"gitlab.com/thorchain/midgard/internal/models"
+	. "gopkg.in/check.v1"
+)
+
+func (s *TimeScaleSuite) TestQuarantinedEvents(c *C) {
+	now := time.Now().UTC().Truncate(time.Second)
+	first := models.QuarantinedEvent{
+		Time:       now,
+		Height:     2,
+		Type:       "new_event",
+		Attributes: map[string]string{"foo": "bar"},
+		Reason:     "no schema of new_event event at height 2",
+	}
+	err := s.Store.CreateQuarantinedEvent(&first)
+	c.Assert(err, IsNil)
+	c.Assert(first.ID, Not(Equals), int64(0))
+	second := models.QuarantinedEvent{
+		Time:       now.Add(-time.Minute),
+		Height:     1,
+		Type:       "stake",
+		Attributes: map[string]string{"pool": "BNB.BNB"},
+		Reason:     `attribute "stake_units" is missing from stake event (schema v1)`,
+	}
+	err = s.Store.CreateQuarantinedEvent(&second)
+	c.Assert(err, IsNil)
+
+	events, count, err := s.Store.GetQuarantinedEvents(context.Background(), 0, 10)
+	c.Assert(err, IsNil)
+	c.Assert(count, Equals, int64(2))
+	c.Assert(events, HasLen, 2)
+	c.Assert(events[0].ID, Equals, second.ID)
+	c.Assert(events[0].Height, Equals, int64(1))
+	c.Assert(events[0].Time.Equal(second.Time), Equals, true)
+	c.Assert(events[0].Attributes, DeepEquals, second.Attributes)
+	c.Assert(events[1].ID, Equals, first.ID)
+
+	events, count, err = s.Store.GetQuarantinedEvents(context.Background(), 1, 10)
+	c.Assert(err, IsNil)
+	c.Assert(count, Equals, int64(2))
+	c.Assert(events, HasLen, 1)
+	c.Assert(events[0].ID, Equals, first.ID)
+
+	// Rollback of the block should remove the events quarantined at that height.
+	err = s.Store.DeleteBlock(2)
+	c.Assert(err, IsNil)
+	events, count, err = s.Store.GetQuarantinedEvents(context.Background(), 0, 10)
+	c.Assert(err, IsNil)
+	c.Assert(count, Equals, int64(1))
+	c.Assert(events[0].ID, Equals, second.ID)
+}
@@ -0,0 +1,223 @@
+package timescale
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/models"
+)
+
+// CreateRawEvents archives the raw events of a block. Events already archived
+// are skipped, so a block can be archived again after a failure.
+func (s *Client) CreateRawEvents(records []models.RawEvent) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(records))
+	args := make([]interface{}, 0, len(records)*7)
+	for i, record := range records {
+		attrs, err := json.Marshal(record.Attributes)
+		if err != nil {
+			return errors.Wrap(err, "could not marshal attributes")
+		}
+		n := len(args)
+		values[i] = fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5, n+6, n+7)
+		args = append(args,
+			record.Height,
+			record.Time,
+			record.Stage,
+			record.TxIndex,
+			record.EventIndex,
+			record.Type,
+			attrs)
+	}
+	q := fmt.Sprintf(`INSERT INTO raw_events (height, time, stage, tx_index, event_index, type, attributes)
+		VALUES %s
+		ON CONFLICT DO NOTHING`, strings.Join(values, ", "))
+	_, err := s.db().Exec(q, args...)
+	return err
+}
+
+// GetRawEvents returns the raw events archived between the given heights ordered
+// by height and position in the block.
+func (s *Client) GetRawEvents(from, to int64) ([]models.RawEvent, error) {
+	q := `SELECT height, time, stage, tx_index, event_index, type, attributes
+		FROM raw_events
+		WHERE height BETWEEN $1 AND $2
+		ORDER BY height, stage, tx_index, event_index`
+	rows, err := s.db().Query(q, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "query failed")
+	}
+	defer rows.Close()
+
+	var events []models.RawEvent
+	for rows.Next() {
+		var (
+			evt   models.RawEvent
+			attrs []byte
+		)
+		err := rows.Scan(&evt.Height, &evt.Time, &evt.Stage, &evt.TxIndex, &evt.EventIndex, &evt.Type, &attrs)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan failed")
+		}
+		err = json.Unmarshal(attrs, &evt.Attributes)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal attributes")
+		}
+		events = append(events, evt)
+	}
+	return events, nil
+}
+
+// GetRawEventsRange returns the heights of the first and last archived blocks or
+// zeros if nothing is archived yet.
+func (s *Client) GetRawEventsRange() (int64, int64, error) {
+	var first, last sql.NullInt64
+	err := s.db().QueryRow(`SELECT MIN(height), MAX(height) FROM raw_events`).Scan(&first, &last)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "query failed")
+	}
+	return first.Int64, last.Int64, nil
+}
+
+// GetLinkedRange returns the range of heights of the events linked to the events
+// between the given heights by outbound or fee events: the first height of the
+// events completed in the range and the last height of the outbound and fee
+// events completing the txs of the range. Zero heights mean no linked events.
+func (s *Client) GetLinkedRange(from, to int64) (int64, int64, error) {
+	q := `WITH in_txs AS (
+			SELECT txs.tx_hash
+			FROM txs
+			JOIN events ON events.id = txs.event_id
+			WHERE txs.direction = 'in'
+			AND events.height BETWEEN $1 AND $2
+		)
+		SELECT MAX(height)
+		FROM raw_events
+		WHERE height > $2
+		AND ((type = 'outbound' AND attributes->>'in_tx_id' IN (SELECT tx_hash FROM in_txs))
+			OR (type = 'fee' AND attributes->>'tx_id' IN (SELECT tx_hash FROM in_txs)))`
+	var last sql.NullInt64
+	err := s.db().QueryRow(q, from, to).Scan(&last)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "query failed")
+	}
+
+	q = `WITH completed_txs AS (
+			SELECT COALESCE(attributes->>'in_tx_id', attributes->>'tx_id') AS tx_hash
+			FROM raw_events
+			WHERE height BETWEEN $1 AND $2
+			AND type IN ('outbound', 'fee')
+		)
+		SELECT MIN(events.height)
+		FROM events
+		JOIN txs ON txs.event_id = events.id
+		WHERE txs.direction = 'in'
+		AND events.height < $1
+		AND txs.tx_hash IN (SELECT tx_hash FROM completed_txs)`
+	var first sql.NullInt64
+	err = s.db().QueryRow(q, from, to).Scan(&first)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "query failed")
+	}
+	return first.Int64, last.Int64, nil
+}
+
+// DeleteHeightRange deletes the data derived from the events between the given
+// heights and reloads the pool cache.
+func (s *Client) DeleteHeightRange(from, to int64) error {
+	err := s.deleteHeightRange(from, to)
+	if err != nil {
+		return err
+	}
+	err = s.initPoolCache()
+	if err != nil {
+		return errors.Wrap(err, "could not reload pool cache")
+	}
+	return nil
+}
+
+// UpdatePoolDepths recomputes the depths of the pools history from the given
+// height as running sums of the amounts, so the depths after a range of
+// reindexed blocks account for the changes of the range.
+func (s *Client) UpdatePoolDepths(from int64) error {
+	q := `UPDATE pools_history
+		SET asset_depth = depths.asset_depth, rune_depth = depths.rune_depth
+		FROM (
+			SELECT id, time,
+				SUM(asset_amount) OVER w AS asset_depth,
+				SUM(rune_amount) OVER w AS rune_depth
+			FROM pools_history
+			WINDOW w AS (PARTITION BY pool ORDER BY height, id)
+		) depths
+		WHERE pools_history.id = depths.id
+		AND pools_history.time = depths.time
+		AND pools_history.height >= $1
+		AND (pools_history.asset_depth != depths.asset_depth OR pools_history.rune_depth != depths.rune_depth)`
+	_, err := s.db().Exec(q, from)
+	if err != nil {
+		return errors.Wrap(err, "query failed")
+	}
+	return nil
+}
+
+// GetStakeTxs returns the in txs of the stake events between the given heights,
+// which are fetched from the node when the events are processed.
+func (s *Client) GetStakeTxs(from, to int64) ([]common.Tx, error) {
+	q := `SELECT txs.event_id, txs.tx_hash, txs.chain, txs.from_address, txs.to_address, txs.memo,
+			coins.chain, coins.symbol, coins.ticker, coins.amount
+		FROM txs
+		JOIN events ON events.id = txs.event_id
+		LEFT JOIN coins ON coins.event_id = txs.event_id AND coins.tx_hash = txs.tx_hash
+		WHERE txs.direction = 'in'
+		AND events.type = 'stake'
+		AND events.height BETWEEN $1 AND $2
+		ORDER BY txs.event_id`
+	rows, err := s.db().Query(q, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "query failed")
+	}
+	defer rows.Close()
+
+	var txs []common.Tx
+	events := map[common.TxID]int64{}
+	for rows.Next() {
+		var (
+			eventID                     int64
+			tx                          common.Tx
+			chain, symbol, ticker, memo sql.NullString
+			amount                      sql.NullInt64
+		)
+		err := rows.Scan(&eventID, &tx.ID, &tx.Chain, &tx.FromAddress, &tx.ToAddress, &memo, &chain, &symbol, &ticker, &amount)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not scan row")
+		}
+		// A tx is saved with every stake event of it.
+		if id, ok := events[tx.ID]; ok && id != eventID {
+			continue
+		}
+		if _, ok := events[tx.ID]; !ok {
+			events[tx.ID] = eventID
+			tx.Memo = common.Memo(memo.String)
+			txs = append(txs, tx)
+		}
+		if amount.Valid {
+			last := &txs[len(txs)-1]
+			last.Coins = append(last.Coins, common.Coin{
+				Asset: common.Asset{
+					Chain:  common.Chain(chain.String),
+					Symbol: common.Symbol(symbol.String),
+					Ticker: common.Ticker(ticker.String),
+				},
+				Amount: amount.Int64,
+			})
+		}
+	}
+	return txs, rows.Err()
+}
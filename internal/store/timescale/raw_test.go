@@ -0,0 +1,146 @@
+package timescale
+
+import (
+	"context"
+	"time"
+
+	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/models"
+	. "gopkg.in/check.v1"
+)
+
+func (s *TimeScaleSuite) TestRawEvents(c *C) {
+	first, last, err := s.Store.GetRawEventsRange()
+	c.Assert(err, IsNil)
+	c.Assert(first, Equals, int64(0))
+	c.Assert(last, Equals, int64(0))
+
+	now := time.Now().UTC().Truncate(time.Second)
+	records := []models.RawEvent{
+		{Height: 5, Time: now, Stage: models.RawEventStageTx, TxIndex: 1, EventIndex: 0, Type: "outbound", Attributes: map[string]string{"in_tx_id": "A"}},
+		{Height: 5, Time: now, Stage: models.RawEventStageTx, TxIndex: 0, EventIndex: 0, Type: "swap", Attributes: map[string]string{"id": "A"}},
+		{Height: 5, Time: now, Stage: models.RawEventStageEnd, TxIndex: 0, EventIndex: 0, Type: "gas", Attributes: map[string]string{"asset": "BNB.BNB"}},
+		{Height: 7, Time: now.Add(time.Minute), Stage: models.RawEventStageBegin, TxIndex: 0, EventIndex: 0, Type: "rewards", Attributes: map[string]string{"bond_reward": "10"}},
+	}
+	err = s.Store.CreateRawEvents(records)
+	c.Assert(err, IsNil)
+	// Archiving a block again should be a no-op.
+	err = s.Store.CreateRawEvents(records[:2])
+	c.Assert(err, IsNil)
+	err = s.Store.CreateRawEvents(nil)
+	c.Assert(err, IsNil)
+
+	events, err := s.Store.GetRawEvents(5, 5)
+	c.Assert(err, IsNil)
+	c.Assert(events, HasLen, 3)
+	c.Assert(events[0].Stage, Equals, models.RawEventStageEnd)
+	c.Assert(events[1].Type, Equals, "swap")
+	c.Assert(events[1].Attributes, DeepEquals, map[string]string{"id": "A"})
+	c.Assert(events[1].Time.Equal(now), Equals, true)
+	c.Assert(events[2].Type, Equals, "outbound")
+	c.Assert(events[2].TxIndex, Equals, 1)
+
+	events, err = s.Store.GetRawEvents(1, 10)
+	c.Assert(err, IsNil)
+	c.Assert(events, HasLen, 4)
+	c.Assert(events[3].Height, Equals, int64(7))
+
+	first, last, err = s.Store.GetRawEventsRange()
+	c.Assert(err, IsNil)
+	c.Assert(first, Equals, int64(5))
+	c.Assert(last, Equals, int64(7))
+}
+
+func (s *TimeScaleSuite) TestGetLinkedRange(c *C) {
+	err := s.Store.CreateStakeRecord(&stakeBnbEvent0)
+	c.Assert(err, IsNil)
+	err = s.Store.CreateStakeRecord(&stakeTomlEvent1)
+	c.Assert(err, IsNil)
+	err = s.Store.CreateRawEvents([]models.RawEvent{
+		{Height: 10, Time: time.Now(), Stage: models.RawEventStageTx, Type: "outbound", Attributes: map[string]string{"in_tx_id": stakeBnbEvent0.InTx.ID.String()}},
+		{Height: 12, Time: time.Now(), Stage: models.RawEventStageTx, Type: "fee", Attributes: map[string]string{"tx_id": stakeBnbEvent0.InTx.ID.String()}},
+		{Height: 12, Time: time.Now(), Stage: models.RawEventStageTx, TxIndex: 1, Type: "outbound", Attributes: map[string]string{"in_tx_id": "UNKNOWN"}},
+	})
+	c.Assert(err, IsNil)
+
+	first, last, err := s.Store.GetLinkedRange(1, 1)
+	c.Assert(err, IsNil)
+	c.Assert(first, Equals, int64(0))
+	c.Assert(last, Equals, int64(12))
+
+	first, last, err = s.Store.GetLinkedRange(2, 11)
+	c.Assert(err, IsNil)
+	c.Assert(first, Equals, int64(1))
+	c.Assert(last, Equals, int64(0))
+
+	first, last, err = s.Store.GetLinkedRange(1, 12)
+	c.Assert(err, IsNil)
+	c.Assert(first, Equals, int64(0))
+	c.Assert(last, Equals, int64(0))
+}
+
+func (s *TimeScaleSuite) TestDeleteHeightRange(c *C) {
+	err := s.Store.CreateStakeRecord(&stakeBnbEvent0)
+	c.Assert(err, IsNil)
+	err = s.Store.CreateStakeRecord(&stakeTomlEvent1)
+	c.Assert(err, IsNil)
+	err = s.Store.CreateUnStakesRecord(&unstakeTomlEvent1)
+	c.Assert(err, IsNil)
+
+	err = s.Store.DeleteHeightRange(2, 2)
+	c.Assert(err, IsNil)
+	txs, count, err := s.Store.GetTxDetails(context.Background(), "", "", common.EmptyAsset, nil, 0, 10)
+	c.Assert(err, IsNil)
+	c.Assert(count, Equals, int64(2))
+	c.Assert(txs, HasLen, 2)
+	for _, tx := range txs {
+		c.Assert(tx.Height, Not(Equals), uint64(2))
+	}
+	height, err := s.Store.GetLastHeight()
+	c.Assert(err, IsNil)
+	c.Assert(height, Equals, int64(3))
+}
+
+func (s *TimeScaleSuite) TestUpdatePoolDepths(c *C) {
+	err := s.Store.CreateStakeRecord(&stakeBnbEvent0)
+	c.Assert(err, IsNil)
+	stake := stakeBnbEvent0
+	stake.ID = 0
+	stake.Height = 3
+	err = s.Store.CreateStakeRecord(&stake)
+	c.Assert(err, IsNil)
+
+	// Delete the first stake without updating the depths after it.
+	_, err = s.Store.db().Exec(`DELETE FROM pools_history WHERE height = 1`)
+	c.Assert(err, IsNil)
+	err = s.Store.UpdatePoolDepths(1)
+	c.Assert(err, IsNil)
+
+	var assetDepth, runeDepth int64
+	err = s.Store.db().QueryRow(`SELECT asset_depth, rune_depth FROM pools_history WHERE height = 3`).Scan(&assetDepth, &runeDepth)
+	c.Assert(err, IsNil)
+	c.Assert(assetDepth, Equals, int64(10))
+	c.Assert(runeDepth, Equals, int64(100))
+}
+
+func (s *TimeScaleSuite) TestGetStakeTxs(c *C) {
+	err := s.Store.CreateStakeRecord(&stakeBnbEvent0)
+	c.Assert(err, IsNil)
+	err = s.Store.CreateStakeRecord(&stakeTomlEvent1)
+	c.Assert(err, IsNil)
+	err = s.Store.CreateUnStakesRecord(&unstakeTomlEvent1)
+	c.Assert(err, IsNil)
+
+	txs, err := s.Store.GetStakeTxs(1, 1)
+	c.Assert(err, IsNil)
+	c.Assert(txs, HasLen, 1)
+	c.Assert(txs[0].ID, Equals, stakeBnbEvent0.InTx.ID)
+	c.Assert(txs[0].FromAddress, Equals, stakeBnbEvent0.InTx.FromAddress)
+	c.Assert(txs[0].Memo, Equals, stakeBnbEvent0.InTx.Memo)
+	c.Assert(txs[0].Coins, HasLen, 2)
+
+	txs, err = s.Store.GetStakeTxs(1, 3)
+	c.Assert(err, IsNil)
+	c.Assert(txs, HasLen, 2)
+	c.Assert(txs[1].ID, Equals, stakeTomlEvent1.InTx.ID)
+}
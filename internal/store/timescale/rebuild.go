@@ -0,0 +1,145 @@
+package timescale
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"gitlab.com/thorchain/midgard/internal/models"
+	"gitlab.com/thorchain/midgard/internal/store"
+)
+
+const publicSchema = "public"
+
+// swapGracePeriod is the time the old version of derived tables is kept after a
+// swap, so the requests which got its connection before the swap can finish.
+const swapGracePeriod = time.Minute
+
+// liveConnection returns the connection serving the current version of derived
+// tables. Derived tables live in public schema until they're first rebuilt.
+func liveConnection(db *sqlx.DB) (connection, error) {
+	conn := connection{
+		db:     db,
+		schema: publicSchema,
+	}
+	var exists bool
+	err := db.QueryRow(`SELECT to_regclass('public.derived_schema') IS NOT NULL`).Scan(&exists)
+	if err != nil || !exists {
+		return conn, err
+	}
+	err = db.QueryRow(`SELECT name, version FROM public.derived_schema`).Scan(&conn.schema, &conn.version)
+	if err == sql.ErrNoRows {
+		return conn, nil
+	}
+	return conn, err
+}
+
+// CreateShadow creates a new version of the derived tables in a separate schema
+// by running the migrations on it. The migrations create the tables which
+// aren't derived from events in public schema explicitly, so they're shared by
+// all versions. The returned store writes to the new tables
+// while the current ones keep serving until SwapShadow is called.
+func (s *Client) CreateShadow() (store.Store, error) {
+	live := s.conn.Load().(connection)
+	schema := fmt.Sprintf("derived_%d", live.version+1)
+	_, err := s.db().Exec(fmt.Sprintf(`DROP SCHEMA IF EXISTS %s CASCADE`, schema))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not drop schema %s", schema)
+	}
+	_, err = s.db().Exec(fmt.Sprintf(`CREATE SCHEMA %s`, schema))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not create schema %s", schema)
+	}
+	db, err := openDB(s.cfg, schema)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open database connection")
+	}
+	shadow := &Client{
+		cfg:           s.cfg,
+		logger:        s.logger.With().Str("schema", schema).Logger(),
+		migrationsDir: s.migrationsDir,
+		pools:         map[string]*models.PoolBasics{},
+	}
+	shadow.conn.Store(connection{
+		db:      db,
+		schema:  schema,
+		version: live.version + 1,
+	})
+	err = shadow.MigrationsUp()
+	if err != nil {
+		db.Close()
+		return nil, errors.Wrapf(err, "could not run migrations on schema %s", schema)
+	}
+	return shadow, nil
+}
+
+// SwapShadow makes the derived tables of the shadow store serve instead of the
+// current ones, which are dropped after a grace period.
+func (s *Client) SwapShadow(shadowStore store.Store) error {
+	shadow, ok := shadowStore.(*Client)
+	if !ok {
+		return errors.Errorf("invalid shadow store %T", shadowStore)
+	}
+	old := s.conn.Load().(connection)
+	next := shadow.conn.Load().(connection)
+	q := `UPDATE public.derived_schema SET name = $1, version = $2, updated_at = NOW()`
+	_, err := next.db.Exec(q, next.schema, next.version)
+	if err != nil {
+		return errors.Wrap(err, "could not update derived schema")
+	}
+	s.conn.Store(next)
+	err = s.initPoolCache()
+	if err != nil {
+		return errors.Wrap(err, "could not reload pool cache")
+	}
+	s.logger.Info().Str("schema", next.schema).Msg("derived tables swapped")
+
+	time.AfterFunc(swapGracePeriod, func() {
+		s.dropOldVersion(old, next.schema)
+	})
+	return nil
+}
+
+// dropOldVersion closes the connection of the old version of derived tables and
+// drops them.
+func (s *Client) dropOldVersion(old connection, next string) {
+	// Close waits for the queries still running on the old tables.
+	err := old.db.Close()
+	if err != nil {
+		s.logger.Error().Err(err).Msg("failed to close old connection")
+	}
+	err = s.dropDerivedTables(old.schema, next)
+	if err != nil {
+		s.logger.Error().Err(err).Str("schema", old.schema).Msg("failed to drop old derived tables")
+		return
+	}
+	s.logger.Info().Str("schema", old.schema).Msg("old derived tables dropped")
+}
+
+// dropDerivedTables drops the old version of derived tables. Public schema is kept
+// since it holds the shared tables too.
+func (s *Client) dropDerivedTables(schema, next string) error {
+	if schema != publicSchema {
+		_, err := s.db().Exec(fmt.Sprintf(`DROP SCHEMA %s CASCADE`, schema))
+		return err
+	}
+	var tables []string
+	q := `SELECT table_name FROM information_schema.tables WHERE table_schema = $1 AND table_type = 'BASE TABLE'`
+	err := s.db().Select(&tables, q, next)
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		if table == "gorp_migrations" {
+			continue
+		}
+		_, err = s.db().Exec(fmt.Sprintf(`DROP TABLE IF EXISTS %s.%s CASCADE`, publicSchema, table))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
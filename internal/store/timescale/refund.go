@@ -1,6 +1,8 @@
 package timescale
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 	"gitlab.com/thorchain/midgard/internal/common"
 	"gitlab.com/thorchain/midgard/internal/models"
@@ -16,7 +18,7 @@ func (s *Client) CreateRefundRecord(record *models.EventRefund) error {
 	if pool.IsEmpty() {
 		return nil
 	}
-	runeDepth, err := s.GetRuneDepth(pool)
+	runeDepth, err := s.GetRuneDepth(context.Background(), pool)
 	if err != nil {
 		return errors.Wrap(err, "Failed to get rune depth")
 	}
@@ -1,46 +1,48 @@
 package timescale
 
 import (
+	"context"
+
 	"gitlab.com/thorchain/midgard/internal/common"
 	. "gopkg.in/check.v1"
 )
 
 func (s *TimeScaleSuite) TestRefund(c *C) {
 	assetBolt, _ := common.NewAsset("BOLT-014")
-	assetDepth, err := s.Store.GetAssetDepth(assetBolt)
+	assetDepth, err := s.Store.GetAssetDepth(context.Background(), assetBolt)
 	c.Assert(err, IsNil)
 	c.Assert(assetDepth, Equals, uint64(0))
-	runeDepth, err := s.Store.GetRuneDepth(assetBolt)
+	runeDepth, err := s.Store.GetRuneDepth(context.Background(), assetBolt)
 	c.Assert(err, IsNil)
 	c.Assert(runeDepth, Equals, uint64(0))
 
 	// Successful refund with one outTx
 	err = s.Store.CreateRefundRecord(&refundBOLTEvent0)
 	c.Assert(err, IsNil)
-	assetDepth, err = s.Store.GetAssetDepth(assetBolt)
+	assetDepth, err = s.Store.GetAssetDepth(context.Background(), assetBolt)
 	c.Assert(err, IsNil)
 	c.Assert(assetDepth, Equals, uint64(10))
-	runeDepth, err = s.Store.GetRuneDepth(assetBolt)
+	runeDepth, err = s.Store.GetRuneDepth(context.Background(), assetBolt)
 	c.Assert(err, IsNil)
 	c.Assert(runeDepth, Equals, uint64(0))
 
 	// Successful refund with two outTx
 	err = s.Store.CreateRefundRecord(&refundBOLTEvent1)
 	c.Assert(err, IsNil)
-	assetDepth, err = s.Store.GetAssetDepth(assetBolt)
+	assetDepth, err = s.Store.GetAssetDepth(context.Background(), assetBolt)
 	c.Assert(err, IsNil)
 	c.Assert(assetDepth, Equals, uint64(13))
-	runeDepth, err = s.Store.GetRuneDepth(assetBolt)
+	runeDepth, err = s.Store.GetRuneDepth(context.Background(), assetBolt)
 	c.Assert(err, IsNil)
 	c.Assert(runeDepth, Equals, uint64(0))
 
 	// Failed refund
 	err = s.Store.CreateRefundRecord(&refundBOLTEvent2)
 	c.Assert(err, IsNil)
-	assetDepth, err = s.Store.GetAssetDepth(assetBolt)
+	assetDepth, err = s.Store.GetAssetDepth(context.Background(), assetBolt)
 	c.Assert(err, IsNil)
 	c.Assert(assetDepth, Equals, uint64(23))
-	runeDepth, err = s.Store.GetRuneDepth(assetBolt)
+	runeDepth, err = s.Store.GetRuneDepth(context.Background(), assetBolt)
 	c.Assert(err, IsNil)
 	c.Assert(runeDepth, Equals, uint64(0))
 }
@@ -50,10 +52,10 @@ func (s *TimeScaleSuite) TestRefundedEvent(c *C) {
 	evt.OutTxs = common.Txs{}
 	err := s.Store.CreateSwapRecord(&evt)
 	c.Assert(err, IsNil)
-	assetDepth, err := s.Store.GetAssetDepth(common.BNBAsset)
+	assetDepth, err := s.Store.GetAssetDepth(context.Background(), common.BNBAsset)
 	c.Assert(err, IsNil)
 	c.Assert(assetDepth, Equals, uint64(10000000))
-	runeDepth, err := s.Store.GetRuneDepth(common.BNBAsset)
+	runeDepth, err := s.Store.GetRuneDepth(context.Background(), common.BNBAsset)
 	c.Assert(err, IsNil)
 	c.Assert(runeDepth, Equals, uint64(0))
 
@@ -63,10 +65,10 @@ func (s *TimeScaleSuite) TestRefundedEvent(c *C) {
 	evt.InTx = common.Tx{}
 	err = s.Store.CreateRefundedEvent(&evt.Event, common.BNBAsset)
 	c.Assert(err, IsNil)
-	assetDepth, err = s.Store.GetAssetDepth(common.BNBAsset)
+	assetDepth, err = s.Store.GetAssetDepth(context.Background(), common.BNBAsset)
 	c.Assert(err, IsNil)
 	c.Assert(assetDepth, Equals, uint64(0))
-	runeDepth, err = s.Store.GetRuneDepth(common.BNBAsset)
+	runeDepth, err = s.Store.GetRuneDepth(context.Background(), common.BNBAsset)
 	c.Assert(err, IsNil)
 	c.Assert(runeDepth, Equals, uint64(0))
 
@@ -74,10 +76,10 @@ func (s *TimeScaleSuite) TestRefundedEvent(c *C) {
 	evt.OutTxs = common.Txs{}
 	err = s.Store.CreateSwapRecord(&evt)
 	c.Assert(err, IsNil)
-	assetDepth, err = s.Store.GetAssetDepth(common.BNBAsset)
+	assetDepth, err = s.Store.GetAssetDepth(context.Background(), common.BNBAsset)
 	c.Assert(err, IsNil)
 	c.Assert(assetDepth, Equals, uint64(0))
-	runeDepth, err = s.Store.GetRuneDepth(common.BNBAsset)
+	runeDepth, err = s.Store.GetRuneDepth(context.Background(), common.BNBAsset)
 	c.Assert(err, IsNil)
 	c.Assert(runeDepth, Equals, uint64(200000000))
 
@@ -87,10 +89,10 @@ func (s *TimeScaleSuite) TestRefundedEvent(c *C) {
 	evt.InTx = common.Tx{}
 	err = s.Store.CreateRefundedEvent(&evt.Event, common.BNBAsset)
 	c.Assert(err, IsNil)
-	assetDepth, err = s.Store.GetAssetDepth(common.BNBAsset)
+	assetDepth, err = s.Store.GetAssetDepth(context.Background(), common.BNBAsset)
 	c.Assert(err, IsNil)
 	c.Assert(assetDepth, Equals, uint64(0))
-	runeDepth, err = s.Store.GetRuneDepth(common.BNBAsset)
+	runeDepth, err = s.Store.GetRuneDepth(context.Background(), common.BNBAsset)
 	c.Assert(err, IsNil)
 	c.Assert(runeDepth, Equals, uint64(0))
 }
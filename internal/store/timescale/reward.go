@@ -1,6 +1,12 @@
 package timescale
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
 	"github.com/pkg/errors"
 
 	"gitlab.com/thorchain/midgard/internal/models"
@@ -12,6 +18,7 @@ func (s *Client) CreateRewardRecord(record *models.EventReward) error {
 		return errors.Wrap(err, "Failed to create event record")
 	}
 
+	var poolRewards int64
 	for _, reward := range record.PoolRewards {
 		change := &models.PoolChange{
 			Time:       record.Time,
@@ -25,6 +32,132 @@ func (s *Client) CreateRewardRecord(record *models.EventReward) error {
 		if err != nil {
 			return errors.Wrap(err, "could not update pool history")
 		}
+		poolRewards += reward.Amount
+	}
+
+	q := `INSERT INTO block_rewards (time, event_id, height, bond_reward, pool_rewards)
+			VALUES ($1, $2, $3, $4, $5)`
+	_, err = s.db().Exec(q, record.Time, record.ID, record.Height, record.BondReward, poolRewards)
+	if err != nil {
+		return errors.Wrap(err, "could not create block rewards record")
 	}
 	return nil
 }
+
+// CreateReserveRecord stores the reserve balance at a block.
+func (s *Client) CreateReserveRecord(record *models.Reserve) error {
+	q := `INSERT INTO reserve_history (time, height, total_reserve)
+			VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING`
+	_, err := s.db().Exec(q, record.Time, record.Height, record.TotalReserve)
+	return err
+}
+
+type rewardsChanges struct {
+	Time        time.Time     `db:"time"`
+	BondReward  sql.NullInt64 `db:"bond_reward"`
+	PoolRewards sql.NullInt64 `db:"pool_rewards"`
+	Blocks      sql.NullInt64 `db:"blocks"`
+}
+
+// GetRewardsChanges returns the emitted rewards in each time bucket between from and to.
+func (s *Client) GetRewardsChanges(ctx context.Context, inv models.Interval, from, to time.Time) ([]models.RewardsChanges, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	colsTemplate := "%s"
+	timeBucket := getTimeBucket(inv)
+	if inv > models.DailyInterval {
+		colsTemplate = "SUM(%s)"
+		sb.GroupBy(timeBucket)
+	}
+	sb.Select(
+		sb.As(timeBucket, "time"),
+		sb.As(fmt.Sprintf(colsTemplate, "bond_reward"), "bond_reward"),
+		sb.As(fmt.Sprintf(colsTemplate, "pool_rewards"), "pool_rewards"),
+		sb.As(fmt.Sprintf(colsTemplate, "blocks"), "blocks"),
+	)
+	sb.From("block_rewards" + getIntervalTableSuffix(inv))
+	sb.Where(sb.Between(timeBucket, from, to))
+	sb.OrderBy("time")
+
+	q, args := sb.Build()
+	rows, err := s.db().QueryxContext(ctx, q, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "query failed")
+	}
+	defer rows.Close()
+
+	result := []models.RewardsChanges{}
+	for rows.Next() {
+		var changes rewardsChanges
+		err := rows.StructScan(&changes)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan failed")
+		}
+		result = append(result, models.RewardsChanges{
+			Time:        changes.Time,
+			BondReward:  changes.BondReward.Int64,
+			PoolRewards: changes.PoolRewards.Int64,
+			TotalReward: changes.BondReward.Int64 + changes.PoolRewards.Int64,
+			Blocks:      changes.Blocks.Int64,
+		})
+	}
+	return result, nil
+}
+
+type reserveChanges struct {
+	Time         time.Time     `db:"time"`
+	Height       sql.NullInt64 `db:"height"`
+	TotalReserve sql.NullInt64 `db:"total_reserve"`
+}
+
+// GetReserveChanges returns the reserve balance at the end of each time bucket between from and to.
+func (s *Client) GetReserveChanges(ctx context.Context, inv models.Interval, from, to time.Time) ([]models.Reserve, error) {
+	timeBucket := getRawTimeBucket(inv)
+	q := fmt.Sprintf(`SELECT %[1]s AS time,
+			last(height, time) AS height,
+			last(total_reserve, time) AS total_reserve
+		FROM reserve_history
+		WHERE %[1]s BETWEEN $1 AND $2
+		GROUP BY %[1]s
+		ORDER BY time`, timeBucket)
+	rows, err := s.db().QueryxContext(ctx, q, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "query failed")
+	}
+	defer rows.Close()
+
+	result := []models.Reserve{}
+	for rows.Next() {
+		var changes reserveChanges
+		err := rows.StructScan(&changes)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan failed")
+		}
+		result = append(result, models.Reserve{
+			Time:         changes.Time,
+			Height:       changes.Height.Int64,
+			TotalReserve: changes.TotalReserve.Int64,
+		})
+	}
+	return result, nil
+}
+
+// getRawTimeBucket returns the time bucket expression of tables which don't
+// have continuous aggregates.
+func getRawTimeBucket(inv models.Interval) string {
+	switch inv {
+	case models.FiveMinInterval:
+		return "time_bucket('5 min', time)"
+	case models.HourlyInterval:
+		return "time_bucket('1 hour', time)"
+	case models.DailyInterval:
+		return "time_bucket('1 day', time)"
+	}
+	return getTimeBucket(inv)
+}
+
+func (s *Client) deleteBlockRewardsInRange(from, to int64) error {
+	q := `DELETE FROM block_rewards WHERE height BETWEEN $1 AND $2`
+	_, err := s.db().Exec(q, from, to)
+	return err
+}
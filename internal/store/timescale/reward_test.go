@@ -1,7 +1,12 @@
 package timescale
 
 import (
+	"context"
+	"time"
+
 	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/models"
+	"gitlab.com/thorchain/midgard/pkg/helpers"
 	. "gopkg.in/check.v1"
 )
 
@@ -9,16 +14,16 @@ func (s *TimeScaleSuite) TestRuneRewarded(c *C) {
 	asset, _ := common.NewAsset("BNB.BNB")
 
 	// Zero pool depth
-	depth, err := s.Store.poolDepth(asset)
+	depth, err := s.Store.poolDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(depth, Equals, uint64(0))
-	depth, err = s.Store.poolDepth(asset)
+	depth, err = s.Store.poolDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(depth, Equals, uint64(0))
-	depth, err = s.Store.GetAssetDepth(asset)
+	depth, err = s.Store.GetAssetDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(depth, Equals, uint64(0))
-	depth, err = s.Store.GetAssetDepth(asset)
+	depth, err = s.Store.GetAssetDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(depth, Equals, uint64(0))
 
@@ -26,10 +31,10 @@ func (s *TimeScaleSuite) TestRuneRewarded(c *C) {
 	err = s.Store.CreateRewardRecord(&rewardBNBEvent0)
 	c.Assert(err, IsNil)
 
-	depth, err = s.Store.poolDepth(asset)
+	depth, err = s.Store.poolDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(depth, Equals, uint64(2000))
-	depth, err = s.Store.GetAssetDepth(asset)
+	depth, err = s.Store.GetAssetDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(depth, Equals, uint64(0))
 
@@ -37,10 +42,10 @@ func (s *TimeScaleSuite) TestRuneRewarded(c *C) {
 	err = s.Store.CreateRewardRecord(&rewardBNBEvent1)
 	c.Assert(err, IsNil)
 
-	depth, err = s.Store.poolDepth(asset)
+	depth, err = s.Store.poolDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(depth, Equals, uint64(6000))
-	depth, err = s.Store.GetAssetDepth(asset)
+	depth, err = s.Store.GetAssetDepth(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(depth, Equals, uint64(0))
 }
@@ -50,3 +55,112 @@ func (s *TimeScaleSuite) TestEmptyRewarded(c *C) {
 	err := s.Store.CreateRewardRecord(&rewardEmptyEvent0)
 	c.Assert(err, IsNil)
 }
+
+func (s *TimeScaleSuite) TestGetRewardsChanges(c *C) {
+	today := time.Date(2020, 8, 10, 0, 0, 0, 0, time.UTC)
+	tomorrow := today.Add(time.Hour * 24)
+
+	rewards := []models.EventReward{
+		{
+			Event: models.Event{
+				Time:   today,
+				ID:     1,
+				Height: 1,
+				Type:   "rewards",
+			},
+			BondReward: 100,
+			PoolRewards: []models.PoolAmount{
+				{Pool: common.BNBAsset, Amount: 30},
+				{Pool: common.BTCAsset, Amount: -10},
+			},
+		},
+		{
+			Event: models.Event{
+				Time:   today.Add(time.Minute * 5),
+				ID:     2,
+				Height: 2,
+				Type:   "rewards",
+			},
+			BondReward: 50,
+		},
+		{
+			Event: models.Event{
+				Time:   tomorrow,
+				ID:     3,
+				Height: 3,
+				Type:   "rewards",
+			},
+			BondReward: 40,
+			PoolRewards: []models.PoolAmount{
+				{Pool: common.BNBAsset, Amount: 10},
+			},
+		},
+	}
+	for i := range rewards {
+		err := s.Store.CreateRewardRecord(&rewards[i])
+		c.Assert(err, IsNil)
+	}
+
+	changes, err := s.Store.GetRewardsChanges(context.Background(), models.DailyInterval, today, tomorrow)
+	c.Assert(err, IsNil)
+	c.Assert(changes, helpers.DeepEquals, []models.RewardsChanges{
+		{
+			Time:        today,
+			BondReward:  150,
+			PoolRewards: 20,
+			TotalReward: 170,
+			Blocks:      2,
+		},
+		{
+			Time:        tomorrow,
+			BondReward:  40,
+			PoolRewards: 10,
+			TotalReward: 50,
+			Blocks:      1,
+		},
+	})
+
+	changes, err = s.Store.GetRewardsChanges(context.Background(), models.MonthlyInterval, today, tomorrow)
+	c.Assert(err, IsNil)
+	c.Assert(changes, helpers.DeepEquals, []models.RewardsChanges{
+		{
+			Time:        time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC),
+			BondReward:  190,
+			PoolRewards: 30,
+			TotalReward: 220,
+			Blocks:      3,
+		},
+	})
+
+	err = s.Store.DeleteBlock(3)
+	c.Assert(err, IsNil)
+	changes, err = s.Store.GetRewardsChanges(context.Background(), models.DailyInterval, today, tomorrow)
+	c.Assert(err, IsNil)
+	c.Assert(changes, HasLen, 1)
+}
+
+func (s *TimeScaleSuite) TestGetReserveChanges(c *C) {
+	today := time.Date(2020, 8, 10, 0, 0, 0, 0, time.UTC)
+	tomorrow := today.Add(time.Hour * 24)
+
+	records := []models.Reserve{
+		{Time: today, Height: 1, TotalReserve: 1000},
+		{Time: today.Add(time.Minute * 5), Height: 2, TotalReserve: 900},
+		{Time: tomorrow, Height: 3, TotalReserve: 800},
+	}
+	for i := range records {
+		err := s.Store.CreateReserveRecord(&records[i])
+		c.Assert(err, IsNil)
+	}
+
+	reserves, err := s.Store.GetReserveChanges(context.Background(), models.DailyInterval, today, tomorrow)
+	c.Assert(err, IsNil)
+	c.Assert(reserves, helpers.DeepEquals, []models.Reserve{
+		{Time: today, Height: 2, TotalReserve: 900},
+		{Time: tomorrow, Height: 3, TotalReserve: 800},
+	})
+
+	reserves, err = s.Store.GetReserveChanges(context.Background(), models.FiveMinInterval, today, today.Add(time.Minute*5))
+	c.Assert(err, IsNil)
+	c.Assert(reserves, helpers.DeepEquals, records[:2])
+}
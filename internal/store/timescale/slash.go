@@ -1,6 +1,11 @@
 package timescale
 
 import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
 	"github.com/pkg/errors"
 	"gitlab.com/thorchain/midgard/internal/common"
 	"gitlab.com/thorchain/midgard/internal/models"
@@ -38,3 +43,58 @@ func (s *Client) CreateSlashRecord(record *models.EventSlash) error {
 	}
 	return nil
 }
+
+type slashChanges struct {
+	Time        time.Time     `db:"time"`
+	Pool        string        `db:"pool"`
+	AssetAmount sql.NullInt64 `db:"asset_amount"`
+	RuneAmount  sql.NullInt64 `db:"rune_amount"`
+	Count       sql.NullInt64 `db:"count"`
+}
+
+// GetSlashChanges returns the slashed amounts of each pool per time bucket between
+// from and to. Changes of all pools are returned if pool is empty.
+func (s *Client) GetSlashChanges(ctx context.Context, pool common.Asset, inv models.Interval, from, to time.Time) ([]models.SlashChanges, error) {
+	timeBucket := getRawTimeBucket(inv)
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(
+		sb.As(timeBucket, "time"),
+		"pool",
+		sb.As("SUM(asset_amount)", "asset_amount"),
+		sb.As("SUM(rune_amount)", "rune_amount"),
+		sb.As("COUNT(DISTINCT(event_id))", "count"),
+	)
+	sb.From("pools_history")
+	sb.Where(sb.Equal("event_type", "slash"))
+	if !pool.IsEmpty() {
+		sb.Where(sb.Equal("pool", pool.String()))
+	}
+	sb.Where(sb.Between(timeBucket, from, to))
+	sb.GroupBy(timeBucket, "pool")
+	sb.OrderBy("time", "pool")
+
+	q, args := sb.Build()
+	rows, err := s.db().QueryxContext(ctx, q, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "query failed")
+	}
+	defer rows.Close()
+
+	result := []models.SlashChanges{}
+	for rows.Next() {
+		var changes slashChanges
+		err := rows.StructScan(&changes)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan failed")
+		}
+		asset, _ := common.NewAsset(changes.Pool)
+		result = append(result, models.SlashChanges{
+			Time:        changes.Time,
+			Pool:        asset,
+			AssetAmount: changes.AssetAmount.Int64,
+			RuneAmount:  changes.RuneAmount.Int64,
+			Count:       changes.Count.Int64,
+		})
+	}
+	return result, nil
+}
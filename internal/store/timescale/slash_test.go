@@ -1,7 +1,12 @@
 package timescale
 
 import (
+	"context"
+	"time"
+
 	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/models"
+	"gitlab.com/thorchain/midgard/pkg/helpers"
 	. "gopkg.in/check.v1"
 )
 
@@ -25,3 +30,108 @@ func (s *TimeScaleSuite) TestSlash(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(assetDepth, Equals, int64(-10))
 }
+
+func (s *TimeScaleSuite) TestGetSlashChanges(c *C) {
+	today := time.Date(2020, 8, 12, 0, 0, 0, 0, time.UTC)
+	tomorrow := today.Add(time.Hour * 24)
+
+	slashes := []models.EventSlash{
+		{
+			Event: models.Event{
+				Time:   today,
+				ID:     1,
+				Status: "Success",
+				Height: 1,
+				Type:   "slash",
+			},
+			Pool: common.BNBAsset,
+			SlashAmount: []models.PoolAmount{
+				{Pool: common.RuneAsset(), Amount: 100},
+				{Pool: common.BNBAsset, Amount: -10},
+			},
+		},
+		{
+			Event: models.Event{
+				Time:   today.Add(time.Hour),
+				ID:     2,
+				Status: "Success",
+				Height: 2,
+				Type:   "slash",
+			},
+			Pool: common.BNBAsset,
+			SlashAmount: []models.PoolAmount{
+				{Pool: common.BNBAsset, Amount: -5},
+			},
+		},
+		{
+			Event: models.Event{
+				Time:   tomorrow,
+				ID:     3,
+				Status: "Success",
+				Height: 3,
+				Type:   "slash",
+			},
+			Pool: common.BTCAsset,
+			SlashAmount: []models.PoolAmount{
+				{Pool: common.RuneAsset(), Amount: 20},
+				{Pool: common.BTCAsset, Amount: -1},
+			},
+		},
+	}
+	for i := range slashes {
+		err := s.Store.CreateSlashRecord(&slashes[i])
+		c.Assert(err, IsNil)
+	}
+
+	changes, err := s.Store.GetSlashChanges(context.Background(), common.EmptyAsset, models.DailyInterval, today, tomorrow)
+	c.Assert(err, IsNil)
+	c.Assert(changes, helpers.DeepEquals, []models.SlashChanges{
+		{
+			Time:        today,
+			Pool:        common.BNBAsset,
+			AssetAmount: -15,
+			RuneAmount:  100,
+			Count:       2,
+		},
+		{
+			Time:        tomorrow,
+			Pool:        common.BTCAsset,
+			AssetAmount: -1,
+			RuneAmount:  20,
+			Count:       1,
+		},
+	})
+
+	changes, err = s.Store.GetSlashChanges(context.Background(), common.BNBAsset, models.HourlyInterval, today, tomorrow)
+	c.Assert(err, IsNil)
+	c.Assert(changes, helpers.DeepEquals, []models.SlashChanges{
+		{
+			Time:        today,
+			Pool:        common.BNBAsset,
+			AssetAmount: -10,
+			RuneAmount:  100,
+			Count:       1,
+		},
+		{
+			Time:        today.Add(time.Hour),
+			Pool:        common.BNBAsset,
+			AssetAmount: -5,
+			Count:       1,
+		},
+	})
+
+	basics, err := s.Store.GetPoolBasics(context.Background(), common.BNBAsset)
+	c.Assert(err, IsNil)
+	c.Assert(basics.AssetSlashed, Equals, int64(-15))
+	c.Assert(basics.RuneSlashed, Equals, int64(100))
+
+	txs, count, err := s.Store.GetTxDetails(context.Background(), "", "", common.EmptyAsset, []string{"slash"}, 0, 10)
+	c.Assert(err, IsNil)
+	c.Assert(count, Equals, int64(3))
+	c.Assert(txs[2].Type, Equals, "slash")
+	c.Assert(txs[2].Pool, Equals, common.BNBAsset)
+	c.Assert(txs[2].Events.Slash, DeepEquals, common.Coins{
+		{Asset: common.RuneAsset(), Amount: 100},
+		{Asset: common.BNBAsset, Amount: -10},
+	})
+}
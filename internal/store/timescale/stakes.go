@@ -1,7 +1,9 @@
 package timescale
 
 import (
+	"context"
 	"database/sql"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -43,14 +45,14 @@ func (s *Client) CreateStakeRecord(record *models.EventStake) error {
 }
 
 // GetStakerAddresses returns an array of all the staker addresses seen by the api
-func (s *Client) GetStakerAddresses() ([]common.Address, error) {
+func (s *Client) GetStakerAddresses(ctx context.Context) ([]common.Address, error) {
 	query := `
 		SELECT DISTINCT from_address
 		FROM txs
 		JOIN pools_history ON txs.event_id = pools_history.event_id
 		WHERE pools_history.units > 0`
 
-	rows, err := s.db.Queryx(query)
+	rows, err := s.db().QueryxContext(ctx, query)
 	if err != nil {
 		return nil, errors.Wrap(err, "getStakerAddresses failed")
 	}
@@ -71,8 +73,96 @@ func (s *Client) GetStakerAddresses() ([]common.Address, error) {
 	return addresses, nil
 }
 
-func (s *Client) GetStakerAddressDetails(address common.Address) (models.StakerAddressDetails, error) {
-	pools, err := s.getPools(address)
+// GetPoolStakers returns the units of all stakers of the given pool at the given time
+// sorted by units in descending order.
+func (s *Client) GetPoolStakers(ctx context.Context, asset common.Asset, at time.Time) ([]models.PoolStaker, error) {
+	query := `
+		SELECT txs.from_address, SUM(pools_history.units) AS units
+		FROM pools_history
+		JOIN (
+			SELECT DISTINCT event_id, from_address
+			FROM txs
+			WHERE direction = 'in'
+		) txs ON pools_history.event_id = txs.event_id
+		WHERE pools_history.pool = $1
+		AND pools_history.event_type IN ('stake', 'unstake')
+		AND pools_history.time <= $2
+		GROUP BY txs.from_address
+		HAVING SUM(pools_history.units) > 0
+		ORDER BY units DESC, txs.from_address`
+
+	rows, err := s.db().QueryxContext(ctx, query, asset.String(), at)
+	if err != nil {
+		return nil, errors.Wrap(err, "getPoolStakers failed")
+	}
+	defer rows.Close()
+
+	stakers := []models.PoolStaker{}
+	for rows.Next() {
+		var addrStr string
+		var units int64
+		err = rows.Scan(&addrStr, &units)
+		if err != nil {
+			return nil, errors.Wrap(err, "getPoolStakers failed")
+		}
+		addr, err := common.NewAddress(addrStr)
+		if err != nil {
+			return nil, errors.Wrap(err, "getPoolStakers failed")
+		}
+		stakers = append(stakers, models.PoolStaker{
+			Address: addr,
+			Units:   units,
+		})
+	}
+	return stakers, nil
+}
+
+// GetPoolStakersChanges returns the changes of the units of the stakers of the given
+// pool up to the given time, summed per staker and per point of the history from
+// from every step. The changes are summed at the first point at or after them, and
+// the ones before from at the first point. They're sorted by point.
+func (s *Client) GetPoolStakersChanges(ctx context.Context, asset common.Asset, from, to time.Time, step time.Duration) ([]models.PoolStakerChange, error) {
+	query := `
+		SELECT txs.from_address,
+			GREATEST(CEIL(EXTRACT(EPOCH FROM pools_history.time - $2) / $4), 0)::BIGINT AS point,
+			SUM(pools_history.units) AS units
+		FROM pools_history
+		JOIN (
+			SELECT DISTINCT event_id, from_address
+			FROM txs
+			WHERE direction = 'in'
+		) txs ON pools_history.event_id = txs.event_id
+		WHERE pools_history.pool = $1
+		AND pools_history.event_type IN ('stake', 'unstake')
+		AND pools_history.time <= $3
+		GROUP BY txs.from_address, point
+		ORDER BY point, txs.from_address`
+
+	rows, err := s.db().QueryxContext(ctx, query, asset.String(), from, to, step.Seconds())
+	if err != nil {
+		return nil, errors.Wrap(err, "getPoolStakersChanges failed")
+	}
+	defer rows.Close()
+
+	changes := []models.PoolStakerChange{}
+	for rows.Next() {
+		var addrStr string
+		var change models.PoolStakerChange
+		err = rows.Scan(&addrStr, &change.Point, &change.Units)
+		if err != nil {
+			return nil, errors.Wrap(err, "getPoolStakersChanges failed")
+		}
+		change.Address, err = common.NewAddress(addrStr)
+		if err != nil {
+			return nil, errors.Wrap(err, "getPoolStakersChanges failed")
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+func (s *Client) GetStakerAddressDetails(ctx context.Context, address common.Address) (models.StakerAddressDetails, error) {
+	pools, err := s.getPools(ctx, address)
 	if err != nil {
 		return models.StakerAddressDetails{}, errors.Wrap(err, "getStakerAddressDetails failed")
 	}
@@ -83,9 +173,9 @@ func (s *Client) GetStakerAddressDetails(address common.Address) (models.StakerA
 }
 
 // GetStakersAddressAndAssetDetails:
-func (s *Client) GetStakersAddressAndAssetDetails(address common.Address, asset common.Asset) (models.StakerAddressAndAssetDetails, error) {
+func (s *Client) GetStakersAddressAndAssetDetails(ctx context.Context, address common.Address, asset common.Asset) (models.StakerAddressAndAssetDetails, error) {
 	// confirm asset in addresses pools
-	pools, err := s.getPools(address)
+	pools, err := s.getPools(ctx, address)
 	if err != nil {
 		return models.StakerAddressAndAssetDetails{}, errors.Wrap(err, "getStakersAddressAndAssetDetails failed")
 	}
@@ -100,22 +190,22 @@ func (s *Client) GetStakersAddressAndAssetDetails(address common.Address, asset
 		return models.StakerAddressAndAssetDetails{}, store.ErrPoolNotFound
 	}
 
-	units, err := s.stakeUnits(address, asset)
+	units, err := s.stakeUnits(ctx, address, asset)
 	if err != nil {
 		return models.StakerAddressAndAssetDetails{}, errors.Wrap(err, "getStakersAddressAndAssetDetails failed")
 	}
 
-	stakeWithdrawn, err := s.stakeWithdrawn(address, asset)
+	stakeWithdrawn, err := s.stakeWithdrawn(ctx, address, asset)
 	if err != nil {
 		return models.StakerAddressAndAssetDetails{}, errors.Wrap(err, "getStakersAddressAndAssetDetails failed")
 	}
 
-	dateFirstStaked, err := s.dateFirstStaked(address, asset)
+	dateFirstStaked, err := s.dateFirstStaked(ctx, address, asset)
 	if err != nil {
 		return models.StakerAddressAndAssetDetails{}, errors.Wrap(err, "getStakersAddressAndAssetDetails failed")
 	}
 
-	heightLastStaked, err := s.heightLastStaked(address, asset)
+	heightLastStaked, err := s.heightLastStaked(ctx, address, asset)
 	if err != nil {
 		return models.StakerAddressAndAssetDetails{}, errors.Wrap(err, "getStakersAddressAndAssetDetails failed")
 	}
@@ -135,7 +225,7 @@ func (s *Client) GetStakersAddressAndAssetDetails(address common.Address, asset
 
 // stakeUnits - sums the total of staker units a specific address has for a
 // particular pool
-func (s *Client) stakeUnits(address common.Address, asset common.Asset) (uint64, error) {
+func (s *Client) stakeUnits(ctx context.Context, address common.Address, asset common.Asset) (uint64, error) {
 	query := `
 		SELECT Sum(units) 
 		FROM   pools_history 
@@ -148,7 +238,7 @@ func (s *Client) stakeUnits(address common.Address, asset common.Asset) (uint64,
 			   AND events.status = 'Success' `
 
 	var stakeUnits sql.NullInt64
-	err := s.db.Get(&stakeUnits, query, asset.String(), address)
+	err := s.db().GetContext(ctx, &stakeUnits, query, asset.String(), address)
 	if err != nil {
 		return 0, errors.Wrap(err, "stakeUnits failed")
 	}
@@ -163,7 +253,7 @@ type stakerStakeWithdrawn struct {
 	RuneWithdrawn  sql.NullInt64 `db:"rune_withdrawn"`
 }
 
-func (s *Client) stakeWithdrawn(address common.Address, asset common.Asset) (*stakerStakeWithdrawn, error) {
+func (s *Client) stakeWithdrawn(ctx context.Context, address common.Address, asset common.Asset) (*stakerStakeWithdrawn, error) {
 	query := `
 		SELECT
 		SUM(asset_amount) FILTER (WHERE asset_amount > 0) as asset_staked,
@@ -178,7 +268,7 @@ func (s *Client) stakeWithdrawn(address common.Address, asset common.Asset) (*st
 		AND txs.from_address = $2`
 
 	var result stakerStakeWithdrawn
-	err := s.db.QueryRowx(query, asset.String(), address).StructScan(&result)
+	err := s.db().QueryRowxContext(ctx, query, asset.String(), address).StructScan(&result)
 	if err != nil {
 		return nil, errors.Wrap(err, "stakeWithdrawn failed")
 	}
@@ -187,7 +277,7 @@ func (s *Client) stakeWithdrawn(address common.Address, asset common.Asset) (*st
 }
 
 // runeStakedForAddress - sum of rune staked by a specific address and pool
-func (s *Client) runeStakedForAddress(address common.Address, asset common.Asset) (int64, error) {
+func (s *Client) runeStakedForAddress(ctx context.Context, address common.Address, asset common.Asset) (int64, error) {
 	query := `
 		SELECT SUM(rune_amount)
 		FROM pools_history
@@ -198,7 +288,7 @@ func (s *Client) runeStakedForAddress(address common.Address, asset common.Asset
 		AND txs.from_address = $2`
 
 	var runeStaked sql.NullInt64
-	err := s.db.Get(&runeStaked, query, asset.String(), address)
+	err := s.db().GetContext(ctx, &runeStaked, query, asset.String(), address)
 	if err != nil {
 		return 0, errors.Wrap(err, "runeStakedForAddress failed")
 	}
@@ -207,7 +297,7 @@ func (s *Client) runeStakedForAddress(address common.Address, asset common.Asset
 }
 
 // runeStakedForAddress - sum of asset staked by a specific address and pool
-func (s *Client) assetStakedForAddress(address common.Address, asset common.Asset) (int64, error) {
+func (s *Client) assetStakedForAddress(ctx context.Context, address common.Address, asset common.Asset) (int64, error) {
 	query := `
 		SELECT SUM(asset_amount)
 		FROM pools_history
@@ -218,7 +308,7 @@ func (s *Client) assetStakedForAddress(address common.Address, asset common.Asse
 		AND txs.from_address = $2`
 
 	var assetStaked sql.NullInt64
-	err := s.db.Get(&assetStaked, query, asset.String(), address)
+	err := s.db().GetContext(ctx, &assetStaked, query, asset.String(), address)
 	if err != nil {
 		return 0, errors.Wrap(err, "assetStakedForAddress failed")
 	}
@@ -226,41 +316,41 @@ func (s *Client) assetStakedForAddress(address common.Address, asset common.Asse
 	return assetStaked.Int64, nil
 }
 
-func (s *Client) poolStaked(address common.Address, asset common.Asset) (int64, error) {
-	runeStaked, err := s.runeStakedForAddress(address, asset)
+func (s *Client) poolStaked(ctx context.Context, address common.Address, asset common.Asset) (int64, error) {
+	runeStaked, err := s.runeStakedForAddress(ctx, address, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "poolStaked failed")
 	}
 
-	assetStaked, err := s.assetStakedForAddress(address, asset)
+	assetStaked, err := s.assetStakedForAddress(ctx, address, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "poolStaked failed")
 	}
 
-	assetPrice, err := s.getPriceInRune(asset)
+	assetPrice, err := s.getPriceInRune(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "poolStaked failed")
 	}
 	return int64(float64(runeStaked) + (float64(assetStaked) * assetPrice)), nil
 }
 
-func (s *Client) runeEarned(address common.Address, asset common.Asset) (int64, error) {
+func (s *Client) runeEarned(ctx context.Context, address common.Address, asset common.Asset) (int64, error) {
 	poolUnits, err := s.poolUnits(asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "runeEarned failed")
 	}
 	if poolUnits > 0 {
-		stakeUnits, err := s.stakeUnits(address, asset)
+		stakeUnits, err := s.stakeUnits(ctx, address, asset)
 		if err != nil {
 			return 0, errors.Wrap(err, "runeEarned failed")
 		}
 
-		runeDepth, err := s.GetRuneDepth(asset)
+		runeDepth, err := s.GetRuneDepth(ctx, asset)
 		if err != nil {
 			return 0, errors.Wrap(err, "runeEarned failed")
 		}
 
-		runeStaked, err := s.runeStakedForAddress(address, asset)
+		runeStaked, err := s.runeStakedForAddress(ctx, address, asset)
 		if err != nil {
 			return 0, errors.Wrap(err, "runeEarned failed")
 		}
@@ -271,13 +361,13 @@ func (s *Client) runeEarned(address common.Address, asset common.Asset) (int64,
 	return 0, nil
 }
 
-func (s *Client) assetEarned(address common.Address, asset common.Asset) (int64, error) {
+func (s *Client) assetEarned(ctx context.Context, address common.Address, asset common.Asset) (int64, error) {
 	poolUnits, err := s.poolUnits(asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "assetEarned failed")
 	}
 	if poolUnits > 0 {
-		stakeUnits, err := s.stakeUnits(address, asset)
+		stakeUnits, err := s.stakeUnits(ctx, address, asset)
 		if err != nil {
 			return 0, errors.Wrap(err, "assetEarned failed")
 		}
@@ -287,12 +377,12 @@ func (s *Client) assetEarned(address common.Address, asset common.Asset) (int64,
 			return 0, errors.Wrap(err, "assetEarned failed")
 		}
 
-		assetDepth, err := s.GetAssetDepth(asset)
+		assetDepth, err := s.GetAssetDepth(ctx, asset)
 		if err != nil {
 			return 0, errors.Wrap(err, "assetEarned failed")
 		}
 
-		assetStaked, err := s.assetStakedForAddress(address, asset)
+		assetStaked, err := s.assetStakedForAddress(ctx, address, asset)
 		if err != nil {
 			return 0, errors.Wrap(err, "assetEarned failed")
 		}
@@ -303,36 +393,36 @@ func (s *Client) assetEarned(address common.Address, asset common.Asset) (int64,
 	return 0, nil
 }
 
-func (s *Client) poolEarned(address common.Address, asset common.Asset) (int64, error) {
-	runeEarned, err := s.runeEarned(address, asset)
+func (s *Client) poolEarned(ctx context.Context, address common.Address, asset common.Asset) (int64, error) {
+	runeEarned, err := s.runeEarned(ctx, address, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "poolEarned failed")
 	}
 
-	assetEarned, err := s.assetEarned(address, asset)
+	assetEarned, err := s.assetEarned(ctx, address, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "poolEarned failed")
 	}
 
-	assetPrice, err := s.getPriceInRune(asset)
+	assetPrice, err := s.getPriceInRune(ctx, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "poolEarned failed")
 	}
 	return int64(float64(runeEarned) + (float64(assetEarned) * assetPrice)), nil
 }
 
-func (s *Client) stakersRuneROI(address common.Address, asset common.Asset) (float64, error) {
-	runeStaked, err := s.runeStakedForAddress(address, asset)
+func (s *Client) stakersRuneROI(ctx context.Context, address common.Address, asset common.Asset) (float64, error) {
+	runeStaked, err := s.runeStakedForAddress(ctx, address, asset)
 	if err != nil {
 		return 0, errors.Wrap(err, "stakersRuneROI failed")
 	}
 	if runeStaked > 0 {
-		runeEarned, err := s.runeEarned(address, asset)
+		runeEarned, err := s.runeEarned(ctx, address, asset)
 		if err != nil {
 			return 0, errors.Wrap(err, "stakersRuneROI failed")
 		}
 
-		runeStaked, err := s.runeStakedForAddress(address, asset)
+		runeStaked, err := s.runeStakedForAddress(ctx, address, asset)
 		if err != nil {
 			return 0, errors.Wrap(err, "stakersRuneROI failed")
 		}
@@ -343,7 +433,7 @@ func (s *Client) stakersRuneROI(address common.Address, asset common.Asset) (flo
 	return 0, nil
 }
 
-func (s *Client) dateFirstStaked(address common.Address, asset common.Asset) (uint64, error) {
+func (s *Client) dateFirstStaked(ctx context.Context, address common.Address, asset common.Asset) (uint64, error) {
 	query := `
 		SELECT MIN(pools_history.time)
 		FROM pools_history
@@ -353,7 +443,7 @@ func (s *Client) dateFirstStaked(address common.Address, asset common.Asset) (ui
 		txs.from_address = $2`
 
 	firstStaked := sql.NullTime{}
-	err := s.db.Get(&firstStaked, query, asset.String(), address.String())
+	err := s.db().GetContext(ctx, &firstStaked, query, asset.String(), address.String())
 	if err != nil {
 		return 0, errors.Wrap(err, "dateFirstStaked failed")
 	}
@@ -365,7 +455,7 @@ func (s *Client) dateFirstStaked(address common.Address, asset common.Asset) (ui
 	return 0, nil
 }
 
-func (s *Client) heightLastStaked(address common.Address, asset common.Asset) (uint64, error) {
+func (s *Client) heightLastStaked(ctx context.Context, address common.Address, asset common.Asset) (uint64, error) {
 	query := `
 		SELECT MAX(events.height) 
 		FROM events 
@@ -376,7 +466,7 @@ func (s *Client) heightLastStaked(address common.Address, asset common.Asset) (u
 		AND txs.from_address = $2`
 
 	lastStaked := sql.NullInt64{}
-	err := s.db.Get(&lastStaked, query, asset.String(), address.String())
+	err := s.db().GetContext(ctx, &lastStaked, query, asset.String(), address.String())
 	if err != nil {
 		return 0, errors.Wrap(err, "heightLastStaked failed")
 	}
@@ -388,7 +478,7 @@ func (s *Client) heightLastStaked(address common.Address, asset common.Asset) (u
 	return 0, nil
 }
 
-func (s *Client) getPools(address common.Address) ([]common.Asset, error) {
+func (s *Client) getPools(ctx context.Context, address common.Address) ([]common.Asset, error) {
 	query := `
 		SELECT pool 
 		FROM   pools_history 
@@ -402,7 +492,7 @@ func (s *Client) getPools(address common.Address) ([]common.Asset, error) {
 		GROUP  BY pool 
 		HAVING Sum(units) > 0 `
 
-	rows, err := s.db.Queryx(query, address.String())
+	rows, err := s.db().QueryxContext(ctx, query, address.String())
 	if err != nil {
 		return nil, errors.Wrap(err, "getPools failed")
 	}
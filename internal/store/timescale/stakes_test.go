@@ -1,6 +1,9 @@
 package timescale
 
 import (
+	"context"
+	"time"
+
 	"gitlab.com/thorchain/midgard/internal/common"
 	"gitlab.com/thorchain/midgard/internal/models"
 	"gitlab.com/thorchain/midgard/internal/store"
@@ -12,7 +15,7 @@ func (s *TimeScaleSuite) TestStakeUnits(c *C) {
 	asset, _ := common.NewAsset("BNB")
 
 	// No stakes
-	stakeUnits, err := s.Store.stakeUnits(address, asset)
+	stakeUnits, err := s.Store.stakeUnits(context.Background(), address, asset)
 	c.Assert(err, IsNil)
 	c.Assert(stakeUnits, Equals, uint64(0))
 
@@ -20,7 +23,7 @@ func (s *TimeScaleSuite) TestStakeUnits(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	stakeUnits, err = s.Store.stakeUnits(address, asset)
+	stakeUnits, err = s.Store.stakeUnits(context.Background(), address, asset)
 	c.Assert(err, IsNil)
 	c.Assert(stakeUnits, Equals, uint64(100))
 
@@ -29,7 +32,7 @@ func (s *TimeScaleSuite) TestStakeUnits(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeTomlEvent1)
 	c.Assert(err, IsNil)
 
-	stakeUnits, err = s.Store.stakeUnits(address, asset)
+	stakeUnits, err = s.Store.stakeUnits(context.Background(), address, asset)
 	c.Assert(err, IsNil)
 	c.Assert(stakeUnits, Equals, uint64(100))
 
@@ -37,7 +40,7 @@ func (s *TimeScaleSuite) TestStakeUnits(c *C) {
 	err = s.Store.CreateUnStakesRecord(&unstakeTomlEvent0)
 	c.Assert(err, IsNil)
 
-	stakeUnits, err = s.Store.stakeUnits(address, asset)
+	stakeUnits, err = s.Store.stakeUnits(context.Background(), address, asset)
 	c.Assert(err, IsNil)
 	c.Assert(stakeUnits, Equals, uint64(0))
 
@@ -48,7 +51,7 @@ func (s *TimeScaleSuite) TestStakeUnits(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent2)
 	c.Assert(err, IsNil)
 
-	stakeUnits, err = s.Store.stakeUnits(address, asset)
+	stakeUnits, err = s.Store.stakeUnits(context.Background(), address, asset)
 	c.Assert(err, IsNil)
 	c.Assert(stakeUnits, Equals, uint64(200), Commentf("%v", stakeUnits))
 }
@@ -57,7 +60,7 @@ func (s *TimeScaleSuite) TestGetPools(c *C) {
 	address, _ := common.NewAddress("bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38")
 
 	// No stakes
-	pools, err := s.Store.getPools(address)
+	pools, err := s.Store.getPools(context.Background(), address)
 	c.Assert(err, IsNil)
 	c.Assert(len(pools), Equals, 0)
 
@@ -65,7 +68,7 @@ func (s *TimeScaleSuite) TestGetPools(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	pools, err = s.Store.getPools(address)
+	pools, err = s.Store.getPools(context.Background(), address)
 	c.Assert(err, IsNil)
 	c.Assert(len(pools), Equals, 1)
 
@@ -73,7 +76,7 @@ func (s *TimeScaleSuite) TestGetPools(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeTomlEvent1)
 	c.Assert(err, IsNil)
 
-	pools, err = s.Store.getPools(address)
+	pools, err = s.Store.getPools(context.Background(), address)
 	c.Assert(err, IsNil)
 	c.Assert(len(pools), Equals, 2)
 
@@ -81,13 +84,13 @@ func (s *TimeScaleSuite) TestGetPools(c *C) {
 	err = s.Store.CreateUnStakesRecord(&unstakeTomlEvent0)
 	c.Assert(err, IsNil)
 
-	pools, err = s.Store.getPools(address)
+	pools, err = s.Store.getPools(context.Background(), address)
 	c.Assert(err, IsNil)
 	c.Assert(len(pools), Equals, 1)
 }
 
 func (s *TimeScaleSuite) TestGetStakerAddresses(c *C) {
-	stakerAddresses, err := s.Store.GetStakerAddresses()
+	stakerAddresses, err := s.Store.GetStakerAddresses(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(len(stakerAddresses), Equals, 0)
 
@@ -95,7 +98,7 @@ func (s *TimeScaleSuite) TestGetStakerAddresses(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	stakerAddresses, err = s.Store.GetStakerAddresses()
+	stakerAddresses, err = s.Store.GetStakerAddresses(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(len(stakerAddresses), Equals, 1)
 	c.Assert(stakerAddresses[0].String(), Equals, "bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38")
@@ -104,7 +107,7 @@ func (s *TimeScaleSuite) TestGetStakerAddresses(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent2)
 	c.Assert(err, IsNil)
 
-	stakerAddresses, err = s.Store.GetStakerAddresses()
+	stakerAddresses, err = s.Store.GetStakerAddresses(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(len(stakerAddresses), Equals, 2)
 	c.Assert(stakerAddresses[0].String(), Equals, "bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38")
@@ -114,13 +117,79 @@ func (s *TimeScaleSuite) TestGetStakerAddresses(c *C) {
 	err = s.Store.CreateUnStakesRecord(&unstakeBnbEvent1)
 	c.Assert(err, IsNil)
 
-	stakerAddresses, err = s.Store.GetStakerAddresses()
+	stakerAddresses, err = s.Store.GetStakerAddresses(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(len(stakerAddresses), Equals, 2)
 	c.Assert(stakerAddresses[0].String(), Equals, "bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38")
 	c.Assert(stakerAddresses[1].String(), Equals, "tbnb1u3xts5zh9zuywdjlfmcph7pzyv4f9t4e95jmdq")
 }
 
+func (s *TimeScaleSuite) TestGetPoolStakers(c *C) {
+	now := time.Now()
+	stakers, err := s.Store.GetPoolStakers(context.Background(), common.BNBAsset, now)
+	c.Assert(err, IsNil)
+	c.Assert(stakers, HasLen, 0)
+
+	stake0 := stakeBnbEvent0
+	stake0.Time = now.Add(-time.Hour * 3)
+	err = s.Store.CreateStakeRecord(&stake0)
+	c.Assert(err, IsNil)
+	stake2 := stakeBnbEvent2
+	stake2.Time = now.Add(-time.Hour * 2)
+	err = s.Store.CreateStakeRecord(&stake2)
+	c.Assert(err, IsNil)
+	unstake1 := unstakeBnbEvent1
+	unstake1.Time = now.Add(-time.Hour)
+	err = s.Store.CreateUnStakesRecord(&unstake1)
+	c.Assert(err, IsNil)
+
+	stakers, err = s.Store.GetPoolStakers(context.Background(), common.BNBAsset, now.Add(-time.Hour*2))
+	c.Assert(err, IsNil)
+	c.Assert(stakers, DeepEquals, []models.PoolStaker{
+		{Address: "tbnb1u3xts5zh9zuywdjlfmcph7pzyv4f9t4e95jmdq", Units: 200},
+		{Address: "bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38", Units: 100},
+	})
+
+	stakers, err = s.Store.GetPoolStakers(context.Background(), common.BNBAsset, now)
+	c.Assert(err, IsNil)
+	c.Assert(stakers, DeepEquals, []models.PoolStaker{
+		{Address: "tbnb1u3xts5zh9zuywdjlfmcph7pzyv4f9t4e95jmdq", Units: 200},
+	})
+
+	stakers, err = s.Store.GetPoolStakers(context.Background(), common.BTCAsset, now)
+	c.Assert(err, IsNil)
+	c.Assert(stakers, HasLen, 0)
+}
+
+func (s *TimeScaleSuite) TestGetPoolStakersChanges(c *C) {
+	now := time.Now()
+	from := now.Add(-time.Hour * 3)
+	stake0 := stakeBnbEvent0
+	stake0.Time = from.Add(-time.Minute)
+	err := s.Store.CreateStakeRecord(&stake0)
+	c.Assert(err, IsNil)
+	stake2 := stakeBnbEvent2
+	stake2.Time = from.Add(time.Hour + time.Minute)
+	err = s.Store.CreateStakeRecord(&stake2)
+	c.Assert(err, IsNil)
+	unstake1 := unstakeBnbEvent1
+	unstake1.Time = from.Add(time.Hour * 2)
+	err = s.Store.CreateUnStakesRecord(&unstake1)
+	c.Assert(err, IsNil)
+
+	changes, err := s.Store.GetPoolStakersChanges(context.Background(), common.BNBAsset, from, now, time.Hour)
+	c.Assert(err, IsNil)
+	c.Assert(changes, DeepEquals, []models.PoolStakerChange{
+		{Address: "bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38", Point: 0, Units: 100},
+		{Address: "bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38", Point: 2, Units: -100},
+		{Address: "tbnb1u3xts5zh9zuywdjlfmcph7pzyv4f9t4e95jmdq", Point: 2, Units: 200},
+	})
+
+	changes, err = s.Store.GetPoolStakersChanges(context.Background(), common.BTCAsset, from, now, time.Hour)
+	c.Assert(err, IsNil)
+	c.Assert(changes, HasLen, 0)
+}
+
 func (s *TimeScaleSuite) TestGetStakersAddressAndAssetDetails(c *C) {
 	err := s.Store.CreateStakeRecord(&stakeTomlEvent1)
 	c.Assert(err, IsNil)
@@ -138,7 +207,7 @@ func (s *TimeScaleSuite) TestGetStakersAddressAndAssetDetails(c *C) {
 		AssetStaked:      10,
 		RuneStaked:       100,
 	}
-	actualDetails, err := s.Store.GetStakersAddressAndAssetDetails(stakeTomlEvent1.InTx.FromAddress, assest)
+	actualDetails, err := s.Store.GetStakersAddressAndAssetDetails(context.Background(), stakeTomlEvent1.InTx.FromAddress, assest)
 	c.Assert(err, IsNil)
 	c.Assert(actualDetails, DeepEquals, expectedDetails)
 
@@ -158,13 +227,13 @@ func (s *TimeScaleSuite) TestGetStakersAddressAndAssetDetails(c *C) {
 		AssetWithdrawn:   5,
 		RuneWithdrawn:    50,
 	}
-	actualDetails, err = s.Store.GetStakersAddressAndAssetDetails(stakeTomlEvent1.InTx.FromAddress, assest)
+	actualDetails, err = s.Store.GetStakersAddressAndAssetDetails(context.Background(), stakeTomlEvent1.InTx.FromAddress, assest)
 	c.Assert(err, IsNil)
 	c.Assert(actualDetails, DeepEquals, expectedDetails)
 
 	assest, err = common.NewAsset("BNB.BNB")
 	c.Assert(err, IsNil)
-	_, err = s.Store.GetStakersAddressAndAssetDetails(stakeTomlEvent1.InTx.FromAddress, assest)
+	_, err = s.Store.GetStakersAddressAndAssetDetails(context.Background(), stakeTomlEvent1.InTx.FromAddress, assest)
 	c.Assert(err, NotNil)
 	c.Assert(err, Equals, store.ErrPoolNotFound)
 }
@@ -174,13 +243,13 @@ func (s *TimeScaleSuite) TestHeightLastStaked(c *C) {
 	c.Assert(err, IsNil)
 	assest, err := common.NewAsset("BNB.TCAN-014")
 	c.Assert(err, IsNil)
-	assetDetail, err := s.Store.GetStakersAddressAndAssetDetails(stakeBnbEvent1.InTx.FromAddress, assest)
+	assetDetail, err := s.Store.GetStakersAddressAndAssetDetails(context.Background(), stakeBnbEvent1.InTx.FromAddress, assest)
 	c.Assert(err, IsNil)
 	c.Assert(assetDetail.HeightLastStaked, Equals, uint64(5))
 
 	err = s.Store.CreateStakeRecord(&stakeTcanEvent4)
 	c.Assert(err, IsNil)
-	assetDetail, err = s.Store.GetStakersAddressAndAssetDetails(stakeBnbEvent1.InTx.FromAddress, assest)
+	assetDetail, err = s.Store.GetStakersAddressAndAssetDetails(context.Background(), stakeBnbEvent1.InTx.FromAddress, assest)
 	c.Assert(err, IsNil)
 	c.Assert(assetDetail.HeightLastStaked, Equals, uint64(6))
 }
@@ -1,6 +1,7 @@
 package timescale
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
@@ -12,7 +13,7 @@ import (
 )
 
 // GetUsersCount returns total number of unique addresses that done tx between "from" to "to".
-func (s *Client) GetUsersCount(from, to *time.Time) (uint64, error) {
+func (s *Client) GetUsersCount(ctx context.Context, from, to *time.Time) (uint64, error) {
 	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
 	sb.Select("COUNT(DISTINCT(subject_address))")
 	sb.From(`(
@@ -25,42 +26,44 @@ func (s *Client) GetUsersCount(from, to *time.Time) (uint64, error) {
 		WHERE txs.direction = 'out'
 		) txs_addresses`)
 
-	count, err := s.queryTimestampInt64(sb, from, to)
+	count, err := s.queryTimestampInt64(ctx, sb, from, to)
 	return uint64(count), err
 }
 
 // GetTxsCount returns total number of transactions between "from" to "to".
-func (s *Client) GetTxsCount(from, to *time.Time) (uint64, error) {
+func (s *Client) GetTxsCount(ctx context.Context, from, to *time.Time) (uint64, error) {
 	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
 	sb.Select("COUNT(DISTINCT(id))")
 	sb.From("events")
 	sb.Where("type in ('stake', 'unstake', 'swap', 'doubleSwap', 'add', 'refund')")
-	count, err := s.queryTimestampInt64(sb, from, to)
+	count, err := s.queryTimestampInt64(ctx, sb, from, to)
 	return uint64(count), err
 }
 
-// GetTotalVolume returns total volume between "from" to "to".
-func (s *Client) GetTotalVolume(from, to *time.Time) (uint64, error) {
+// GetTotalVolume returns total volume between "from" to "to". Double swaps are
+// counted once by their first leg.
+func (s *Client) GetTotalVolume(ctx context.Context, from, to *time.Time) (uint64, error) {
 	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
 	sb.Select("SUM(ABS(runeAmt))").From("swaps")
-	vol, err := s.queryTimestampInt64(sb, from, to)
+	sb.Where("event_id NOT IN (SELECT second_event_id FROM double_swaps)")
+	vol, err := s.queryTimestampInt64(ctx, sb, from, to)
 	return uint64(vol), err
 }
 
-func (s *Client) TotalStaked() (uint64, error) {
+func (s *Client) TotalStaked(ctx context.Context) (uint64, error) {
 	var totalStaked uint64
 
-	pools, err := s.GetPools()
+	pools, err := s.GetPools(ctx)
 	if err != nil {
 		return 0, errors.Wrap(err, "bTotalStaked failed")
 	}
 
 	for _, pool := range pools {
-		poolStakedTotal, err := s.poolStakedTotal(pool)
+		poolStakedTotal, err := s.poolStakedTotal(ctx, pool)
 		if err != nil {
 			return 0, errors.Wrap(err, "bTotalStaked failed")
 		}
-		poolTotalAdded, err := s.poolAddedTotal(pool)
+		poolTotalAdded, err := s.poolAddedTotal(ctx, pool)
 		if err != nil {
 			return 0, errors.Wrap(err, "bTotalStaked failed")
 		}
@@ -69,7 +72,7 @@ func (s *Client) TotalStaked() (uint64, error) {
 	return totalStaked, nil
 }
 
-func (s *Client) GetTotalDepth() (uint64, error) {
+func (s *Client) GetTotalDepth(ctx context.Context) (uint64, error) {
 	totalDepth := uint64(0)
 	for _, pool := range s.pools {
 		if pool.Status != models.Suspended {
@@ -79,7 +82,7 @@ func (s *Client) GetTotalDepth() (uint64, error) {
 	return totalDepth, nil
 }
 
-func (s *Client) TotalRuneStaked() (int64, error) {
+func (s *Client) TotalRuneStaked(ctx context.Context) (int64, error) {
 	stmnt := `
 		SELECT SUM(rune_amount)
 		FROM pools_history
@@ -87,7 +90,7 @@ func (s *Client) TotalRuneStaked() (int64, error) {
 		WHERE events.type in ('stake', 'unstake')`
 
 	var totalRuneStaked sql.NullInt64
-	row := s.db.QueryRow(stmnt)
+	row := s.db().QueryRowContext(ctx, stmnt)
 
 	if err := row.Scan(&totalRuneStaked); err != nil {
 		return 0, errors.Wrap(err, "totalRuneStaked failed")
@@ -96,13 +99,14 @@ func (s *Client) TotalRuneStaked() (int64, error) {
 	return totalRuneStaked.Int64, nil
 }
 
-func (s *Client) runeSwaps() (int64, error) {
+func (s *Client) runeSwaps(ctx context.Context) (int64, error) {
 	stmnt := `
 		SELECT SUM(runeAmt) FROM swaps
+		WHERE event_id NOT IN (SELECT second_event_id FROM double_swaps)
 	`
 
 	var runeIncomingSwaps sql.NullInt64
-	row := s.db.QueryRow(stmnt)
+	row := s.db().QueryRowContext(ctx, stmnt)
 
 	if err := row.Scan(&runeIncomingSwaps); err != nil {
 		return 0, errors.Wrap(err, "runeSwaps failed")
@@ -111,12 +115,12 @@ func (s *Client) runeSwaps() (int64, error) {
 	return runeIncomingSwaps.Int64, nil
 }
 
-func (s *Client) PoolCount() (uint64, error) {
+func (s *Client) PoolCount(ctx context.Context) (uint64, error) {
 	var poolCount uint64
 
 	stmnt := `SELECT DISTINCT(pool) FROM pools_history`
 
-	rows, err := s.db.Queryx(stmnt)
+	rows, err := s.db().QueryxContext(ctx, stmnt)
 	if err != nil {
 		return 0, errors.Wrap(err, "poolCount failed")
 	}
@@ -128,7 +132,7 @@ func (s *Client) PoolCount() (uint64, error) {
 		}
 
 		asset, _ := common.NewAsset(pool)
-		depth, err := s.GetRuneDepth(asset)
+		depth, err := s.GetRuneDepth(ctx, asset)
 		if err != nil {
 			return 0, errors.Wrap(err, "poolCount failed")
 		}
@@ -140,10 +144,13 @@ func (s *Client) PoolCount() (uint64, error) {
 	return poolCount, nil
 }
 
-func (s *Client) TotalAssetBuys() (uint64, error) {
-	stmnt := `SELECT COUNT(pool) FROM swaps WHERE assetAmt > 0`
+// TotalAssetBuys returns the number of swaps buying an asset. Double swaps are
+// counted once by their first leg.
+func (s *Client) TotalAssetBuys(ctx context.Context) (uint64, error) {
+	stmnt := `SELECT COUNT(pool) FROM swaps WHERE assetAmt > 0
+		AND event_id NOT IN (SELECT second_event_id FROM double_swaps)`
 	var totalAssetBuys sql.NullInt64
-	row := s.db.QueryRow(stmnt)
+	row := s.db().QueryRowContext(ctx, stmnt)
 
 	if err := row.Scan(&totalAssetBuys); err != nil {
 		return 0, errors.Wrap(err, "totalAssetBuys failed")
@@ -152,10 +159,13 @@ func (s *Client) TotalAssetBuys() (uint64, error) {
 	return uint64(totalAssetBuys.Int64), nil
 }
 
-func (s *Client) TotalAssetSells() (uint64, error) {
-	stmnt := `SELECT COUNT(pool) FROM swaps WHERE runeAmt > 0`
+// TotalAssetSells returns the number of swaps selling an asset. Double swaps are
+// counted once by their first leg.
+func (s *Client) TotalAssetSells(ctx context.Context) (uint64, error) {
+	stmnt := `SELECT COUNT(pool) FROM swaps WHERE runeAmt > 0
+		AND event_id NOT IN (SELECT second_event_id FROM double_swaps)`
 	var totalAssetSells sql.NullInt64
-	row := s.db.QueryRow(stmnt)
+	row := s.db().QueryRowContext(ctx, stmnt)
 
 	if err := row.Scan(&totalAssetSells); err != nil {
 		return 0, errors.Wrap(err, "totalAssetSells failed")
@@ -164,11 +174,11 @@ func (s *Client) TotalAssetSells() (uint64, error) {
 	return uint64(totalAssetSells.Int64), nil
 }
 
-func (s *Client) TotalStakeTx() (uint64, error) {
+func (s *Client) TotalStakeTx(ctx context.Context) (uint64, error) {
 	stmnt := `SELECT COUNT(id) FROM events WHERE type = 'stake'`
 
 	var totalStakeTx sql.NullInt64
-	row := s.db.QueryRow(stmnt)
+	row := s.db().QueryRowContext(ctx, stmnt)
 
 	if err := row.Scan(&totalStakeTx); err != nil {
 		return 0, errors.Wrap(err, "totalStakeTx failed")
@@ -177,10 +187,10 @@ func (s *Client) TotalStakeTx() (uint64, error) {
 	return uint64(totalStakeTx.Int64), nil
 }
 
-func (s *Client) TotalWithdrawTx() (uint64, error) {
+func (s *Client) TotalWithdrawTx(ctx context.Context) (uint64, error) {
 	stmnt := `SELECT COUNT(id) FROM events WHERE type = 'unstake'`
 	var totalStakeTx sql.NullInt64
-	row := s.db.QueryRow(stmnt)
+	row := s.db().QueryRowContext(ctx, stmnt)
 
 	if err := row.Scan(&totalStakeTx); err != nil {
 		return 0, errors.Wrap(err, "totalWithdrawTx failed")
@@ -189,22 +199,22 @@ func (s *Client) TotalWithdrawTx() (uint64, error) {
 	return uint64(totalStakeTx.Int64), nil
 }
 
-func (s *Client) TotalEarned() (int64, error) {
-	pools, err := s.GetPools()
+func (s *Client) TotalEarned(ctx context.Context) (int64, error) {
+	pools, err := s.GetPools(ctx)
 	if err != nil {
 		return 0, err
 	}
 	var totalEarned int64
 	for _, pool := range pools {
-		poolBasic, err := s.GetPoolBasics(pool)
+		poolBasic, err := s.GetPoolBasics(ctx, pool)
 		if err != nil {
 			return 0, err
 		}
-		buyFee, err := s.buyFeesTotal(pool)
+		buyFee, err := s.buyFeesTotal(ctx, pool)
 		if err != nil {
 			return 0, err
 		}
-		sellFee, err := s.sellFeesTotal(pool)
+		sellFee, err := s.sellFeesTotal(ctx, pool)
 		if err != nil {
 			return 0, err
 		}
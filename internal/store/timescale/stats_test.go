@@ -1,6 +1,7 @@
 package timescale
 
 import (
+	"context"
 	"time"
 
 	"gitlab.com/thorchain/midgard/internal/common"
@@ -9,7 +10,7 @@ import (
 )
 
 func (s *TimeScaleSuite) TestGetUsersCount(c *C) {
-	count, err := s.Store.GetUsersCount(nil, nil)
+	count, err := s.Store.GetUsersCount(context.Background(), nil, nil)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, uint64(0))
 
@@ -17,44 +18,44 @@ func (s *TimeScaleSuite) TestGetUsersCount(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 	to := time.Now()
-	count, err = s.Store.GetUsersCount(&from, &to)
+	count, err = s.Store.GetUsersCount(context.Background(), &from, &to)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, uint64(1))
 
 	err = s.Store.CreateStakeRecord(&stakeTomlEvent1)
 	c.Assert(err, IsNil)
 	to = time.Now()
-	count, err = s.Store.GetUsersCount(&from, &to)
+	count, err = s.Store.GetUsersCount(context.Background(), &from, &to)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, uint64(1))
 
 	err = s.Store.CreateUnStakesRecord(&unstakeTomlEvent0)
 	c.Assert(err, IsNil)
 	to = time.Now()
-	count, err = s.Store.GetUsersCount(&from, &to)
+	count, err = s.Store.GetUsersCount(context.Background(), &from, &to)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, uint64(1))
 
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent2)
 	c.Assert(err, IsNil)
 	to = time.Now()
-	count, err = s.Store.GetUsersCount(&from, &to)
+	count, err = s.Store.GetUsersCount(context.Background(), &from, &to)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, uint64(2))
 
 	from = time.Now().Add(-time.Hour * 2)
 	to = from.Add(time.Hour)
-	count, err = s.Store.GetUsersCount(&from, &to)
+	count, err = s.Store.GetUsersCount(context.Background(), &from, &to)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, uint64(0))
 
-	count, err = s.Store.GetUsersCount(nil, nil)
+	count, err = s.Store.GetUsersCount(context.Background(), nil, nil)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, uint64(2))
 }
 
 func (s *TimeScaleSuite) TestGetTxsCount(c *C) {
-	count, err := s.Store.GetTxsCount(nil, nil)
+	count, err := s.Store.GetTxsCount(context.Background(), nil, nil)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, uint64(0))
 
@@ -62,34 +63,34 @@ func (s *TimeScaleSuite) TestGetTxsCount(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 	to := time.Now()
-	count, err = s.Store.GetTxsCount(&from, &to)
+	count, err = s.Store.GetTxsCount(context.Background(), &from, &to)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, uint64(1))
 
 	err = s.Store.CreateStakeRecord(&stakeTomlEvent1)
 	c.Assert(err, IsNil)
 	to = time.Now()
-	count, err = s.Store.GetTxsCount(&from, &to)
+	count, err = s.Store.GetTxsCount(context.Background(), &from, &to)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, uint64(2))
 
 	err = s.Store.CreateUnStakesRecord(&unstakeTomlEvent0)
 	c.Assert(err, IsNil)
 	to = time.Now()
-	count, err = s.Store.GetTxsCount(&from, &to)
+	count, err = s.Store.GetTxsCount(context.Background(), &from, &to)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, uint64(3))
 
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent2)
 	c.Assert(err, IsNil)
 	to = time.Now()
-	count, err = s.Store.GetTxsCount(&from, &to)
+	count, err = s.Store.GetTxsCount(context.Background(), &from, &to)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, uint64(4))
 
 	from = time.Now().Add(-time.Hour * 2)
 	to = from.Add(time.Hour)
-	count, err = s.Store.GetTxsCount(&from, &to)
+	count, err = s.Store.GetTxsCount(context.Background(), &from, &to)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, uint64(0))
 
@@ -99,13 +100,13 @@ func (s *TimeScaleSuite) TestGetTxsCount(c *C) {
 	c.Assert(err, IsNil)
 	err = s.Store.CreateRefundRecord(&refundBOLTEvent0)
 	c.Assert(err, IsNil)
-	count, err = s.Store.GetTxsCount(nil, nil)
+	count, err = s.Store.GetTxsCount(context.Background(), nil, nil)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, uint64(7))
 }
 
 func (s *TimeScaleSuite) TestGetTotalVolume(c *C) {
-	vol, err := s.Store.GetTotalVolume(nil, nil)
+	vol, err := s.Store.GetTotalVolume(context.Background(), nil, nil)
 	c.Assert(err, IsNil)
 	c.Assert(vol, Equals, uint64(0))
 
@@ -113,44 +114,44 @@ func (s *TimeScaleSuite) TestGetTotalVolume(c *C) {
 	err = s.Store.CreateSwapRecord(&swapSellTusdb2RuneEvent0)
 	c.Assert(err, IsNil)
 	to := time.Now()
-	vol, err = s.Store.GetTotalVolume(&from, &to)
+	vol, err = s.Store.GetTotalVolume(context.Background(), &from, &to)
 	c.Assert(err, IsNil)
 	c.Assert(vol, Equals, uint64(10))
 
 	err = s.Store.CreateSwapRecord(&swapBuyRune2BnbEvent3)
 	c.Assert(err, IsNil)
 	to = time.Now()
-	vol, err = s.Store.GetTotalVolume(&from, &to)
+	vol, err = s.Store.GetTotalVolume(context.Background(), &from, &to)
 	c.Assert(err, IsNil)
 	c.Assert(vol, Equals, uint64(200000010))
 
 	err = s.Store.CreateSwapRecord(&swapBuyRune2BoltEvent1)
 	c.Assert(err, IsNil)
 	to = time.Now()
-	vol, err = s.Store.GetTotalVolume(&from, &to)
+	vol, err = s.Store.GetTotalVolume(context.Background(), &from, &to)
 	c.Assert(err, IsNil)
 	c.Assert(vol, Equals, uint64(200000011))
 
 	err = s.Store.CreateSwapRecord(&swapSellBnb2RuneEvent4)
 	c.Assert(err, IsNil)
 	to = time.Now()
-	vol, err = s.Store.GetTotalVolume(&from, &to)
+	vol, err = s.Store.GetTotalVolume(context.Background(), &from, &to)
 	c.Assert(err, IsNil)
 	c.Assert(vol, Equals, uint64(200000012))
 
 	from = time.Now().Add(-time.Hour * 2)
 	to = from.Add(time.Hour)
-	vol, err = s.Store.GetTotalVolume(&from, &to)
+	vol, err = s.Store.GetTotalVolume(context.Background(), &from, &to)
 	c.Assert(err, IsNil)
 	c.Assert(vol, Equals, uint64(0))
 
-	vol, err = s.Store.GetTotalVolume(nil, nil)
+	vol, err = s.Store.GetTotalVolume(context.Background(), nil, nil)
 	c.Assert(err, IsNil)
 	c.Assert(vol, Equals, uint64(200000012))
 }
 
 func (s *TimeScaleSuite) TestTotalDepth(c *C) {
-	totalDepth, err := s.Store.GetTotalDepth()
+	totalDepth, err := s.Store.GetTotalDepth(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalDepth, Equals, uint64(0))
 
@@ -158,7 +159,7 @@ func (s *TimeScaleSuite) TestTotalDepth(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	totalDepth, err = s.Store.GetTotalDepth()
+	totalDepth, err = s.Store.GetTotalDepth(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalDepth, Equals, uint64(100))
 
@@ -166,14 +167,14 @@ func (s *TimeScaleSuite) TestTotalDepth(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeTomlEvent1)
 	c.Assert(err, IsNil)
 
-	totalDepth, err = s.Store.GetTotalDepth()
+	totalDepth, err = s.Store.GetTotalDepth(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalDepth, Equals, uint64(200))
 
 	err = s.Store.CreateUnStakesRecord(&unstakeTomlEvent0)
 	c.Assert(err, IsNil)
 
-	totalDepth, err = s.Store.GetTotalDepth()
+	totalDepth, err = s.Store.GetTotalDepth(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalDepth, Equals, uint64(100))
 
@@ -181,13 +182,13 @@ func (s *TimeScaleSuite) TestTotalDepth(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent2)
 	c.Assert(err, IsNil)
 
-	totalDepth, err = s.Store.GetTotalDepth()
+	totalDepth, err = s.Store.GetTotalDepth(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalDepth, Equals, uint64(50000100))
 }
 
 func (s *TimeScaleSuite) TestTotalRuneStaked(c *C) {
-	totalRuneStaked, err := s.Store.TotalRuneStaked()
+	totalRuneStaked, err := s.Store.TotalRuneStaked(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalRuneStaked, Equals, int64(0))
 
@@ -195,7 +196,7 @@ func (s *TimeScaleSuite) TestTotalRuneStaked(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	totalRuneStaked, err = s.Store.TotalRuneStaked()
+	totalRuneStaked, err = s.Store.TotalRuneStaked(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalRuneStaked, Equals, int64(100))
 
@@ -203,14 +204,14 @@ func (s *TimeScaleSuite) TestTotalRuneStaked(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeTomlEvent1)
 	c.Assert(err, IsNil)
 
-	totalRuneStaked, err = s.Store.TotalRuneStaked()
+	totalRuneStaked, err = s.Store.TotalRuneStaked(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalRuneStaked, Equals, int64(200))
 
 	err = s.Store.CreateUnStakesRecord(&unstakeTomlEvent0)
 	c.Assert(err, IsNil)
 
-	totalRuneStaked, err = s.Store.TotalRuneStaked()
+	totalRuneStaked, err = s.Store.TotalRuneStaked(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalRuneStaked, Equals, int64(100))
 
@@ -218,13 +219,13 @@ func (s *TimeScaleSuite) TestTotalRuneStaked(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent2)
 	c.Assert(err, IsNil)
 
-	totalRuneStaked, err = s.Store.TotalRuneStaked()
+	totalRuneStaked, err = s.Store.TotalRuneStaked(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalRuneStaked, Equals, int64(50000100))
 }
 
 func (s *TimeScaleSuite) TestRuneSwaps(c *C) {
-	runeSwaps, err := s.Store.runeSwaps()
+	runeSwaps, err := s.Store.runeSwaps(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(runeSwaps, Equals, int64(0))
 
@@ -232,7 +233,7 @@ func (s *TimeScaleSuite) TestRuneSwaps(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	runeSwaps, err = s.Store.runeSwaps()
+	runeSwaps, err = s.Store.runeSwaps(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(runeSwaps, Equals, int64(0))
 
@@ -240,20 +241,20 @@ func (s *TimeScaleSuite) TestRuneSwaps(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeTomlEvent1)
 	c.Assert(err, IsNil)
 
-	runeSwaps, err = s.Store.runeSwaps()
+	runeSwaps, err = s.Store.runeSwaps(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(runeSwaps, Equals, int64(0))
 
 	err = s.Store.CreateUnStakesRecord(&unstakeTomlEvent0)
 	c.Assert(err, IsNil)
 
-	runeSwaps, err = s.Store.runeSwaps()
+	runeSwaps, err = s.Store.runeSwaps(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(runeSwaps, Equals, int64(0))
 }
 
 func (s *TimeScaleSuite) TestPoolCount(c *C) {
-	poolCount, err := s.Store.PoolCount()
+	poolCount, err := s.Store.PoolCount(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(poolCount, Equals, uint64(0))
 
@@ -261,7 +262,7 @@ func (s *TimeScaleSuite) TestPoolCount(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	poolCount, err = s.Store.PoolCount()
+	poolCount, err = s.Store.PoolCount(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(poolCount, Equals, uint64(1))
 
@@ -269,7 +270,7 @@ func (s *TimeScaleSuite) TestPoolCount(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeTomlEvent1)
 	c.Assert(err, IsNil)
 
-	poolCount, err = s.Store.PoolCount()
+	poolCount, err = s.Store.PoolCount(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(poolCount, Equals, uint64(2))
 
@@ -277,13 +278,13 @@ func (s *TimeScaleSuite) TestPoolCount(c *C) {
 	err = s.Store.CreateUnStakesRecord(&unstakeTomlEvent0)
 	c.Assert(err, IsNil)
 
-	poolCount, err = s.Store.PoolCount()
+	poolCount, err = s.Store.PoolCount(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(poolCount, Equals, uint64(1))
 }
 
 func (s *TimeScaleSuite) TestTotalAssetBuys(c *C) {
-	totalAssetBuys, err := s.Store.TotalAssetBuys()
+	totalAssetBuys, err := s.Store.TotalAssetBuys(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalAssetBuys, Equals, uint64(0))
 
@@ -291,7 +292,7 @@ func (s *TimeScaleSuite) TestTotalAssetBuys(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	totalAssetBuys, err = s.Store.TotalAssetBuys()
+	totalAssetBuys, err = s.Store.TotalAssetBuys(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalAssetBuys, Equals, uint64(0))
 
@@ -299,13 +300,13 @@ func (s *TimeScaleSuite) TestTotalAssetBuys(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeTomlEvent1)
 	c.Assert(err, IsNil)
 
-	totalAssetBuys, err = s.Store.TotalAssetBuys()
+	totalAssetBuys, err = s.Store.TotalAssetBuys(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalAssetBuys, Equals, uint64(0))
 }
 
 func (s *TimeScaleSuite) TestTotalAssetSells(c *C) {
-	totalAssetSells, err := s.Store.TotalAssetSells()
+	totalAssetSells, err := s.Store.TotalAssetSells(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalAssetSells, Equals, uint64(0))
 
@@ -313,7 +314,7 @@ func (s *TimeScaleSuite) TestTotalAssetSells(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	totalAssetSells, err = s.Store.TotalAssetSells()
+	totalAssetSells, err = s.Store.TotalAssetSells(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalAssetSells, Equals, uint64(0))
 
@@ -321,7 +322,7 @@ func (s *TimeScaleSuite) TestTotalAssetSells(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeTomlEvent1)
 	c.Assert(err, IsNil)
 
-	totalAssetSells, err = s.Store.TotalAssetSells()
+	totalAssetSells, err = s.Store.TotalAssetSells(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalAssetSells, Equals, uint64(0))
 
@@ -329,13 +330,13 @@ func (s *TimeScaleSuite) TestTotalAssetSells(c *C) {
 	err = s.Store.CreateUnStakesRecord(&unstakeTomlEvent0)
 	c.Assert(err, IsNil)
 
-	totalAssetSells, err = s.Store.TotalAssetSells()
+	totalAssetSells, err = s.Store.TotalAssetSells(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalAssetSells, Equals, uint64(0))
 }
 
 func (s *TimeScaleSuite) TestTotalStakeTx(c *C) {
-	totalStakeTx, err := s.Store.TotalStakeTx()
+	totalStakeTx, err := s.Store.TotalStakeTx(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalStakeTx, Equals, uint64(0))
 
@@ -343,7 +344,7 @@ func (s *TimeScaleSuite) TestTotalStakeTx(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	totalStakeTx, err = s.Store.TotalStakeTx()
+	totalStakeTx, err = s.Store.TotalStakeTx(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalStakeTx, Equals, uint64(1))
 
@@ -351,7 +352,7 @@ func (s *TimeScaleSuite) TestTotalStakeTx(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeTomlEvent1)
 	c.Assert(err, IsNil)
 
-	totalStakeTx, err = s.Store.TotalStakeTx()
+	totalStakeTx, err = s.Store.TotalStakeTx(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalStakeTx, Equals, uint64(2))
 
@@ -359,7 +360,7 @@ func (s *TimeScaleSuite) TestTotalStakeTx(c *C) {
 	err = s.Store.CreateUnStakesRecord(&unstakeTomlEvent0)
 	c.Assert(err, IsNil)
 
-	totalStakeTx, err = s.Store.TotalStakeTx()
+	totalStakeTx, err = s.Store.TotalStakeTx(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalStakeTx, Equals, uint64(2))
 
@@ -376,13 +377,13 @@ func (s *TimeScaleSuite) TestTotalStakeTx(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBoltEvent5)
 	c.Assert(err, IsNil)
 
-	totalStakeTx, err = s.Store.TotalStakeTx()
+	totalStakeTx, err = s.Store.TotalStakeTx(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalStakeTx, Equals, uint64(6))
 }
 
 func (s *TimeScaleSuite) TestTotalWithdrawTx(c *C) {
-	totalWithdrawTx, err := s.Store.TotalWithdrawTx()
+	totalWithdrawTx, err := s.Store.TotalWithdrawTx(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalWithdrawTx, Equals, uint64(0))
 
@@ -390,7 +391,7 @@ func (s *TimeScaleSuite) TestTotalWithdrawTx(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	totalWithdrawTx, err = s.Store.TotalWithdrawTx()
+	totalWithdrawTx, err = s.Store.TotalWithdrawTx(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalWithdrawTx, Equals, uint64(0))
 
@@ -398,7 +399,7 @@ func (s *TimeScaleSuite) TestTotalWithdrawTx(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeTomlEvent1)
 	c.Assert(err, IsNil)
 
-	totalWithdrawTx, err = s.Store.TotalWithdrawTx()
+	totalWithdrawTx, err = s.Store.TotalWithdrawTx(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalWithdrawTx, Equals, uint64(0))
 
@@ -406,50 +407,50 @@ func (s *TimeScaleSuite) TestTotalWithdrawTx(c *C) {
 	err = s.Store.CreateUnStakesRecord(&unstakeTomlEvent0)
 	c.Assert(err, IsNil)
 
-	totalWithdrawTx, err = s.Store.TotalWithdrawTx()
+	totalWithdrawTx, err = s.Store.TotalWithdrawTx(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalWithdrawTx, Equals, uint64(1))
 }
 
 func (s *TimeScaleSuite) TestTotalPoolsEarned(c *C) {
-	totalEarned, err := s.Store.TotalEarned()
+	totalEarned, err := s.Store.TotalEarned(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalEarned, Equals, int64(0))
 
 	err = s.Store.CreateStakeRecord(&stakeBoltEvent5)
 	c.Assert(err, IsNil)
-	totalEarned, err = s.Store.TotalEarned()
+	totalEarned, err = s.Store.TotalEarned(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalEarned, Equals, int64(0))
 
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
-	totalEarned, err = s.Store.TotalEarned()
+	totalEarned, err = s.Store.TotalEarned(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalEarned, Equals, int64(0))
 
 	err = s.Store.CreateSwapRecord(&swapSellBolt2RuneEvent2)
 	c.Assert(err, IsNil)
-	totalEarned, err = s.Store.TotalEarned()
+	totalEarned, err = s.Store.TotalEarned(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalEarned, Equals, int64(7463556))
 
 	err = s.Store.CreateSwapRecord(&swapSellBnb2RuneEvent4)
 	c.Assert(err, IsNil)
-	totalEarned, err = s.Store.TotalEarned()
+	totalEarned, err = s.Store.TotalEarned(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalEarned, Equals, int64(14927112))
 }
 
 func (s *TimeScaleSuite) TestTotalStaked(c *C) {
-	totalStaked, err := s.Store.TotalStaked()
+	totalStaked, err := s.Store.TotalStaked(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalStaked, Equals, uint64(0))
 
 	err = s.Store.CreateStakeRecord(&stakeTomlEvent1)
 	c.Assert(err, IsNil)
 
-	totalStaked, err = s.Store.TotalStaked()
+	totalStaked, err = s.Store.TotalStaked(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalStaked, Equals, uint64(200))
 
@@ -459,7 +460,7 @@ func (s *TimeScaleSuite) TestTotalStaked(c *C) {
 	err = s.Store.CreateAddRecord(&AddEvt)
 	c.Assert(err, IsNil)
 
-	totalStaked, err = s.Store.TotalStaked()
+	totalStaked, err = s.Store.TotalStaked(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(totalStaked, Equals, uint64(400))
 }
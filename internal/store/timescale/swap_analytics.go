@@ -0,0 +1,164 @@
+package timescale
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/models"
+)
+
+// swapFeeInRune converts the liquidity fee of buy swaps, which is in asset, to rune
+// based on the execution price of the swap.
+const swapFeeInRune = `CASE WHEN runeAmt > 0 AND assetAmt < 0 THEN liquidity_fee * runeAmt::float8 / -assetAmt ELSE liquidity_fee END`
+
+var swapMetricColumns = map[models.SwapMetric]string{
+	models.SwapMetricTradeSize: "ABS(runeAmt)",
+	models.SwapMetricSlip:      "trade_slip",
+	models.SwapMetricFee:       swapFeeInRune,
+}
+
+// buildSwapsFilter filters the swaps of the given pool between from and to. The
+// swaps of all pools count double swaps once by their first leg, while each leg
+// is a swap of its own pool.
+func buildSwapsFilter(sb *sqlbuilder.SelectBuilder, asset common.Asset, from, to time.Time) {
+	sb.Where(sb.Between("time", from, to))
+	if asset.IsEmpty() {
+		sb.Where("event_id NOT IN (SELECT second_event_id FROM double_swaps)")
+	} else {
+		sb.Where(sb.Equal("pool", asset.String()))
+	}
+}
+
+// GetSwapsStats returns the average and percentiles of swap metrics of the given pool
+// between from and to. All pools are included if the asset is empty.
+func (s *Client) GetSwapsStats(ctx context.Context, asset common.Asset, from, to time.Time) (models.SwapsStats, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	cols := []string{"COUNT(*)"}
+	for _, metric := range []models.SwapMetric{models.SwapMetricTradeSize, models.SwapMetricSlip, models.SwapMetricFee} {
+		col := swapMetricColumns[metric]
+		cols = append(cols,
+			fmt.Sprintf("AVG(%s)", col),
+			fmt.Sprintf("percentile_cont(0.5) WITHIN GROUP (ORDER BY %s)", col),
+			fmt.Sprintf("percentile_cont(0.9) WITHIN GROUP (ORDER BY %s)", col),
+			fmt.Sprintf("percentile_cont(0.99) WITHIN GROUP (ORDER BY %s)", col),
+		)
+	}
+	sb.Select(cols...)
+	sb.From("swaps")
+	buildSwapsFilter(sb, asset, from, to)
+
+	q, args := sb.Build()
+	var count sql.NullInt64
+	var values [12]sql.NullFloat64
+	dest := []interface{}{&count}
+	for i := range values {
+		dest = append(dest, &values[i])
+	}
+	if err := s.db().QueryRowContext(ctx, q, args...).Scan(dest...); err != nil {
+		return models.SwapsStats{}, errors.Wrap(err, "getSwapsStats failed")
+	}
+
+	distribution := func(v []sql.NullFloat64) models.Distribution {
+		return models.Distribution{
+			Average: v[0].Float64,
+			P50:     v[1].Float64,
+			P90:     v[2].Float64,
+			P99:     v[3].Float64,
+		}
+	}
+	return models.SwapsStats{
+		SwapCount: count.Int64,
+		TradeSize: distribution(values[0:4]),
+		Slip:      distribution(values[4:8]),
+		Fee:       distribution(values[8:12]),
+	}, nil
+}
+
+// GetSwapsHistogram returns number of swaps of the given pool between from and to in each
+// bucket of the metric specified by sorted bounds. The first bucket counts the swaps below
+// the first bound and the last one counts the swaps above the last bound, so the result has
+// len(bounds)+1 items. All pools are included if the asset is empty.
+func (s *Client) GetSwapsHistogram(ctx context.Context, asset common.Asset, metric models.SwapMetric, bounds []float64, from, to time.Time) ([]int64, error) {
+	col, ok := swapMetricColumns[metric]
+	if !ok {
+		return nil, errors.Errorf("invalid swap metric %s", metric)
+	}
+
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(
+		sb.As(fmt.Sprintf("width_bucket(%s, %s::float8[])", col, sb.Var(pq.Array(bounds))), "bucket"),
+		"COUNT(*)",
+	)
+	sb.From("swaps")
+	buildSwapsFilter(sb, asset, from, to)
+	sb.GroupBy("bucket")
+
+	q, args := sb.Build()
+	rows, err := s.db().QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "getSwapsHistogram failed")
+	}
+	defer rows.Close()
+
+	counts := make([]int64, len(bounds)+1)
+	for rows.Next() {
+		var bucket sql.NullInt64
+		var count int64
+		if err := rows.Scan(&bucket, &count); err != nil {
+			return nil, errors.Wrap(err, "getSwapsHistogram failed")
+		}
+		// Swaps with null metric don't belong to any bucket.
+		if !bucket.Valid {
+			continue
+		}
+		counts[bucket.Int64] = count
+	}
+	return counts, nil
+}
+
+// GetTopSwappers returns the addresses with the highest swap volume in the given pool
+// between from and to. All pools are included if the asset is empty.
+func (s *Client) GetTopSwappers(ctx context.Context, asset common.Asset, from, to time.Time, limit int64) ([]models.Swapper, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(
+		"from_address",
+		"COUNT(*)",
+		sb.As("SUM(ABS(runeAmt))", "volume"),
+		fmt.Sprintf("SUM(%s)", swapFeeInRune),
+	)
+	sb.From("swaps")
+	buildSwapsFilter(sb, asset, from, to)
+	sb.GroupBy("from_address")
+	sb.OrderBy("volume").Desc()
+	sb.Limit(int(limit))
+
+	q, args := sb.Build()
+	rows, err := s.db().QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "getTopSwappers failed")
+	}
+	defer rows.Close()
+
+	swappers := []models.Swapper{}
+	for rows.Next() {
+		var address string
+		var count int64
+		var volume, fees sql.NullFloat64
+		if err := rows.Scan(&address, &count, &volume, &fees); err != nil {
+			return nil, errors.Wrap(err, "getTopSwappers failed")
+		}
+		swappers = append(swappers, models.Swapper{
+			Address:   common.Address(address),
+			SwapCount: count,
+			Volume:    int64(volume.Float64),
+			Fees:      int64(fees.Float64),
+		})
+	}
+	return swappers, nil
+}
@@ -0,0 +1,117 @@
+package timescale
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/models"
+	. "gopkg.in/check.v1"
+)
+
+func newAnalyticsSwap(id int64, from common.Address, pool common.Asset, in, out common.Coin, slip, fee int64, now time.Time) models.EventSwap {
+	return models.EventSwap{
+		Event: models.Event{
+			Time:   now,
+			ID:     id,
+			Status: "Success",
+			Height: id,
+			Type:   "swap",
+			InTx: common.Tx{
+				ID:          common.TxID(fmt.Sprintf("IN%d", id)),
+				Chain:       pool.Chain,
+				FromAddress: from,
+				Coins:       common.Coins{in},
+			},
+			OutTxs: common.Txs{
+				{
+					ID:        common.TxID(fmt.Sprintf("OUT%d", id)),
+					Chain:     pool.Chain,
+					ToAddress: from,
+					Coins:     common.Coins{out},
+				},
+			},
+		},
+		Pool:         pool,
+		TradeSlip:    slip,
+		LiquidityFee: fee,
+	}
+}
+
+func assertDistribution(c *C, obtained, expected models.Distribution) {
+	const epsilon = 1e-9
+	c.Assert(math.Abs(obtained.Average-expected.Average) < epsilon, Equals, true, Commentf("average %v, expected %v", obtained.Average, expected.Average))
+	c.Assert(math.Abs(obtained.P50-expected.P50) < epsilon, Equals, true, Commentf("p50 %v, expected %v", obtained.P50, expected.P50))
+	c.Assert(math.Abs(obtained.P90-expected.P90) < epsilon, Equals, true, Commentf("p90 %v, expected %v", obtained.P90, expected.P90))
+	c.Assert(math.Abs(obtained.P99-expected.P99) < epsilon, Equals, true, Commentf("p99 %v, expected %v", obtained.P99, expected.P99))
+}
+
+func (s *TimeScaleSuite) TestSwapAnalytics(c *C) {
+	now := time.Now()
+	runeAsset := common.RuneAsset()
+	swaps := []models.EventSwap{
+		// Trade size 100, slip 0.01 and fee 10 BNB = 20 RUNE
+		newAnalyticsSwap(1, "bnbA", common.BNBAsset, common.NewCoin(runeAsset, 100), common.NewCoin(common.BNBAsset, 50), 100, 10, now),
+		// Trade size 200, slip 0.05 and fee 30 RUNE
+		newAnalyticsSwap(2, "bnbB", common.BNBAsset, common.NewCoin(common.BNBAsset, 40), common.NewCoin(runeAsset, 200), 500, 30, now),
+		// Trade size 300, slip 0.2 and fee 5 BNB = 15 RUNE
+		newAnalyticsSwap(3, "bnbA", common.BNBAsset, common.NewCoin(runeAsset, 300), common.NewCoin(common.BNBAsset, 100), 2000, 5, now),
+		// Trade size 1000, slip 0.005 and no fee
+		newAnalyticsSwap(4, "bnbC", common.BTCAsset, common.NewCoin(runeAsset, 1000), common.NewCoin(common.BTCAsset, 1), 50, 0, now),
+	}
+	for i := range swaps {
+		err := s.Store.CreateSwapRecord(&swaps[i])
+		c.Assert(err, IsNil)
+	}
+
+	stats, err := s.Store.GetSwapsStats(context.Background(), common.BNBAsset, now.Add(-time.Hour), now)
+	c.Assert(err, IsNil)
+	c.Assert(stats.SwapCount, Equals, int64(3))
+	assertDistribution(c, stats.TradeSize, models.Distribution{Average: 200, P50: 200, P90: 280, P99: 298})
+	assertDistribution(c, stats.Slip, models.Distribution{Average: 0.26 / 3, P50: 0.05, P90: 0.17, P99: 0.197})
+	assertDistribution(c, stats.Fee, models.Distribution{Average: 65.0 / 3, P50: 20, P90: 28, P99: 29.8})
+
+	stats, err = s.Store.GetSwapsStats(context.Background(), common.EmptyAsset, now.Add(-time.Hour), now)
+	c.Assert(err, IsNil)
+	c.Assert(stats.SwapCount, Equals, int64(4))
+	assertDistribution(c, stats.TradeSize, models.Distribution{Average: 400, P50: 250, P90: 790, P99: 979})
+
+	stats, err = s.Store.GetSwapsStats(context.Background(), common.BTCAsset, now.Add(-2*time.Hour), now.Add(-time.Hour))
+	c.Assert(err, IsNil)
+	c.Assert(stats.SwapCount, Equals, int64(0))
+
+	counts, err := s.Store.GetSwapsHistogram(context.Background(), common.BNBAsset, models.SwapMetricSlip, []float64{0.02, 0.1}, now.Add(-time.Hour), now)
+	c.Assert(err, IsNil)
+	c.Assert(counts, DeepEquals, []int64{1, 1, 1})
+
+	counts, err = s.Store.GetSwapsHistogram(context.Background(), common.EmptyAsset, models.SwapMetricTradeSize, []float64{150, 500}, now.Add(-time.Hour), now)
+	c.Assert(err, IsNil)
+	c.Assert(counts, DeepEquals, []int64{1, 2, 1})
+
+	_, err = s.Store.GetSwapsHistogram(context.Background(), common.BNBAsset, models.SwapMetric("invalid"), []float64{1}, now.Add(-time.Hour), now)
+	c.Assert(err, NotNil)
+
+	swappers, err := s.Store.GetTopSwappers(context.Background(), common.EmptyAsset, now.Add(-time.Hour), now, 10)
+	c.Assert(err, IsNil)
+	c.Assert(swappers, DeepEquals, []models.Swapper{
+		{Address: "bnbC", SwapCount: 1, Volume: 1000, Fees: 0},
+		{Address: "bnbA", SwapCount: 2, Volume: 400, Fees: 35},
+		{Address: "bnbB", SwapCount: 1, Volume: 200, Fees: 30},
+	})
+
+	swappers, err = s.Store.GetTopSwappers(context.Background(), common.EmptyAsset, now.Add(-time.Hour), now, 2)
+	c.Assert(err, IsNil)
+	c.Assert(swappers, DeepEquals, []models.Swapper{
+		{Address: "bnbC", SwapCount: 1, Volume: 1000, Fees: 0},
+		{Address: "bnbA", SwapCount: 2, Volume: 400, Fees: 35},
+	})
+
+	swappers, err = s.Store.GetTopSwappers(context.Background(), common.BNBAsset, now.Add(-time.Hour), now, 10)
+	c.Assert(err, IsNil)
+	c.Assert(swappers, DeepEquals, []models.Swapper{
+		{Address: "bnbA", SwapCount: 2, Volume: 400, Fees: 35},
+		{Address: "bnbB", SwapCount: 1, Volume: 200, Fees: 30},
+	})
+}
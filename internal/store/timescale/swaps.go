@@ -1,6 +1,7 @@
 package timescale
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
@@ -58,7 +59,7 @@ func (s *Client) CreateSwapRecord(record *models.EventSwap) error {
 			runeAmt,
 			assetAmt
 		)  VALUES ( $1, $2, $3, $4, $5, $6, $7, $8, $9, $10 ) RETURNING event_id`, models.ModelSwapsTable)
-	_, err = s.db.Exec(query,
+	_, err = s.db().Exec(query,
 		record.Event.Time,
 		record.Event.ID,
 		record.Event.InTx.FromAddress,
@@ -115,7 +116,7 @@ func (s *Client) UpdateSwapRecord(record models.EventSwap) error {
 			   assetamt = assetamt - $2
 		WHERE  event_id = $3 returning event_id`, models.ModelSwapsTable)
 
-	_, err := s.db.Exec(query,
+	_, err := s.db().Exec(query,
 		runeAmt,
 		assetAmt,
 		record.Event.ID,
@@ -124,7 +125,7 @@ func (s *Client) UpdateSwapRecord(record models.EventSwap) error {
 		return errors.Wrap(err, "Failed to prepareNamed query for SwapRecord")
 	}
 
-	pool, err := s.GetEventPool(record.ID)
+	pool, err := s.GetEventPool(context.Background(), record.ID)
 	if err != nil {
 		return errors.Wrapf(err, "could not get pool of event %d", record.ID)
 	}
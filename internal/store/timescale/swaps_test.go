@@ -1,6 +1,8 @@
 package timescale
 
 import (
+	"context"
+
 	"gitlab.com/thorchain/midgard/internal/common"
 	. "gopkg.in/check.v1"
 )
@@ -9,28 +11,28 @@ func (s *TimeScaleSuite) TestSwap(c *C) {
 	asset, err := common.NewAsset("BNB.BNB")
 	c.Assert(err, IsNil)
 
-	assetSwapped, err := s.Store.assetSwap(asset)
+	assetSwapped, err := s.Store.assetSwap(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetSwapped, Equals, int64(0))
-	runeSwapped, err := s.Store.runeSwapped(asset)
+	runeSwapped, err := s.Store.runeSwapped(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(runeSwapped, Equals, int64(0))
 
 	err = s.Store.CreateSwapRecord(&swapSellBnb2RuneEvent4)
 	c.Assert(err, IsNil)
-	assetSwapped, err = s.Store.assetSwap(asset)
+	assetSwapped, err = s.Store.assetSwap(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetSwapped, Equals, int64(20000000))
-	runeSwapped, err = s.Store.runeSwapped(asset)
+	runeSwapped, err = s.Store.runeSwapped(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(runeSwapped, Equals, int64(-1))
 
 	err = s.Store.CreateSwapRecord(&swapBuyRune2BnbEvent3)
 	c.Assert(err, IsNil)
-	assetSwapped, err = s.Store.assetSwap(asset)
+	assetSwapped, err = s.Store.assetSwap(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetSwapped, Equals, int64(0))
-	runeSwapped, err = s.Store.runeSwapped(asset)
+	runeSwapped, err = s.Store.runeSwapped(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(runeSwapped, Equals, int64(199999999))
 }
@@ -39,10 +41,10 @@ func (s *TimeScaleSuite) TestUpdateSwap(c *C) {
 	asset, err := common.NewAsset("BNB.BNB")
 	c.Assert(err, IsNil)
 
-	assetSwapped, err := s.Store.assetSwap(asset)
+	assetSwapped, err := s.Store.assetSwap(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetSwapped, Equals, int64(0))
-	runeSwapped, err := s.Store.runeSwapped(asset)
+	runeSwapped, err := s.Store.runeSwapped(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(runeSwapped, Equals, int64(0))
 
@@ -51,20 +53,20 @@ func (s *TimeScaleSuite) TestUpdateSwap(c *C) {
 	swapEvent.OutTxs = nil
 	err = s.Store.CreateSwapRecord(&swapEvent)
 	c.Assert(err, IsNil)
-	assetSwapped, err = s.Store.assetSwap(asset)
+	assetSwapped, err = s.Store.assetSwap(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetSwapped, Equals, int64(20000000))
-	runeSwapped, err = s.Store.runeSwapped(asset)
+	runeSwapped, err = s.Store.runeSwapped(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(runeSwapped, Equals, int64(0))
 
 	swapEvent.OutTxs = swapSellBnb2RuneEvent4.OutTxs
 	err = s.Store.UpdateSwapRecord(swapEvent)
 	c.Assert(err, IsNil)
-	assetSwapped, err = s.Store.assetSwap(asset)
+	assetSwapped, err = s.Store.assetSwap(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetSwapped, Equals, int64(20000000))
-	runeSwapped, err = s.Store.runeSwapped(asset)
+	runeSwapped, err = s.Store.runeSwapped(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(runeSwapped, Equals, int64(-1))
 }
@@ -73,10 +75,10 @@ func (s *TimeScaleSuite) TestSwapFee(c *C) {
 	asset, err := common.NewAsset("BNB.BNB")
 	c.Assert(err, IsNil)
 
-	assetSwapped, err := s.Store.assetSwap(asset)
+	assetSwapped, err := s.Store.assetSwap(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetSwapped, Equals, int64(0))
-	runeSwapped, err := s.Store.runeSwapped(asset)
+	runeSwapped, err := s.Store.runeSwapped(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(runeSwapped, Equals, int64(0))
 
@@ -85,10 +87,10 @@ func (s *TimeScaleSuite) TestSwapFee(c *C) {
 	swapEvent.OutTxs = nil
 	err = s.Store.CreateSwapRecord(&swapEvent)
 	c.Assert(err, IsNil)
-	assetSwapped, err = s.Store.assetSwap(asset)
+	assetSwapped, err = s.Store.assetSwap(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetSwapped, Equals, int64(20000000))
-	runeSwapped, err = s.Store.runeSwapped(asset)
+	runeSwapped, err = s.Store.runeSwapped(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(runeSwapped, Equals, int64(0))
 
@@ -106,10 +108,10 @@ func (s *TimeScaleSuite) TestSwapFee(c *C) {
 	}
 	err = s.Store.UpdateSwapRecord(swapEvent)
 	c.Assert(err, IsNil)
-	assetSwapped, err = s.Store.assetSwap(asset)
+	assetSwapped, err = s.Store.assetSwap(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetSwapped, Equals, int64(20000000))
-	runeSwapped, err = s.Store.runeSwapped(asset)
+	runeSwapped, err = s.Store.runeSwapped(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(runeSwapped, Equals, int64(-2))
 
@@ -127,10 +129,10 @@ func (s *TimeScaleSuite) TestSwapFee(c *C) {
 	}
 	err = s.Store.UpdateSwapRecord(swapEvent)
 	c.Assert(err, IsNil)
-	assetSwapped, err = s.Store.assetSwap(asset)
+	assetSwapped, err = s.Store.assetSwap(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetSwapped, Equals, int64(19999990))
-	runeSwapped, err = s.Store.runeSwapped(asset)
+	runeSwapped, err = s.Store.runeSwapped(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(runeSwapped, Equals, int64(-2))
 
@@ -138,10 +140,10 @@ func (s *TimeScaleSuite) TestSwapFee(c *C) {
 	swapEvent.OutTxs = swapSellBnb2RuneEvent4.OutTxs
 	err = s.Store.UpdateSwapRecord(swapEvent)
 	c.Assert(err, IsNil)
-	assetSwapped, err = s.Store.assetSwap(asset)
+	assetSwapped, err = s.Store.assetSwap(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(assetSwapped, Equals, int64(19999990))
-	runeSwapped, err = s.Store.runeSwapped(asset)
+	runeSwapped, err = s.Store.runeSwapped(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(runeSwapped, Equals, int64(-3))
 }
@@ -1,9 +1,12 @@
 package timescale
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/huandu/go-sqlbuilder"
@@ -20,31 +23,48 @@ import (
 )
 
 type Client struct {
-	db            *sqlx.DB
+	conn          atomic.Value
+	cfg           config.TimeScaleConfiguration
 	logger        zerolog.Logger
 	migrationsDir string
 	mu            sync.RWMutex
 	pools         map[string]*models.PoolBasics
 }
 
+// connection is the database handle serving a version of the derived tables.
+type connection struct {
+	db      *sqlx.DB
+	schema  string
+	version int
+}
+
 func NewClient(cfg config.TimeScaleConfiguration) (*Client, error) {
 	if err := createDB(cfg.Host, cfg.Port, cfg.Sslmode, cfg.UserName, cfg.Password, cfg.Database); err != nil {
 		return nil, errors.Wrapf(err, "could not create database %s", cfg.Database)
 	}
 
 	logger := log.With().Str("module", "timescale").Logger()
-	db, err := openDB(cfg)
+	db, err := openDB(cfg, publicSchema)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not open database connection")
 	}
-	db.SetMaxOpenConns(cfg.MaxConnections)
-	db.SetMaxIdleConns(cfg.MaxConnections)
-	db.SetConnMaxLifetime(cfg.ConnectionMaxLifetime)
+	conn, err := liveConnection(db)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get the schema of derived tables")
+	}
+	if conn.schema != publicSchema {
+		db.Close()
+		conn.db, err = openDB(cfg, conn.schema)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not open database connection")
+		}
+	}
 	cli := &Client{
-		db:            db,
+		cfg:           cfg,
 		logger:        logger,
 		migrationsDir: cfg.MigrationsDir,
 	}
+	cli.conn.Store(conn)
 
 	if err := cli.MigrationsUp(); err != nil {
 		return nil, errors.Wrap(err, "failed to run migrations up")
@@ -61,16 +81,28 @@ func NewClient(cfg config.TimeScaleConfiguration) (*Client, error) {
 	return cli, nil
 }
 
-func (s *Client) Ping() error {
-	return s.db.Ping()
+func (s *Client) db() *sqlx.DB {
+	return s.conn.Load().(connection).db
 }
 
-func openDB(cfg config.TimeScaleConfiguration) (*sqlx.DB, error) {
+func (s *Client) Ping(ctx context.Context) error {
+	return s.db().PingContext(ctx)
+}
+
+// openDB opens a connection which resolves the derived tables in the given schema
+// and the rest of tables in public schema.
+func openDB(cfg config.TimeScaleConfiguration, schema string) (*sqlx.DB, error) {
 	connStr := fmt.Sprintf("user=%s dbname=%s sslmode=%v password=%v host=%v port=%v", cfg.UserName, cfg.Database, cfg.Sslmode, cfg.Password, cfg.Host, cfg.Port)
+	if schema != publicSchema {
+		connStr += fmt.Sprintf(" search_path=%s,%s", schema, publicSchema)
+	}
 	db, err := sqlx.Open("postgres", connStr)
 	if err != nil {
 		return &sqlx.DB{}, err
 	}
+	db.SetMaxOpenConns(cfg.MaxConnections)
+	db.SetMaxIdleConns(cfg.MaxConnections)
+	db.SetConnMaxLifetime(cfg.ConnectionMaxLifetime)
 
 	return db, nil
 }
@@ -100,7 +132,7 @@ func createDB(host string, port int, ssl, username, password, name string) error
 }
 
 func (s *Client) MigrationsUp() error {
-	n, err := migrate.Exec(s.db.DB, "postgres", &migrate.FileMigrationSource{Dir: s.migrationsDir}, migrate.Up)
+	n, err := migrate.Exec(s.db().DB, "postgres", &migrate.FileMigrationSource{Dir: s.migrationsDir}, migrate.Up)
 	if err != nil {
 		return err
 	}
@@ -109,7 +141,7 @@ func (s *Client) MigrationsUp() error {
 }
 
 func (s *Client) MigrationsDown() error {
-	n, err := migrate.Exec(s.db.DB, "postgres", &migrate.FileMigrationSource{Dir: s.migrationsDir}, migrate.Down)
+	n, err := migrate.Exec(s.db().DB, "postgres", &migrate.FileMigrationSource{Dir: s.migrationsDir}, migrate.Down)
 	if err != nil {
 		return err
 	}
@@ -117,7 +149,7 @@ func (s *Client) MigrationsDown() error {
 	return nil
 }
 
-func (s *Client) queryTimestampInt64(sb *sqlbuilder.SelectBuilder, from, to *time.Time) (int64, error) {
+func (s *Client) queryTimestampInt64(ctx context.Context, sb *sqlbuilder.SelectBuilder, from, to *time.Time) (int64, error) {
 	if from != nil {
 		sb.Where(sb.GE("time", *from))
 	}
@@ -127,7 +159,7 @@ func (s *Client) queryTimestampInt64(sb *sqlbuilder.SelectBuilder, from, to *tim
 	query, args := sb.Build()
 
 	var value sql.NullInt64
-	row := s.db.QueryRow(query, args...)
+	row := s.db().QueryRowContext(ctx, query, args...)
 
 	err := row.Scan(&value)
 	return value.Int64, err
@@ -163,6 +195,8 @@ func (s *Client) fetchAllPoolsBalances() error {
 		SUM(rune_amount) FILTER (WHERE event_type = 'gas'),
 		SUM(asset_amount) FILTER (WHERE event_type = 'add'),
 		SUM(rune_amount) FILTER (WHERE event_type = 'add'),
+		SUM(asset_amount) FILTER (WHERE event_type = 'slash'),
+		SUM(rune_amount) FILTER (WHERE event_type = 'slash'),
 		SUM(units) FILTER (WHERE events.status = 'Success'),
 		COUNT(*) FILTER (WHERE units > 0 AND events.status = 'Success'),
 		COUNT(*) FILTER (WHERE units < 0 AND events.status = 'Success'),
@@ -171,7 +205,7 @@ func (s *Client) fetchAllPoolsBalances() error {
 		LEFT JOIN events
 		ON events.id = pools_history.event_id
 		GROUP BY pool`
-	rows, err := s.db.Queryx(q)
+	rows, err := s.db().Queryx(q)
 	if err != nil {
 		return err
 	}
@@ -191,6 +225,8 @@ func (s *Client) fetchAllPoolsBalances() error {
 			gasReplenished sql.NullInt64
 			assetAdded     sql.NullInt64
 			runeAdded      sql.NullInt64
+			assetSlashed   sql.NullInt64
+			runeSlashed    sql.NullInt64
 			units          sql.NullInt64
 			stakeCount     sql.NullInt64
 			withdrawCount  sql.NullInt64
@@ -198,7 +234,7 @@ func (s *Client) fetchAllPoolsBalances() error {
 		)
 		if err := rows.Scan(&pool, &assetDepth, &assetStaked, &assetWithdrawn,
 			&runeDepth, &runeStaked, &runeWithdrawn, &reward, &gasUsed, &gasReplenished, &assetAdded, &runeAdded,
-			&units, &stakeCount, &withdrawCount, &dateCreated); err != nil {
+			&assetSlashed, &runeSlashed, &units, &stakeCount, &withdrawCount, &dateCreated); err != nil {
 			return err
 		}
 		asset, _ := common.NewAsset(pool)
@@ -215,6 +251,8 @@ func (s *Client) fetchAllPoolsBalances() error {
 			GasReplenished: gasReplenished.Int64,
 			AssetAdded:     assetAdded.Int64,
 			RuneAdded:      runeAdded.Int64,
+			AssetSlashed:   assetSlashed.Int64,
+			RuneSlashed:    runeSlashed.Int64,
 			Units:          units.Int64,
 			StakeCount:     stakeCount.Int64,
 			WithdrawCount:  withdrawCount.Int64,
@@ -232,7 +270,7 @@ func (s *Client) fetchAllPoolsStatus() error {
 			WHERE status > 0
 		) t 
 		WHERE row_num = 1`
-	rows, err := s.db.Queryx(q)
+	rows, err := s.db().Queryx(q)
 	if err != nil {
 		return err
 	}
@@ -263,7 +301,7 @@ func (s *Client) fetchAllPoolsSwap() error {
 		COUNT(*) FILTER (WHERE runeAmt < 0)
 		FROM swaps
 		GROUP BY pool`
-	rows, err := s.db.Queryx(q)
+	rows, err := s.db().Queryx(q)
 	if err != nil {
 		return err
 	}
@@ -338,6 +376,9 @@ func (s *Client) updatePoolCache(change *models.PoolChange) {
 	case "add":
 		p.AssetAdded += change.AssetAmount
 		p.RuneAdded += change.RuneAmount
+	case "slash":
+		p.AssetSlashed += change.AssetAmount
+		p.RuneSlashed += change.RuneAmount
 	}
 	switch change.SwapType {
 	case models.SwapTypeBuy:
@@ -373,52 +414,72 @@ func (s *Client) deleteLatestBlock() error {
 }
 
 func (s *Client) DeleteBlock(height int64) error {
+	err := s.deleteHeightRange(height, math.MaxInt64)
+	if err != nil {
+		return err
+	}
+	s.logger.Info().Int64("height", height).Msg("latest block records have been deleted successfully")
+	return nil
+}
+
+func (s *Client) deleteHeightRange(from, to int64) error {
 	var err error
-	if err = s.deleteCoinsAtHeight(height); err != nil {
-		return errors.Wrapf(err, "could not delete coins at height %d", height)
+	if err = s.deleteCoinsInRange(from, to); err != nil {
+		return errors.Wrapf(err, "could not delete coins at heights [%d, %d]", from, to)
 	}
-	if err = s.deleteTxsAtHeight(height); err != nil {
-		return errors.Wrapf(err, "could not delete txs at height %d", height)
+	if err = s.deleteTxsInRange(from, to); err != nil {
+		return errors.Wrapf(err, "could not delete txs at heights [%d, %d]", from, to)
 	}
-	if err = s.deleteSwapsAtHeight(height); err != nil {
-		return errors.Wrapf(err, "could not delete swaps at height %d", height)
+	if err = s.deleteSwapsInRange(from, to); err != nil {
+		return errors.Wrapf(err, "could not delete swaps at heights [%d, %d]", from, to)
 	}
-	if err = s.deletePoolsHistoryAtHeight(height); err != nil {
-		return errors.Wrapf(err, "could not delete pools history at height %d", height)
+	if err = s.deleteDoubleSwapsInRange(from, to); err != nil {
+		return errors.Wrapf(err, "could not delete double swaps at heights [%d, %d]", from, to)
 	}
-	if err = s.deleteEventsAtHeight(height); err != nil {
-		return errors.Wrapf(err, "could not delete events at height %d", height)
+	if err = s.deletePoolsHistoryInRange(from, to); err != nil {
+		return errors.Wrapf(err, "could not delete pools history at heights [%d, %d]", from, to)
+	}
+	if err = s.deleteTxFeesInRange(from, to); err != nil {
+		return errors.Wrapf(err, "could not delete tx fees at heights [%d, %d]", from, to)
+	}
+	if err = s.deleteBlockRewardsInRange(from, to); err != nil {
+		return errors.Wrapf(err, "could not delete block rewards at heights [%d, %d]", from, to)
+	}
+	if err = s.deleteQuarantinedEventsInRange(from, to); err != nil {
+		return errors.Wrapf(err, "could not delete quarantined events at heights [%d, %d]", from, to)
+	}
+	if err = s.deleteEventsInRange(from, to); err != nil {
+		return errors.Wrapf(err, "could not delete events at heights [%d, %d]", from, to)
 	}
-	s.logger.Info().Int64("height", height).Msg("latest block records have been deleted successfully")
 	return nil
 }
 
-func (s *Client) deleteCoinsAtHeight(height int64) error {
-	q := `DELETE FROM coins USING events WHERE coins.event_id = events.id AND events.height >= $1`
-	_, err := s.db.Exec(q, height)
+func (s *Client) deleteCoinsInRange(from, to int64) error {
+	q := `DELETE FROM coins USING events WHERE coins.event_id = events.id AND events.height BETWEEN $1 AND $2`
+	_, err := s.db().Exec(q, from, to)
 	return err
 }
 
-func (s *Client) deleteTxsAtHeight(height int64) error {
-	q := `DELETE FROM txs USING events WHERE txs.event_id = events.id AND events.height >= $1`
-	_, err := s.db.Exec(q, height)
+func (s *Client) deleteTxsInRange(from, to int64) error {
+	q := `DELETE FROM txs USING events WHERE txs.event_id = events.id AND events.height BETWEEN $1 AND $2`
+	_, err := s.db().Exec(q, from, to)
 	return err
 }
 
-func (s *Client) deleteSwapsAtHeight(height int64) error {
-	q := `DELETE FROM swaps USING events WHERE swaps.event_id = events.id AND events.height >= $1`
-	_, err := s.db.Exec(q, height)
+func (s *Client) deleteSwapsInRange(from, to int64) error {
+	q := `DELETE FROM swaps USING events WHERE swaps.event_id = events.id AND events.height BETWEEN $1 AND $2`
+	_, err := s.db().Exec(q, from, to)
 	return err
 }
 
-func (s *Client) deletePoolsHistoryAtHeight(height int64) error {
-	q := `DELETE FROM pools_history WHERE height = $1`
-	_, err := s.db.Exec(q, height)
+func (s *Client) deletePoolsHistoryInRange(from, to int64) error {
+	q := `DELETE FROM pools_history WHERE height BETWEEN $1 AND $2`
+	_, err := s.db().Exec(q, from, to)
 	return err
 }
 
-func (s *Client) deleteEventsAtHeight(height int64) error {
-	q := `DELETE FROM events WHERE height >= $1`
-	_, err := s.db.Exec(q, height)
+func (s *Client) deleteEventsInRange(from, to int64) error {
+	q := `DELETE FROM events WHERE height BETWEEN $1 AND $2`
+	_, err := s.db().Exec(q, from, to)
 	return err
 }
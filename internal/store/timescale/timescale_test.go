@@ -1,6 +1,7 @@
 package timescale
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -15,7 +16,7 @@ import (
 	"gitlab.com/thorchain/midgard/pkg/helpers"
 )
 
-var tables = []string{"coins", "events", "pools_history", "swaps", "txs"}
+var tables = []string{"api_key_usage", "api_keys", "block_rewards", "coins", "double_swaps", "events", "pools_history", "price_deviation_alerts", "price_deviations", "quarantined_events", "raw_events", "reserve_history", "swaps", "tx_fees", "txs"}
 
 func Test(t *testing.T) {
 	TestingT(t)
@@ -2125,7 +2126,7 @@ func MigrationDown(c *C, migrations Migrations) {
 func DbCleaner(c *C, store *Client) {
 	for _, table := range tables {
 		query := fmt.Sprintf(`DELETE FROM %s WHERE 1 = 1`, table)
-		_, err := store.db.Exec(query)
+		_, err := store.db().Exec(query)
 		if err != nil {
 			c.Fatal(err.Error())
 		}
@@ -2143,7 +2144,7 @@ func (s *TimeScaleSuite) TestDeleteLatestBlock(c *C) {
 	height, err := s.Store.GetLastHeight()
 	c.Assert(err, IsNil)
 	c.Assert(height, Equals, int64(7))
-	txsCount, err := s.Store.GetTxsCount(nil, nil)
+	txsCount, err := s.Store.GetTxsCount(context.Background(), nil, nil)
 	c.Assert(err, IsNil)
 	c.Assert(txsCount, Equals, uint64(3))
 
@@ -2153,7 +2154,7 @@ func (s *TimeScaleSuite) TestDeleteLatestBlock(c *C) {
 	height, err = s.Store.GetLastHeight()
 	c.Assert(err, IsNil)
 	c.Assert(height, Equals, int64(3))
-	txsCount, err = s.Store.GetTxsCount(nil, nil)
+	txsCount, err = s.Store.GetTxsCount(context.Background(), nil, nil)
 	c.Assert(err, IsNil)
 	c.Assert(txsCount, Equals, uint64(2))
 }
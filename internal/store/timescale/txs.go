@@ -1,6 +1,7 @@
 package timescale
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
@@ -12,22 +13,22 @@ import (
 )
 
 // GetTxDetails returns events with pagination and given query params.
-func (s *Client) GetTxDetails(address common.Address, txID common.TxID, asset common.Asset, eventTypes []string, offset, limit int64) ([]models.TxDetails, int64, error) {
-	txs, err := s.getTxDetails(address, txID, asset, eventTypes, offset, limit)
+func (s *Client) GetTxDetails(ctx context.Context, address common.Address, txID common.TxID, asset common.Asset, eventTypes []string, offset, limit int64) ([]models.TxDetails, int64, error) {
+	txs, err := s.getTxDetails(ctx, address, txID, asset, eventTypes, offset, limit)
 	if err != nil {
 		return nil, 0, errors.Wrap(err, "GetTxDetails failed")
 	}
 
-	count, err := s.getTxsCount(address, txID, asset, eventTypes)
+	count, err := s.getTxsCount(ctx, address, txID, asset, eventTypes)
 	if err != nil {
 		return nil, 0, errors.Wrap(err, "GetTxDetails failed")
 	}
 	return txs, count, nil
 }
 
-func (s *Client) getTxDetails(address common.Address, txID common.TxID, asset common.Asset, eventTypes []string, offset, limit int64) ([]models.TxDetails, error) {
+func (s *Client) getTxDetails(ctx context.Context, address common.Address, txID common.TxID, asset common.Asset, eventTypes []string, offset, limit int64) ([]models.TxDetails, error) {
 	q, args := s.buildEventsQuery(address.String(), txID.String(), asset.String(), eventTypes, false, limit, offset)
-	rows, err := s.db.Queryx(q, args...)
+	rows, err := s.db().QueryxContext(ctx, q, args...)
 	if err != nil {
 		return nil, errors.Wrap(err, "getTxDetails failed")
 	}
@@ -44,12 +45,12 @@ func (s *Client) getTxDetails(address common.Address, txID common.TxID, asset co
 		events = append(events, uint64(eventID))
 	}
 
-	return s.processEvents(events)
+	return s.processEvents(ctx, events)
 }
 
-func (s *Client) getTxsCount(address common.Address, txID common.TxID, asset common.Asset, eventTypes []string) (int64, error) {
+func (s *Client) getTxsCount(ctx context.Context, address common.Address, txID common.TxID, asset common.Asset, eventTypes []string) (int64, error) {
 	q, args := s.buildEventsQuery(address.String(), txID.String(), asset.String(), eventTypes, true, 0, 0)
-	row := s.db.QueryRow(q, args...)
+	row := s.db().QueryRowContext(ctx, q, args...)
 
 	var count sql.NullInt64
 	if err := row.Scan(&count); err != nil {
@@ -61,87 +62,145 @@ func (s *Client) getTxsCount(address common.Address, txID common.TxID, asset com
 	return count.Int64, nil
 }
 
+// txlessEventTypes are the types of events which are listed even though they
+// don't have any transaction.
+var txlessEventTypes = []string{"slash"}
+
 func (s *Client) buildEventsQuery(address, txID, asset string, eventTypes []string, isCount bool, limit, offset int64) (string, []interface{}) {
-	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
-	if isCount {
-		sb.Select("COUNT(DISTINCT(txs.event_id))")
-	} else {
-		sb.Select("DISTINCT(txs.event_id), events.height")
-		sb.OrderBy("events.height")
-		sb.Desc()
-		sb.Limit(int(limit))
-		sb.Offset(int(offset))
-	}
-	sb.From("txs")
-	sb.JoinWithOption(sqlbuilder.LeftJoin, "events", "txs.event_id = events.id")
+	txsSb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	txsSb.Select(txsSb.As("DISTINCT(txs.event_id)", "event_id"), "events.height")
+	txsSb.From("txs")
+	txsSb.JoinWithOption(sqlbuilder.LeftJoin, "events", "txs.event_id = events.id")
 	if address != "" {
-		sb.Where(sb.Or(sb.Equal("txs.from_address", address), sb.Equal("txs.to_address", address)))
+		txsSb.Where(txsSb.Or(txsSb.Equal("txs.from_address", address), txsSb.Equal("txs.to_address", address)))
 	}
 	if txID != "" {
-		sb.Where(sb.Equal("txs.tx_hash", txID))
+		txsSb.Where(txsSb.Equal("txs.tx_hash", txID))
 	}
 	if asset != "." {
-		sb.JoinWithOption(sqlbuilder.LeftJoin, "pools_history", "pools_history.event_id = events.id")
-		sb.Where(sb.Equal("pools_history.pool", asset))
+		txsSb.JoinWithOption(sqlbuilder.LeftJoin, "pools_history", "pools_history.event_id = events.id")
+		txsSb.Where(txsSb.Equal("pools_history.pool", asset))
 	}
 	if len(eventTypes) > 0 {
 		var types []interface{}
 		for _, ev := range eventTypes {
 			types = append(types, ev)
 		}
-		sb.Where(sb.In("events.type", types...))
+		txsSb.Where(txsSb.In("events.type", types...))
+	}
+	txsSb.Where("events.type != ''")
+
+	// The events without transaction can't match the address or the tx id.
+	var events sqlbuilder.Builder = txsSb
+	if address == "" && txID == "" {
+		if txless := buildTxlessEventsQuery(asset, eventTypes); txless != nil {
+			events = sqlbuilder.Buildf("%v UNION %v", txsSb, txless)
+		}
 	}
-	sb.Where("events.type != ''")
+
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	if isCount {
+		sb.Select("COUNT(*)")
+	} else {
+		sb.Select("event_id", "height")
+		sb.OrderBy("height")
+		sb.Desc()
+		sb.Limit(int(limit))
+		sb.Offset(int(offset))
+	}
+	sb.From(sb.BuilderAs(events, "listed"))
 	return sb.Build()
 }
 
-func (s *Client) processEvents(events []uint64) ([]models.TxDetails, error) {
+// buildTxlessEventsQuery returns the query of the events without transaction
+// of the given pool and types, nil if none of the types is listed without
+// transaction.
+func buildTxlessEventsQuery(asset string, eventTypes []string) sqlbuilder.Builder {
+	types := txlessEventTypes
+	if len(eventTypes) > 0 {
+		types = nil
+		for _, typ := range txlessEventTypes {
+			for _, ev := range eventTypes {
+				if ev == typ {
+					types = append(types, typ)
+					break
+				}
+			}
+		}
+		if len(types) == 0 {
+			return nil
+		}
+	}
+
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(sb.As("DISTINCT(events.id)", "event_id"), "events.height")
+	sb.From("events")
+	if asset != "." {
+		sb.Join("pools_history", "pools_history.event_id = events.id")
+		sb.Where(sb.Equal("pools_history.pool", asset))
+	}
+	sb.Where(sb.In("events.type", sqlbuilder.Flatten(types)...))
+	return sb
+}
+
+func (s *Client) processEvents(ctx context.Context, events []uint64) ([]models.TxDetails, error) {
 	var txData []models.TxDetails
 
 	for _, eventId := range events {
 
-		eventDate, height, eventType, status, err := s.eventBasic(eventId)
+		eventDate, height, eventType, status, err := s.eventBasic(ctx, eventId)
 		if err != nil {
 			return nil, errors.Wrap(err, "processEvents failed")
 		}
-		inTx := s.inTx(eventId)
-		outTx := s.outTxs(eventId)
+		inTx := s.inTx(ctx, eventId)
+		outTx := s.outTxs(ctx, eventId)
 		var event1 models.Events
+		feeEvents := []uint64{eventId}
 		if eventType == "doubleSwap" {
-			event1 = s.events(eventId, "swap")
-			outTx = s.outTxs(eventId + 1)
-			event2 := s.events(eventId+1, "swap")
-			event1.Slip += event2.Slip
-			event1.Fee += event2.Fee
+			doubleSwap, err := s.GetDoubleSwap(ctx, int64(eventId))
+			if err != nil {
+				return nil, errors.Wrap(err, "processEvents failed")
+			}
+			event1, outTx = s.doubleSwapEvents(ctx, eventId, doubleSwap)
+			if doubleSwap != nil {
+				feeEvents = append(feeEvents, uint64(doubleSwap.SecondEventID))
+			}
 			if len(outTx) == 0 {
 				status = "pending"
 			}
 		} else {
-			event1 = s.events(eventId, eventType)
+			event1 = s.events(ctx, eventId, eventType)
 		}
+		networkFee, networkFeeInRune := s.networkFee(ctx, feeEvents...)
 		txData = append(txData, models.TxDetails{
-			Pool:    s.eventPool(eventId),
+			Pool:    s.eventPool(ctx, eventId),
 			Type:    eventType,
 			Status:  status,
 			In:      inTx,
 			Out:     outTx,
-			Options: s.options(eventId, eventType),
+			Options: s.options(ctx, eventId, eventType),
 			Events:  event1,
-			Date:    uint64(eventDate.Unix()),
-			Height:  height,
+			Fees: models.FeeBreakdown{
+				NetworkFee:       networkFee,
+				NetworkFeeInRune: networkFeeInRune,
+				LiquidityFee:     event1.Fee,
+				Slip:             event1.Slip,
+			},
+			Date:   uint64(eventDate.Unix()),
+			Height: height,
 		})
 	}
 
 	return txData, nil
 }
 
-func (s *Client) eventPool(eventId uint64) common.Asset {
+func (s *Client) eventPool(ctx context.Context, eventId uint64) common.Asset {
 	stmnt := `
 		SELECT pool
 			FROM pools_history
 		WHERE event_id = $1`
 
-	rows, err := s.db.Queryx(stmnt, eventId)
+	rows, err := s.db().QueryxContext(ctx, stmnt, eventId)
 	if err != nil {
 		s.logger.Err(err).Msg("Failed")
 		return common.Asset{}
@@ -163,23 +222,23 @@ func (s *Client) eventPool(eventId uint64) common.Asset {
 	return asset
 }
 
-func (s *Client) inTx(eventId uint64) models.TxData {
-	tx := s.txForDirection(eventId, "in")
-	tx.Coin = s.coinsForTxHash(tx.TxID, eventId)
+func (s *Client) inTx(ctx context.Context, eventId uint64) models.TxData {
+	tx := s.txForDirection(ctx, eventId, "in")
+	tx.Coin = s.coinsForTxHash(ctx, tx.TxID, eventId)
 
 	return tx
 }
 
-func (s *Client) outTxs(eventId uint64) []models.TxData {
-	txs := s.txsForDirection(eventId, "out")
+func (s *Client) outTxs(ctx context.Context, eventId uint64) []models.TxData {
+	txs := s.txsForDirection(ctx, eventId, "out")
 	for i, tx := range txs {
-		txs[i].Coin = s.coinsForTxHash(tx.TxID, eventId)
+		txs[i].Coin = s.coinsForTxHash(ctx, tx.TxID, eventId)
 	}
 
 	return txs
 }
 
-func (s *Client) txForDirection(eventId uint64, direction string) models.TxData {
+func (s *Client) txForDirection(ctx context.Context, eventId uint64, direction string) models.TxData {
 	stmnt := `
 		SELECT txs.tx_hash AS tx_id, txs.memo, txs.from_address AS address
 			FROM txs
@@ -187,7 +246,7 @@ func (s *Client) txForDirection(eventId uint64, direction string) models.TxData
 		AND txs.direction = $2`
 
 	tx := models.TxData{}
-	row := s.db.QueryRow(stmnt, eventId, direction)
+	row := s.db().QueryRowContext(ctx, stmnt, eventId, direction)
 	if err := row.Scan(&tx.TxID, &tx.Memo, &tx.Address); err != nil {
 		if err == sql.ErrNoRows {
 			return tx
@@ -199,14 +258,14 @@ func (s *Client) txForDirection(eventId uint64, direction string) models.TxData
 	return tx
 }
 
-func (s *Client) txsForDirection(eventId uint64, direction string) []models.TxData {
+func (s *Client) txsForDirection(ctx context.Context, eventId uint64, direction string) []models.TxData {
 	stmnt := `
 		SELECT txs.tx_hash AS tx_id, txs.memo, txs.from_address AS address
 			FROM txs
 		WHERE txs.event_id = $1
 		AND txs.direction = $2`
 
-	rows, err := s.db.Queryx(stmnt, eventId, direction)
+	rows, err := s.db().QueryxContext(ctx, stmnt, eventId, direction)
 	if err != nil {
 		s.logger.Err(err).Msg("Failed")
 		return nil
@@ -231,14 +290,14 @@ func (s *Client) txsForDirection(eventId uint64, direction string) []models.TxDa
 	return txs
 }
 
-func (s *Client) coinsForTxHash(txHash string, eventID uint64) common.Coins {
+func (s *Client) coinsForTxHash(ctx context.Context, txHash string, eventID uint64) common.Coins {
 	stmnt := `
 		SELECT coins.chain, coins.symbol, coins.ticker, coins.amount
 			FROM coins
 		WHERE coins.tx_hash = $1
 		AND   coins.event_Id= $2`
 
-	rows, err := s.db.Queryx(stmnt, txHash, eventID)
+	rows, err := s.db().QueryxContext(ctx, stmnt, txHash, eventID)
 	if err != nil {
 		s.logger.Err(err).Msg("Failed")
 		return nil
@@ -275,7 +334,7 @@ func (s *Client) coinsForTxHash(txHash string, eventID uint64) common.Coins {
 	return coins
 }
 
-func (s *Client) gas(eventId uint64) models.TxGas {
+func (s *Client) gas(ctx context.Context, eventId uint64) models.TxGas {
 	stmnt := `
 		SELECT gas.pool, gas.amount
 			FROM gas
@@ -286,7 +345,7 @@ func (s *Client) gas(eventId uint64) models.TxGas {
 		amount uint64
 	)
 
-	row := s.db.QueryRow(stmnt, eventId)
+	row := s.db().QueryRowContext(ctx, stmnt, eventId)
 	if err := row.Scan(&pool, &amount); err != nil {
 		return models.TxGas{}
 	}
@@ -298,37 +357,39 @@ func (s *Client) gas(eventId uint64) models.TxGas {
 	}
 }
 
-func (s *Client) options(eventId uint64, eventType string) models.Options {
+func (s *Client) options(ctx context.Context, eventId uint64, eventType string) models.Options {
 	var options models.Options
 
 	if eventType == "stake" {
-		options.PriceTarget = s.priceTarget(eventId)
+		options.PriceTarget = s.priceTarget(ctx, eventId)
 	}
 
 	return options
 }
 
-func (s *Client) events(eventId uint64, eventType string) models.Events {
+func (s *Client) events(ctx context.Context, eventId uint64, eventType string) models.Events {
 	switch eventType {
 	case "swap":
-		return s.swapEvents(eventId)
+		return s.swapEvents(ctx, eventId)
 	case "stake":
-		return s.stakeEvents(eventId)
+		return s.stakeEvents(ctx, eventId)
 	case "unstake":
-		return s.stakeEvents(eventId)
+		return s.stakeEvents(ctx, eventId)
+	case "slash":
+		return s.slashEvents(ctx, eventId)
 	}
 
 	return models.Events{}
 }
 
-func (s *Client) swapEvents(eventId uint64) models.Events {
+func (s *Client) swapEvents(ctx context.Context, eventId uint64) models.Events {
 	stmnt := `
 		SELECT swaps.trade_slip, swaps.liquidity_fee
 			FROM swaps
 		WHERE event_id = $1`
 
 	var events models.Events
-	row := s.db.QueryRow(stmnt, eventId)
+	row := s.db().QueryRowContext(ctx, stmnt, eventId)
 	if err := row.Scan(&events.Slip, &events.Fee); err != nil {
 		return models.Events{}
 	}
@@ -336,7 +397,36 @@ func (s *Client) swapEvents(eventId uint64) models.Events {
 	return events
 }
 
-func (s *Client) stakeEvents(eventId uint64) models.Events {
+// doubleSwapEvents returns the events of both legs of a double swap alongside
+// the outbound txs of its second leg. doubleSwap is nil if the second leg is not
+// observed yet.
+func (s *Client) doubleSwapEvents(ctx context.Context, eventId uint64, doubleSwap *models.DoubleSwap) (models.Events, []models.TxData) {
+	events := s.swapEvents(ctx, eventId)
+	events.Legs = []models.SwapLeg{
+		{
+			Pool: s.eventPool(ctx, eventId),
+			Fee:  events.Fee,
+			Slip: events.Slip,
+		},
+	}
+	if doubleSwap == nil {
+		return events, []models.TxData{}
+	}
+
+	secondLeg := uint64(doubleSwap.SecondEventID)
+	event2 := s.swapEvents(ctx, secondLeg)
+	events.Fee += event2.Fee
+	events.Slip += event2.Slip
+	events.IntermediateRune = doubleSwap.RuneAmount
+	events.Legs = append(events.Legs, models.SwapLeg{
+		Pool: s.eventPool(ctx, secondLeg),
+		Fee:  event2.Fee,
+		Slip: event2.Slip,
+	})
+	return events, s.outTxs(ctx, secondLeg)
+}
+
+func (s *Client) stakeEvents(ctx context.Context, eventId uint64) models.Events {
 	stmnt := `
 		SELECT units
 		FROM pools_history
@@ -344,7 +434,7 @@ func (s *Client) stakeEvents(eventId uint64) models.Events {
 		ORDER BY units`
 
 	var events models.Events
-	row := s.db.QueryRow(stmnt, eventId)
+	row := s.db().QueryRowContext(ctx, stmnt, eventId)
 	if err := row.Scan(&events.StakeUnits); err != nil {
 		return models.Events{}
 	}
@@ -352,18 +442,53 @@ func (s *Client) stakeEvents(eventId uint64) models.Events {
 	return events
 }
 
-func (s *Client) txDate(eventId uint64) (time.Time, error) {
+func (s *Client) slashEvents(ctx context.Context, eventId uint64) models.Events {
+	stmnt := `
+		SELECT pool, asset_amount, rune_amount
+		FROM pools_history
+		WHERE event_id = $1
+		ORDER BY id`
+
+	rows, err := s.db().QueryxContext(ctx, stmnt, eventId)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed")
+		return models.Events{}
+	}
+	defer rows.Close()
+
+	var events models.Events
+	for rows.Next() {
+		var (
+			pool                    string
+			assetAmount, runeAmount int64
+		)
+		if err := rows.Scan(&pool, &assetAmount, &runeAmount); err != nil {
+			s.logger.Err(err).Msg("Scan error")
+			continue
+		}
+		if assetAmount != 0 {
+			asset, _ := common.NewAsset(pool)
+			events.Slash = append(events.Slash, common.Coin{Asset: asset, Amount: assetAmount})
+		}
+		if runeAmount != 0 {
+			events.Slash = append(events.Slash, common.Coin{Asset: common.RuneAsset(), Amount: runeAmount})
+		}
+	}
+	return events
+}
+
+func (s *Client) txDate(ctx context.Context, eventId uint64) (time.Time, error) {
 	stmnt := `SELECT time FROM events WHERE id = $1`
 	var t time.Time
-	row := s.db.QueryRow(stmnt, eventId)
+	row := s.db().QueryRowContext(ctx, stmnt, eventId)
 	err := row.Scan(&t)
 	return t, err
 }
 
-func (s *Client) priceTarget(eventId uint64) uint64 {
+func (s *Client) priceTarget(ctx context.Context, eventId uint64) uint64 {
 	stmnt := `SELECT price_target FROM swaps WHERE event_id = $1`
 	var priceTarget uint64
-	row := s.db.QueryRow(stmnt, eventId)
+	row := s.db().QueryRowContext(ctx, stmnt, eventId)
 
 	if err := row.Scan(&priceTarget); err != nil {
 		return 0
@@ -372,7 +497,7 @@ func (s *Client) priceTarget(eventId uint64) uint64 {
 	return priceTarget
 }
 
-func (s *Client) eventBasic(eventId uint64) (time.Time, uint64, string, string, error) {
+func (s *Client) eventBasic(ctx context.Context, eventId uint64) (time.Time, uint64, string, string, error) {
 	stmnt := `
 		SELECT time, height, type, status 
 			FROM events
@@ -384,7 +509,7 @@ func (s *Client) eventBasic(eventId uint64) (time.Time, uint64, string, string,
 		eventType, status string
 	)
 
-	row := s.db.QueryRow(stmnt, eventId)
+	row := s.db().QueryRowContext(ctx, stmnt, eventId)
 	if err := row.Scan(&eventTime, &height, &eventType, &status); err != nil {
 		return eventTime, 0, "eventBasic failed", "eventBasic failed", errors.Wrap(err, "eventBasic failed")
 	}
@@ -1,6 +1,8 @@
 package timescale
 
 import (
+	"context"
+
 	"gitlab.com/thorchain/midgard/internal/common"
 	"gitlab.com/thorchain/midgard/internal/models"
 	. "gopkg.in/check.v1"
@@ -12,7 +14,7 @@ func (s *TimeScaleSuite) TestGetTxDetailsByAddress(c *C) {
 	c.Assert(err, IsNil)
 
 	address, _ := common.NewAddress("bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38")
-	events, count, err := s.Store.GetTxDetails(address, common.EmptyTxID, common.EmptyAsset, nil, 0, 1)
+	events, count, err := s.Store.GetTxDetails(context.Background(), address, common.EmptyTxID, common.EmptyAsset, nil, 0, 1)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(1))
 	c.Assert(events[0].Pool.Chain.String(), Equals, "BNB")
@@ -48,7 +50,7 @@ func (s *TimeScaleSuite) TestGetTxDetailsByAddress(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeTomlEvent1)
 	c.Assert(err, IsNil)
 
-	events, count, err = s.Store.GetTxDetails(address, common.EmptyTxID, common.EmptyAsset, nil, 0, 2)
+	events, count, err = s.Store.GetTxDetails(context.Background(), address, common.EmptyTxID, common.EmptyAsset, nil, 0, 2)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(2))
 	c.Assert(events[0].Pool.Chain.String(), Equals, "BNB")
@@ -88,7 +90,7 @@ func (s *TimeScaleSuite) TestGetTxDetailsByAddressAsset(c *C) {
 
 	address, _ := common.NewAddress("bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38")
 	asset, _ := common.NewAsset("BNB")
-	events, count, err := s.Store.GetTxDetails(address, common.EmptyTxID, asset, nil, 0, 1)
+	events, count, err := s.Store.GetTxDetails(context.Background(), address, common.EmptyTxID, asset, nil, 0, 1)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(1))
 
@@ -127,7 +129,7 @@ func (s *TimeScaleSuite) TestGetTxDetailsByAddressAsset(c *C) {
 
 	address, _ = common.NewAddress("bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38")
 	asset, _ = common.NewAsset("BNB.TOML-4BC")
-	events, count, err = s.Store.GetTxDetails(address, common.EmptyTxID, asset, nil, 0, 1)
+	events, count, err = s.Store.GetTxDetails(context.Background(), address, common.EmptyTxID, asset, nil, 0, 1)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(1))
 	c.Assert(events[0].Pool.Chain.String(), Equals, "BNB")
@@ -167,7 +169,7 @@ func (s *TimeScaleSuite) TestGetTxDetailsByAddressTxID(c *C) {
 
 	address, _ := common.NewAddress("bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38")
 	txid, _ := common.NewTxID("2F624637DE179665BA3322B864DB9F30001FD37B4E0D22A0B6ECE6A5B078DAB4")
-	events, count, err := s.Store.GetTxDetails(address, txid, common.EmptyAsset, nil, 0, 1)
+	events, count, err := s.Store.GetTxDetails(context.Background(), address, txid, common.EmptyAsset, nil, 0, 1)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(1))
 	c.Assert(events[0].Pool.Chain.String(), Equals, "BNB")
@@ -204,7 +206,7 @@ func (s *TimeScaleSuite) TestGetTxDetailsByAddressTxID(c *C) {
 	c.Assert(err, IsNil)
 
 	txid, _ = common.NewTxID("E7A0395D6A013F37606B86FDDF17BB3B358217C2452B3F5C153E9A7D00FDA998")
-	events, count, err = s.Store.GetTxDetails(address, txid, common.EmptyAsset, nil, 0, 1)
+	events, count, err = s.Store.GetTxDetails(context.Background(), address, txid, common.EmptyAsset, nil, 0, 1)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(1))
 	c.Assert(events[0].Pool.Chain.String(), Equals, "BNB")
@@ -243,7 +245,7 @@ func (s *TimeScaleSuite) TestGetTxDetailsByAsset(c *C) {
 	c.Assert(err, IsNil)
 
 	asset, _ := common.NewAsset("BNB")
-	events, count, err := s.Store.GetTxDetails(common.NoAddress, common.EmptyTxID, asset, nil, 0, 1)
+	events, count, err := s.Store.GetTxDetails(context.Background(), common.NoAddress, common.EmptyTxID, asset, nil, 0, 1)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(1))
 	c.Assert(events[0].Pool.Chain.String(), Equals, "BNB")
@@ -280,7 +282,7 @@ func (s *TimeScaleSuite) TestGetTxDetailsByAsset(c *C) {
 	c.Assert(err, IsNil)
 
 	asset, _ = common.NewAsset("BNB.TOML-4BC")
-	events, count, err = s.Store.GetTxDetails(common.NoAddress, common.EmptyTxID, asset, nil, 0, 1)
+	events, count, err = s.Store.GetTxDetails(context.Background(), common.NoAddress, common.EmptyTxID, asset, nil, 0, 1)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(1))
 	c.Assert(events[0].Pool.Chain.String(), Equals, "BNB")
@@ -314,7 +316,7 @@ func (s *TimeScaleSuite) TestGetTxDetailsByAsset(c *C) {
 }
 
 func (s *TimeScaleSuite) TestGetTxDetailsByEventType(c *C) {
-	_, count, err := s.Store.GetTxDetails("", common.EmptyTxID, common.EmptyAsset, nil, 0, 1)
+	_, count, err := s.Store.GetTxDetails(context.Background(), "", common.EmptyTxID, common.EmptyAsset, nil, 0, 1)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(0))
 
@@ -351,7 +353,7 @@ func (s *TimeScaleSuite) TestGetTxDetailsByEventType(c *C) {
 		txDetail,
 	}
 
-	events, count, err := s.Store.GetTxDetails("", common.EmptyTxID, common.EmptyAsset, []string{"stake"}, 0, 1)
+	events, count, err := s.Store.GetTxDetails(context.Background(), "", common.EmptyTxID, common.EmptyAsset, []string{"stake"}, 0, 1)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(1))
 	c.Assert(events[0], DeepEquals, evts[0])
@@ -388,7 +390,7 @@ func (s *TimeScaleSuite) TestGetTxDetailsByEventType(c *C) {
 	}
 	evts = append(evts, txDetail)
 
-	events, count, err = s.Store.GetTxDetails("", common.EmptyTxID, common.EmptyAsset, []string{"stake"}, 0, 1)
+	events, count, err = s.Store.GetTxDetails(context.Background(), "", common.EmptyTxID, common.EmptyAsset, []string{"stake"}, 0, 1)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(2))
 	c.Assert(events[0], DeepEquals, evts[1])
@@ -396,21 +398,21 @@ func (s *TimeScaleSuite) TestGetTxDetailsByEventType(c *C) {
 	err = s.Store.CreateSwapRecord(&swapSellTusdb2RuneEvent0)
 	c.Assert(err, IsNil)
 
-	events, count, err = s.Store.GetTxDetails("", common.EmptyTxID, common.EmptyAsset, []string{"stake"}, 0, 1)
+	events, count, err = s.Store.GetTxDetails(context.Background(), "", common.EmptyTxID, common.EmptyAsset, []string{"stake"}, 0, 1)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(2))
 	c.Assert(events[0], DeepEquals, evts[1])
 }
 
 func (s *TimeScaleSuite) TestGetTxDetailsAssetFilter(c *C) {
-	_, count, err := s.Store.GetTxDetails("", common.EmptyTxID, common.EmptyAsset, nil, 0, 1)
+	_, count, err := s.Store.GetTxDetails(context.Background(), "", common.EmptyTxID, common.EmptyAsset, nil, 0, 1)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(0))
 	err = s.Store.CreateStakeRecord(&stakeTomlEvent1)
 	c.Assert(err, IsNil)
 	asset, _ := common.NewAsset("TOML-4BC")
 	c.Assert(err, IsNil)
-	events, count, err := s.Store.GetTxDetails("", common.EmptyTxID, asset, nil, 0, 50)
+	events, count, err := s.Store.GetTxDetails(context.Background(), "", common.EmptyTxID, asset, nil, 0, 50)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(1))
 	c.Assert(events, DeepEquals, []models.TxDetails{
@@ -445,7 +447,7 @@ func (s *TimeScaleSuite) TestGetTxDetailsAssetFilter(c *C) {
 	err = s.Store.CreateUnStakesRecord(&unstakeTomlEvent0)
 	c.Assert(err, IsNil)
 
-	events, count, err = s.Store.GetTxDetails("", common.EmptyTxID, asset, nil, 0, 50)
+	events, count, err = s.Store.GetTxDetails(context.Background(), "", common.EmptyTxID, asset, nil, 0, 50)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(2))
 	c.Assert(len(events), Equals, 2)
@@ -469,6 +471,12 @@ func (s *TimeScaleSuite) TestGetTxDetailsAssetFilter(c *C) {
 			Events: models.Events{
 				StakeUnits: -100,
 			},
+			Fees: models.FeeBreakdown{
+				NetworkFee: common.Coins{
+					{Asset: common.RuneAsset(), Amount: 10},
+				},
+				NetworkFeeInRune: 10,
+			},
 			Status: "Success",
 			Out: []models.TxData{
 				{
@@ -523,14 +531,14 @@ func (s *TimeScaleSuite) TestGetTxDetailsAssetFilter(c *C) {
 		},
 	})
 
-	events, count, err = s.Store.GetTxDetails("", common.EmptyTxID, common.BNBAsset, nil, 0, 50)
+	events, count, err = s.Store.GetTxDetails(context.Background(), "", common.EmptyTxID, common.BNBAsset, nil, 0, 50)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(0))
 	c.Assert(len(events), Equals, 0)
 }
 
 func (s *TimeScaleSuite) TestGetTxDetailsPagination(c *C) {
-	_, count, err := s.Store.GetTxDetails("", common.EmptyTxID, common.EmptyAsset, nil, 0, 1)
+	_, count, err := s.Store.GetTxDetails(context.Background(), "", common.EmptyTxID, common.EmptyAsset, nil, 0, 1)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(0))
 
@@ -538,7 +546,7 @@ func (s *TimeScaleSuite) TestGetTxDetailsPagination(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
 
-	events, count, err := s.Store.GetTxDetails("", common.EmptyTxID, common.EmptyAsset, nil, 0, 1)
+	events, count, err := s.Store.GetTxDetails(context.Background(), "", common.EmptyTxID, common.EmptyAsset, nil, 0, 1)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(1))
 	c.Assert(len(events), Equals, 1)
@@ -547,32 +555,32 @@ func (s *TimeScaleSuite) TestGetTxDetailsPagination(c *C) {
 	err = s.Store.CreateStakeRecord(&stakeTomlEvent1)
 	c.Assert(err, IsNil)
 
-	events, count, err = s.Store.GetTxDetails("", common.EmptyTxID, common.EmptyAsset, nil, 0, 1)
+	events, count, err = s.Store.GetTxDetails(context.Background(), "", common.EmptyTxID, common.EmptyAsset, nil, 0, 1)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(2))
 	c.Assert(len(events), Equals, 1)
 
 	// Change page limit
-	events, count, err = s.Store.GetTxDetails("", common.EmptyTxID, common.EmptyAsset, nil, 0, 2)
+	events, count, err = s.Store.GetTxDetails(context.Background(), "", common.EmptyTxID, common.EmptyAsset, nil, 0, 2)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(2))
 	c.Assert(len(events), Equals, 2)
 
 	// Change offset
-	events, count, err = s.Store.GetTxDetails("", common.EmptyTxID, common.EmptyAsset, nil, 1, 2)
+	events, count, err = s.Store.GetTxDetails(context.Background(), "", common.EmptyTxID, common.EmptyAsset, nil, 1, 2)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(2))
 	c.Assert(len(events), Equals, 1)
 
 	// Change offset
-	events, count, err = s.Store.GetTxDetails("", common.EmptyTxID, common.EmptyAsset, nil, 2, 2)
+	events, count, err = s.Store.GetTxDetails(context.Background(), "", common.EmptyTxID, common.EmptyAsset, nil, 2, 2)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(2))
 	c.Assert(len(events), Equals, 0)
 }
 
 func (s *TimeScaleSuite) TestGetTxDetailsByDoubleSwap(c *C) {
-	_, count, err := s.Store.GetTxDetails("", common.EmptyTxID, common.EmptyAsset, nil, 0, 1)
+	_, count, err := s.Store.GetTxDetails(context.Background(), "", common.EmptyTxID, common.EmptyAsset, nil, 0, 1)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(0))
 
@@ -580,10 +588,19 @@ func (s *TimeScaleSuite) TestGetTxDetailsByDoubleSwap(c *C) {
 	swapEvnt.Type = "doubleSwap"
 	err = s.Store.CreateSwapRecord(&swapEvnt)
 	c.Assert(err, IsNil)
+	firstLeg := swapEvnt.ID
 	swapEvnt = swapBNB2Tusdb1
 	swapEvnt.Type = ""
 	err = s.Store.CreateSwapRecord(&swapEvnt)
 	c.Assert(err, IsNil)
+	err = s.Store.CreateDoubleSwapRecord(&models.DoubleSwap{
+		Time:          swapBNB2Tusdb0.Time,
+		TxID:          swapBNB2Tusdb0.InTx.ID,
+		FirstEventID:  firstLeg,
+		SecondEventID: swapEvnt.ID,
+		RuneAmount:    25,
+	})
+	c.Assert(err, IsNil)
 
 	txDetail := models.TxDetails{
 		Status: swapBNB2Tusdb0.Status,
@@ -597,8 +614,25 @@ func (s *TimeScaleSuite) TestGetTxDetailsByDoubleSwap(c *C) {
 			TxID:    swapBNB2Tusdb0.InTx.ID.String(),
 		},
 		Events: models.Events{
-			Fee:  uint64(swapBNB2Tusdb0.LiquidityFee + swapBNB2Tusdb1.LiquidityFee),
-			Slip: float64(swapBNB2Tusdb0.TradeSlip+swapBNB2Tusdb1.TradeSlip) / slipBasisPoints,
+			Fee:              uint64(swapBNB2Tusdb0.LiquidityFee + swapBNB2Tusdb1.LiquidityFee),
+			Slip:             float64(swapBNB2Tusdb0.TradeSlip+swapBNB2Tusdb1.TradeSlip) / slipBasisPoints,
+			IntermediateRune: 25,
+			Legs: []models.SwapLeg{
+				{
+					Pool: swapBNB2Tusdb0.Pool,
+					Fee:  uint64(swapBNB2Tusdb0.LiquidityFee),
+					Slip: float64(swapBNB2Tusdb0.TradeSlip) / slipBasisPoints,
+				},
+				{
+					Pool: swapBNB2Tusdb1.Pool,
+					Fee:  uint64(swapBNB2Tusdb1.LiquidityFee),
+					Slip: float64(swapBNB2Tusdb1.TradeSlip) / slipBasisPoints,
+				},
+			},
+		},
+		Fees: models.FeeBreakdown{
+			LiquidityFee: uint64(swapBNB2Tusdb0.LiquidityFee + swapBNB2Tusdb1.LiquidityFee),
+			Slip:         float64(swapBNB2Tusdb0.TradeSlip+swapBNB2Tusdb1.TradeSlip) / slipBasisPoints,
 		},
 		Date: uint64(swapBNB2Tusdb0.Time.Unix()),
 		Out:  make([]models.TxData, 0),
@@ -616,26 +650,26 @@ func (s *TimeScaleSuite) TestGetTxDetailsByDoubleSwap(c *C) {
 		txDetail,
 	}
 
-	events, count, err := s.Store.GetTxDetails("", common.EmptyTxID, common.EmptyAsset, nil, 0, 1)
+	events, count, err := s.Store.GetTxDetails(context.Background(), "", common.EmptyTxID, common.EmptyAsset, nil, 0, 1)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(1))
 	c.Assert(events[0], DeepEquals, evts[0])
 
-	events, count, err = s.Store.GetTxDetails("", common.EmptyTxID, common.EmptyAsset, []string{"doubleSwap"}, 0, 1)
+	events, count, err = s.Store.GetTxDetails(context.Background(), "", common.EmptyTxID, common.EmptyAsset, []string{"doubleSwap"}, 0, 1)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(1))
 	c.Assert(events[0], DeepEquals, evts[0])
 
 	err = s.Store.CreateStakeRecord(&stakeBnbEvent0)
 	c.Assert(err, IsNil)
-	events, count, err = s.Store.GetTxDetails("", common.EmptyTxID, common.EmptyAsset, []string{"doubleSwap", "stake"}, 0, 1)
+	events, count, err = s.Store.GetTxDetails(context.Background(), "", common.EmptyTxID, common.EmptyAsset, []string{"doubleSwap", "stake"}, 0, 1)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(2))
 	c.Assert(events[0], DeepEquals, evts[0])
 
 	err = s.Store.CreateSwapRecord(&swapBuyRune2BnbEvent3)
 	c.Assert(err, IsNil)
-	events, count, err = s.Store.GetTxDetails("", common.EmptyTxID, common.EmptyAsset, []string{"doubleSwap"}, 0, 1)
+	events, count, err = s.Store.GetTxDetails(context.Background(), "", common.EmptyTxID, common.EmptyAsset, []string{"doubleSwap"}, 0, 1)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(1))
 	c.Assert(events[0], DeepEquals, evts[0])
@@ -655,6 +689,10 @@ func (s *TimeScaleSuite) TestGetTxDetailsByDoubleSwap(c *C) {
 			Fee:  uint64(swapBuyRune2BnbEvent3.LiquidityFee),
 			Slip: float64(swapBuyRune2BnbEvent3.TradeSlip) / slipBasisPoints,
 		},
+		Fees: models.FeeBreakdown{
+			LiquidityFee: uint64(swapBuyRune2BnbEvent3.LiquidityFee),
+			Slip:         float64(swapBuyRune2BnbEvent3.TradeSlip) / slipBasisPoints,
+		},
 		Date: uint64(swapBuyRune2BnbEvent3.Time.Unix()),
 		Out:  make([]models.TxData, 0),
 	}
@@ -671,7 +709,7 @@ func (s *TimeScaleSuite) TestGetTxDetailsByDoubleSwap(c *C) {
 		txDetail,
 	}
 
-	events, count, err = s.Store.GetTxDetails("", common.EmptyTxID, common.EmptyAsset, []string{"swap"}, 0, 1)
+	events, count, err = s.Store.GetTxDetails(context.Background(), "", common.EmptyTxID, common.EmptyAsset, []string{"swap"}, 0, 1)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(1))
 	c.Assert(events[0], DeepEquals, evts[0])
@@ -696,13 +734,17 @@ func (s *TimeScaleSuite) TestGetTxDetailsByDoubleSwap(c *C) {
 			Fee:  uint64(swapEvent.LiquidityFee),
 			Slip: float64(swapEvent.TradeSlip) / slipBasisPoints,
 		},
+		Fees: models.FeeBreakdown{
+			LiquidityFee: uint64(swapEvent.LiquidityFee),
+			Slip:         float64(swapEvent.TradeSlip) / slipBasisPoints,
+		},
 		Date: uint64(swapEvent.Time.Unix()),
 		Out:  make([]models.TxData, 0),
 	}
 	evts = []models.TxDetails{
 		txDetail,
 	}
-	events, count, err = s.Store.GetTxDetails("", swapEvent.InTx.ID, common.EmptyAsset, []string{"swap"}, 0, 1)
+	events, count, err = s.Store.GetTxDetails(context.Background(), "", swapEvent.InTx.ID, common.EmptyAsset, []string{"swap"}, 0, 1)
 	c.Assert(err, IsNil)
 	c.Assert(count, Equals, int64(1))
 	c.Assert(events[0], DeepEquals, evts[0])
@@ -714,7 +756,7 @@ func (s *TimeScaleSuite) TestEventPool(c *C) {
 	c.Assert(err, IsNil)
 
 	eventId := uint64(stakeBnbEvent0.ID)
-	eventPool := s.Store.eventPool(eventId)
+	eventPool := s.Store.eventPool(context.Background(), eventId)
 
 	c.Assert(eventPool.Chain.String(), Equals, "BNB")
 	c.Assert(eventPool.Symbol.String(), Equals, "BNB")
@@ -725,7 +767,7 @@ func (s *TimeScaleSuite) TestEventPool(c *C) {
 	c.Assert(err, IsNil)
 
 	eventId = uint64(stakeTomlEvent1.ID)
-	eventPool = s.Store.eventPool(eventId)
+	eventPool = s.Store.eventPool(context.Background(), eventId)
 
 	c.Assert(eventPool.Chain.String(), Equals, "BNB")
 	c.Assert(eventPool.Symbol.String(), Equals, "TOML-4BC")
@@ -738,7 +780,7 @@ func (s *TimeScaleSuite) TestInTx(c *C) {
 	c.Assert(err, IsNil)
 
 	eventId := uint64(stakeBnbEvent0.ID)
-	inTx := s.Store.inTx(eventId)
+	inTx := s.Store.inTx(context.Background(), eventId)
 
 	c.Assert(inTx.Address, Equals, "bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38")
 	c.Assert(inTx.Coin[0].Asset.Chain.String(), Equals, "BNB")
@@ -757,7 +799,7 @@ func (s *TimeScaleSuite) TestInTx(c *C) {
 	c.Assert(err, IsNil)
 
 	eventId = uint64(stakeTomlEvent1.ID)
-	inTx = s.Store.inTx(eventId)
+	inTx = s.Store.inTx(context.Background(), eventId)
 
 	c.Assert(inTx.Address, Equals, "bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38")
 	c.Assert(inTx.Coin[0].Asset.Chain.String(), Equals, "BNB")
@@ -778,7 +820,7 @@ func (s *TimeScaleSuite) TestOutTx(c *C) {
 	c.Assert(err, IsNil)
 
 	eventId := uint64(stakeBnbEvent0.ID)
-	outTxs := s.Store.outTxs(eventId)
+	outTxs := s.Store.outTxs(context.Background(), eventId)
 
 	c.Assert(len(outTxs), Equals, 0)
 
@@ -787,7 +829,7 @@ func (s *TimeScaleSuite) TestOutTx(c *C) {
 	c.Assert(err, IsNil)
 
 	eventId = uint64(stakeTomlEvent1.ID)
-	outTxs = s.Store.outTxs(eventId)
+	outTxs = s.Store.outTxs(context.Background(), eventId)
 
 	c.Assert(len(outTxs), Equals, 0)
 }
@@ -798,13 +840,13 @@ func (s *TimeScaleSuite) TestTxForDirection(c *C) {
 	c.Assert(err, IsNil)
 
 	eventId := uint64(stakeBnbEvent0.ID)
-	inTx := s.Store.txForDirection(eventId, "in")
+	inTx := s.Store.txForDirection(context.Background(), eventId, "in")
 
 	c.Assert(inTx.Address, Equals, "bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38")
 	c.Assert(inTx.Memo, Equals, "stake:BNB.BNB")
 	c.Assert(inTx.TxID, Equals, "2F624637DE179665BA3322B864DB9F30001FD37B4E0D22A0B6ECE6A5B078DAB4")
 
-	outTxs := s.Store.txsForDirection(eventId, "out")
+	outTxs := s.Store.txsForDirection(context.Background(), eventId, "out")
 	c.Assert(len(outTxs), Equals, 0)
 
 	// Additional stake
@@ -812,13 +854,13 @@ func (s *TimeScaleSuite) TestTxForDirection(c *C) {
 	c.Assert(err, IsNil)
 
 	eventId = uint64(stakeTomlEvent1.ID)
-	inTx = s.Store.txForDirection(eventId, "in")
+	inTx = s.Store.txForDirection(context.Background(), eventId, "in")
 
 	c.Assert(inTx.Address, Equals, "bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38")
 	c.Assert(inTx.Memo, Equals, "stake:TOML")
 	c.Assert(inTx.TxID, Equals, "E7A0395D6A013F37606B86FDDF17BB3B358217C2452B3F5C153E9A7D00FDA998")
 
-	outTxs = s.Store.txsForDirection(eventId, "out")
+	outTxs = s.Store.txsForDirection(context.Background(), eventId, "out")
 	c.Assert(len(outTxs), Equals, 0)
 }
 
@@ -828,7 +870,7 @@ func (s *TimeScaleSuite) TestCoinsForTxHash(c *C) {
 	c.Assert(err, IsNil)
 
 	txid := "2F624637DE179665BA3322B864DB9F30001FD37B4E0D22A0B6ECE6A5B078DAB4"
-	coinsForTxHash := s.Store.coinsForTxHash(txid, uint64(stakeBnbEvent0.ID))
+	coinsForTxHash := s.Store.coinsForTxHash(context.Background(), txid, uint64(stakeBnbEvent0.ID))
 
 	c.Assert(coinsForTxHash[0].Asset.Chain.String(), Equals, "BNB")
 	c.Assert(coinsForTxHash[0].Asset.Symbol.String(), Equals, "RUNE-B1A")
@@ -839,7 +881,7 @@ func (s *TimeScaleSuite) TestCoinsForTxHash(c *C) {
 	c.Assert(err, IsNil)
 
 	txid = "E7A0395D6A013F37606B86FDDF17BB3B358217C2452B3F5C153E9A7D00FDA998"
-	coinsForTxHash = s.Store.coinsForTxHash(txid, uint64(stakeTomlEvent1.ID))
+	coinsForTxHash = s.Store.coinsForTxHash(context.Background(), txid, uint64(stakeTomlEvent1.ID))
 
 	c.Assert(coinsForTxHash[1].Asset.Chain.String(), Equals, "BNB")
 	c.Assert(coinsForTxHash[1].Asset.Symbol.String(), Equals, "TOML-4BC")
@@ -850,7 +892,7 @@ func (s *TimeScaleSuite) TestCoinsForTxHash(c *C) {
 	c.Assert(err, IsNil)
 
 	txid = "67C9MZZS1WOMM05S0RBTTDIFFLV3RQAZPJFD9V82EBPMG3P3HFUU3PBT3C18DV1E"
-	coinsForTxHash = s.Store.coinsForTxHash(txid, uint64(stakeBnbEvent2.ID))
+	coinsForTxHash = s.Store.coinsForTxHash(context.Background(), txid, uint64(stakeBnbEvent2.ID))
 
 	c.Assert(coinsForTxHash[1].Asset.Chain.String(), Equals, "BNB")
 	c.Assert(coinsForTxHash[1].Asset.Symbol.String(), Equals, "BNB")
@@ -863,7 +905,7 @@ func (s *TimeScaleSuite) TestOptions(c *C) {
 	c.Assert(err, IsNil)
 
 	eventId := uint64(stakeBnbEvent0.ID)
-	options := s.Store.options(eventId, "stake")
+	options := s.Store.options(context.Background(), eventId, "stake")
 
 	c.Assert(options.WithdrawBasisPoints, Equals, float64(0))
 	c.Assert(options.PriceTarget, Equals, uint64(0))
@@ -874,7 +916,7 @@ func (s *TimeScaleSuite) TestOptions(c *C) {
 	c.Assert(err, IsNil)
 
 	eventId = uint64(stakeTomlEvent1.ID)
-	options = s.Store.options(eventId, "stake")
+	options = s.Store.options(context.Background(), eventId, "stake")
 
 	c.Assert(options.WithdrawBasisPoints, Equals, float64(0))
 	c.Assert(options.PriceTarget, Equals, uint64(0))
@@ -887,7 +929,7 @@ func (s *TimeScaleSuite) TestEvents(c *C) {
 	c.Assert(err, IsNil)
 
 	eventId := uint64(stakeBnbEvent0.ID)
-	events := s.Store.events(eventId, "stake")
+	events := s.Store.events(context.Background(), eventId, "stake")
 
 	c.Assert(events.StakeUnits, Equals, int64(100))
 	c.Assert(events.Slip, Equals, float64(0))
@@ -898,7 +940,7 @@ func (s *TimeScaleSuite) TestEvents(c *C) {
 	c.Assert(err, IsNil)
 
 	eventId = uint64(stakeTomlEvent1.ID)
-	events = s.Store.events(eventId, "stake")
+	events = s.Store.events(context.Background(), eventId, "stake")
 
 	c.Assert(events.StakeUnits, Equals, int64(100))
 	c.Assert(events.Slip, Equals, float64(0))
@@ -911,7 +953,7 @@ func (s *TimeScaleSuite) TestTxDate(c *C) {
 	c.Assert(err, IsNil)
 
 	eventId := uint64(stakeBnbEvent0.ID)
-	txDate, err := s.Store.txDate(eventId)
+	txDate, err := s.Store.txDate(context.Background(), eventId)
 	c.Assert(err, IsNil)
 	c.Assert(txDate.Unix(), Equals, stakeBnbEvent0.Time.Unix())
 
@@ -920,7 +962,7 @@ func (s *TimeScaleSuite) TestTxDate(c *C) {
 	c.Assert(err, IsNil)
 
 	eventId = uint64(stakeTomlEvent1.ID)
-	txDate, err = s.Store.txDate(eventId)
+	txDate, err = s.Store.txDate(context.Background(), eventId)
 	c.Assert(err, IsNil)
 	c.Assert(txDate.Unix(), Equals, stakeTomlEvent1.Time.Unix())
 }
@@ -1,6 +1,8 @@
 package timescale
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 
 	"gitlab.com/thorchain/midgard/internal/common"
@@ -62,7 +64,7 @@ func (s *Client) UpdateUnStakesRecord(record models.EventUnstake) error {
 		}
 	}
 
-	pool, err := s.GetEventPool(record.ID)
+	pool, err := s.GetEventPool(context.Background(), record.ID)
 	if err != nil {
 		return errors.Wrapf(err, "could not get pool of event %d", record.ID)
 	}
@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"gitlab.com/thorchain/midgard/internal/models"
+)
+
+// GetAPIKeys returns the api keys stored in the database.
+func (uc *Usecase) GetAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	return uc.store.GetAPIKeys(ctx)
+}
+
+// GetAPIKeysUsage returns the number of requests made with each api key during
+// the given day.
+func (uc *Usecase) GetAPIKeysUsage(ctx context.Context, day time.Time) ([]models.APIKeyUsage, error) {
+	return uc.store.GetAPIKeysUsage(ctx, day)
+}
+
+// AddAPIKeysUsage accounts the given requests to the api keys.
+func (uc *Usecase) AddAPIKeysUsage(usage []models.APIKeyUsage) error {
+	return uc.store.AddAPIKeysUsage(usage)
+}
@@ -1,9 +1,13 @@
 package usecase
 
 import (
+	"context"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/mitchellh/mapstructure"
@@ -39,49 +43,99 @@ var (
 	assetType = reflect.TypeOf(common.Asset{})
 )
 
+// txSource fetches the txs which events refer to.
+type txSource interface {
+	GetTx(ctx context.Context, txId common.TxID) (common.Tx, error)
+	GetObservedTx(ctx context.Context, txId common.TxID) (thorchain.ObservedTx, error)
+}
+
 type eventHandler struct {
-	thorchain    thorchain.Thorchain
+	thorchain    txSource
 	store        store.Store
-	handlers     map[string]handler
+	schemas      *schemaRegistry
 	decodeConfig mapstructure.DecoderConfig
 	height       int64
 	blockTime    time.Time
 	events       []thorchain.Event
+	raw          []models.RawEvent
+	txIndex      int
 	errorFlag    bool
+	listeners    []blockListener
+	mu           sync.Mutex
 	logger       zerolog.Logger
 }
 
 type handler func(thorchain.Event) error
 
-func newEventHandler(store store.Store, thorchain thorchain.Thorchain) (*eventHandler, error) {
+// blockListener is notified after each block is processed successfully.
+type blockListener func(height int64, blockTime time.Time)
+
+// backgroundTask runs the slow part of a block listener, like requests to
+// remote services, out of the scanner. Blocks arriving while it's still running
+// are skipped.
+type backgroundTask struct {
+	running int32
+	wg      sync.WaitGroup
+}
+
+// run runs f in background unless it's still running for a previous block.
+func (t *backgroundTask) run(f func()) {
+	if !atomic.CompareAndSwapInt32(&t.running, 0, 1) {
+		return
+	}
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		defer atomic.StoreInt32(&t.running, 0)
+		f()
+	}()
+}
+
+// wait waits for the task running in background to finish.
+func (t *backgroundTask) wait() {
+	t.wg.Wait()
+}
+
+func newEventHandler(store store.Store, thorchain txSource) (*eventHandler, error) {
 	decodeHook := mapstructure.ComposeDecodeHookFunc(decodeCoinsHook, decodeAssetHook, decodePoolStatusHook)
 	eh := &eventHandler{
 		thorchain: thorchain,
 		store:     store,
-		handlers:  map[string]handler{},
+		schemas:   newSchemaRegistry(),
 		decodeConfig: mapstructure.DecoderConfig{
 			DecodeHook:       decodeHook,
 			WeaklyTypedInput: true,
 		},
 		logger: log.With().Str("module", "event_handler").Logger(),
 	}
-	eh.handlers[stakeEventType] = eh.processStakeEvent
-	eh.handlers[swapEventType] = eh.processSwapEvent
-	eh.handlers[unstakeEventType] = eh.processUnstakeEvent
-	eh.handlers[rewardEventType] = eh.processRewardEvent
-	eh.handlers[refundEventType] = eh.processRefundEvent
-	eh.handlers[addEventType] = eh.processAddEvent
-	eh.handlers[poolEventType] = eh.processPoolEvent
-	eh.handlers[gasEventType] = eh.processGasEvent
-	eh.handlers[slashEventType] = eh.processSlashEvent
-	eh.handlers[errataEventType] = eh.processErrataEvent
-	eh.handlers[feeEventType] = eh.processFeeEvent
-	eh.handlers[outboundEventType] = eh.processOutbound
+	schemas := []eventSchema{
+		{eventType: stakeEventType, required: []string{"pool", "stake_units"}, handler: eh.processStakeEvent},
+		{eventType: swapEventType, required: []string{"id", "pool", "coin"}, handler: eh.processSwapEvent},
+		{eventType: unstakeEventType, required: []string{"id", "pool"}, handler: eh.processUnstakeEvent},
+		{eventType: rewardEventType, handler: eh.processRewardEvent},
+		{eventType: refundEventType, required: []string{"id", "coin"}, handler: eh.processRefundEvent},
+		{eventType: addEventType, required: []string{"id", "pool"}, handler: eh.processAddEvent},
+		{eventType: poolEventType, required: []string{"pool", "pool_status"}, handler: eh.processPoolEvent},
+		{eventType: gasEventType, handler: eh.processGasEvent},
+		{eventType: slashEventType, required: []string{"pool"}, handler: eh.processSlashEvent},
+		{eventType: errataEventType, required: []string{"in_tx_id", "asset"}, handler: eh.processErrataEvent},
+		{eventType: feeEventType, required: []string{"tx_id"}, handler: eh.processFeeEvent},
+		{eventType: outboundEventType, required: []string{"in_tx_id"}, handler: eh.processOutbound},
+	}
+	for _, schema := range schemas {
+		err := eh.schemas.register(schema)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return eh, nil
 }
 
 // NewBlock implements Callback.NewBlock
 func (eh *eventHandler) NewBlock(height int64, blockTime time.Time, begin, end []thorchain.Event) error {
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+
 	if eh.errorFlag {
 		err := eh.store.DeleteBlock(eh.height)
 		if err != nil {
@@ -91,27 +145,93 @@ func (eh *eventHandler) NewBlock(height int64, blockTime time.Time, begin, end [
 		eh.errorFlag = false
 	}
 
-	eh.height = height
-	eh.blockTime = blockTime
-	eh.events = append(eh.events, begin...)
-	eh.events = append(eh.events, end...)
-	err := eh.processBlock()
+	err := eh.archiveBlock(height, blockTime, begin, end)
+	if err != nil {
+		eh.clearBuffer()
+		return errors.Wrap(err, "could not archive block's raw events")
+	}
+	err = eh.processBlock(height, blockTime, begin, end)
 	if err != nil {
 		// Set the flag so in the next time we delete this blocks data first.
 		eh.errorFlag = true
 		return errors.Wrap(err, "could not insert block's data to the database")
 	}
+	for _, listener := range eh.listeners {
+		listener(height, blockTime)
+	}
 	return nil
 }
 
 // NewTx implements Callback.NewTx
 func (eh *eventHandler) NewTx(height int64, events []thorchain.Event) {
+	eh.raw = appendRawEvents(eh.raw, height, models.RawEventStageTx, eh.txIndex, events)
+	eh.txIndex++
 	eh.events = append(eh.events, events...)
 }
 
-func (eh *eventHandler) processBlock() error {
+// archiveBlock stores the raw events of the block before they're processed.
+func (eh *eventHandler) archiveBlock(height int64, blockTime time.Time, begin, end []thorchain.Event) error {
+	eh.raw = appendRawEvents(eh.raw, height, models.RawEventStageBegin, 0, begin)
+	eh.raw = appendRawEvents(eh.raw, height, models.RawEventStageEnd, 0, end)
+	for i := range eh.raw {
+		eh.raw[i].Time = blockTime
+	}
+	return eh.store.CreateRawEvents(eh.raw)
+}
+
+func appendRawEvents(raw []models.RawEvent, height int64, stage string, txIndex int, events []thorchain.Event) []models.RawEvent {
+	for i, ev := range events {
+		raw = append(raw, models.RawEvent{
+			Height:     height,
+			Stage:      stage,
+			TxIndex:    txIndex,
+			EventIndex: i,
+			Type:       ev.Type,
+			Attributes: ev.Attributes,
+		})
+	}
+	return raw
+}
+
+// replayBlock processes the archived raw events of a block again. Block listeners
+// are not notified since they track the latest state of the chain.
+func (eh *eventHandler) replayBlock(raw []models.RawEvent) error {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+
+	sort.SliceStable(raw, func(i, j int) bool {
+		if raw[i].TxIndex != raw[j].TxIndex {
+			return raw[i].TxIndex < raw[j].TxIndex
+		}
+		return raw[i].EventIndex < raw[j].EventIndex
+	})
+	var begin, end []thorchain.Event
+	for _, r := range raw {
+		ev := thorchain.Event{Type: r.Type, Attributes: r.Attributes}
+		switch r.Stage {
+		case models.RawEventStageBegin:
+			begin = append(begin, ev)
+		case models.RawEventStageEnd:
+			end = append(end, ev)
+		default:
+			eh.events = append(eh.events, ev)
+		}
+	}
+	return eh.processBlock(raw[0].Height, raw[0].Time, begin, end)
+}
+
+func (eh *eventHandler) processBlock(height int64, blockTime time.Time, begin, end []thorchain.Event) error {
 	defer eh.clearBuffer()
 
+	eh.height = height
+	eh.blockTime = blockTime
+	eh.events = append(eh.events, begin...)
+	eh.events = append(eh.events, end...)
+
 	// Shift outbound events to the end of list (First outbound of double swap comes before swap event)
 	var outboundEvts []thorchain.Event
 	i := 0
@@ -136,18 +256,55 @@ func (eh *eventHandler) processBlock() error {
 
 func (eh *eventHandler) clearBuffer() {
 	eh.events = eh.events[:0]
+	eh.raw = eh.raw[:0]
+	eh.txIndex = 0
 }
 
 func (eh *eventHandler) processEvent(event thorchain.Event) error {
-	h, ok := eh.handlers[event.Type]
-	if ok {
-		eh.logger.Debug().Str("evt.Type", event.Type).Msg("New event")
-		err := h(event)
-		if err != nil {
-			return err
-		}
-	} else {
-		eh.logger.Info().Str("evt.Type", event.Type).Msg("Unknown event type")
+	if ignoredEventTypes[event.Type] {
+		return nil
+	}
+	err := eh.handleEvent(event)
+	if isDecodeError(err) {
+		return eh.quarantine(event, err)
+	}
+	return err
+}
+
+// handleEvent validates the event against the schema of its type at the current
+// height and passes it to the schema handler. Events of types which Midgard
+// doesn't handle, such as bond or reserve, are dropped.
+func (eh *eventHandler) handleEvent(event thorchain.Event) error {
+	if !eh.schemas.known(event.Type) {
+		eh.logger.Debug().Str("evt.Type", event.Type).Msg("event type not registered")
+		return nil
+	}
+	schema, ok := eh.schemas.lookup(event.Type, eh.height)
+	if !ok {
+		return unknownEventError(event.Type, eh.height)
+	}
+	eh.logger.Debug().Str("evt.Type", event.Type).Msg("New event")
+	err := schema.validate(event)
+	if err != nil {
+		return err
+	}
+	return schema.handler(event)
+}
+
+// quarantine stores the event which couldn't be decoded so it can be reprocessed
+// once its schema is fixed.
+func (eh *eventHandler) quarantine(event thorchain.Event, reason error) error {
+	eh.logger.Warn().Err(reason).Str("evt.Type", event.Type).Int64("height", eh.height).Msg("event quarantined")
+	record := models.QuarantinedEvent{
+		Time:       eh.blockTime,
+		Height:     eh.height,
+		Type:       event.Type,
+		Attributes: event.Attributes,
+		Reason:     reason.Error(),
+	}
+	err := eh.store.CreateQuarantinedEvent(&record)
+	if err != nil {
+		return errors.Wrap(err, "could not quarantine event")
 	}
 	return nil
 }
@@ -179,7 +336,7 @@ func (eh *eventHandler) processStakeEvent(event thorchain.Event) error {
 		}
 	}
 	for _, ev := range stake.GetStakes() {
-		tx, err := eh.thorchain.GetTx(ev.InTx.ID)
+		tx, err := eh.thorchain.GetTx(thorchain.WithoutCache(context.Background()), ev.InTx.ID)
 		if err != nil {
 			return errors.Wrap(err, "failed to get InTx")
 		}
@@ -210,6 +367,10 @@ func (eh *eventHandler) processUnstakeEvent(event thorchain.Event) error {
 		return errors.Wrap(err, "failed to decode unstake")
 	}
 	unstake.Status = pendingEvent
+	// The rune and the asset withdrawn are paid by separate outbounds. Thorchain
+	// skips the ones which can't pay the fee, so the count is reconciled with the
+	// outbounds it actually sent, see reconcileOutbounds.
+	unstake.ExpectedOutbounds = 2
 	err = eh.store.CreateUnStakesRecord(&unstake)
 	if err != nil {
 		return errors.Wrap(err, "failed to save unstake event")
@@ -230,6 +391,13 @@ func (eh *eventHandler) processRefundEvent(event thorchain.Event) error {
 		return errors.Wrap(err, "failed to decode refund")
 	}
 	refund.Status = pendingEvent
+	// Every coin of the inbound is refunded by a separate outbound, unless it
+	// can't pay the fee, see reconcileOutbounds.
+	for _, coin := range refund.InTx.Coins {
+		if !coin.IsEmpty() {
+			refund.ExpectedOutbounds++
+		}
+	}
 
 	err = eh.store.CreateRefundRecord(&refund)
 	if err != nil {
@@ -263,21 +431,44 @@ func (eh *eventHandler) processSwapEvent(event thorchain.Event) error {
 			isDoubleSwap = false
 		}
 	}
+	// The second leg of a double swap has no type and is linked to the first one.
+	var firstLeg *models.Event
 	if isDoubleSwap {
 		swap.Event.Type = doubleswapEventType
 	} else {
-		evts, err := eh.store.GetEventsByTxID(swap.InTx.ID)
+		evts, err := eh.store.GetEventsByTxID(context.Background(), swap.InTx.ID)
 		if err != nil {
 			return errors.Wrap(err, "failed to get event")
 		}
 		if len(evts) != 0 {
 			swap.Event.Type = ""
+			if evts[0].Type == doubleswapEventType {
+				firstLeg = &evts[0]
+			}
 		}
 	}
 	err = eh.store.CreateSwapRecord(&swap)
 	if err != nil {
 		return errors.Wrap(err, "failed to save swap event")
 	}
+	if firstLeg != nil {
+		var runeAmount int64
+		for _, coin := range swap.InTx.Coins {
+			if common.IsRuneAsset(coin.Asset) {
+				runeAmount += coin.Amount
+			}
+		}
+		err = eh.store.CreateDoubleSwapRecord(&models.DoubleSwap{
+			Time:          firstLeg.Time,
+			TxID:          swap.InTx.ID,
+			FirstEventID:  firstLeg.ID,
+			SecondEventID: swap.ID,
+			RuneAmount:    runeAmount,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to save double swap")
+		}
+	}
 	return nil
 }
 
@@ -399,39 +590,69 @@ func (eh *eventHandler) processFeeEvent(event thorchain.Event) error {
 		return errors.Wrap(err, "failed to save fee event")
 	}
 	inTxID, _ := common.NewTxID(event.Attributes["tx_id"])
-	evts, err := eh.store.GetEventsByTxID(inTxID)
+	evts, err := eh.store.GetEventsByTxID(context.Background(), inTxID)
 	if err != nil {
 		return errors.Wrap(err, "failed to get fee event")
 	}
-	if len(evts) > 0 {
-		if evts[0].Type == unstakeEventType {
-			evts[0].Fee = evt.Fee
-			evts[0].Height = eh.height
-			err = eh.store.UpdateUnStakesRecord(models.EventUnstake{
-				Event: evts[0],
-			})
-		} else if evts[0].Type == swapEventType || evts[0].Type == doubleswapEventType {
-			// Only second tx of double swap has fee
-			evts[len(evts)-1].Fee = evt.Fee
-			evts[len(evts)-1].Height = eh.height
-			err = eh.store.UpdateSwapRecord(models.EventSwap{
-				Event: evts[len(evts)-1],
-			})
-		}
+	if len(evts) == 0 {
+		return nil
+	}
+	target := evts[0]
+	if evts[0].Type == unstakeEventType {
+		evts[0].Fee = evt.Fee
+		evts[0].Height = eh.height
+		err = eh.store.UpdateUnStakesRecord(models.EventUnstake{
+			Event: evts[0],
+		})
+	} else if evts[0].Type == swapEventType || evts[0].Type == doubleswapEventType {
+		// Only second tx of double swap has fee
+		target = evts[len(evts)-1]
+		evts[len(evts)-1].Fee = evt.Fee
+		evts[len(evts)-1].Height = eh.height
+		err = eh.store.UpdateSwapRecord(models.EventSwap{
+			Event: evts[len(evts)-1],
+		})
 	}
 	if err != nil {
 		return errors.Wrap(err, "failed to update event")
 	}
+
+	pool := evt.Fee.Asset()
+	if pool.IsEmpty() {
+		pool, err = eh.store.GetEventPool(context.Background(), target.ID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get pool of event %d", target.ID)
+		}
+	}
+	err = eh.store.CreateTxFeeRecord(&models.TxFee{
+		Time:        evt.Time,
+		Height:      evt.Height,
+		EventID:     target.ID,
+		Pool:        pool,
+		AssetAmount: evt.Fee.AssetFee(),
+		RuneAmount:  evt.Fee.RuneFee(),
+		PoolDeduct:  evt.Fee.PoolDeduct,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to save tx fee")
+	}
 	return nil
 }
 
 func (eh *eventHandler) processRewardEvent(event thorchain.Event) error {
-	if len(event.Attributes) <= 1 {
+	if len(event.Attributes) == 0 {
 		return nil
 	}
 	reward := models.EventReward{
 		Event: newEvent(event, eh.height, eh.blockTime),
 	}
+	if v, ok := event.Attributes["bond_reward"]; ok {
+		bondReward, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "could not parse bond reward")
+		}
+		reward.BondReward = bondReward
+	}
 	reward.PoolRewards = getPoolAmount(event.Attributes)
 	reward.Status = successEvent
 
@@ -452,7 +673,7 @@ func (eh *eventHandler) processOutbound(event thorchain.Event) error {
 	if err != nil {
 		return err
 	}
-	evts, err := eh.store.GetEventsByTxID(txID)
+	evts, err := eh.store.GetEventsByTxID(context.Background(), txID)
 	if err != nil {
 		return err
 	}
@@ -462,12 +683,12 @@ func (eh *eventHandler) processOutbound(event thorchain.Event) error {
 	refunded := false
 	for _, evt := range evts {
 		if evt.Type == refundEventType {
-			err = eh.store.UpdateEventStatus(evt.ID, successEvent)
+			evt.OutTxs = common.Txs{outTx}
+			err = eh.store.ProcessTxRecord("out", evt, outTx)
 			if err != nil {
 				return err
 			}
-			evt.OutTxs = common.Txs{outTx}
-			err = eh.store.ProcessTxRecord("out", evt, outTx)
+			err = eh.completeEvents(txID, evt, evt)
 			if err != nil {
 				return err
 			}
@@ -478,7 +699,7 @@ func (eh *eventHandler) processOutbound(event thorchain.Event) error {
 		// refund main event
 		for _, evt := range evts {
 			if evt.Type != refundEventType {
-				pool, err := eh.store.GetEventPool(evt.ID)
+				pool, err := eh.store.GetEventPool(context.Background(), evt.ID)
 				if err != nil {
 					return errors.Wrapf(err, "could not get pool of event %d", evt.ID)
 				}
@@ -501,13 +722,13 @@ func (eh *eventHandler) processOutbound(event thorchain.Event) error {
 		evt.OutTxs = common.Txs{outTx}
 		var unstake models.EventUnstake
 		unstake.Event = evt
-		unstakeEvt, _, err := eh.store.GetTxDetails(common.NoAddress, txID, common.EmptyAsset, nil, 0, 1)
+		// The rune and the asset withdrawn are paid by separate outbounds.
+		done, err := eh.outboundsComplete(txID, evt)
 		if err != nil {
 			return err
 		}
-		if len(unstakeEvt) > 0 && unstakeEvt[0].Status != successEvent {
-			eh.store.UpdatePoolUnits(unstakeEvt[0].Pool, unstakeEvt[0].Events.StakeUnits)
-			err = eh.store.UpdateEventStatus(evt.ID, successEvent)
+		if done {
+			err = eh.completeUnstake(txID, evt.ID)
 			if err != nil {
 				return err
 			}
@@ -519,10 +740,27 @@ func (eh *eventHandler) processOutbound(event thorchain.Event) error {
 		}
 	} else if evts[0].Type == swapEventType || evts[0].Type == doubleswapEventType {
 		evt = evts[0]
-		if !outTx.ID.Equals(common.BlankTxID) && len(evts) == 2 { // Second outbound for double swap
-			evt = evts[1]
+		legs := evts
+		if evts[0].Type == doubleswapEventType {
+			// The first leg of a double swap pays rune to the second one through
+			// an outbound with blank id. The actual outbound belongs to the second leg.
+			if outTx.ID.Equals(common.BlankTxID) {
+				legs = evts[:1]
+			} else {
+				doubleSwap, err := eh.store.GetDoubleSwap(context.Background(), evts[0].ID)
+				if err != nil {
+					return errors.Wrapf(err, "could not get double swap of event %d", evts[0].ID)
+				}
+				if doubleSwap != nil {
+					for _, ev := range evts {
+						if ev.ID == doubleSwap.SecondEventID {
+							evt = ev
+						}
+					}
+				}
+			}
 		}
-		for _, ev := range evts {
+		for _, ev := range legs {
 			err = eh.store.UpdateEventStatus(ev.ID, successEvent)
 			if err != nil {
 				return err
@@ -541,23 +779,138 @@ func (eh *eventHandler) processOutbound(event thorchain.Event) error {
 			return err
 		}
 	} else {
-		// TODO: Refund event with multiple coins in input transaction should have more than one outbound.
-		for _, ev := range evts {
-			err = eh.store.UpdateEventStatus(ev.ID, successEvent)
-			if err != nil {
-				return err
-			}
-		}
 		evt = evts[0]
 		evt.OutTxs = common.Txs{outTx}
 		err = eh.store.ProcessTxRecord("out", evt, outTx)
 		if err != nil {
 			return err
 		}
+		err = eh.completeEvents(txID, evt, evts...)
+		if err != nil {
+			return err
+		}
 	}
 	return err
 }
 
+// completeEvents marks the given events as successful if all the expected
+// outbounds of evt, paying the inbound txID, are observed.
+func (eh *eventHandler) completeEvents(txID common.TxID, evt models.Event, evts ...models.Event) error {
+	done, err := eh.outboundsComplete(txID, evt)
+	if err != nil || !done {
+		return err
+	}
+	for _, ev := range evts {
+		err := eh.store.UpdateEventStatus(ev.ID, successEvent)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// completeUnstake marks the unstake of the inbound txID as successful and
+// removes its units from the pool, unless it's already completed.
+func (eh *eventHandler) completeUnstake(txID common.TxID, eventID int64) error {
+	details, _, err := eh.store.GetTxDetails(context.Background(), common.NoAddress, txID, common.EmptyAsset, nil, 0, 1)
+	if err != nil {
+		return err
+	}
+	if len(details) == 0 || details[0].Status == successEvent {
+		return nil
+	}
+	eh.store.UpdatePoolUnits(details[0].Pool, details[0].Events.StakeUnits)
+	return eh.store.UpdateEventStatus(eventID, successEvent)
+}
+
+// outboundsComplete tells whether all the expected outbounds of evt, paying the
+// inbound txID, are observed. Events with no more than one expected outbound
+// are completed by the first one. The expected count of the others is an upper
+// bound, which is reconciled with thorchain until it's reached.
+func (eh *eventHandler) outboundsComplete(txID common.TxID, evt models.Event) (bool, error) {
+	if evt.ExpectedOutbounds <= 1 {
+		return true, nil
+	}
+	count, err := eh.store.GetOutboundsCount(context.Background(), evt.ID)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not get outbounds count of event %d", evt.ID)
+	}
+	if count >= evt.ExpectedOutbounds {
+		return true, nil
+	}
+	expected, err := eh.reconcileOutbounds(txID, evt)
+	if err != nil {
+		return false, err
+	}
+	return count >= expected, nil
+}
+
+// reconcileOutbounds returns the number of outbounds thorchain sent to pay the
+// inbound txID of evt once it's done sending them, and saves it as the expected
+// count of evt. It returns the current expected count while thorchain is still
+// sending them, or if it can't be asked, e.g. when blocks are replayed.
+func (eh *eventHandler) reconcileOutbounds(txID common.TxID, evt models.Event) (int64, error) {
+	if eh.thorchain == nil {
+		return evt.ExpectedOutbounds, nil
+	}
+	tx, err := eh.thorchain.GetObservedTx(thorchain.WithoutCache(context.Background()), txID)
+	if err != nil {
+		// The outbounds are reconciled again later by the pending tracker.
+		eh.logger.Warn().Err(err).Str("tx_id", txID.String()).Msg("could not get outbounds of tx")
+		return evt.ExpectedOutbounds, nil
+	}
+	if tx.Status != thorchain.ObservedTxDone {
+		return evt.ExpectedOutbounds, nil
+	}
+	expected := int64(len(tx.OutHashes))
+	if expected != evt.ExpectedOutbounds {
+		err = eh.store.UpdateExpectedOutbounds(evt.ID, expected)
+		if err != nil {
+			return 0, errors.Wrapf(err, "could not update expected outbounds of event %d", evt.ID)
+		}
+	}
+	return expected, nil
+}
+
+// reconcilePending completes the given pending unstakes and refunds if
+// thorchain sent all their outbounds, which may be fewer than expected or
+// none at all for coins that can't pay the fee.
+func (eh *eventHandler) reconcilePending(txs []models.PendingTx) error {
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+
+	for _, tx := range txs {
+		if tx.Type != unstakeEventType && tx.Type != refundEventType {
+			continue
+		}
+		txID, err := common.NewTxID(tx.In.TxID)
+		if err != nil {
+			continue
+		}
+		evt := models.Event{
+			ID:                tx.EventID,
+			Type:              tx.Type,
+			ExpectedOutbounds: tx.ExpectedOutbounds,
+		}
+		expected, err := eh.reconcileOutbounds(txID, evt)
+		if err != nil {
+			return err
+		}
+		if tx.Outbounds < expected {
+			continue
+		}
+		if tx.Type == unstakeEventType {
+			err = eh.completeUnstake(txID, tx.EventID)
+		} else {
+			err = eh.store.UpdateEventStatus(tx.EventID, successEvent)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (eh *eventHandler) decode(attrs map[string]string, v interface{}) error {
 	// Copy config
 	conf := eh.decodeConfig
@@ -569,7 +922,7 @@ func (eh *eventHandler) decode(attrs map[string]string, v interface{}) error {
 
 	err = decoder.Decode(attrs)
 	if err != nil {
-		return errors.Wrapf(err, "could not decode %v to %T", attrs, v)
+		return decodeError{errors.Wrapf(err, "could not decode %v to %T", attrs, v)}
 	}
 	return nil
 }
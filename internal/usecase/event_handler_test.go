@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"strconv"
 	"time"
@@ -36,7 +37,7 @@ type StakeThorchain struct {
 	*ThorchainDummy
 }
 
-func (t *StakeThorchain) GetTx(txId common.TxID) (common.Tx, error) {
+func (t *StakeThorchain) GetTx(ctx context.Context, txId common.TxID) (common.Tx, error) {
 	return common.Tx{ID: txId}, nil
 }
 
@@ -217,8 +218,9 @@ func (s *EventHandlerSuite) TestUnStakeEvent(c *C) {
 				Memo:  "WITHDRAW:BTC.BTC:1000",
 				Chain: common.BNBChain,
 			},
-			Type:   "unstake",
-			Status: "Pending",
+			Type:              "unstake",
+			Status:            "Pending",
+			ExpectedOutbounds: 2,
 		},
 	}
 	c.Assert(store.record, DeepEquals, expectedEvent)
@@ -276,11 +278,18 @@ func (s *EventHandlerSuite) TestRefundEvent(c *C) {
 				},
 				Chain: common.BNBChain,
 			},
-			Type:   "refund",
-			Status: "Pending",
+			Type:              "refund",
+			Status:            "Pending",
+			ExpectedOutbounds: 2,
 		},
 	}
 	c.Assert(store.record, DeepEquals, expectedEvent)
+
+	evt.Attributes["coin"] = "150000000 BNB.BNB"
+	eh.NewTx(2, []thorchain.Event{evt})
+	eh.NewBlock(2, blockTime, nil, nil)
+	c.Assert(store.record.ExpectedOutbounds, Equals, int64(1))
+	c.Assert(store.record.Status, Equals, "Pending")
 }
 
 type SwapTestStore struct {
@@ -344,6 +353,69 @@ func (s *EventHandlerSuite) TestSwapEvent(c *C) {
 	c.Assert(store.record, DeepEquals, expectedEvent)
 }
 
+type DoubleSwapTestStore struct {
+	*StoreDummy
+	events     []models.Event
+	record     models.EventSwap
+	doubleSwap models.DoubleSwap
+}
+
+func (s *DoubleSwapTestStore) GetEventsByTxID(_ context.Context, _ common.TxID) ([]models.Event, error) {
+	return s.events, nil
+}
+
+func (s *DoubleSwapTestStore) CreateSwapRecord(record *models.EventSwap) error {
+	record.ID = 8
+	s.record = *record
+	return nil
+}
+
+func (s *DoubleSwapTestStore) CreateDoubleSwapRecord(record *models.DoubleSwap) error {
+	s.doubleSwap = *record
+	return nil
+}
+
+func (s *EventHandlerSuite) TestDoubleSwapSecondLegEvent(c *C) {
+	firstLegTime := time.Now().Add(-time.Second)
+	store := &DoubleSwapTestStore{
+		events: []models.Event{
+			{
+				ID:   7,
+				Time: firstLegTime,
+				Type: "doubleSwap",
+			},
+		},
+	}
+	eh, err := newEventHandler(store, s.dummyThorchain)
+	c.Assert(err, IsNil)
+	evt := thorchain.Event{
+		Type: "swap",
+		Attributes: map[string]string{
+			"chain":                 "BNB",
+			"coin":                  "1200000 BNB.RUNE-67C",
+			"from":                  "tbnb157dxmw9jz5emuf0apj4d6p3ee42ck0uwksxfff",
+			"id":                    "0F1DE3EC877075636F21AF1E7399AA9B9C710A4989E61A9F5942A78B9FA96621",
+			"liquidity_fee":         "1200",
+			"liquidity_fee_in_rune": "1200",
+			"memo":                  "SWAP:BTC.BTC:bcrt1qqqnde7kqe5sf96j6zf8jpzwr44dh4gkd3ehaqh",
+			"pool":                  "BTC.BTC",
+			"price_target":          "1",
+			"to":                    "tbnb153nknrl2d2nmvguhhvacd4dfsm4jlv8c87nscv",
+			"trade_slip":            "12",
+		},
+	}
+	eh.NewTx(1, []thorchain.Event{evt})
+	eh.NewBlock(1, time.Now(), nil, nil)
+	c.Assert(store.record.Type, Equals, "")
+	c.Assert(store.doubleSwap, DeepEquals, models.DoubleSwap{
+		Time:          firstLegTime,
+		TxID:          "0F1DE3EC877075636F21AF1E7399AA9B9C710A4989E61A9F5942A78B9FA96621",
+		FirstEventID:  7,
+		SecondEventID: 8,
+		RuneAmount:    1200000,
+	})
+}
+
 type PoolTestStore struct {
 	*StoreDummy
 	record models.EventPool
@@ -555,6 +627,7 @@ func (s *EventHandlerSuite) TestRewardEvent(c *C) {
 	blockTime := time.Now()
 	eh.NewTx(1, []thorchain.Event{evt})
 	eh.NewBlock(1, blockTime, nil, nil)
+	c.Assert(store.record.BondReward, Equals, int64(106372190))
 	c.Assert(len(store.record.PoolRewards), Equals, len(evt.Attributes)-1)
 	for _, pool := range store.record.PoolRewards {
 		obtainedAmt := evt.Attributes[pool.Pool.String()]
@@ -669,18 +742,41 @@ type OutboundTestStore struct {
 	pool         common.Asset
 	RefundedEvt  models.Event
 	RefundedPool common.Asset
+	doubleSwap   *models.DoubleSwap
+	outbounds    int64
+	units        int64
 }
 
-func (s *OutboundTestStore) GetEventsByTxID(_ common.TxID) ([]models.Event, error) {
+func (s *OutboundTestStore) GetEventsByTxID(_ context.Context, _ common.TxID) ([]models.Event, error) {
 	return s.events, nil
 }
 
 func (s *OutboundTestStore) ProcessTxRecord(direction string, _ models.Event, record common.Tx) error {
 	s.direction = direction
 	s.tx = record
+	if direction == "out" {
+		s.outbounds++
+	}
+	return nil
+}
+
+func (s *OutboundTestStore) GetOutboundsCount(_ context.Context, _ int64) (int64, error) {
+	return s.outbounds, nil
+}
+
+func (s *OutboundTestStore) UpdateExpectedOutbounds(id int64, expected int64) error {
+	for i := range s.events {
+		if s.events[i].ID == id {
+			s.events[i].ExpectedOutbounds = expected
+		}
+	}
 	return nil
 }
 
+func (s *OutboundTestStore) UpdatePoolUnits(_ common.Asset, units int64) {
+	s.units += units
+}
+
 func (s *OutboundTestStore) UpdateUnStakesRecord(record models.EventUnstake) error {
 	s.unstake = record
 	return nil
@@ -711,21 +807,41 @@ func (s *OutboundTestStore) UpdateEventStatus(id int64, status string) error {
 	return nil
 }
 
-func (s *OutboundTestStore) GetTxDetails(_ common.Address, _ common.TxID, _ common.Asset, _ []string, _, _ int64) ([]models.TxDetails, int64, error) {
+func (s *OutboundTestStore) GetTxDetails(ctx context.Context, _ common.Address, _ common.TxID, _ common.Asset, _ []string, _, _ int64) ([]models.TxDetails, int64, error) {
+	status := ""
+	if len(s.events) > 0 {
+		status = s.events[0].Status
+	}
 	return []models.TxDetails{
 		{
+			Status: status,
 			Out: []models.TxData{
 				{},
 				{},
 			},
+			Events: models.Events{StakeUnits: -100},
 		},
 	}, 1, nil
 }
 
-func (s *OutboundTestStore) GetEventPool(id int64) (common.Asset, error) {
+// OutboundThorchain returns the observed inbound tx.
+type OutboundThorchain struct {
+	ThorchainDummy
+	observed thorchain.ObservedTx
+}
+
+func (t *OutboundThorchain) GetObservedTx(ctx context.Context, txId common.TxID) (thorchain.ObservedTx, error) {
+	return t.observed, nil
+}
+
+func (s *OutboundTestStore) GetEventPool(_ context.Context, id int64) (common.Asset, error) {
 	return s.pool, nil
 }
 
+func (s *OutboundTestStore) GetDoubleSwap(ctx context.Context, _ int64) (*models.DoubleSwap, error) {
+	return s.doubleSwap, nil
+}
+
 func (s *EventHandlerSuite) TestUnstakeOutboundEvent(c *C) {
 	store := &OutboundTestStore{}
 	eh, err := newEventHandler(store, s.dummyThorchain)
@@ -783,6 +899,108 @@ func (s *EventHandlerSuite) TestUnstakeOutboundEvent(c *C) {
 	c.Assert(store.tx, DeepEquals, expectedEvent.OutTxs[0])
 }
 
+func (s *EventHandlerSuite) TestUnstakeMultiOutboundEvent(c *C) {
+	store := &OutboundTestStore{}
+	eh, err := newEventHandler(store, s.dummyThorchain)
+	c.Assert(err, IsNil)
+	blockTime := time.Now()
+	store.events = []models.Event{
+		{
+			ID:                1,
+			Type:              "unstake",
+			Status:            "Pending",
+			Time:              blockTime.Add(-10 * time.Second),
+			ExpectedOutbounds: 2,
+		},
+	}
+	evt := thorchain.Event{
+		Type: "outbound",
+		Attributes: map[string]string{
+			"chain":    "BTC",
+			"coin":     "23282731 BTC.BTC",
+			"from":     "bcrt1q53nknrl2d2nmvguhhvacd4dfsm4jlv8c46ed3y",
+			"id":       "04AE4EC733CA6366D431376DA600C1E4E091982D06F25B13028C99EC11A4C1E4",
+			"in_tx_id": "04FFE1117647700F48F678DF53372D503F31C745D6DDE3599D9CB6381188620E",
+			"memo":     "OUTBOUND:04FFE1117647700F48F678DF53372D503F31C745D6DDE3599D9CB6381188620E",
+			"to":       "bcrt1q0s4mg25tu6termrk8egltfyme4q7sg3h8kkydt",
+		},
+	}
+	eh.NewTx(1, []thorchain.Event{evt})
+	err = eh.NewBlock(1, blockTime, nil, nil)
+	c.Assert(err, IsNil)
+	c.Assert(store.unstake.OutTxs[0].Coins, DeepEquals, common.Coins{common.NewCoin(common.BTCAsset, 23282731)})
+	c.Assert(store.events[0].Status, Equals, "Pending")
+
+	evt.Attributes["chain"] = "BNB"
+	evt.Attributes["id"] = "AA578D052B0EC26F2E4E50901512AC3145F5D5614D24231179C7E86892D42B4D"
+	evt.Attributes["coin"] = "49000000000 BNB.RUNE-67C"
+	eh.NewTx(2, []thorchain.Event{evt})
+	err = eh.NewBlock(2, blockTime, nil, nil)
+	c.Assert(err, IsNil)
+	c.Assert(store.unstake.OutTxs[0].Coins, DeepEquals, common.Coins{common.NewCoin(common.Rune67CAsset, 49000000000)})
+	c.Assert(store.events[0].Status, Equals, "Success")
+	c.Assert(store.units, Equals, int64(-100))
+
+	// Thorchain sends a single outbound if the other can't pay the fee.
+	client := &OutboundThorchain{}
+	eh, err = newEventHandler(store, client)
+	c.Assert(err, IsNil)
+	store.outbounds = 0
+	store.events[0].Status = "Pending"
+	store.events[0].ExpectedOutbounds = 2
+	client.observed.Status = "incomplete"
+	eh.NewTx(3, []thorchain.Event{evt})
+	err = eh.NewBlock(3, blockTime, nil, nil)
+	c.Assert(err, IsNil)
+	c.Assert(store.events[0].Status, Equals, "Pending")
+	c.Assert(store.events[0].ExpectedOutbounds, Equals, int64(2))
+
+	store.outbounds = 0
+	client.observed = thorchain.ObservedTx{
+		Status:    thorchain.ObservedTxDone,
+		OutHashes: []string{"AA578D052B0EC26F2E4E50901512AC3145F5D5614D24231179C7E86892D42B4D"},
+	}
+	eh.NewTx(4, []thorchain.Event{evt})
+	err = eh.NewBlock(4, blockTime, nil, nil)
+	c.Assert(err, IsNil)
+	c.Assert(store.events[0].Status, Equals, "Success")
+	c.Assert(store.events[0].ExpectedOutbounds, Equals, int64(1))
+	c.Assert(store.units, Equals, int64(-200))
+}
+
+func (s *EventHandlerSuite) TestReconcilePending(c *C) {
+	store := &OutboundTestStore{}
+	client := &OutboundThorchain{}
+	eh, err := newEventHandler(store, client)
+	c.Assert(err, IsNil)
+	store.events = []models.Event{
+		{ID: 1, Type: "unstake", Status: "Pending", ExpectedOutbounds: 2},
+	}
+	pending := []models.PendingTx{
+		{
+			EventID:           1,
+			Type:              "unstake",
+			In:                models.TxData{TxID: "04FFE1117647700F48F678DF53372D503F31C745D6DDE3599D9CB6381188620E"},
+			Outbounds:         0,
+			ExpectedOutbounds: 2,
+		},
+	}
+
+	// Thorchain is still sending the outbounds.
+	client.observed.Status = "incomplete"
+	err = eh.reconcilePending(pending)
+	c.Assert(err, IsNil)
+	c.Assert(store.events[0].Status, Equals, "Pending")
+
+	// None of the outbounds could pay the fee.
+	client.observed.Status = thorchain.ObservedTxDone
+	err = eh.reconcilePending(pending)
+	c.Assert(err, IsNil)
+	c.Assert(store.events[0].Status, Equals, "Success")
+	c.Assert(store.events[0].ExpectedOutbounds, Equals, int64(0))
+	c.Assert(store.units, Equals, int64(-100))
+}
+
 func (s *EventHandlerSuite) TestSwapOutboundEvent(c *C) {
 	store := &OutboundTestStore{}
 	eh, err := newEventHandler(store, s.dummyThorchain)
@@ -844,26 +1062,45 @@ func (s *EventHandlerSuite) TestSwapOutboundEvent(c *C) {
 	store.events = []models.Event{
 		{
 			ID:     2,
-			Type:   "swap",
+			Type:   "doubleSwap",
 			Time:   blockTime.Add(-10 * time.Second),
-			Status: "Success",
+			Status: "Pending",
 		},
 		{
 			ID:     3,
-			Type:   "swap",
+			Type:   "",
 			Time:   blockTime.Add(-10 * time.Second),
-			Status: "Success",
+			Status: "Pending",
 		},
 	}
+	store.doubleSwap = &models.DoubleSwap{
+		FirstEventID:  2,
+		SecondEventID: 3,
+	}
 	evt.Attributes["id"] = common.BlankTxID.String()
 	eh.NewTx(1, []thorchain.Event{evt})
 	eh.NewBlock(1, blockTime, nil, nil)
 	expectedEvent.ID = 2
+	expectedEvent.Type = "doubleSwap"
+	expectedEvent.Status = "Pending"
 	expectedEvent.OutTxs[0].ID = common.BlankTxID
 	c.Assert(store.swap, DeepEquals, expectedEvent)
 	c.Assert(store.direction, Equals, "out")
 	c.Assert(store.unstake, DeepEquals, models.EventUnstake{})
 	c.Assert(store.tx, DeepEquals, expectedEvent.OutTxs[0])
+	c.Assert(store.events[0].Status, Equals, "Success")
+	c.Assert(store.events[1].Status, Equals, "Pending")
+
+	// Second outbound for double swap
+	evt.Attributes["id"] = "AA578D052B0EC26F2E4E50901512AC3145F5D5614D24231179C7E86892D42B4D"
+	eh.NewTx(1, []thorchain.Event{evt})
+	eh.NewBlock(1, blockTime, nil, nil)
+	expectedEvent.ID = 3
+	expectedEvent.Type = ""
+	expectedEvent.OutTxs[0].ID = "AA578D052B0EC26F2E4E50901512AC3145F5D5614D24231179C7E86892D42B4D"
+	c.Assert(store.swap, DeepEquals, expectedEvent)
+	c.Assert(store.tx, DeepEquals, expectedEvent.OutTxs[0])
+	c.Assert(store.events[1].Status, Equals, "Success")
 }
 
 func (s *EventHandlerSuite) TestOutboundEvent(c *C) {
@@ -1074,3 +1311,238 @@ func (s *EventHandlerSuite) TestRefundedSwapEvent(c *C) {
 	})
 	c.Assert(store.RefundedPool, DeepEquals, common.BTCAsset)
 }
+
+func (s *EventHandlerSuite) TestMultiCoinRefundEvent(c *C) {
+	store := &OutboundTestStore{
+		pool: common.BNBAsset,
+	}
+	eh, err := newEventHandler(store, s.dummyThorchain)
+	c.Assert(err, IsNil)
+	blockTime := time.Now()
+	store.events = []models.Event{
+		{
+			ID:     1,
+			Type:   "stake",
+			Time:   blockTime.Add(-10 * time.Second),
+			Status: "Pending",
+		},
+		{
+			ID:                2,
+			Type:              "refund",
+			Time:              blockTime.Add(-10 * time.Second),
+			Status:            "Pending",
+			ExpectedOutbounds: 2,
+		},
+	}
+	evt := thorchain.Event{
+		Type: "outbound",
+		Attributes: map[string]string{
+			"chain":    "BNB",
+			"coin":     "149000000 BNB.BNB",
+			"from":     "tbnb153nknrl2d2nmvguhhvacd4dfsm4jlv8c87nscv",
+			"id":       "04AE4EC733CA6366D431376DA600C1E4E091982D06F25B13028C99EC11A4C1E4",
+			"in_tx_id": "98C1864036571E805BB0E0CCBAFF0F8D80F69BDEA32D5B26E0DDB95301C74D0C",
+			"memo":     "REFUND:98C1864036571E805BB0E0CCBAFF0F8D80F69BDEA32D5B26E0DDB95301C74D0C",
+			"to":       "tbnb189az9plcke2c00vns0zfmllfpfdw67dtv25kgx",
+		},
+	}
+	eh.NewTx(1, []thorchain.Event{evt})
+	err = eh.NewBlock(1, blockTime, nil, nil)
+	c.Assert(err, IsNil)
+	c.Assert(store.RefundedEvt.OutTxs[0].Coins, DeepEquals, common.Coins{common.NewCoin(common.BNBAsset, 149000000)})
+	c.Assert(store.events[1].Status, Equals, "Pending")
+
+	evt.Attributes["id"] = "AA578D052B0EC26F2E4E50901512AC3145F5D5614D24231179C7E86892D42B4D"
+	evt.Attributes["coin"] = "49000000000 BNB.RUNE-67C"
+	eh.NewTx(2, []thorchain.Event{evt})
+	err = eh.NewBlock(2, blockTime, nil, nil)
+	c.Assert(err, IsNil)
+	c.Assert(store.RefundedEvt.OutTxs[0].Coins, DeepEquals, common.Coins{common.NewCoin(common.Rune67CAsset, 49000000000)})
+	c.Assert(store.events[1].Status, Equals, "Success")
+}
+
+type QuarantineTestStore struct {
+	*StoreDummy
+	quarantined []models.QuarantinedEvent
+	lastID      int64
+}
+
+func (s *QuarantineTestStore) CreateQuarantinedEvent(record *models.QuarantinedEvent) error {
+	s.lastID++
+	record.ID = s.lastID
+	s.quarantined = append(s.quarantined, *record)
+	return nil
+}
+
+func (s *QuarantineTestStore) GetQuarantinedEvents(ctx context.Context, offset, limit int64) ([]models.QuarantinedEvent, int64, error) {
+	count := int64(len(s.quarantined))
+	if offset >= count {
+		return nil, count, nil
+	}
+	end := offset + limit
+	if end > count {
+		end = count
+	}
+	events := make([]models.QuarantinedEvent, end-offset)
+	copy(events, s.quarantined[offset:end])
+	return events, count, nil
+}
+
+func (s *EventHandlerSuite) TestQuarantineEvent(c *C) {
+	store := &QuarantineTestStore{}
+	eh, err := newEventHandler(store, s.dummyThorchain)
+	c.Assert(err, IsNil)
+	err = eh.schemas.register(eventSchema{
+		eventType:  "new_event",
+		fromHeight: 10,
+		handler: func(evt thorchain.Event) error {
+			return nil
+		},
+	})
+	c.Assert(err, IsNil)
+	blockTime := time.Now()
+	eh.NewTx(5, []thorchain.Event{
+		{
+			Type: "message",
+			Attributes: map[string]string{
+				"action": "set_observed_txin",
+			},
+		},
+		{
+			Type: "bond",
+			Attributes: map[string]string{
+				"amount": "100",
+			},
+		},
+		{
+			Type: "new_event",
+			Attributes: map[string]string{
+				"foo": "bar",
+			},
+		},
+		{
+			Type: "stake",
+			Attributes: map[string]string{
+				"pool": "BNB.BNB",
+			},
+		},
+		{
+			Type: "stake",
+			Attributes: map[string]string{
+				"pool":        "BNB.BNB",
+				"stake_units": "invalid",
+			},
+		},
+	})
+	err = eh.NewBlock(5, blockTime, nil, nil)
+	c.Assert(err, IsNil)
+	c.Assert(store.quarantined, HasLen, 3)
+	c.Assert(store.quarantined[0].Type, Equals, "new_event")
+	c.Assert(store.quarantined[0].Height, Equals, int64(5))
+	c.Assert(store.quarantined[0].Time, Equals, blockTime)
+	c.Assert(store.quarantined[0].Attributes, DeepEquals, map[string]string{"foo": "bar"})
+	c.Assert(store.quarantined[0].Reason, Equals, "no schema of new_event event at height 5")
+	c.Assert(store.quarantined[1].Type, Equals, "stake")
+	c.Assert(store.quarantined[1].Reason, Equals, `attribute "stake_units" is missing from stake event (schema from height 0)`)
+	c.Assert(store.quarantined[2].Type, Equals, "stake")
+	c.Assert(store.quarantined[2].Attributes["stake_units"], Equals, "invalid")
+}
+
+type RawEventsTestStore struct {
+	*StoreDummy
+	raw []models.RawEvent
+	gas []models.EventGas
+	err error
+}
+
+func (s *RawEventsTestStore) CreateRawEvents(records []models.RawEvent) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.raw = append(s.raw, records...)
+	return nil
+}
+
+func (s *RawEventsTestStore) CreateGasRecord(record *models.EventGas) error {
+	s.gas = append(s.gas, *record)
+	return nil
+}
+
+func (s *EventHandlerSuite) TestArchiveRawEvents(c *C) {
+	store := &RawEventsTestStore{}
+	eh, err := newEventHandler(store, s.dummyThorchain)
+	c.Assert(err, IsNil)
+	gas := thorchain.Event{
+		Type: "gas",
+		Attributes: map[string]string{
+			"asset":     "BNB.BNB",
+			"asset_amt": "75000",
+			"rune_amt":  "24900200",
+		},
+	}
+	message := thorchain.Event{
+		Type: "message",
+		Attributes: map[string]string{
+			"action": "set_observed_txin",
+		},
+	}
+	blockTime := time.Now()
+	eh.NewTx(3, []thorchain.Event{message})
+	eh.NewTx(3, []thorchain.Event{message, gas})
+	err = eh.NewBlock(3, blockTime, []thorchain.Event{gas}, nil)
+	c.Assert(err, IsNil)
+	c.Assert(store.raw, DeepEquals, []models.RawEvent{
+		{Height: 3, Time: blockTime, Stage: models.RawEventStageTx, TxIndex: 0, EventIndex: 0, Type: "message", Attributes: message.Attributes},
+		{Height: 3, Time: blockTime, Stage: models.RawEventStageTx, TxIndex: 1, EventIndex: 0, Type: "message", Attributes: message.Attributes},
+		{Height: 3, Time: blockTime, Stage: models.RawEventStageTx, TxIndex: 1, EventIndex: 1, Type: "gas", Attributes: gas.Attributes},
+		{Height: 3, Time: blockTime, Stage: models.RawEventStageBegin, TxIndex: 0, EventIndex: 0, Type: "gas", Attributes: gas.Attributes},
+	})
+	c.Assert(store.gas, HasLen, 2)
+
+	// Next block should start from the first tx.
+	store.raw = nil
+	eh.NewTx(4, []thorchain.Event{gas})
+	err = eh.NewBlock(4, blockTime, nil, nil)
+	c.Assert(err, IsNil)
+	c.Assert(store.raw, HasLen, 1)
+	c.Assert(store.raw[0].TxIndex, Equals, 0)
+
+	// Nothing is processed if archiving fails.
+	store.err = errors.New("archive failed")
+	store.gas = nil
+	eh.NewTx(5, []thorchain.Event{gas})
+	err = eh.NewBlock(5, blockTime, nil, nil)
+	c.Assert(err, NotNil)
+	c.Assert(store.gas, HasLen, 0)
+	c.Assert(eh.events, HasLen, 0)
+	c.Assert(eh.raw, HasLen, 0)
+}
+
+func (s *EventHandlerSuite) TestReplayBlock(c *C) {
+	store := &RawEventsTestStore{}
+	eh, err := newEventHandler(store, s.dummyThorchain)
+	c.Assert(err, IsNil)
+	var order []string
+	err = eh.schemas.register(eventSchema{
+		eventType: "test",
+		handler: func(evt thorchain.Event) error {
+			order = append(order, evt.Attributes["name"])
+			return nil
+		},
+	})
+	c.Assert(err, IsNil)
+	blockTime := time.Now()
+	raw := []models.RawEvent{
+		{Height: 7, Time: blockTime, Stage: models.RawEventStageBegin, Type: "test", Attributes: map[string]string{"name": "begin"}},
+		{Height: 7, Time: blockTime, Stage: models.RawEventStageEnd, Type: "test", Attributes: map[string]string{"name": "end"}},
+		{Height: 7, Time: blockTime, Stage: models.RawEventStageTx, TxIndex: 1, Type: "test", Attributes: map[string]string{"name": "tx1"}},
+		{Height: 7, Time: blockTime, Stage: models.RawEventStageTx, TxIndex: 0, EventIndex: 1, Type: "test", Attributes: map[string]string{"name": "tx0-1"}},
+		{Height: 7, Time: blockTime, Stage: models.RawEventStageTx, TxIndex: 0, Type: "test", Attributes: map[string]string{"name": "tx0-0"}},
+	}
+	err = eh.replayBlock(raw)
+	c.Assert(err, IsNil)
+	c.Assert(order, DeepEquals, []string{"tx0-0", "tx0-1", "tx1", "begin", "end"})
+	c.Assert(eh.height, Equals, int64(7))
+	c.Assert(eh.blockTime, Equals, blockTime)
+	c.Assert(store.raw, HasLen, 0)
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gitlab.com/thorchain/midgard/pkg/clients/thorchain"
+)
+
+// ignoredEventTypes are the types of events emitted by the cosmos sdk modules
+// which carry no data for Midgard.
+var ignoredEventTypes = map[string]bool{
+	"message":  true,
+	"transfer": true,
+}
+
+// eventSchema describes the attributes of an event type emitted by THORChain
+// within a range of block heights and the handler decoding it. When THORChain
+// changes an event, the current schema is closed at the height before the
+// change and the new one is registered from it.
+type eventSchema struct {
+	eventType string
+	// fromHeight and toHeight are the inclusive range of heights in which the
+	// schema is valid. Zero toHeight means the schema is still valid.
+	fromHeight int64
+	toHeight   int64
+	required   []string
+	handler    handler
+}
+
+func (s eventSchema) covers(height int64) bool {
+	return height >= s.fromHeight && (s.toHeight == 0 || height <= s.toHeight)
+}
+
+func (s eventSchema) overlaps(other eventSchema) bool {
+	return (s.toHeight == 0 || other.fromHeight <= s.toHeight) &&
+		(other.toHeight == 0 || s.fromHeight <= other.toHeight)
+}
+
+// validate checks that the event contains all the required attributes.
+func (s eventSchema) validate(event thorchain.Event) error {
+	for _, attr := range s.required {
+		if _, ok := event.Attributes[attr]; !ok {
+			return decodeError{errors.Errorf("attribute %q is missing from %s event (schema from height %d)", attr, s.eventType, s.fromHeight)}
+		}
+	}
+	return nil
+}
+
+// schemaRegistry keeps the schemas of each event type keyed by block height range.
+type schemaRegistry struct {
+	schemas map[string][]eventSchema
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas: map[string][]eventSchema{},
+	}
+}
+
+// register adds a new schema. Schemas of the same type must not overlap.
+func (r *schemaRegistry) register(schema eventSchema) error {
+	if schema.toHeight != 0 && schema.toHeight < schema.fromHeight {
+		return errors.Errorf("invalid height range [%d, %d] of %s schema", schema.fromHeight, schema.toHeight, schema.eventType)
+	}
+	for _, s := range r.schemas[schema.eventType] {
+		if s.overlaps(schema) {
+			return errors.Errorf("%s schema from height %d overlaps with the one from height %d", schema.eventType, schema.fromHeight, s.fromHeight)
+		}
+	}
+	r.schemas[schema.eventType] = append(r.schemas[schema.eventType], schema)
+	return nil
+}
+
+// known returns whether any schema of the event type is registered.
+func (r *schemaRegistry) known(eventType string) bool {
+	return len(r.schemas[eventType]) > 0
+}
+
+// lookup returns the schema of the event type at the given height.
+func (r *schemaRegistry) lookup(eventType string, height int64) (eventSchema, bool) {
+	for _, s := range r.schemas[eventType] {
+		if s.covers(height) {
+			return s, true
+		}
+	}
+	return eventSchema{}, false
+}
+
+// decodeError is returned when an event doesn't match the schema of its type.
+// Such events are quarantined instead of failing the whole block.
+type decodeError struct {
+	err error
+}
+
+func (e decodeError) Error() string {
+	return e.err.Error()
+}
+
+func isDecodeError(err error) bool {
+	_, ok := errors.Cause(err).(decodeError)
+	return ok
+}
+
+func unknownEventError(eventType string, height int64) error {
+	return decodeError{fmt.Errorf("no schema of %s event at height %d", eventType, height)}
+}
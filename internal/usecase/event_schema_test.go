@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"gitlab.com/thorchain/midgard/pkg/clients/thorchain"
+	. "gopkg.in/check.v1"
+)
+
+var _ = Suite(&EventSchemaSuite{})
+
+type EventSchemaSuite struct{}
+
+func (s *EventSchemaSuite) TestRegister(c *C) {
+	r := newSchemaRegistry()
+	err := r.register(eventSchema{eventType: "swap", toHeight: 100})
+	c.Assert(err, IsNil)
+	err = r.register(eventSchema{eventType: "swap", fromHeight: 101})
+	c.Assert(err, IsNil)
+	err = r.register(eventSchema{eventType: "stake"})
+	c.Assert(err, IsNil)
+
+	// Overlapping ranges
+	err = r.register(eventSchema{eventType: "swap", fromHeight: 50, toHeight: 60})
+	c.Assert(err, NotNil)
+	err = r.register(eventSchema{eventType: "swap", fromHeight: 1000})
+	c.Assert(err, NotNil)
+
+	// Invalid range
+	err = r.register(eventSchema{eventType: "pool", fromHeight: 10, toHeight: 5})
+	c.Assert(err, NotNil)
+}
+
+func (s *EventSchemaSuite) TestLookup(c *C) {
+	r := newSchemaRegistry()
+	err := r.register(eventSchema{eventType: "swap", toHeight: 100})
+	c.Assert(err, IsNil)
+	err = r.register(eventSchema{eventType: "swap", fromHeight: 101})
+	c.Assert(err, IsNil)
+
+	schema, ok := r.lookup("swap", 1)
+	c.Assert(ok, Equals, true)
+	c.Assert(schema.fromHeight, Equals, int64(0))
+	schema, ok = r.lookup("swap", 100)
+	c.Assert(ok, Equals, true)
+	c.Assert(schema.fromHeight, Equals, int64(0))
+	schema, ok = r.lookup("swap", 101)
+	c.Assert(ok, Equals, true)
+	c.Assert(schema.fromHeight, Equals, int64(101))
+	_, ok = r.lookup("stake", 1)
+	c.Assert(ok, Equals, false)
+	c.Assert(r.known("swap"), Equals, true)
+	c.Assert(r.known("stake"), Equals, false)
+}
+
+func (s *EventSchemaSuite) TestValidate(c *C) {
+	schema := eventSchema{eventType: "swap", required: []string{"id", "pool"}}
+	err := schema.validate(thorchain.Event{
+		Type:       "swap",
+		Attributes: map[string]string{"id": "1", "pool": "BNB.BNB"},
+	})
+	c.Assert(err, IsNil)
+
+	err = schema.validate(thorchain.Event{
+		Type:       "swap",
+		Attributes: map[string]string{"id": "1"},
+	})
+	c.Assert(err, NotNil)
+	c.Assert(isDecodeError(err), Equals, true)
+}
@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/models"
+	"gitlab.com/thorchain/midgard/internal/store"
+)
+
+var pendingTxsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "midgard",
+	Name:      "pending_txs",
+	Help:      "Number of txs waiting for their outbound by state (pending or stuck).",
+}, []string{"state"})
+
+// RegisterMetrics registers the metrics of the usecase to reg.
+func RegisterMetrics(reg prometheus.Registerer) error {
+	return reg.Register(pendingTxsGauge)
+}
+
+const (
+	// reconcileAge is the age after which pending events are reconciled with
+	// thorchain, since their outbounds are usually observed within a few blocks.
+	reconcileAge = time.Minute
+	// reconcileLimit is the maximum number of pending events reconciled per block.
+	reconcileLimit = 20
+)
+
+// pendingTracker counts the events waiting for their outbound tx after each
+// new block and flags the ones pending for longer than threshold as stuck.
+// Events are aged against the time of the last block so syncing doesn't flag
+// them falsely, both here and in the api.
+// Blocks older than maxBlockAge are skipped so syncing doesn't count them for
+// every historical block. Pending events older than reconcileAge are passed to
+// reconcile in background, a page per block, in case thorchain sent fewer
+// outbounds than expected.
+type pendingTracker struct {
+	store       store.Store
+	threshold   time.Duration
+	maxBlockAge time.Duration
+	reconcile   func([]models.PendingTx) error
+	offset      int64
+	background  backgroundTask
+	blockTime   time.Time
+	pending     int64
+	stuck       int64
+	mu          sync.RWMutex
+	logger      zerolog.Logger
+}
+
+func newPendingTracker(store store.Store, threshold, maxBlockAge time.Duration) *pendingTracker {
+	return &pendingTracker{
+		store:       store,
+		threshold:   threshold,
+		maxBlockAge: maxBlockAge,
+		logger:      log.With().Str("module", "pending_tracker").Logger(),
+	}
+}
+
+// newBlock is called by event handler after each block is processed.
+func (t *pendingTracker) newBlock(height int64, blockTime time.Time) {
+	err := t.check(blockTime)
+	if err != nil {
+		t.logger.Error().Err(err).Int64("height", height).Msg("failed to check pending txs")
+	}
+}
+
+func (t *pendingTracker) check(blockTime time.Time) error {
+	t.mu.Lock()
+	t.blockTime = blockTime
+	t.mu.Unlock()
+	if t.maxBlockAge > 0 && time.Since(blockTime) > t.maxBlockAge {
+		return nil
+	}
+
+	pending, err := t.store.GetPendingTxsCount(context.Background(), time.Time{})
+	if err != nil {
+		return errors.Wrap(err, "could not get pending txs count")
+	}
+	stuck, err := t.store.GetPendingTxsCount(context.Background(), blockTime.Add(-t.threshold))
+	if err != nil {
+		return errors.Wrap(err, "could not get stuck txs count")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if stuck > t.stuck {
+		t.logger.Warn().Int64("stuck", stuck).Dur("threshold", t.threshold).Msg("txs are stuck waiting for outbound")
+	}
+	t.pending = pending
+	t.stuck = stuck
+	pendingTxsGauge.WithLabelValues("pending").Set(float64(pending))
+	pendingTxsGauge.WithLabelValues("stuck").Set(float64(stuck))
+
+	if t.reconcile != nil && pending > 0 {
+		t.background.run(func() {
+			err := t.reconcileOld(blockTime)
+			if err != nil {
+				t.logger.Error().Err(err).Msg("failed to reconcile pending txs")
+			}
+		})
+	}
+	return nil
+}
+
+// reconcileOld reconciles the next page of the pending events older than
+// reconcileAge. The pages go from the oldest events to the newest ones and
+// start over.
+func (t *pendingTracker) reconcileOld(blockTime time.Time) error {
+	txs, count, err := t.store.GetPendingTxs(context.Background(), t.offset, reconcileLimit)
+	if err != nil {
+		return errors.Wrap(err, "could not get pending txs")
+	}
+	var old []models.PendingTx
+	for _, tx := range txs {
+		if tx.Time.Before(blockTime.Add(-reconcileAge)) {
+			old = append(old, tx)
+		}
+	}
+	// The next pages only have newer events if this one isn't full of old ones.
+	t.offset += int64(len(txs))
+	if len(old) < len(txs) || t.offset >= count {
+		t.offset = 0
+	}
+	if len(old) == 0 {
+		return nil
+	}
+	return t.reconcile(old)
+}
+
+// counts returns the number of pending and stuck txs as of the last block.
+func (t *pendingTracker) counts() (int64, int64) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.pending, t.stuck
+}
+
+// now returns the time of the last block, or the current time if no block was
+// processed yet.
+func (t *pendingTracker) now() time.Time {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.blockTime.IsZero() {
+		return time.Now()
+	}
+	return t.blockTime
+}
+
+// GetPendingTxs returns the txs waiting for their outbound alongside their age
+// as of the last block and the chain of the expected outbound.
+func (uc *Usecase) GetPendingTxs(ctx context.Context, page models.Page) ([]models.PendingTx, int64, error) {
+	err := page.Validate()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	txs, count, err := uc.store.GetPendingTxs(ctx, page.Offset, page.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	now := uc.pending.now()
+	for i := range txs {
+		txs[i].OutboundChain = outboundChain(txs[i])
+		txs[i].Age = now.Sub(txs[i].Time)
+		txs[i].Stuck = txs[i].Age > uc.conf.StuckTxThreshold
+	}
+	return txs, count, nil
+}
+
+// outboundChain returns the chain on which the outbound of the given tx is expected.
+// Swaps pay out to the target asset of the memo, refunds to the inbound chain and
+// unstakes to the chain of the pool.
+func outboundChain(tx models.PendingTx) common.Chain {
+	switch tx.Type {
+	case swapEventType, doubleswapEventType:
+		parts := strings.Split(tx.In.Memo, ":")
+		if len(parts) > 1 {
+			asset, err := common.NewAsset(parts[1])
+			if err == nil {
+				return asset.Chain
+			}
+		}
+	case refundEventType:
+		if len(tx.In.Coin) > 0 {
+			return tx.In.Coin[0].Asset.Chain
+		}
+	}
+	if !tx.Pool.IsEmpty() {
+		return tx.Pool.Chain
+	}
+	if len(tx.In.Coin) > 0 {
+		return tx.In.Coin[0].Asset.Chain
+	}
+	return common.Chain("")
+}
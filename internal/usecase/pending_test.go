@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/models"
+	. "gopkg.in/check.v1"
+)
+
+type TestPendingStore struct {
+	StoreDummy
+	txs []models.PendingTx
+}
+
+func (s *TestPendingStore) GetPendingTxs(ctx context.Context, offset, limit int64) ([]models.PendingTx, int64, error) {
+	return s.txs, int64(len(s.txs)), nil
+}
+
+func (s *TestPendingStore) GetPendingTxsCount(ctx context.Context, before time.Time) (int64, error) {
+	var count int64
+	for _, tx := range s.txs {
+		if before.IsZero() || tx.Time.Before(before) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *UsecaseSuite) TestPendingTracker(c *C) {
+	now := time.Now()
+	store := &TestPendingStore{
+		txs: []models.PendingTx{
+			{EventID: 1, Time: now.Add(-time.Hour)},
+			{EventID: 2, Time: now.Add(-time.Minute)},
+			{EventID: 3, Time: now},
+		},
+	}
+	tracker := newPendingTracker(store, time.Minute*15, time.Hour*2)
+
+	err := tracker.check(now)
+	c.Assert(err, IsNil)
+	pending, stuck := tracker.counts()
+	c.Assert(pending, Equals, int64(3))
+	c.Assert(stuck, Equals, int64(1))
+	c.Assert(testutil.ToFloat64(pendingTxsGauge.WithLabelValues("pending")), Equals, float64(3))
+	c.Assert(testutil.ToFloat64(pendingTxsGauge.WithLabelValues("stuck")), Equals, float64(1))
+
+	// Txs should be aged against the block time.
+	err = tracker.check(now.Add(-time.Hour))
+	c.Assert(err, IsNil)
+	pending, stuck = tracker.counts()
+	c.Assert(pending, Equals, int64(3))
+	c.Assert(stuck, Equals, int64(0))
+
+	// Blocks older than the max age should be skipped.
+	store.txs = store.txs[1:]
+	err = tracker.check(now.Add(-time.Hour * 3))
+	c.Assert(err, IsNil)
+	pending, stuck = tracker.counts()
+	c.Assert(pending, Equals, int64(3))
+	c.Assert(stuck, Equals, int64(0))
+}
+
+func (s *UsecaseSuite) TestPendingTrackerReconcile(c *C) {
+	now := time.Now()
+	store := &TestPendingStore{
+		txs: []models.PendingTx{
+			{EventID: 1, Time: now.Add(-time.Hour)},
+			{EventID: 2, Time: now},
+		},
+	}
+	tracker := newPendingTracker(store, time.Minute*15, time.Hour*2)
+	var reconciled []models.PendingTx
+	tracker.reconcile = func(txs []models.PendingTx) error {
+		reconciled = txs
+		return nil
+	}
+
+	// Only the events older than reconcileAge are reconciled.
+	err := tracker.reconcileOld(now)
+	c.Assert(err, IsNil)
+	c.Assert(reconciled, DeepEquals, store.txs[:1])
+	c.Assert(tracker.offset, Equals, int64(0))
+}
+
+func (s *UsecaseSuite) TestGetPendingTxs(c *C) {
+	now := time.Now()
+	store := &TestPendingStore{
+		txs: []models.PendingTx{
+			{
+				EventID: 1,
+				Type:    swapEventType,
+				Time:    now.Add(-time.Hour),
+				Pool:    common.BNBAsset,
+				In: models.TxData{
+					Memo: "SWAP:BNB.BNB",
+					Coin: common.Coins{{Asset: common.RuneAsset(), Amount: 10}},
+				},
+			},
+			{
+				EventID: 2,
+				Type:    doubleswapEventType,
+				Time:    now,
+				Pool:    common.BNBAsset,
+				In: models.TxData{
+					Memo: "SWAP:BTC.BTC",
+					Coin: common.Coins{{Asset: common.BNBAsset, Amount: 10}},
+				},
+			},
+			{
+				EventID: 3,
+				Type:    unstakeEventType,
+				Time:    now,
+				Pool:    common.BTCAsset,
+				In: models.TxData{
+					Memo: "WITHDRAW:BTC.BTC",
+					Coin: common.Coins{{Asset: common.RuneAsset(), Amount: 1}},
+				},
+			},
+			{
+				EventID: 4,
+				Type:    refundEventType,
+				Time:    now,
+				In: models.TxData{
+					Memo: "SWAP:BTC.BTC",
+					Coin: common.Coins{{Asset: common.BNBAsset, Amount: 10}},
+				},
+			},
+		},
+	}
+	s.config.StuckTxThreshold = time.Minute * 15
+	uc, err := NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
+	c.Assert(err, IsNil)
+
+	txs, count, err := uc.GetPendingTxs(context.Background(), models.NewPage(0, 10))
+	c.Assert(err, IsNil)
+	c.Assert(count, Equals, int64(4))
+	c.Assert(txs, HasLen, 4)
+	c.Assert(txs[0].OutboundChain, Equals, common.BNBChain)
+	c.Assert(txs[0].Stuck, Equals, true)
+	c.Assert(txs[0].Age >= time.Hour, Equals, true)
+	c.Assert(txs[1].OutboundChain, Equals, common.BTCChain)
+	c.Assert(txs[1].Stuck, Equals, false)
+	c.Assert(txs[2].OutboundChain, Equals, common.BTCChain)
+	c.Assert(txs[3].OutboundChain, Equals, common.BNBChain)
+
+	// Txs should be aged against the last block like the tracker does.
+	uc.pending.reconcile = nil
+	err = uc.pending.check(now.Add(-time.Minute * 50))
+	c.Assert(err, IsNil)
+	txs, _, err = uc.GetPendingTxs(context.Background(), models.NewPage(0, 10))
+	c.Assert(err, IsNil)
+	c.Assert(txs[0].Age, Equals, time.Minute*10)
+	c.Assert(txs[0].Stuck, Equals, false)
+
+	_, _, err = uc.GetPendingTxs(context.Background(), models.NewPage(0, 100))
+	c.Assert(err, NotNil)
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/models"
+)
+
+// maxConcentrationPoints is the maximum number of points in pool concentration history.
+const maxConcentrationPoints = 100
+
+// GetPoolStakers returns a page of the specified pool stakers ranked by units alongside
+// the current concentration of the pool ownership.
+func (uc *Usecase) GetPoolStakers(ctx context.Context, pool common.Asset, page models.Page) (*models.PoolStakers, error) {
+	err := page.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	stakers, err := uc.store.GetPoolStakers(ctx, pool, now)
+	if err != nil {
+		return nil, err
+	}
+	concentration := calculateConcentration(stakers)
+	concentration.Time = now
+
+	count := int64(len(stakers))
+	start := page.Offset
+	if start > count {
+		start = count
+	}
+	end := start + page.Limit
+	if end > count {
+		end = count
+	}
+	result := &models.PoolStakers{
+		Stakers:       stakers[start:end],
+		TotalCount:    count,
+		Concentration: concentration,
+	}
+	return result, nil
+}
+
+// GetPoolConcentrationHistory returns the concentration of the specified pool ownership
+// at the start of each interval between from and to. The units of the stakers are
+// fetched at once as changes per interval and summed up to each point.
+func (uc *Usecase) GetPoolConcentrationHistory(ctx context.Context, pool common.Asset, inv models.Interval, from, to time.Time) ([]models.PoolConcentration, error) {
+	if err := inv.Validate(); err != nil {
+		return nil, err
+	}
+	step := intervalDuration(inv)
+	if step == 0 {
+		return nil, errors.New("interval should be bounded")
+	}
+	if to.Before(from) {
+		return nil, errors.New("from should be before to")
+	}
+	if int64(to.Sub(from)/step) >= maxConcentrationPoints {
+		return nil, errors.Errorf("requested period contains more than %d intervals", maxConcentrationPoints)
+	}
+
+	changes, err := uc.store.GetPoolStakersChanges(ctx, pool, from, to, step)
+	if err != nil {
+		return nil, err
+	}
+	units := map[common.Address]int64{}
+	history := []models.PoolConcentration{}
+	var point int64
+	for t := from; !t.After(to); t = t.Add(step) {
+		for len(changes) > 0 && changes[0].Point <= point {
+			units[changes[0].Address] += changes[0].Units
+			changes = changes[1:]
+		}
+		concentration := calculateConcentration(rankStakers(units))
+		concentration.Time = t
+		history = append(history, concentration)
+		point++
+	}
+	return history, nil
+}
+
+// rankStakers returns the stakers with units sorted by units in descending order.
+func rankStakers(units map[common.Address]int64) []models.PoolStaker {
+	stakers := []models.PoolStaker{}
+	for addr, u := range units {
+		if u > 0 {
+			stakers = append(stakers, models.PoolStaker{Address: addr, Units: u})
+		}
+	}
+	sort.Slice(stakers, func(i, j int) bool {
+		if stakers[i].Units != stakers[j].Units {
+			return stakers[i].Units > stakers[j].Units
+		}
+		return stakers[i].Address < stakers[j].Address
+	})
+	return stakers
+}
+
+// calculateConcentration returns the ownership concentration metrics of a pool given
+// its stakers sorted by units in descending order, and fills their share of the pool.
+func calculateConcentration(stakers []models.PoolStaker) models.PoolConcentration {
+	var total int64
+	for _, staker := range stakers {
+		total += staker.Units
+	}
+	concentration := models.PoolConcentration{
+		StakersCount: int64(len(stakers)),
+		Units:        total,
+	}
+	if total <= 0 {
+		return concentration
+	}
+
+	// Gini = (2 * sum(rank * units)) / (n * total) - (n + 1) / n
+	// where stakers are ranked by units in ascending order starting from 1.
+	n := float64(len(stakers))
+	var rankedSum float64
+	for i := range stakers {
+		share := float64(stakers[i].Units) / float64(total)
+		stakers[i].Share = share
+		concentration.Herfindahl += share * share
+		if i < 10 {
+			concentration.Top10Share += share
+		}
+		rankedSum += (n - float64(i)) * float64(stakers[i].Units)
+	}
+	concentration.Gini = 2*rankedSum/(n*float64(total)) - (n+1)/n
+	return concentration
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/models"
+	. "gopkg.in/check.v1"
+)
+
+type TestGetPoolStakersStore struct {
+	StoreDummy
+	stakers []models.PoolStaker
+	changes []models.PoolStakerChange
+	times   []time.Time
+	err     error
+}
+
+func (s *TestGetPoolStakersStore) GetPoolStakers(ctx context.Context, _ common.Asset, at time.Time) ([]models.PoolStaker, error) {
+	s.times = append(s.times, at)
+	stakers := make([]models.PoolStaker, len(s.stakers))
+	copy(stakers, s.stakers)
+	return stakers, s.err
+}
+
+func (s *TestGetPoolStakersStore) GetPoolStakersChanges(ctx context.Context, _ common.Asset, from, to time.Time, step time.Duration) ([]models.PoolStakerChange, error) {
+	s.times = append(s.times, from, to)
+	return s.changes, s.err
+}
+
+func (s *UsecaseSuite) TestGetPoolStakers(c *C) {
+	store := &TestGetPoolStakersStore{
+		stakers: []models.PoolStaker{
+			{Address: "bnb1a", Units: 500},
+			{Address: "bnb1b", Units: 300},
+			{Address: "bnb1c", Units: 200},
+		},
+	}
+	uc, err := NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
+	c.Assert(err, IsNil)
+
+	stakers, err := uc.GetPoolStakers(context.Background(), common.BNBAsset, models.NewPage(1, 5))
+	c.Assert(err, IsNil)
+	c.Assert(stakers.TotalCount, Equals, int64(3))
+	c.Assert(stakers.Stakers, DeepEquals, []models.PoolStaker{
+		{Address: "bnb1b", Units: 300, Share: 0.3},
+		{Address: "bnb1c", Units: 200, Share: 0.2},
+	})
+	c.Assert(stakers.Concentration.StakersCount, Equals, int64(3))
+	c.Assert(stakers.Concentration.Units, Equals, int64(1000))
+	c.Assert(stakers.Concentration.Top10Share, Equals, 1.0)
+	// (0.5^2 + 0.3^2 + 0.2^2)
+	c.Assert(math.Abs(stakers.Concentration.Herfindahl-0.38) < 1e-9, Equals, true)
+	// 2 * (1*200 + 2*300 + 3*500) / (3 * 1000) - 4/3
+	c.Assert(math.Abs(stakers.Concentration.Gini-0.2) < 1e-9, Equals, true)
+
+	stakers, err = uc.GetPoolStakers(context.Background(), common.BNBAsset, models.NewPage(10, 5))
+	c.Assert(err, IsNil)
+	c.Assert(stakers.Stakers, HasLen, 0)
+	c.Assert(stakers.TotalCount, Equals, int64(3))
+
+	_, err = uc.GetPoolStakers(context.Background(), common.BNBAsset, models.NewPage(0, 0))
+	c.Assert(err, NotNil)
+
+	store.err = errors.New("could not fetch requested data")
+	_, err = uc.GetPoolStakers(context.Background(), common.BNBAsset, models.NewPage(0, 5))
+	c.Assert(err, NotNil)
+}
+
+func (s *UsecaseSuite) TestGetPoolConcentrationHistory(c *C) {
+	store := &TestGetPoolStakersStore{
+		changes: []models.PoolStakerChange{
+			{Address: "bnb1a", Point: 0, Units: 1000},
+			{Address: "bnb1b", Point: 2, Units: 1000},
+			{Address: "bnb1a", Point: 3, Units: -1000},
+		},
+	}
+	uc, err := NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
+	c.Assert(err, IsNil)
+
+	from := time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC)
+	history, err := uc.GetPoolConcentrationHistory(context.Background(), common.BNBAsset, models.DailyInterval, from, from.Add(day*3))
+	c.Assert(err, IsNil)
+	c.Assert(store.times, DeepEquals, []time.Time{from, from.Add(day * 3)})
+	c.Assert(history, HasLen, 4)
+	c.Assert(history[1], DeepEquals, models.PoolConcentration{
+		Time:         from.Add(day),
+		StakersCount: 1,
+		Units:        1000,
+		Gini:         0,
+		Top10Share:   1,
+		Herfindahl:   1,
+	})
+	c.Assert(history[2].StakersCount, Equals, int64(2))
+	c.Assert(history[2].Units, Equals, int64(2000))
+	c.Assert(history[2].Herfindahl, Equals, 0.5)
+	c.Assert(history[3].StakersCount, Equals, int64(1))
+	c.Assert(history[3].Units, Equals, int64(1000))
+
+	_, err = uc.GetPoolConcentrationHistory(context.Background(), common.BNBAsset, models.MaxInterval, from, from.Add(day))
+	c.Assert(err, NotNil)
+	_, err = uc.GetPoolConcentrationHistory(context.Background(), common.BNBAsset, models.DailyInterval, from, from.Add(-day))
+	c.Assert(err, NotNil)
+	_, err = uc.GetPoolConcentrationHistory(context.Background(), common.BNBAsset, models.HourlyInterval, from, from.Add(day*10))
+	c.Assert(err, NotNil)
+
+	store.err = errors.New("could not fetch requested data")
+	_, err = uc.GetPoolConcentrationHistory(context.Background(), common.BNBAsset, models.DailyInterval, from, from.Add(day))
+	c.Assert(err, NotNil)
+}
+
+func (s *UsecaseSuite) TestCalculateConcentration(c *C) {
+	concentration := calculateConcentration(nil)
+	c.Assert(concentration, DeepEquals, models.PoolConcentration{})
+
+	stakers := make([]models.PoolStaker, 20)
+	for i := range stakers {
+		stakers[i] = models.PoolStaker{Units: 100}
+	}
+	concentration = calculateConcentration(stakers)
+	c.Assert(concentration.StakersCount, Equals, int64(20))
+	c.Assert(math.Abs(concentration.Gini) < 1e-9, Equals, true)
+	c.Assert(math.Abs(concentration.Top10Share-0.5) < 1e-9, Equals, true)
+	c.Assert(math.Abs(concentration.Herfindahl-0.05) < 1e-9, Equals, true)
+	c.Assert(stakers[0].Share, Equals, 0.05)
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/models"
+	"gitlab.com/thorchain/midgard/internal/store"
+	"gitlab.com/thorchain/midgard/pkg/clients/pricefeed"
+)
+
+// deviationDetector compares the USD price implied by each pool with the reference
+// price of its asset on every new block and stores the alerts of the pools which
+// have deviated more than the threshold. The reference prices are fetched in
+// background so the scanner doesn't wait for the price source.
+type deviationDetector struct {
+	store       store.Store
+	source      pricefeed.Source
+	threshold   float64
+	maxBlockAge time.Duration
+	background  backgroundTask
+	logger      zerolog.Logger
+}
+
+func newDeviationDetector(store store.Store, source pricefeed.Source, threshold float64, maxBlockAge time.Duration) *deviationDetector {
+	return &deviationDetector{
+		store:       store,
+		source:      source,
+		threshold:   threshold,
+		maxBlockAge: maxBlockAge,
+		logger:      log.With().Str("module", "deviation_detector").Logger(),
+	}
+}
+
+// newBlock is called by event handler after each block is processed.
+func (d *deviationDetector) newBlock(height int64, blockTime time.Time) {
+	record, err := d.prepare(height, blockTime)
+	if err != nil {
+		d.logger.Error().Err(err).Int64("height", height).Msg("failed to check price deviations")
+	}
+	if record == nil {
+		return
+	}
+	d.background.run(func() {
+		err := record()
+		if err != nil {
+			d.logger.Error().Err(err).Int64("height", height).Msg("failed to check price deviations")
+		}
+	})
+}
+
+// check records the price deviation of every pool with a reference price at the given block.
+func (d *deviationDetector) check(height int64, blockTime time.Time) error {
+	record, err := d.prepare(height, blockTime)
+	if err != nil || record == nil {
+		return err
+	}
+	return record()
+}
+
+// prepare reads the pool depths at the given block and returns the function
+// fetching the reference prices and recording the deviations. It returns nil
+// if the block is skipped.
+func (d *deviationDetector) prepare(height int64, blockTime time.Time) (func() error, error) {
+	// Current reference prices mean nothing for old blocks.
+	if d.maxBlockAge > 0 && time.Since(blockTime) > d.maxBlockAge {
+		return nil, nil
+	}
+
+	pools, err := d.store.GetPools(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	depths := make([]models.PoolBasics, 0, len(pools))
+	for _, pool := range pools {
+		basics, err := d.store.GetPoolBasics(context.Background(), pool)
+		if err != nil {
+			return nil, err
+		}
+		if basics.AssetDepth <= 0 || basics.RuneDepth <= 0 {
+			continue
+		}
+		basics.Asset = pool
+		depths = append(depths, basics)
+	}
+	return func() error {
+		return d.record(height, blockTime, depths)
+	}, nil
+}
+
+// record records the price deviation of the given pools with a reference price.
+func (d *deviationDetector) record(height int64, blockTime time.Time, depths []models.PoolBasics) error {
+	prices, err := d.source.GetPrices()
+	if err != nil {
+		return errors.Wrap(err, "could not get reference prices")
+	}
+	runePrice := prices[pricefeed.RuneKey]
+	if runePrice <= 0 {
+		return errors.New("RUNE price is missing from reference prices")
+	}
+	for _, basics := range depths {
+		pool := basics.Asset
+		refPrice := prices[pool.String()]
+		if refPrice <= 0 {
+			continue
+		}
+
+		poolPrice := calculatePrice(basics.AssetDepth, basics.RuneDepth) * runePrice
+		record := models.PriceDeviation{
+			Time:           blockTime,
+			Height:         height,
+			Pool:           pool,
+			PoolPrice:      poolPrice,
+			ReferencePrice: refPrice,
+			Deviation:      (poolPrice - refPrice) / refPrice,
+		}
+		err = d.store.CreatePriceDeviationRecord(&record)
+		if err != nil {
+			return errors.Wrapf(err, "could not store price deviation of pool %s", pool)
+		}
+		err = d.updateAlert(record)
+		if err != nil {
+			return errors.Wrapf(err, "could not update price deviation alert of pool %s", pool)
+		}
+	}
+	return nil
+}
+
+// updateAlert creates or updates the alert of the pool of record if it has
+// deviated more than the threshold, or removes it otherwise.
+func (d *deviationDetector) updateAlert(record models.PriceDeviation) error {
+	if math.Abs(record.Deviation) < d.threshold {
+		return d.store.DeleteDeviationAlert(record.Pool)
+	}
+	return d.store.SaveDeviationAlert(&record)
+}
+
+// getAlert returns the current alert of the given pool or nil if it's not deviated.
+func (d *deviationDetector) getAlert(ctx context.Context, pool common.Asset) (*models.DeviationAlert, error) {
+	alerts, err := d.store.GetDeviationAlerts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, alert := range alerts {
+		if alert.Pool.Equals(pool) {
+			return &alert, nil
+		}
+	}
+	return nil, nil
+}
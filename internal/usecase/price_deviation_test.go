@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/models"
+	"gitlab.com/thorchain/midgard/pkg/clients/pricefeed"
+	. "gopkg.in/check.v1"
+)
+
+type TestDeviationStore struct {
+	StoreDummy
+	basics  map[common.Asset]models.PoolBasics
+	records []models.PriceDeviation
+	alerts  []models.DeviationAlert
+}
+
+func (s *TestDeviationStore) GetPools(ctx context.Context) ([]common.Asset, error) {
+	return []common.Asset{common.BNBAsset, common.BTCAsset}, nil
+}
+
+func (s *TestDeviationStore) GetPoolBasics(ctx context.Context, asset common.Asset) (models.PoolBasics, error) {
+	return s.basics[asset], nil
+}
+
+func (s *TestDeviationStore) CreatePriceDeviationRecord(record *models.PriceDeviation) error {
+	s.records = append(s.records, *record)
+	return nil
+}
+
+func (s *TestDeviationStore) GetPriceDeviations(ctx context.Context, pool common.Asset, _, _ time.Time) ([]models.PriceDeviation, error) {
+	var result []models.PriceDeviation
+	for _, record := range s.records {
+		if record.Pool.Equals(pool) {
+			result = append(result, record)
+		}
+	}
+	return result, nil
+}
+
+func (s *TestDeviationStore) SaveDeviationAlert(record *models.PriceDeviation) error {
+	for i, alert := range s.alerts {
+		if alert.Pool.Equals(record.Pool) {
+			s.alerts[i].PriceDeviation = *record
+			return nil
+		}
+	}
+	s.alerts = append(s.alerts, models.DeviationAlert{PriceDeviation: *record, Since: record.Time})
+	return nil
+}
+
+func (s *TestDeviationStore) DeleteDeviationAlert(pool common.Asset) error {
+	for i, alert := range s.alerts {
+		if alert.Pool.Equals(pool) {
+			s.alerts = append(s.alerts[:i], s.alerts[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (s *TestDeviationStore) GetDeviationAlerts(ctx context.Context) ([]models.DeviationAlert, error) {
+	return append([]models.DeviationAlert{}, s.alerts...), nil
+}
+
+type TestPriceSource struct {
+	prices map[string]float64
+	err    error
+}
+
+func (s *TestPriceSource) GetPrices() (map[string]float64, error) {
+	return s.prices, s.err
+}
+
+func (s *UsecaseSuite) TestPriceDeviation(c *C) {
+	store := &TestDeviationStore{
+		basics: map[common.Asset]models.PoolBasics{
+			common.BNBAsset: {
+				Asset:      common.BNBAsset,
+				AssetDepth: 100,
+				RuneDepth:  3400,
+			},
+			common.BTCAsset: {
+				Asset:      common.BTCAsset,
+				AssetDepth: 100,
+				RuneDepth:  50,
+			},
+		},
+	}
+	source := &TestPriceSource{
+		prices: map[string]float64{
+			pricefeed.RuneKey:        0.5,
+			common.BNBAsset.String(): 17,
+			// BTC reference price is deliberately half of the pool price.
+			common.BTCAsset.String(): 0.125,
+		},
+	}
+	conf := &Config{
+		PriceSource:          source,
+		DeviationThreshold:   0.05,
+		DeviationMaxBlockAge: time.Minute,
+	}
+	uc, err := NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, conf)
+	c.Assert(err, IsNil)
+
+	now := time.Now()
+	err = uc.detector.check(1, now)
+	c.Assert(err, IsNil)
+	c.Assert(store.records, DeepEquals, []models.PriceDeviation{
+		{
+			Time:           now,
+			Height:         1,
+			Pool:           common.BNBAsset,
+			PoolPrice:      17,
+			ReferencePrice: 17,
+			Deviation:      0,
+		},
+		{
+			Time:           now,
+			Height:         1,
+			Pool:           common.BTCAsset,
+			PoolPrice:      0.25,
+			ReferencePrice: 0.125,
+			Deviation:      1,
+		},
+	})
+	alerts, err := uc.GetDeviationAlerts(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(alerts, HasLen, 1)
+	c.Assert(alerts[0].Pool, Equals, common.BTCAsset)
+	c.Assert(alerts[0].Since, Equals, now)
+
+	// Alert should be kept since the first deviated block.
+	err = uc.detector.check(2, now.Add(time.Second))
+	c.Assert(err, IsNil)
+	deviation, err := uc.GetPoolDeviation(context.Background(), common.BTCAsset, now, now.Add(time.Second))
+	c.Assert(err, IsNil)
+	c.Assert(deviation.History, HasLen, 2)
+	c.Assert(deviation.Alert, NotNil)
+	c.Assert(deviation.Alert.Height, Equals, int64(2))
+	c.Assert(deviation.Alert.Since, Equals, now)
+	deviation, err = uc.GetPoolDeviation(context.Background(), common.BNBAsset, now, now.Add(time.Second))
+	c.Assert(err, IsNil)
+	c.Assert(deviation.History, HasLen, 2)
+	c.Assert(deviation.Alert, IsNil)
+
+	// Alert should be removed when arbitrageurs correct the pool price.
+	store.basics[common.BTCAsset] = models.PoolBasics{
+		Asset:      common.BTCAsset,
+		AssetDepth: 100,
+		RuneDepth:  25,
+	}
+	err = uc.detector.check(3, now.Add(time.Second*2))
+	c.Assert(err, IsNil)
+	alerts, err = uc.GetDeviationAlerts(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(alerts, HasLen, 0)
+	c.Assert(store.records, HasLen, 6)
+
+	// Old blocks should be skipped.
+	err = uc.detector.check(4, now.Add(-time.Hour))
+	c.Assert(err, IsNil)
+	c.Assert(store.records, HasLen, 6)
+
+	// RUNE price is required.
+	delete(source.prices, pricefeed.RuneKey)
+	err = uc.detector.check(5, now)
+	c.Assert(err, NotNil)
+
+	source.err = errors.New("could not fetch requested data")
+	err = uc.detector.check(6, now)
+	c.Assert(err, NotNil)
+
+	// Detector is disabled without price source.
+	uc, err = NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
+	c.Assert(err, IsNil)
+	c.Assert(uc.detector, IsNil)
+	alerts, err = uc.GetDeviationAlerts(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(alerts, HasLen, 0)
+	deviation, err = uc.GetPoolDeviation(context.Background(), common.BTCAsset, now, now)
+	c.Assert(err, IsNil)
+	c.Assert(deviation.Alert, IsNil)
+}
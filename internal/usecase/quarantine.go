@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"gitlab.com/thorchain/midgard/internal/models"
+)
+
+// ErrNothingQuarantined is returned when a reprocess is requested while no event is quarantined.
+var ErrNothingQuarantined = errors.New("no events are quarantined")
+
+// GetQuarantinedEvents returns the events which couldn't be decoded with the
+// registered schemas, ordered by height.
+func (uc *Usecase) GetQuarantinedEvents(ctx context.Context, page models.Page) ([]models.QuarantinedEvent, int64, error) {
+	err := page.Validate()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return uc.store.GetQuarantinedEvents(ctx, page.Offset, page.Limit)
+}
+
+// StartReprocess starts reprocessing the quarantined events in background by
+// rebuilding the derived tables from the archived raw events with the current
+// schemas. The quarantined events which can be decoded now are processed in
+// order and the current tables keep serving until the new ones replace them.
+// The progress and the number of events still quarantined are reported in
+// health status.
+func (uc *Usecase) StartReprocess() (models.RebuildStatus, error) {
+	_, count, err := uc.store.GetQuarantinedEvents(context.Background(), 0, 1)
+	if err != nil {
+		return models.RebuildStatus{}, errors.Wrap(err, "could not get quarantined events")
+	}
+	if count == 0 {
+		return models.RebuildStatus{}, ErrNothingQuarantined
+	}
+	return uc.StartRebuild()
+}
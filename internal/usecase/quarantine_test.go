@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/midgard/internal/models"
+	"gitlab.com/thorchain/midgard/internal/store"
+)
+
+type ReprocessTestStore struct {
+	*RebuildTestStore
+	quarantined []models.QuarantinedEvent
+	shadow      *ReprocessShadowStore
+}
+
+func (s *ReprocessTestStore) GetQuarantinedEvents(ctx context.Context, offset, limit int64) ([]models.QuarantinedEvent, int64, error) {
+	return nil, int64(len(s.quarantined)), nil
+}
+
+func (s *ReprocessTestStore) CreateShadow() (store.Store, error) {
+	return s.shadow, nil
+}
+
+type ReprocessShadowStore struct {
+	*QuarantineTestStore
+	pools []models.EventPool
+}
+
+func (s *ReprocessShadowStore) CreatePoolRecord(record *models.EventPool) error {
+	s.pools = append(s.pools, *record)
+	return nil
+}
+
+func (s *UsecaseSuite) TestStartReprocess(c *C) {
+	blockTime := time.Now()
+	db := &ReprocessTestStore{
+		RebuildTestStore: &RebuildTestStore{
+			raw: []models.RawEvent{
+				{Height: 100, Time: blockTime, Stage: models.RawEventStageEnd, Type: "pool", Attributes: map[string]string{"pool": "BNB.BNB", "pool_status": "Enabled"}},
+				{Height: 150, Time: blockTime, Stage: models.RawEventStageEnd, Type: "pool", Attributes: map[string]string{"pool": "BNB.BNB", "pool_status": "Bootstrap"}},
+				{Height: 150, Time: blockTime, Stage: models.RawEventStageEnd, EventIndex: 1, Type: "new_event", Attributes: map[string]string{"foo": "bar"}},
+				{Height: 150, Time: blockTime, Stage: models.RawEventStageEnd, EventIndex: 2, Type: "stake", Attributes: map[string]string{"pool": "BNB.BNB"}},
+				{Height: 300, Time: blockTime, Stage: models.RawEventStageEnd, Type: "pool", Attributes: map[string]string{"pool": "BNB.BNB", "pool_status": "Enabled"}},
+			},
+			lasts:      []int64{300},
+			firstEvent: 100,
+		},
+		shadow: &ReprocessShadowStore{
+			QuarantineTestStore: &QuarantineTestStore{},
+		},
+	}
+	uc, err := NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, db, s.config)
+	c.Assert(err, IsNil)
+
+	// Nothing quarantined
+	_, err = uc.StartReprocess()
+	c.Assert(err, Equals, ErrNothingQuarantined)
+	c.Assert(uc.rebuild.current(), IsNil)
+
+	// The pool event was quarantined by a previous version.
+	db.quarantined = []models.QuarantinedEvent{
+		{Height: 150, Type: "pool", Reason: "no schema of pool event at height 150"},
+		{Height: 150, Type: "stake", Reason: `attribute "stake_units" is missing from stake event (schema from height 0)`},
+	}
+	status, err := uc.StartReprocess()
+	c.Assert(err, IsNil)
+	c.Assert(status.State, Equals, models.RebuildStateRunning)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for uc.rebuild.current().State == models.RebuildStateRunning && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	current := uc.rebuild.current()
+	c.Assert(current.State, Equals, models.RebuildStateDone)
+	c.Assert(current.Error, Equals, "")
+	c.Assert(current.Quarantined, Equals, int64(1))
+	c.Assert(db.swapped, Equals, db.shadow)
+	c.Assert(db.shadow.pools, HasLen, 3)
+	c.Assert(db.shadow.pools[1].Height, Equals, int64(150))
+	c.Assert(db.shadow.pools[1].Status, Equals, models.Bootstrap)
+	c.Assert(db.shadow.quarantined, HasLen, 1)
+	c.Assert(db.shadow.quarantined[0].Type, Equals, "stake")
+}
@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"gitlab.com/thorchain/midgard/internal/models"
+)
+
+// ErrRebuildInProgress is returned when a rebuild is requested while another one is running.
+var ErrRebuildInProgress = errors.New("rebuild already in progress")
+
+// rebuildSwapLag is the number of blocks behind the last archived block under
+// which the scanner is paused to replay the remaining blocks and swap the tables.
+const rebuildSwapLag = 100
+
+// rebuilder rebuilds the derived tables in shadow tables from the archived raw
+// events while the current tables keep serving, then swaps them.
+type rebuilder struct {
+	mu     sync.Mutex
+	status *models.RebuildStatus
+	logger zerolog.Logger
+}
+
+func newRebuilder() *rebuilder {
+	return &rebuilder{
+		logger: log.With().Str("module", "rebuild").Logger(),
+	}
+}
+
+// start marks a new rebuild as running unless another one is running already.
+func (r *rebuilder) start() (models.RebuildStatus, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.status != nil && r.status.State == models.RebuildStateRunning {
+		return *r.status, ErrRebuildInProgress
+	}
+	r.status = &models.RebuildStatus{
+		State:     models.RebuildStateRunning,
+		StartedAt: time.Now(),
+	}
+	return *r.status, nil
+}
+
+func (r *rebuilder) setStart(height int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.status.StartHeight = height
+}
+
+func (r *rebuilder) setTarget(height int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.status.TargetHeight = height
+}
+
+func (r *rebuilder) setHeight(height int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.status.Height = height
+	r.logger.Debug().Int64("height", height).Int64("target", r.status.TargetHeight).Msg("blocks replayed")
+}
+
+func (r *rebuilder) setQuarantined(count int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.status.Quarantined = count
+}
+
+func (r *rebuilder) finish(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.status.FinishedAt = &now
+	if err != nil {
+		r.status.State = models.RebuildStateFailed
+		r.status.Error = err.Error()
+		r.logger.Error().Err(err).Msg("rebuild failed")
+		return
+	}
+	r.status.State = models.RebuildStateDone
+	r.logger.Info().Msg("rebuild finished")
+}
+
+// current returns a copy of the status of the latest rebuild or nil if there was none.
+func (r *rebuilder) current() *models.RebuildStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.status == nil {
+		return nil
+	}
+	status := *r.status
+	if status.TargetHeight >= status.StartHeight && status.Height >= status.StartHeight {
+		replayed := status.Height - status.StartHeight + 1
+		status.Progress = float64(replayed) / float64(status.TargetHeight-status.StartHeight+1)
+	}
+	return &status
+}
+
+// StartRebuild starts rebuilding the derived tables in background. The progress
+// is reported in health status.
+func (uc *Usecase) StartRebuild() (models.RebuildStatus, error) {
+	status, err := uc.rebuild.start()
+	if err != nil {
+		return status, err
+	}
+	go func() {
+		uc.rebuild.finish(uc.rebuildDerivedTables())
+	}()
+	return status, nil
+}
+
+func (uc *Usecase) rebuildDerivedTables() error {
+	first, last, err := uc.store.GetRawEventsRange()
+	if err != nil {
+		return errors.Wrap(err, "could not get archived range")
+	}
+	if first == 0 {
+		return errors.New("no raw events archived")
+	}
+	firstEvent, err := uc.store.GetFirstHeight()
+	if err != nil {
+		return errors.Wrap(err, "could not get first height")
+	}
+	if firstEvent != 0 && firstEvent < first {
+		return errors.Errorf("raw events are not archived before height %d", first)
+	}
+
+	shadow, err := uc.store.CreateShadow()
+	if err != nil {
+		return errors.Wrap(err, "could not create shadow tables")
+	}
+	eh, err := newEventHandler(shadow, uc.thorchain)
+	if err != nil {
+		return errors.Wrap(err, "could not create event handler")
+	}
+	uc.rebuild.setStart(first)
+	height := first - 1
+	for last-height > rebuildSwapLag {
+		uc.rebuild.setTarget(last)
+		err = uc.replay(eh, height+1, last, uc.rebuild.setHeight)
+		if err != nil {
+			return err
+		}
+		height = last
+		_, last, err = uc.store.GetRawEventsRange()
+		if err != nil {
+			return errors.Wrap(err, "could not get archived range")
+		}
+	}
+
+	// Pause the scanner so no block is missed between the last replayed one and the swap.
+	uc.eh.mu.Lock()
+	defer uc.eh.mu.Unlock()
+
+	_, last, err = uc.store.GetRawEventsRange()
+	if err != nil {
+		return errors.Wrap(err, "could not get archived range")
+	}
+	uc.rebuild.setTarget(last)
+	err = uc.replay(eh, height+1, last, uc.rebuild.setHeight)
+	if err != nil {
+		return err
+	}
+	_, quarantined, err := shadow.GetQuarantinedEvents(context.Background(), 0, 1)
+	if err != nil {
+		return errors.Wrap(err, "could not get quarantined events")
+	}
+	err = uc.store.SwapShadow(shadow)
+	if err != nil {
+		return errors.Wrap(err, "could not swap tables")
+	}
+	atomic.AddInt64(&uc.generation, 1)
+	uc.rebuild.setQuarantined(quarantined)
+	return nil
+}
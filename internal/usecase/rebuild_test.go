@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"gitlab.com/thorchain/midgard/internal/models"
+	"gitlab.com/thorchain/midgard/internal/store"
+	. "gopkg.in/check.v1"
+)
+
+type RebuildTestStore struct {
+	*StoreDummy
+	raw        []models.RawEvent
+	lasts      []int64
+	firstEvent int64
+	shadow     *RawEventsTestStore
+	swapped    store.Store
+	created    chan struct{}
+	proceed    chan struct{}
+}
+
+func (s *RebuildTestStore) GetRawEventsRange() (int64, int64, error) {
+	// Simulate the scanner archiving new blocks between calls.
+	last := s.lasts[0]
+	if len(s.lasts) > 1 {
+		s.lasts = s.lasts[1:]
+	}
+	return s.raw[0].Height, last, nil
+}
+
+func (s *RebuildTestStore) GetFirstHeight() (int64, error) {
+	return s.firstEvent, nil
+}
+
+func (s *RebuildTestStore) GetRawEvents(from, to int64) ([]models.RawEvent, error) {
+	var events []models.RawEvent
+	for _, ev := range s.raw {
+		if ev.Height >= from && ev.Height <= to {
+			events = append(events, ev)
+		}
+	}
+	return events, nil
+}
+
+func (s *RebuildTestStore) CreateShadow() (store.Store, error) {
+	if s.created != nil {
+		s.created <- struct{}{}
+		<-s.proceed
+	}
+	return s.shadow, nil
+}
+
+func (s *RebuildTestStore) SwapShadow(shadow store.Store) error {
+	s.swapped = shadow
+	return nil
+}
+
+func (s *RawEventsTestStore) GetQuarantinedEvents(ctx context.Context, offset, limit int64) ([]models.QuarantinedEvent, int64, error) {
+	return nil, 0, nil
+}
+
+func (s *UsecaseSuite) TestRebuild(c *C) {
+	blockTime := time.Now()
+	gas := func(height int64) models.RawEvent {
+		return models.RawEvent{
+			Height: height,
+			Time:   blockTime,
+			Stage:  models.RawEventStageTx,
+			Type:   "gas",
+			Attributes: map[string]string{
+				"asset":     "BNB.BNB",
+				"asset_amt": "75000",
+				"rune_amt":  "24900200",
+			},
+		}
+	}
+	db := &RebuildTestStore{
+		raw:        []models.RawEvent{gas(1), gas(150), gas(1200), gas(1260)},
+		lasts:      []int64{1200, 1250, 1260},
+		firstEvent: 1,
+		shadow:     &RawEventsTestStore{},
+		created:    make(chan struct{}),
+		proceed:    make(chan struct{}),
+	}
+	uc, err := NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, db, s.config)
+	c.Assert(err, IsNil)
+	c.Assert(uc.rebuild.current(), IsNil)
+
+	status, err := uc.StartRebuild()
+	c.Assert(err, IsNil)
+	c.Assert(status.State, Equals, models.RebuildStateRunning)
+	<-db.created
+	_, err = uc.StartRebuild()
+	c.Assert(err, Equals, ErrRebuildInProgress)
+	close(db.proceed)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for uc.rebuild.current().State == models.RebuildStateRunning && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	current := uc.rebuild.current()
+	c.Assert(current.State, Equals, models.RebuildStateDone)
+	c.Assert(current.Error, Equals, "")
+	c.Assert(current.StartHeight, Equals, int64(1))
+	c.Assert(current.Height, Equals, int64(1260))
+	c.Assert(current.TargetHeight, Equals, int64(1260))
+	c.Assert(current.Progress, Equals, 1.0)
+	c.Assert(current.FinishedAt, NotNil)
+	c.Assert(db.swapped, Equals, db.shadow)
+	c.Assert(db.shadow.gas, HasLen, 4)
+	for i, height := range []int64{1, 150, 1200, 1260} {
+		c.Assert(db.shadow.gas[i].Height, Equals, height)
+	}
+}
+
+func (s *UsecaseSuite) TestRebuildMissingArchive(c *C) {
+	db := &RebuildTestStore{
+		raw:        []models.RawEvent{{Height: 100}},
+		lasts:      []int64{200},
+		firstEvent: 1,
+	}
+	uc, err := NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, db, s.config)
+	c.Assert(err, IsNil)
+	_, err = uc.StartRebuild()
+	c.Assert(err, IsNil)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for uc.rebuild.current().State == models.RebuildStateRunning && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	current := uc.rebuild.current()
+	c.Assert(current.State, Equals, models.RebuildStateFailed)
+	c.Assert(current.Error, Equals, "raw events are not archived before height 100")
+	c.Assert(db.swapped, IsNil)
+}
+
+func (s *UsecaseSuite) TestRebuildProgress(c *C) {
+	r := newRebuilder()
+	_, err := r.start()
+	c.Assert(err, IsNil)
+	r.setStart(1001)
+	r.setTarget(2000)
+	c.Assert(r.current().Progress, Equals, 0.0)
+
+	// The progress counts the blocks from the first archived one.
+	r.setHeight(1500)
+	c.Assert(r.current().Progress, Equals, 0.5)
+	r.setHeight(2000)
+	c.Assert(r.current().Progress, Equals, 1.0)
+}
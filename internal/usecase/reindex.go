@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+
+	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/store"
+	"gitlab.com/thorchain/midgard/pkg/clients/thorchain"
+)
+
+// reindexBatchSize is the number of blocks whose raw events are fetched at once while reindexing.
+const reindexBatchSize = 1000
+
+// Reindex deletes the data derived from the events between the given heights and
+// rebuilds it by replaying the archived raw events. Zero to means the last indexed
+// height. The range is extended to cover the events linked by outbound or fee
+// events across its bounds. It works on the store only: the in txs of the stake
+// events, which are fetched from the node when the blocks are scanned, are read
+// from the derived data before it's deleted. The pool depths of the blocks after
+// the range are recomputed, since they're accumulated from the blocks before.
+// The scanner must not run while reindexing.
+func Reindex(store store.Store, from, to int64) error {
+	logger := log.With().Str("module", "reindex").Logger()
+	uc := &Usecase{store: store}
+
+	last, err := store.GetLastHeight()
+	if err != nil {
+		return errors.Wrap(err, "could not get last height")
+	}
+	if to == 0 || to > last {
+		to = last
+	}
+	if from < 1 || from > to {
+		return errors.Errorf("invalid height range [%d, %d]", from, to)
+	}
+	from, to, err = uc.linkedRange(from, to)
+	if err != nil {
+		return err
+	}
+
+	txs, err := store.GetStakeTxs(from, to)
+	if err != nil {
+		return errors.Wrap(err, "could not get stake txs")
+	}
+	archive := archivedTxs{}
+	for _, tx := range txs {
+		archive[tx.ID] = tx
+	}
+	eh, err := newEventHandler(store, archive)
+	if err != nil {
+		return errors.Wrap(err, "could not create event handler")
+	}
+
+	logger.Info().Int64("from", from).Int64("to", to).Msg("deleting derived data")
+	err = store.DeleteHeightRange(from, to)
+	if err != nil {
+		return errors.Wrap(err, "could not delete derived data")
+	}
+	err = uc.replay(eh, from, to, func(height int64) {
+		logger.Info().Int64("height", height).Msg("blocks replayed")
+	})
+	if err != nil {
+		return err
+	}
+	if to < last {
+		logger.Info().Int64("from", from).Msg("updating pool depths")
+		err = store.UpdatePoolDepths(from)
+		if err != nil {
+			return errors.Wrap(err, "could not update pool depths")
+		}
+	}
+	return nil
+}
+
+// archivedTxs serves the in txs of the stake events saved before reindexing, so
+// the blocks are replayed without the node.
+type archivedTxs map[common.TxID]common.Tx
+
+func (a archivedTxs) GetTx(_ context.Context, txID common.TxID) (common.Tx, error) {
+	tx, ok := a[txID]
+	if !ok {
+		return common.Tx{}, errors.Errorf("tx %s is not saved", txID)
+	}
+	return tx, nil
+}
+
+// GetObservedTx returns no outbounds, so the unstakes keep their provisional
+// expected outbounds until the pending tracker reconciles them once midgard
+// runs again.
+func (a archivedTxs) GetObservedTx(_ context.Context, _ common.TxID) (thorchain.ObservedTx, error) {
+	return thorchain.ObservedTx{}, nil
+}
+
+// linkedRange extends the range to cover the events linked by outbound or fee
+// events across its bounds and checks the raw events of it are archived.
+func (uc *Usecase) linkedRange(from, to int64) (int64, int64, error) {
+	for {
+		first, last, err := uc.store.GetLinkedRange(from, to)
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "could not get linked range")
+		}
+		if (first == 0 || first >= from) && last <= to {
+			break
+		}
+		if first != 0 && first < from {
+			from = first
+		}
+		if last > to {
+			to = last
+		}
+		log.Info().Str("module", "reindex").Int64("from", from).Int64("to", to).Msg("range extended to the events linked by outbounds")
+	}
+	first, _, err := uc.store.GetRawEventsRange()
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "could not get first archived height")
+	}
+	if first == 0 {
+		return 0, 0, errors.New("no raw events archived")
+	}
+	if from < first {
+		return 0, 0, errors.Errorf("raw events are not archived before height %d", first)
+	}
+	return from, to, nil
+}
+
+// replay passes the archived raw events between the given heights to the event
+// handler block by block. progress is called after each batch of blocks.
+func (uc *Usecase) replay(eh *eventHandler, from, to int64, progress func(height int64)) error {
+	for start := from; start <= to; start += reindexBatchSize {
+		end := start + reindexBatchSize - 1
+		if end > to {
+			end = to
+		}
+		events, err := uc.store.GetRawEvents(start, end)
+		if err != nil {
+			return errors.Wrapf(err, "could not get raw events at heights [%d, %d]", start, end)
+		}
+		for len(events) > 0 {
+			n := 1
+			for n < len(events) && events[n].Height == events[0].Height {
+				n++
+			}
+			err = eh.replayBlock(events[:n])
+			if err != nil {
+				return errors.Wrapf(err, "could not replay block %d", events[0].Height)
+			}
+			events = events[n:]
+		}
+		progress(end)
+	}
+	return nil
+}
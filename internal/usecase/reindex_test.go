@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"time"
+
+	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/models"
+	. "gopkg.in/check.v1"
+)
+
+type ReindexTestStore struct {
+	*StoreDummy
+	lastHeight  int64
+	firstRaw    int64
+	lastRaw     int64
+	linked      map[[2]int64][2]int64
+	raw         []models.RawEvent
+	deleted     [][2]int64
+	rawRequests [][2]int64
+	stakeTxs    []common.Tx
+	pools       []models.EventPool
+	inTxs       []common.Tx
+	depthsFrom  []int64
+}
+
+func (s *ReindexTestStore) GetStakeTxs(from, to int64) ([]common.Tx, error) {
+	return s.stakeTxs, nil
+}
+
+func (s *ReindexTestStore) CreatePoolRecord(record *models.EventPool) error {
+	s.pools = append(s.pools, *record)
+	return nil
+}
+
+func (s *ReindexTestStore) CreateStakeRecord(record *models.EventStake) error {
+	return nil
+}
+
+func (s *ReindexTestStore) ProcessTxRecord(direction string, parent models.Event, record common.Tx) error {
+	s.inTxs = append(s.inTxs, record)
+	return nil
+}
+
+func (s *ReindexTestStore) UpdatePoolDepths(from int64) error {
+	s.depthsFrom = append(s.depthsFrom, from)
+	return nil
+}
+
+func (s *ReindexTestStore) GetLastHeight() (int64, error) {
+	return s.lastHeight, nil
+}
+
+func (s *ReindexTestStore) GetRawEventsRange() (int64, int64, error) {
+	return s.firstRaw, s.lastRaw, nil
+}
+
+func (s *ReindexTestStore) GetLinkedRange(from, to int64) (int64, int64, error) {
+	r := s.linked[[2]int64{from, to}]
+	return r[0], r[1], nil
+}
+
+func (s *ReindexTestStore) DeleteHeightRange(from, to int64) error {
+	s.deleted = append(s.deleted, [2]int64{from, to})
+	return nil
+}
+
+func (s *ReindexTestStore) GetRawEvents(from, to int64) ([]models.RawEvent, error) {
+	s.rawRequests = append(s.rawRequests, [2]int64{from, to})
+	var events []models.RawEvent
+	for _, ev := range s.raw {
+		if ev.Height >= from && ev.Height <= to {
+			events = append(events, ev)
+		}
+	}
+	return events, nil
+}
+
+func (s *UsecaseSuite) TestReindex(c *C) {
+	blockTime := time.Now()
+	pool := func(height int64, stage string, status string) models.RawEvent {
+		return models.RawEvent{
+			Height:     height,
+			Time:       blockTime,
+			Stage:      stage,
+			Type:       "pool",
+			Attributes: map[string]string{"pool": "BNB.BNB", "pool_status": status},
+		}
+	}
+	stakeTx := common.Tx{
+		ID:          "91811747D3FBD9401CD5627F4F453BF3E7F0409D65FF6F4FDEC8772FE1387369",
+		Chain:       common.BNBChain,
+		FromAddress: "tbnb1mkymsmnqenxthlmaa9f60kd6wgr9yjy9h5mz6q",
+		Memo:        "STAKE:BNB.BNB",
+	}
+	store := &ReindexTestStore{
+		lastHeight: 2100,
+		firstRaw:   10,
+		linked: map[[2]int64][2]int64{
+			{100, 2100}: {50, 2100},
+			{100, 1200}: {50, 1200},
+		},
+		raw: []models.RawEvent{
+			pool(99, models.RawEventStageTx, "Enabled"),
+			pool(150, models.RawEventStageTx, "Bootstrap"),
+			{
+				Height:  150,
+				Time:    blockTime,
+				Stage:   models.RawEventStageTx,
+				TxIndex: 1,
+				Type:    "stake",
+				Attributes: map[string]string{
+					"BNB_txid":     stakeTx.ID.String(),
+					"asset_amount": "150000000",
+					"rune_amount":  "50000000000",
+					"pool":         "BNB.BNB",
+					"stake_units":  "25075000000",
+				},
+			},
+			pool(1500, models.RawEventStageEnd, "Enabled"),
+			pool(2200, models.RawEventStageTx, "Bootstrap"),
+		},
+		stakeTxs: []common.Tx{stakeTx},
+	}
+
+	// The stake tx is read from the archived txs since there is no node.
+	err := Reindex(store, 100, 0)
+	c.Assert(err, IsNil)
+	c.Assert(store.deleted, DeepEquals, [][2]int64{{50, 2100}})
+	c.Assert(store.rawRequests, DeepEquals, [][2]int64{{50, 1049}, {1050, 2049}, {2050, 2100}})
+	c.Assert(store.pools, HasLen, 3)
+	for i, height := range []int64{99, 150, 1500} {
+		c.Assert(store.pools[i].Height, Equals, height)
+	}
+	c.Assert(store.inTxs, DeepEquals, []common.Tx{stakeTx})
+	c.Assert(store.depthsFrom, HasLen, 0)
+
+	// The depths after a range ending before the last height are updated.
+	store.deleted = nil
+	store.pools = nil
+	err = Reindex(store, 100, 1200)
+	c.Assert(err, IsNil)
+	c.Assert(store.deleted, DeepEquals, [][2]int64{{50, 1200}})
+	c.Assert(store.pools, HasLen, 2)
+	c.Assert(store.depthsFrom, DeepEquals, []int64{50})
+
+	// A stake tx which isn't archived fails the reindex.
+	store.deleted = nil
+	store.stakeTxs = nil
+	err = Reindex(store, 100, 0)
+	c.Assert(err, NotNil)
+
+	// Invalid ranges
+	store.deleted = nil
+	err = Reindex(store, 0, 100)
+	c.Assert(err, NotNil)
+	err = Reindex(store, 200, 100)
+	c.Assert(err, NotNil)
+	err = Reindex(store, 5, 2100)
+	c.Assert(err, NotNil)
+	c.Assert(store.deleted, HasLen, 0)
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gitlab.com/thorchain/midgard/internal/models"
+	"gitlab.com/thorchain/midgard/internal/store"
+	"gitlab.com/thorchain/midgard/pkg/clients/thorchain"
+)
+
+// reserveTracker records the reserve balance after each new block. Thorchain
+// only exposes the current balance so blocks older than maxBlockAge are skipped.
+// The balance is requested in background so the scanner doesn't wait for it.
+type reserveTracker struct {
+	store       store.Store
+	thorchain   thorchain.Thorchain
+	maxBlockAge time.Duration
+	background  backgroundTask
+	logger      zerolog.Logger
+}
+
+func newReserveTracker(store store.Store, thorchain thorchain.Thorchain, maxBlockAge time.Duration) *reserveTracker {
+	return &reserveTracker{
+		store:       store,
+		thorchain:   thorchain,
+		maxBlockAge: maxBlockAge,
+		logger:      log.With().Str("module", "reserve_tracker").Logger(),
+	}
+}
+
+// newBlock is called by event handler after each block is processed.
+func (t *reserveTracker) newBlock(height int64, blockTime time.Time) {
+	if t.maxBlockAge > 0 && time.Since(blockTime) > t.maxBlockAge {
+		return
+	}
+	t.background.run(func() {
+		err := t.record(height, blockTime)
+		if err != nil {
+			t.logger.Error().Err(err).Int64("height", height).Msg("failed to record reserve balance")
+		}
+	})
+}
+
+func (t *reserveTracker) record(height int64, blockTime time.Time) error {
+	if t.maxBlockAge > 0 && time.Since(blockTime) > t.maxBlockAge {
+		return nil
+	}
+
+	vault, err := t.thorchain.GetVaultData(thorchain.WithoutCache(context.Background()))
+	if err != nil {
+		return errors.Wrap(err, "could not get vault data")
+	}
+	record := models.Reserve{
+		Time:         blockTime,
+		Height:       height,
+		TotalReserve: int64(vault.TotalReserve),
+	}
+	return t.store.CreateReserveRecord(&record)
+}
+
+// GetRewardsHistory returns the rewards emitted to bonders and pools per interval
+// alongside the emission expected by the emission schedule.
+func (uc *Usecase) GetRewardsHistory(ctx context.Context, inv models.Interval, from, to time.Time) ([]models.RewardsChanges, error) {
+	if err := inv.Validate(); err != nil {
+		return nil, err
+	}
+
+	changes, err := uc.store.GetRewardsChanges(ctx, inv, from, to)
+	if err != nil {
+		return nil, err
+	}
+	reserves, err := uc.store.GetReserveChanges(ctx, inv, from, to)
+	if err != nil {
+		return nil, err
+	}
+	reserveAt := make(map[int64]int64, len(reserves))
+	for _, r := range reserves {
+		reserveAt[r.Time.Unix()] = r.TotalReserve
+	}
+	emission := uc.consts.Int64Values["EmissionCurve"]
+	blocksPerYear := uc.consts.Int64Values["BlocksPerYear"]
+	if emission <= 0 || blocksPerYear <= 0 {
+		return changes, nil
+	}
+	for i := range changes {
+		reserve, ok := reserveAt[changes[i].Time.Unix()]
+		if !ok {
+			continue
+		}
+		blockReward := float64(reserve) / float64(emission*blocksPerYear)
+		changes[i].ExpectedEmission = int64(blockReward * float64(changes[i].Blocks))
+	}
+	return changes, nil
+}
+
+// GetReserveHistory returns the reserve balance at the end of each interval.
+func (uc *Usecase) GetReserveHistory(ctx context.Context, inv models.Interval, from, to time.Time) ([]models.Reserve, error) {
+	if err := inv.Validate(); err != nil {
+		return nil, err
+	}
+
+	return uc.store.GetReserveChanges(ctx, inv, from, to)
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"gitlab.com/thorchain/midgard/internal/models"
+	"gitlab.com/thorchain/midgard/pkg/clients/thorchain"
+	. "gopkg.in/check.v1"
+)
+
+type TestReserveStore struct {
+	StoreDummy
+	records  []models.Reserve
+	rewards  []models.RewardsChanges
+	reserves []models.Reserve
+}
+
+func (s *TestReserveStore) CreateReserveRecord(record *models.Reserve) error {
+	s.records = append(s.records, *record)
+	return nil
+}
+
+func (s *TestReserveStore) GetRewardsChanges(ctx context.Context, _ models.Interval, _, _ time.Time) ([]models.RewardsChanges, error) {
+	return s.rewards, nil
+}
+
+func (s *TestReserveStore) GetReserveChanges(ctx context.Context, _ models.Interval, _, _ time.Time) ([]models.Reserve, error) {
+	return s.reserves, nil
+}
+
+func (s *UsecaseSuite) TestReserveTracker(c *C) {
+	store := &TestReserveStore{}
+	client := &TestGetNetworkInfoThorchain{
+		vaultData: thorchain.VaultData{TotalReserve: 1000},
+	}
+	tracker := newReserveTracker(store, client, time.Minute)
+
+	// Old blocks should be skipped.
+	err := tracker.record(1, time.Now().Add(-time.Hour))
+	c.Assert(err, IsNil)
+	c.Assert(store.records, HasLen, 0)
+
+	now := time.Now()
+	err = tracker.record(2, now)
+	c.Assert(err, IsNil)
+	c.Assert(store.records, DeepEquals, []models.Reserve{
+		{
+			Time:         now,
+			Height:       2,
+			TotalReserve: 1000,
+		},
+	})
+
+	// New blocks are recorded in background.
+	tracker.newBlock(3, now)
+	for atomic.LoadInt32(&tracker.background.running) != 0 {
+		time.Sleep(time.Millisecond)
+	}
+	c.Assert(store.records, HasLen, 2)
+	c.Assert(store.records[1].Height, Equals, int64(3))
+}
+
+func (s *UsecaseSuite) TestGetRewardsHistory(c *C) {
+	today := time.Date(2020, 8, 10, 0, 0, 0, 0, time.UTC)
+	yesterday := today.Add(-day)
+	store := &TestReserveStore{
+		rewards: []models.RewardsChanges{
+			{
+				Time:        yesterday,
+				BondReward:  30,
+				PoolRewards: 20,
+				TotalReward: 50,
+				Blocks:      5,
+			},
+			{
+				Time:        today,
+				BondReward:  40,
+				PoolRewards: -10,
+				TotalReward: 30,
+				Blocks:      3,
+			},
+		},
+		reserves: []models.Reserve{
+			{
+				Time:         today,
+				Height:       8,
+				TotalReserve: emissionCurve * blocksPerYear * 10,
+			},
+		},
+	}
+	client := &TestGetNetworkInfoThorchain{}
+	uc, err := NewUsecase(client, s.dummyTendermint, s.dummyTendermint, store, s.config)
+	c.Assert(err, IsNil)
+
+	changes, err := uc.GetRewardsHistory(context.Background(), models.DailyInterval, yesterday, today)
+	c.Assert(err, IsNil)
+	c.Assert(changes, DeepEquals, []models.RewardsChanges{
+		{
+			Time:        yesterday,
+			BondReward:  30,
+			PoolRewards: 20,
+			TotalReward: 50,
+			Blocks:      5,
+		},
+		{
+			Time:             today,
+			BondReward:       40,
+			PoolRewards:      -10,
+			TotalReward:      30,
+			Blocks:           3,
+			ExpectedEmission: 30,
+		},
+	})
+}
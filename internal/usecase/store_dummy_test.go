@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -20,6 +21,14 @@ func (s *StoreDummy) UpdateEventStatus(_ int64, _ string) error {
 	return ErrNotImplemented
 }
 
+func (s *StoreDummy) GetOutboundsCount(ctx context.Context, _ int64) (int64, error) {
+	return 0, ErrNotImplemented
+}
+
+func (s *StoreDummy) UpdateExpectedOutbounds(_ int64, _ int64) error {
+	return ErrNotImplemented
+}
+
 func (s *StoreDummy) GetLastHeight() (int64, error) {
 	return 0, nil
 }
@@ -72,107 +81,107 @@ func (s *StoreDummy) CreateErrataRecord(_ *models.EventErrata) error {
 	return ErrNotImplemented
 }
 
-func (s *StoreDummy) Ping() error {
+func (s *StoreDummy) Ping(ctx context.Context) error {
 	return ErrNotImplemented
 }
 
-func (s *StoreDummy) GetTxDetails(_ common.Address, _ common.TxID, _ common.Asset, _ []string, _, _ int64) ([]models.TxDetails, int64, error) {
+func (s *StoreDummy) GetTxDetails(ctx context.Context, _ common.Address, _ common.TxID, _ common.Asset, _ []string, _, _ int64) ([]models.TxDetails, int64, error) {
 	return nil, 0, ErrNotImplemented
 }
 
-func (s *StoreDummy) GetPools() ([]common.Asset, error) {
+func (s *StoreDummy) GetPools(ctx context.Context) ([]common.Asset, error) {
 	return nil, ErrNotImplemented
 }
 
-func (s *StoreDummy) GetPool(asset common.Asset) (common.Asset, error) {
+func (s *StoreDummy) GetPool(ctx context.Context, asset common.Asset) (common.Asset, error) {
 	return common.EmptyAsset, ErrNotImplemented
 }
 
-func (s *StoreDummy) GetAssetDepth(asset common.Asset) (uint64, error) {
+func (s *StoreDummy) GetAssetDepth(ctx context.Context, asset common.Asset) (uint64, error) {
 	return 0, ErrNotImplemented
 }
 
-func (s *StoreDummy) GetRuneDepth(asset common.Asset) (uint64, error) {
+func (s *StoreDummy) GetRuneDepth(ctx context.Context, asset common.Asset) (uint64, error) {
 	return 0, ErrNotImplemented
 }
 
-func (s *StoreDummy) GetPoolBasics(asset common.Asset) (models.PoolBasics, error) {
+func (s *StoreDummy) GetPoolBasics(ctx context.Context, asset common.Asset) (models.PoolBasics, error) {
 	return models.PoolBasics{}, ErrNotImplemented
 }
 
-func (s *StoreDummy) GetPoolVolume(asset common.Asset, from, to time.Time) (int64, error) {
+func (s *StoreDummy) GetPoolVolume(ctx context.Context, asset common.Asset, from, to time.Time) (int64, error) {
 	return 0, ErrNotImplemented
 }
 
-func (s *StoreDummy) GetPoolStatus(asset common.Asset) (models.PoolStatus, error) {
+func (s *StoreDummy) GetPoolStatus(ctx context.Context, asset common.Asset) (models.PoolStatus, error) {
 	return models.Unknown, ErrNotImplemented
 }
 
-func (s *StoreDummy) GetDateCreated(asset common.Asset) (uint64, error) {
+func (s *StoreDummy) GetDateCreated(ctx context.Context, asset common.Asset) (uint64, error) {
 	return 0, ErrNotImplemented
 }
 
-func (s *StoreDummy) GetTotalDepth() (uint64, error) {
+func (s *StoreDummy) GetTotalDepth(ctx context.Context) (uint64, error) {
 	return 0, ErrNotImplemented
 }
 
-func (s *StoreDummy) GetUsersCount(_, _ *time.Time) (uint64, error) {
+func (s *StoreDummy) GetUsersCount(ctx context.Context, _, _ *time.Time) (uint64, error) {
 	return 0, ErrNotImplemented
 }
 
-func (s *StoreDummy) GetTxsCount(_, _ *time.Time) (uint64, error) {
+func (s *StoreDummy) GetTxsCount(ctx context.Context, _, _ *time.Time) (uint64, error) {
 	return 0, ErrNotImplemented
 }
 
-func (s *StoreDummy) GetTotalVolume(_, _ *time.Time) (uint64, error) {
+func (s *StoreDummy) GetTotalVolume(ctx context.Context, _, _ *time.Time) (uint64, error) {
 	return 0, ErrNotImplemented
 }
 
-func (s *StoreDummy) TotalStaked() (uint64, error) {
+func (s *StoreDummy) TotalStaked(ctx context.Context) (uint64, error) {
 	return 0, ErrNotImplemented
 }
 
-func (s *StoreDummy) PoolCount() (uint64, error) {
+func (s *StoreDummy) PoolCount(ctx context.Context) (uint64, error) {
 	return 0, ErrNotImplemented
 }
 
-func (s *StoreDummy) TotalAssetBuys() (uint64, error) {
+func (s *StoreDummy) TotalAssetBuys(ctx context.Context) (uint64, error) {
 	return 0, ErrNotImplemented
 }
 
-func (s *StoreDummy) TotalAssetSells() (uint64, error) {
+func (s *StoreDummy) TotalAssetSells(ctx context.Context) (uint64, error) {
 	return 0, ErrNotImplemented
 }
 
-func (s *StoreDummy) TotalStakeTx() (uint64, error) {
+func (s *StoreDummy) TotalStakeTx(ctx context.Context) (uint64, error) {
 	return 0, ErrNotImplemented
 }
 
-func (s *StoreDummy) TotalWithdrawTx() (uint64, error) {
+func (s *StoreDummy) TotalWithdrawTx(ctx context.Context) (uint64, error) {
 	return 0, ErrNotImplemented
 }
 
-func (s *StoreDummy) GetPoolSwapStats(asset common.Asset) (models.PoolSwapStats, error) {
+func (s *StoreDummy) GetPoolSwapStats(ctx context.Context, asset common.Asset) (models.PoolSwapStats, error) {
 	return models.PoolSwapStats{}, ErrNotImplemented
 }
 
-func (s *StoreDummy) GetStakerAddresses() ([]common.Address, error) {
+func (s *StoreDummy) GetStakerAddresses(ctx context.Context) ([]common.Address, error) {
 	return nil, ErrNotImplemented
 }
 
-func (s *StoreDummy) GetStakerAddressDetails(address common.Address) (models.StakerAddressDetails, error) {
+func (s *StoreDummy) GetStakerAddressDetails(ctx context.Context, address common.Address) (models.StakerAddressDetails, error) {
 	return models.StakerAddressDetails{}, ErrNotImplemented
 }
 
-func (s *StoreDummy) GetStakersAddressAndAssetDetails(address common.Address, asset common.Asset) (models.StakerAddressAndAssetDetails, error) {
+func (s *StoreDummy) GetStakersAddressAndAssetDetails(ctx context.Context, address common.Address, asset common.Asset) (models.StakerAddressAndAssetDetails, error) {
 	return models.StakerAddressAndAssetDetails{}, ErrNotImplemented
 }
 
-func (s *StoreDummy) TotalEarned() (int64, error) {
+func (s *StoreDummy) TotalEarned(ctx context.Context) (int64, error) {
 	return 0, ErrNotImplemented
 }
 
-func (s *StoreDummy) GetEventsByTxID(txID common.TxID) ([]models.Event, error) {
+func (s *StoreDummy) GetEventsByTxID(ctx context.Context, txID common.TxID) ([]models.Event, error) {
 	return nil, ErrNotImplemented
 }
 
@@ -184,11 +193,15 @@ func (s *StoreDummy) UpdateSwapRecord(record models.EventSwap) error {
 	return ErrNotImplemented
 }
 
-func (s *StoreDummy) GetTotalVolChanges(inv models.Interval, from, to time.Time) ([]models.TotalVolChanges, error) {
+func (s *StoreDummy) GetTotalVolChanges(ctx context.Context, inv models.Interval, from, to time.Time) ([]models.TotalVolChanges, error) {
 	return nil, ErrNotImplemented
 }
 
-func (s *StoreDummy) GetPoolAggChanges(pool common.Asset, inv models.Interval, from, to time.Time) ([]models.PoolAggChanges, error) {
+func (s *StoreDummy) GetPoolAggChanges(ctx context.Context, pool common.Asset, inv models.Interval, from, to time.Time) ([]models.PoolAggChanges, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *StoreDummy) GetPoolYieldChanges(ctx context.Context, pool common.Asset, inv models.Interval, from, to time.Time) ([]models.PoolYieldChanges, error) {
 	return nil, ErrNotImplemented
 }
 
@@ -199,30 +212,174 @@ func (s *StoreDummy) DeleteBlock(height int64) error {
 	return nil
 }
 
-func (s *StoreDummy) GetPoolROI12(asset common.Asset) (float64, error) {
+func (s *StoreDummy) GetPoolROI12(ctx context.Context, asset common.Asset) (float64, error) {
 	return 0, ErrNotImplemented
 }
 
-func (s *StoreDummy) GetStakersCount(asset common.Asset) (uint64, error) {
+func (s *StoreDummy) GetStakersCount(ctx context.Context, asset common.Asset) (uint64, error) {
 	return 0, ErrNotImplemented
 }
 
-func (s *StoreDummy) GetSwappersCount(asset common.Asset) (uint64, error) {
+func (s *StoreDummy) GetPoolStakers(ctx context.Context, asset common.Asset, at time.Time) ([]models.PoolStaker, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *StoreDummy) GetPoolStakersChanges(ctx context.Context, asset common.Asset, from, to time.Time, step time.Duration) ([]models.PoolStakerChange, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *StoreDummy) GetSwappersCount(ctx context.Context, asset common.Asset) (uint64, error) {
 	return 0, ErrNotImplemented
 }
 
-func (s *StoreDummy) GetPoolEarned(_ common.Asset, _ time.Time) (int64, error) {
+func (s *StoreDummy) GetPoolEarned(ctx context.Context, _ common.Asset, _ time.Time) (int64, error) {
 	return 0, nil
 }
 
-func (s *StoreDummy) GetPoolLastEnabledDate(_ common.Asset) (time.Time, error) {
+func (s *StoreDummy) GetPoolLastEnabledDate(ctx context.Context, _ common.Asset) (time.Time, error) {
 	return time.Time{}, nil
 }
 
-func (s *StoreDummy) GetEventPool(id int64) (common.Asset, error) {
+func (s *StoreDummy) GetEventPool(ctx context.Context, id int64) (common.Asset, error) {
 	return common.Asset{}, ErrNotImplemented
 }
 
 func (s *StoreDummy) CreateRefundedEvent(record *models.Event, pool common.Asset) error {
 	return ErrNotImplemented
 }
+
+func (s *StoreDummy) CreatePriceDeviationRecord(record *models.PriceDeviation) error {
+	return ErrNotImplemented
+}
+
+func (s *StoreDummy) GetPriceDeviations(ctx context.Context, pool common.Asset, from, to time.Time) ([]models.PriceDeviation, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *StoreDummy) SaveDeviationAlert(record *models.PriceDeviation) error {
+	return ErrNotImplemented
+}
+
+func (s *StoreDummy) DeleteDeviationAlert(pool common.Asset) error {
+	return ErrNotImplemented
+}
+
+func (s *StoreDummy) GetDeviationAlerts(ctx context.Context) ([]models.DeviationAlert, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *StoreDummy) GetSwapsStats(ctx context.Context, asset common.Asset, from, to time.Time) (models.SwapsStats, error) {
+	return models.SwapsStats{}, ErrNotImplemented
+}
+
+func (s *StoreDummy) GetSwapsHistogram(ctx context.Context, asset common.Asset, metric models.SwapMetric, bounds []float64, from, to time.Time) ([]int64, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *StoreDummy) GetTopSwappers(ctx context.Context, asset common.Asset, from, to time.Time, limit int64) ([]models.Swapper, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *StoreDummy) CreateReserveRecord(record *models.Reserve) error {
+	return ErrNotImplemented
+}
+
+func (s *StoreDummy) GetRewardsChanges(ctx context.Context, inv models.Interval, from, to time.Time) ([]models.RewardsChanges, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *StoreDummy) GetReserveChanges(ctx context.Context, inv models.Interval, from, to time.Time) ([]models.Reserve, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *StoreDummy) GetSlashChanges(ctx context.Context, pool common.Asset, inv models.Interval, from, to time.Time) ([]models.SlashChanges, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *StoreDummy) GetGasChanges(ctx context.Context, chain common.Chain, inv models.Interval, from, to time.Time) ([]models.GasChanges, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *StoreDummy) CreateTxFeeRecord(record *models.TxFee) error {
+	return ErrNotImplemented
+}
+
+func (s *StoreDummy) GetFeeChanges(ctx context.Context, pool common.Asset, inv models.Interval, from, to time.Time) ([]models.FeeChanges, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *StoreDummy) GetPendingTxs(ctx context.Context, offset, limit int64) ([]models.PendingTx, int64, error) {
+	return nil, 0, ErrNotImplemented
+}
+
+func (s *StoreDummy) GetPendingTxsCount(ctx context.Context, before time.Time) (int64, error) {
+	return 0, ErrNotImplemented
+}
+
+func (s *StoreDummy) CreateDoubleSwapRecord(record *models.DoubleSwap) error {
+	return ErrNotImplemented
+}
+
+func (s *StoreDummy) GetDoubleSwap(ctx context.Context, eventID int64) (*models.DoubleSwap, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *StoreDummy) CreateQuarantinedEvent(record *models.QuarantinedEvent) error {
+	return ErrNotImplemented
+}
+
+func (s *StoreDummy) GetQuarantinedEvents(ctx context.Context, offset, limit int64) ([]models.QuarantinedEvent, int64, error) {
+	return nil, 0, ErrNotImplemented
+}
+
+func (s *StoreDummy) CreateRawEvents(records []models.RawEvent) error {
+	return nil
+}
+
+func (s *StoreDummy) GetRawEvents(from, to int64) ([]models.RawEvent, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *StoreDummy) GetRawEventsRange() (int64, int64, error) {
+	return 0, 0, ErrNotImplemented
+}
+
+func (s *StoreDummy) GetLinkedRange(from, to int64) (int64, int64, error) {
+	return 0, 0, ErrNotImplemented
+}
+
+func (s *StoreDummy) DeleteHeightRange(from, to int64) error {
+	return ErrNotImplemented
+}
+
+func (s *StoreDummy) UpdatePoolDepths(from int64) error {
+	return ErrNotImplemented
+}
+
+func (s *StoreDummy) GetStakeTxs(from, to int64) ([]common.Tx, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *StoreDummy) GetFirstHeight() (int64, error) {
+	return 0, ErrNotImplemented
+}
+
+func (s *StoreDummy) GetAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *StoreDummy) GetAPIKeysUsage(ctx context.Context, day time.Time) ([]models.APIKeyUsage, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *StoreDummy) AddAPIKeysUsage(usage []models.APIKeyUsage) error {
+	return ErrNotImplemented
+}
+
+func (s *StoreDummy) CreateShadow() (store.Store, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *StoreDummy) SwapShadow(shadow store.Store) error {
+	return ErrNotImplemented
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/models"
+)
+
+// Histogram bounds of swap metrics. Trade size and fee bounds are in rune.
+var (
+	tradeSizeBounds = []float64{1e8, 1e9, 1e10, 1e11, 1e12}
+	slipBounds      = []float64{0.001, 0.005, 0.01, 0.02, 0.05, 0.1}
+	feeBounds       = []float64{1e6, 1e7, 1e8, 1e9, 1e10}
+)
+
+// GetSwapAnalytics returns the distribution of trade size, slip and liquidity fee of swaps
+// in the given pool between from and to alongside the top swappers by volume. All pools are
+// included if the asset is empty.
+func (uc *Usecase) GetSwapAnalytics(ctx context.Context, asset common.Asset, from, to time.Time, topSwappers int64) (*models.SwapAnalytics, error) {
+	stats, err := uc.store.GetSwapsStats(ctx, asset, from, to)
+	if err != nil {
+		return nil, err
+	}
+	stats.TradeSize.Histogram, err = uc.getSwapsHistogram(ctx, asset, models.SwapMetricTradeSize, tradeSizeBounds, from, to)
+	if err != nil {
+		return nil, err
+	}
+	stats.Slip.Histogram, err = uc.getSwapsHistogram(ctx, asset, models.SwapMetricSlip, slipBounds, from, to)
+	if err != nil {
+		return nil, err
+	}
+	stats.Fee.Histogram, err = uc.getSwapsHistogram(ctx, asset, models.SwapMetricFee, feeBounds, from, to)
+	if err != nil {
+		return nil, err
+	}
+	swappers, err := uc.store.GetTopSwappers(ctx, asset, from, to, topSwappers)
+	if err != nil {
+		return nil, err
+	}
+
+	analytics := &models.SwapAnalytics{
+		SwapsStats:  stats,
+		TopSwappers: swappers,
+	}
+	return analytics, nil
+}
+
+func (uc *Usecase) getSwapsHistogram(ctx context.Context, asset common.Asset, metric models.SwapMetric, bounds []float64, from, to time.Time) ([]models.HistogramBucket, error) {
+	counts, err := uc.store.GetSwapsHistogram(ctx, asset, metric, bounds, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make([]models.HistogramBucket, len(bounds)+1)
+	for i := range buckets {
+		if i > 0 {
+			buckets[i].From = bounds[i-1]
+		}
+		if i < len(bounds) {
+			buckets[i].To = bounds[i]
+		}
+		if i < len(counts) {
+			buckets[i].Count = counts[i]
+		}
+	}
+	return buckets, nil
+}
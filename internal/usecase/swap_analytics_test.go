@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/internal/models"
+	. "gopkg.in/check.v1"
+)
+
+type TestGetSwapAnalyticsStore struct {
+	StoreDummy
+	stats      models.SwapsStats
+	histograms map[models.SwapMetric][]int64
+	swappers   []models.Swapper
+	limit      int64
+	err        error
+}
+
+func (s *TestGetSwapAnalyticsStore) GetSwapsStats(ctx context.Context, _ common.Asset, _, _ time.Time) (models.SwapsStats, error) {
+	return s.stats, s.err
+}
+
+func (s *TestGetSwapAnalyticsStore) GetSwapsHistogram(ctx context.Context, _ common.Asset, metric models.SwapMetric, _ []float64, _, _ time.Time) ([]int64, error) {
+	return s.histograms[metric], nil
+}
+
+func (s *TestGetSwapAnalyticsStore) GetTopSwappers(ctx context.Context, _ common.Asset, _, _ time.Time, limit int64) ([]models.Swapper, error) {
+	s.limit = limit
+	return s.swappers, nil
+}
+
+func (s *UsecaseSuite) TestGetSwapAnalytics(c *C) {
+	store := &TestGetSwapAnalyticsStore{
+		stats: models.SwapsStats{
+			SwapCount: 12,
+			TradeSize: models.Distribution{
+				Average: 5e9,
+				P50:     2e9,
+				P90:     8e10,
+				P99:     2e11,
+			},
+			Slip: models.Distribution{
+				Average: 0.004,
+				P50:     0.002,
+				P90:     0.015,
+				P99:     0.06,
+			},
+			Fee: models.Distribution{
+				Average: 2e7,
+				P50:     4e6,
+				P90:     1.2e9,
+				P99:     1.2e10,
+			},
+		},
+		histograms: map[models.SwapMetric][]int64{
+			models.SwapMetricTradeSize: {1, 2, 3, 4, 1, 1},
+			models.SwapMetricSlip:      {2, 3, 2, 2, 1, 1, 1},
+			models.SwapMetricFee:       {3, 4, 2, 1, 1, 1},
+		},
+		swappers: []models.Swapper{
+			{
+				Address:   "bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38",
+				SwapCount: 3,
+				Volume:    3e11,
+				Fees:      2e10,
+			},
+		},
+	}
+	uc, err := NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
+	c.Assert(err, IsNil)
+
+	now := time.Now()
+	analytics, err := uc.GetSwapAnalytics(context.Background(), common.EmptyAsset, now.Add(-day), now, 5)
+	c.Assert(err, IsNil)
+	c.Assert(store.limit, Equals, int64(5))
+	c.Assert(analytics.SwapCount, Equals, int64(12))
+	c.Assert(analytics.TopSwappers, DeepEquals, store.swappers)
+	c.Assert(analytics.TradeSize.P99, Equals, 2e11)
+	c.Assert(analytics.TradeSize.Histogram, DeepEquals, []models.HistogramBucket{
+		{From: 0, To: 1e8, Count: 1},
+		{From: 1e8, To: 1e9, Count: 2},
+		{From: 1e9, To: 1e10, Count: 3},
+		{From: 1e10, To: 1e11, Count: 4},
+		{From: 1e11, To: 1e12, Count: 1},
+		{From: 1e12, To: 0, Count: 1},
+	})
+	c.Assert(analytics.Slip.Histogram, HasLen, len(slipBounds)+1)
+	c.Assert(analytics.Slip.Histogram[6], DeepEquals, models.HistogramBucket{From: 0.1, Count: 1})
+	c.Assert(analytics.Fee.Histogram, HasLen, len(feeBounds)+1)
+	c.Assert(analytics.Fee.Histogram[0], DeepEquals, models.HistogramBucket{To: 1e6, Count: 3})
+
+	store.err = errors.New("could not fetch requested data")
+	_, err = uc.GetSwapAnalytics(context.Background(), common.BNBAsset, now.Add(-day), now, 5)
+	c.Assert(err, NotNil)
+}
@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"context"
+
 	"gitlab.com/thorchain/midgard/internal/common"
 	"gitlab.com/thorchain/midgard/internal/models"
 	"gitlab.com/thorchain/midgard/pkg/clients/thorchain"
@@ -11,38 +13,42 @@ var _ thorchain.Thorchain = (*ThorchainDummy)(nil)
 // ThorchainDummy is test purpose implementation of Thorchain.
 type ThorchainDummy struct{}
 
-func (t *ThorchainDummy) GetMimir() (map[string]string, error) {
+func (t *ThorchainDummy) GetMimir(ctx context.Context) (map[string]string, error) {
 	return map[string]string{}, nil
 }
 
-func (t *ThorchainDummy) GetPoolStatus(pool common.Asset) (models.PoolStatus, error) {
+func (t *ThorchainDummy) GetPoolStatus(ctx context.Context, pool common.Asset) (models.PoolStatus, error) {
 	return models.Unknown, ErrNotImplemented
 }
 
-func (t *ThorchainDummy) GetTx(txId common.TxID) (common.Tx, error) {
+func (t *ThorchainDummy) GetTx(ctx context.Context, txId common.TxID) (common.Tx, error) {
 	return common.Tx{}, ErrNotImplemented
 }
 
-func (t *ThorchainDummy) GetNodeAccounts() ([]thorchain.NodeAccount, error) {
+func (t *ThorchainDummy) GetObservedTx(ctx context.Context, txId common.TxID) (thorchain.ObservedTx, error) {
+	return thorchain.ObservedTx{}, ErrNotImplemented
+}
+
+func (t *ThorchainDummy) GetNodeAccounts(ctx context.Context) ([]thorchain.NodeAccount, error) {
 	return nil, ErrNotImplemented
 }
 
-func (t *ThorchainDummy) GetVaultData() (thorchain.VaultData, error) {
+func (t *ThorchainDummy) GetVaultData(ctx context.Context) (thorchain.VaultData, error) {
 	return thorchain.VaultData{}, ErrNotImplemented
 }
 
-func (t *ThorchainDummy) GetConstants() (thorchain.ConstantValues, error) {
+func (t *ThorchainDummy) GetConstants(ctx context.Context) (thorchain.ConstantValues, error) {
 	return thorchain.ConstantValues{}, nil
 }
 
-func (t *ThorchainDummy) GetAsgardVaults() ([]thorchain.Vault, error) {
+func (t *ThorchainDummy) GetAsgardVaults(ctx context.Context) ([]thorchain.Vault, error) {
 	return nil, ErrNotImplemented
 }
 
-func (t *ThorchainDummy) GetLastChainHeight() (thorchain.LastHeights, error) {
+func (t *ThorchainDummy) GetLastChainHeight(ctx context.Context) (thorchain.LastHeights, error) {
 	return thorchain.LastHeights{}, ErrNotImplemented
 }
 
-func (t *ThorchainDummy) GetPools() ([]thorchain.Pool, error) {
+func (t *ThorchainDummy) GetPools(ctx context.Context) ([]thorchain.Pool, error) {
 	return nil, ErrNotImplemented
 }
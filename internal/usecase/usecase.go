@@ -1,16 +1,19 @@
 package usecase
 
 import (
+	"context"
 	"math"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
 	"gitlab.com/thorchain/midgard/internal/common"
 	"gitlab.com/thorchain/midgard/internal/models"
 	"gitlab.com/thorchain/midgard/internal/store"
+	"gitlab.com/thorchain/midgard/pkg/clients/pricefeed"
 	"gitlab.com/thorchain/midgard/pkg/clients/thorchain"
 )
 
@@ -18,17 +21,34 @@ const (
 	day           = time.Hour * 24
 	month         = day * 30
 	monthsPerYear = 12
+	year          = month * monthsPerYear
 )
 
 // Config contains configuration params to create a new Usecase with NewUsecase.
 type Config struct {
 	ScanInterval         time.Duration
 	UseThorchainBalances bool
+	// PriceSource is the source of reference prices for detecting pool price
+	// deviations. Detection is disabled if nil.
+	PriceSource          pricefeed.Source
+	DeviationThreshold   float64
+	DeviationMaxBlockAge time.Duration
+	// ReserveMaxBlockAge is the maximum age of blocks after which the reserve
+	// balance is recorded so historical blocks are skipped while syncing.
+	ReserveMaxBlockAge time.Duration
+	// StuckTxThreshold is the duration after which a tx waiting for its
+	// outbound is considered stuck.
+	StuckTxThreshold time.Duration
+	// PendingMaxBlockAge is the maximum age of blocks after which the pending
+	// txs are counted so historical blocks are skipped while syncing.
+	PendingMaxBlockAge time.Duration
 }
 
 // Usecase describes the logic layer and it needs to get it's data from
 // pkg data store, tendermint and thorchain clients.
 type Usecase struct {
+	// generation is first to be 64-bit aligned for atomic operations.
+	generation          int64
 	store               store.Store
 	thorchain           thorchain.Thorchain
 	tendermint          thorchain.Tendermint
@@ -41,6 +61,10 @@ type Usecase struct {
 	thorchainPools      []thorchain.Pool
 	thorchainLock       sync.Mutex
 	thorchainLastUpdate time.Time
+	detector            *deviationDetector
+	reserve             *reserveTracker
+	pending             *pendingTracker
+	rebuild             *rebuilder
 }
 
 // NewUsecase initiate a new Usecase.
@@ -49,7 +73,7 @@ func NewUsecase(client thorchain.Thorchain, tendermint thorchain.Tendermint, ten
 		return nil, errors.New("conf can't be nil")
 	}
 
-	consts, err := client.GetConstants()
+	consts, err := client.GetConstants(context.Background())
 	if err != nil {
 		return nil, errors.New("could not fetch network constants")
 	}
@@ -61,6 +85,21 @@ func NewUsecase(client thorchain.Thorchain, tendermint thorchain.Tendermint, ten
 		conf:            conf,
 		consts:          consts,
 	}
+	uc.reserve = newReserveTracker(store, client, conf.ReserveMaxBlockAge)
+	uc.pending = newPendingTracker(store, conf.StuckTxThreshold, conf.PendingMaxBlockAge)
+	uc.rebuild = newRebuilder()
+	if conf.PriceSource != nil {
+		uc.detector = newDeviationDetector(store, conf.PriceSource, conf.DeviationThreshold, conf.DeviationMaxBlockAge)
+	}
+	uc.eh, err = newEventHandler(store, uc.thorchain)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create event handler")
+	}
+	uc.pending.reconcile = uc.eh.reconcilePending
+	uc.eh.listeners = append(uc.eh.listeners, uc.reserve.newBlock, uc.pending.newBlock)
+	if uc.detector != nil {
+		uc.eh.listeners = append(uc.eh.listeners, uc.detector.newBlock)
+	}
 	if conf.UseThorchainBalances {
 		go func() {
 			for {
@@ -74,13 +113,6 @@ func NewUsecase(client thorchain.Thorchain, tendermint thorchain.Tendermint, ten
 
 // StartScanner starts the scanner.
 func (uc *Usecase) StartScanner() error {
-	if uc.eh == nil {
-		eh, err := newEventHandler(uc.store, uc.thorchain)
-		if err != nil {
-			return errors.New("could not create event handler")
-		}
-		uc.eh = eh
-	}
 	if uc.scanner == nil {
 		uc.scanner = thorchain.NewBlockScanner(uc.tendermint, uc.tendermintBatch, uc.eh, uc.conf.ScanInterval)
 	}
@@ -95,40 +127,66 @@ func (uc *Usecase) StartScanner() error {
 	return uc.scanner.Start()
 }
 
-// StopScanner stops the scanner.
+// StopScanner stops the scanner and waits for the block listeners running in
+// background to finish.
 func (uc *Usecase) StopScanner() error {
-	return uc.scanner.Stop()
+	err := uc.scanner.Stop()
+	uc.reserve.background.wait()
+	uc.pending.background.wait()
+	if uc.detector != nil {
+		uc.detector.background.wait()
+	}
+	return err
+}
+
+// GetScannerHeight returns the height of the last block processed by the scanner.
+func (uc *Usecase) GetScannerHeight() int64 {
+	if uc.scanner == nil {
+		return 0
+	}
+	return uc.scanner.GetHeight()
+}
+
+// GetDataGeneration returns the number of times the indexed data was changed
+// other than by scanning a block, i.e. by reprocessing the quarantined events or
+// swapping rebuilt tables.
+func (uc *Usecase) GetDataGeneration() int64 {
+	return atomic.LoadInt64(&uc.generation)
 }
 
 // GetHealth returns health status of Midgard's crucial units.
-func (uc *Usecase) GetHealth() *models.HealthStatus {
+func (uc *Usecase) GetHealth(ctx context.Context) *models.HealthStatus {
+	pending, stuck := uc.pending.counts()
 	return &models.HealthStatus{
-		Database:      uc.store.Ping() == nil,
+		Database:      uc.store.Ping(ctx) == nil,
 		ScannerHeight: uc.scanner.GetHeight(),
 		CatchingUp:    uc.scanner.IsSynced(),
+		PendingTxs:    pending,
+		StuckTxs:      stuck,
+		Rebuild:       uc.rebuild.current(),
 	}
 }
 
 // GetTxDetails returns details and count of txs selected with query.
-func (uc *Usecase) GetTxDetails(address common.Address, txID common.TxID, asset common.Asset, eventType []string, page models.Page) ([]models.TxDetails, int64, error) {
+func (uc *Usecase) GetTxDetails(ctx context.Context, address common.Address, txID common.TxID, asset common.Asset, eventType []string, page models.Page) ([]models.TxDetails, int64, error) {
 	err := page.Validate()
 	if err != nil {
 		return nil, 0, err
 	}
 
-	txs, count, err := uc.store.GetTxDetails(address, txID, asset, eventType, page.Offset, page.Limit)
+	txs, count, err := uc.store.GetTxDetails(ctx, address, txID, asset, eventType, page.Offset, page.Limit)
 	return txs, count, err
 }
 
 // GetPools returns all active pools in the system.
-func (uc *Usecase) GetPools() ([]common.Asset, error) {
-	pools, err := uc.store.GetPools()
+func (uc *Usecase) GetPools(ctx context.Context) ([]common.Asset, error) {
+	pools, err := uc.store.GetPools(ctx)
 	return pools, err
 }
 
 // GetAssetDetails returns details of requested asset.
-func (uc *Usecase) GetAssetDetails(asset common.Asset) (*models.AssetDetails, error) {
-	pool, err := uc.store.GetPool(asset)
+func (uc *Usecase) GetAssetDetails(ctx context.Context, asset common.Asset) (*models.AssetDetails, error) {
+	pool, err := uc.store.GetPool(ctx, asset)
 	if err != nil {
 		return nil, err
 	}
@@ -144,16 +202,16 @@ func (uc *Usecase) GetAssetDetails(asset common.Asset) (*models.AssetDetails, er
 		assetDepth = uint64(basics.AssetDepth)
 		runeDepth = uint64(basics.RuneDepth)
 	} else {
-		assetDepth, err = uc.store.GetAssetDepth(asset)
+		assetDepth, err = uc.store.GetAssetDepth(ctx, asset)
 		if err != nil {
 			return nil, err
 		}
-		runeDepth, err = uc.store.GetRuneDepth(asset)
+		runeDepth, err = uc.store.GetRuneDepth(ctx, asset)
 		if err != nil {
 			return nil, err
 		}
 	}
-	dateCreated, err := uc.store.GetDateCreated(pool)
+	dateCreated, err := uc.store.GetDateCreated(ctx, pool)
 	if err != nil {
 		return nil, err
 	}
@@ -173,72 +231,72 @@ func (uc *Usecase) calculateAssetPrice(assetDepth, runeDepth uint64) float64 {
 }
 
 // GetStats returns some historical statistic data of network.
-func (uc *Usecase) GetStats() (*models.StatsData, error) {
+func (uc *Usecase) GetStats(ctx context.Context) (*models.StatsData, error) {
 	now := time.Now()
 	pastDay := now.Add(-day)
 	pastMonth := now.Add(-month)
 
-	dailyActiveUsers, err := uc.store.GetUsersCount(&pastDay, &now)
+	dailyActiveUsers, err := uc.store.GetUsersCount(ctx, &pastDay, &now)
 	if err != nil {
 		return nil, err
 	}
-	monthlyActiveUsers, err := uc.store.GetUsersCount(&pastMonth, &now)
+	monthlyActiveUsers, err := uc.store.GetUsersCount(ctx, &pastMonth, &now)
 	if err != nil {
 		return nil, err
 	}
-	totalUsers, err := uc.store.GetUsersCount(nil, nil)
+	totalUsers, err := uc.store.GetUsersCount(ctx, nil, nil)
 	if err != nil {
 		return nil, err
 	}
-	dailyTx, err := uc.store.GetTxsCount(&pastDay, &now)
+	dailyTx, err := uc.store.GetTxsCount(ctx, &pastDay, &now)
 	if err != nil {
 		return nil, err
 	}
-	monthlyTx, err := uc.store.GetTxsCount(&pastMonth, &now)
+	monthlyTx, err := uc.store.GetTxsCount(ctx, &pastMonth, &now)
 	if err != nil {
 		return nil, err
 	}
-	totalTx, err := uc.store.GetTxsCount(nil, nil)
+	totalTx, err := uc.store.GetTxsCount(ctx, nil, nil)
 	if err != nil {
 		return nil, err
 	}
-	totalVolume24hr, err := uc.store.GetTotalVolume(&pastDay, &now)
+	totalVolume24hr, err := uc.store.GetTotalVolume(ctx, &pastDay, &now)
 	if err != nil {
 		return nil, err
 	}
-	totalVolume, err := uc.store.GetTotalVolume(nil, nil)
+	totalVolume, err := uc.store.GetTotalVolume(ctx, nil, nil)
 	if err != nil {
 		return nil, err
 	}
-	bTotalStaked, err := uc.store.TotalStaked()
+	bTotalStaked, err := uc.store.TotalStaked(ctx)
 	if err != nil {
 		return nil, err
 	}
-	totalDepth, err := uc.store.GetTotalDepth()
+	totalDepth, err := uc.store.GetTotalDepth(ctx)
 	if err != nil {
 		return nil, err
 	}
-	totalEarned, err := uc.store.TotalEarned()
+	totalEarned, err := uc.store.TotalEarned(ctx)
 	if err != nil {
 		return nil, err
 	}
-	poolCount, err := uc.store.PoolCount()
+	poolCount, err := uc.store.PoolCount(ctx)
 	if err != nil {
 		return nil, err
 	}
-	totalAssetBuys, err := uc.store.TotalAssetBuys()
+	totalAssetBuys, err := uc.store.TotalAssetBuys(ctx)
 	if err != nil {
 		return nil, err
 	}
-	totalAssetSells, err := uc.store.TotalAssetSells()
+	totalAssetSells, err := uc.store.TotalAssetSells(ctx)
 	if err != nil {
 		return nil, err
 	}
-	totalStakeTx, err := uc.store.TotalStakeTx()
+	totalStakeTx, err := uc.store.TotalStakeTx(ctx)
 	if err != nil {
 		return nil, err
 	}
-	totalWithdrawTx, err := uc.store.TotalWithdrawTx()
+	totalWithdrawTx, err := uc.store.TotalWithdrawTx(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -265,10 +323,10 @@ func (uc *Usecase) GetStats() (*models.StatsData, error) {
 }
 
 // GetPoolBasics returns the basics of pool like asset and rune depths, units and status.
-func (uc *Usecase) GetPoolBasics(asset common.Asset) (models.PoolBasics, error) {
-	basics, err := uc.store.GetPoolBasics(asset)
+func (uc *Usecase) GetPoolBasics(ctx context.Context, asset common.Asset) (models.PoolBasics, error) {
+	basics, err := uc.store.GetPoolBasics(ctx, asset)
 	if basics.Status == models.Unknown {
-		basics.Status, err = uc.fetchPoolStatus(asset)
+		basics.Status, err = uc.fetchPoolStatus(ctx, asset)
 		if err != nil {
 			return models.PoolBasics{}, err
 		}
@@ -283,7 +341,7 @@ func (uc *Usecase) GetPoolBasics(asset common.Asset) (models.PoolBasics, error)
 }
 
 func (uc *Usecase) fetchThorchainPool() {
-	pools, err := uc.thorchain.GetPools()
+	pools, err := uc.thorchain.GetPools(context.Background())
 	if err == nil {
 		uc.thorchainLock.Lock()
 		defer uc.thorchainLock.Unlock()
@@ -309,20 +367,20 @@ func (uc *Usecase) overwriteDepth(basic *models.PoolBasics) error {
 }
 
 // GetPoolSimpleDetails returns pool depths, status and swap stats of the given asset.
-func (uc *Usecase) GetPoolSimpleDetails(asset common.Asset) (*models.PoolSimpleDetails, error) {
-	basics, err := uc.store.GetPoolBasics(asset)
+func (uc *Usecase) GetPoolSimpleDetails(ctx context.Context, asset common.Asset) (*models.PoolSimpleDetails, error) {
+	basics, err := uc.store.GetPoolBasics(ctx, asset)
 	if err != nil {
 		return nil, err
 	}
 	if basics.Status == models.Unknown {
-		basics.Status, err = uc.fetchPoolStatus(asset)
+		basics.Status, err = uc.fetchPoolStatus(ctx, asset)
 		if err != nil {
 			return nil, err
 		}
 	}
 	now := time.Now()
 	pastDay := now.Add(-day)
-	vol24, err := uc.store.GetPoolVolume(asset, pastDay, now)
+	vol24, err := uc.store.GetPoolVolume(ctx, asset, pastDay, now)
 	if err != nil {
 		return nil, err
 	}
@@ -354,7 +412,7 @@ func (uc *Usecase) GetPoolSimpleDetails(asset common.Asset) (*models.PoolSimpleD
 	poolVolume := int64(float64(details.BuyVolume)*details.Price) + details.SellVolume
 	details.PoolSlipAverage = (basics.BuySlipTotal + basics.SellSlipTotal) / float64(details.SwappingTxCount)
 	details.PoolTxAverage = float64(poolVolume) / float64(details.SwappingTxCount)
-	details.PoolAPY, err = uc.getPoolAPY(asset)
+	details.PoolAPY, err = uc.getPoolAPY(ctx, asset)
 	if err != nil {
 		return nil, err
 	}
@@ -381,8 +439,8 @@ func calculateAPY(periodicRate float64, n float64) float64 {
 }
 
 // fetchPoolStatus fetches pool status from thorchain and update database.
-func (uc *Usecase) fetchPoolStatus(asset common.Asset) (models.PoolStatus, error) {
-	status, err := uc.thorchain.GetPoolStatus(asset)
+func (uc *Usecase) fetchPoolStatus(ctx context.Context, asset common.Asset) (models.PoolStatus, error) {
+	status, err := uc.thorchain.GetPoolStatus(ctx, asset)
 	if err != nil {
 		return models.Unknown, errors.Wrap(err, "failed to get pool status")
 	}
@@ -403,13 +461,13 @@ func (uc *Usecase) fetchPoolStatus(asset common.Asset) (models.PoolStatus, error
 }
 
 // GetPoolDetails returns price, buyers and sellers and tx statstic data.
-func (uc *Usecase) GetPoolDetails(asset common.Asset) (*models.PoolDetails, error) {
-	basics, err := uc.store.GetPoolBasics(asset)
+func (uc *Usecase) GetPoolDetails(ctx context.Context, asset common.Asset) (*models.PoolDetails, error) {
+	basics, err := uc.store.GetPoolBasics(ctx, asset)
 	if err != nil {
 		return nil, err
 	}
 	if basics.Status == models.Unknown {
-		status, err := uc.fetchPoolStatus(asset)
+		status, err := uc.fetchPoolStatus(ctx, asset)
 		if err != nil {
 			return nil, err
 		}
@@ -424,19 +482,19 @@ func (uc *Usecase) GetPoolDetails(asset common.Asset) (*models.PoolDetails, erro
 
 	now := time.Now()
 	pastDay := now.Add(-day)
-	vol24, err := uc.store.GetPoolVolume(asset, pastDay, now)
+	vol24, err := uc.store.GetPoolVolume(ctx, asset, pastDay, now)
 	if err != nil {
 		return nil, err
 	}
-	poolROI12, err := uc.store.GetPoolROI12(asset)
+	poolROI12, err := uc.store.GetPoolROI12(ctx, asset)
 	if err != nil {
 		return nil, err
 	}
-	stakersCount, err := uc.store.GetStakersCount(asset)
+	stakersCount, err := uc.store.GetStakersCount(ctx, asset)
 	if err != nil {
 		return nil, err
 	}
-	swappersCount, err := uc.store.GetSwappersCount(asset)
+	swappersCount, err := uc.store.GetSwappersCount(ctx, asset)
 	if err != nil {
 		return nil, err
 	}
@@ -477,7 +535,7 @@ func (uc *Usecase) GetPoolDetails(asset common.Asset) (*models.PoolDetails, erro
 	details.PoolStakedTotal = uint64(float64(details.AssetStaked)*details.Price + float64(details.RuneStaked))
 	details.PoolROI = (details.AssetROI + details.RuneROI) / 2
 	details.PoolEarned = int64(float64(details.AssetEarned)*details.Price) + details.RuneEarned
-	details.PoolAPY, err = uc.getPoolAPY(asset)
+	details.PoolAPY, err = uc.getPoolAPY(ctx, asset)
 	if err != nil {
 		return nil, err
 	}
@@ -487,22 +545,22 @@ func (uc *Usecase) GetPoolDetails(asset common.Asset) (*models.PoolDetails, erro
 // GetPoolAPY calculate poolAPY as follow
 // periodicRate = poolEarned/totalDepth (if pool is active less than 30 days, then we should extrapolate to 30)
 // APY = (1 + periodicRate) ^ 12 -1
-func (uc *Usecase) getPoolAPY(pool common.Asset) (float64, error) {
-	poolBasic, err := uc.GetPoolBasics(pool)
+func (uc *Usecase) getPoolAPY(ctx context.Context, pool common.Asset) (float64, error) {
+	poolBasic, err := uc.GetPoolBasics(ctx, pool)
 	if err != nil {
 		return 0, errors.Wrap(err, "GetPoolAPY failed")
 	}
 	if poolBasic.Status != models.Enabled {
 		return 0, nil
 	}
-	lastActiveDate, err := uc.store.GetPoolLastEnabledDate(pool)
+	lastActiveDate, err := uc.store.GetPoolLastEnabledDate(ctx, pool)
 	if err != nil {
 		return 0, errors.Wrap(err, "GetPoolAPY failed")
 	}
 	if lastActiveDate.Before(time.Now().Add(-30 * 24 * time.Hour)) {
 		lastActiveDate = time.Now().Add(-30 * 24 * time.Hour)
 	}
-	poolEarned, err := uc.store.GetPoolEarned(pool, lastActiveDate)
+	poolEarned, err := uc.store.GetPoolEarned(ctx, pool, lastActiveDate)
 	if err != nil {
 		return 0, errors.Wrap(err, "GetPoolAPY failed")
 	}
@@ -515,14 +573,14 @@ func (uc *Usecase) getPoolAPY(pool common.Asset) (float64, error) {
 }
 
 // GetStakers returns list of all active stakers in network.
-func (uc *Usecase) GetStakers() ([]common.Address, error) {
-	stakers, err := uc.store.GetStakerAddresses()
+func (uc *Usecase) GetStakers(ctx context.Context) ([]common.Address, error) {
+	stakers, err := uc.store.GetStakerAddresses(ctx)
 	return stakers, err
 }
 
 // GetStakerDetails returns staker general details.
-func (uc *Usecase) GetStakerDetails(address common.Address) (*models.StakerAddressDetails, error) {
-	details, err := uc.store.GetStakerAddressDetails(address)
+func (uc *Usecase) GetStakerDetails(ctx context.Context, address common.Address) (*models.StakerAddressDetails, error) {
+	details, err := uc.store.GetStakerAddressDetails(ctx, address)
 	if err != nil {
 		return nil, err
 	}
@@ -530,8 +588,8 @@ func (uc *Usecase) GetStakerDetails(address common.Address) (*models.StakerAddre
 }
 
 // GetStakerAssetDetails returns staker details for an specific asset.
-func (uc *Usecase) GetStakerAssetDetails(address common.Address, asset common.Asset) (*models.StakerAddressAndAssetDetails, error) {
-	details, err := uc.store.GetStakersAddressAndAssetDetails(address, asset)
+func (uc *Usecase) GetStakerAssetDetails(ctx context.Context, address common.Address, asset common.Asset) (*models.StakerAddressAndAssetDetails, error) {
+	details, err := uc.store.GetStakersAddressAndAssetDetails(ctx, address, asset)
 	if err != nil {
 		return nil, err
 	}
@@ -539,19 +597,19 @@ func (uc *Usecase) GetStakerAssetDetails(address common.Address, asset common.As
 }
 
 // GetNetworkInfo returns some details about nodes stats in network.
-func (uc *Usecase) GetNetworkInfo() (*models.NetworkInfo, error) {
+func (uc *Usecase) GetNetworkInfo(ctx context.Context) (*models.NetworkInfo, error) {
 	uc.constsMu.Lock()
 	defer uc.constsMu.Unlock()
-	err := uc.updateConstantsByMimir()
+	err := uc.updateConstantsByMimir(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to update constants from mimir")
 	}
-	totalDepth, err := uc.store.GetTotalDepth()
+	totalDepth, err := uc.store.GetTotalDepth(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	nodeAccounts, err := uc.thorchain.GetNodeAccounts()
+	nodeAccounts, err := uc.thorchain.GetNodeAccounts(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get NodeAccounts")
 	}
@@ -562,22 +620,22 @@ func (uc *Usecase) GetNetworkInfo() (*models.NetworkInfo, error) {
 	metrics := calculateBondMetrics(activeBonds, standbyBonds)
 	totalActiveBond := metrics.TotalActiveBond
 
-	vaultData, err := uc.thorchain.GetVaultData()
+	vaultData, err := uc.thorchain.GetVaultData(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get VaultData")
 	}
 	poolShareFactor := calculatePoolShareFactor(totalActiveBond, totalDepth)
 	rewards := uc.calculateRewards(vaultData.TotalReserve, poolShareFactor)
 
-	lastHeight, err := uc.thorchain.GetLastChainHeight()
+	lastHeight, err := uc.thorchain.GetLastChainHeight(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get LastChainHeight")
 	}
-	nextChurnHeight, err := uc.computeNextChurnHight(lastHeight.Thorchain)
+	nextChurnHeight, err := uc.computeNextChurnHight(ctx, lastHeight.Thorchain)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get NodeAccounts")
 	}
-	totalEnabledRuneDepth, err := uc.totalEnabledRuneDepth()
+	totalEnabledRuneDepth, err := uc.totalEnabledRuneDepth(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get NodeAccounts")
 	}
@@ -603,14 +661,14 @@ func (uc *Usecase) GetNetworkInfo() (*models.NetworkInfo, error) {
 	return &netInfo, nil
 }
 
-func (uc *Usecase) totalEnabledRuneDepth() (int64, error) {
-	pools, err := uc.GetPools()
+func (uc *Usecase) totalEnabledRuneDepth(ctx context.Context) (int64, error) {
+	pools, err := uc.GetPools(ctx)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to get totalEnabledRuneDepth")
 	}
 	var runeDepth int64
 	for _, pool := range pools {
-		poolBasic, err := uc.GetPoolBasics(pool)
+		poolBasic, err := uc.GetPoolBasics(ctx, pool)
 		if err != nil {
 			return 0, errors.Wrap(err, "failed to get totalEnabledRuneDepth")
 		}
@@ -716,8 +774,8 @@ func (uc *Usecase) calculateRewards(totalReserve uint64, poolShareFactor float64
 	}
 }
 
-func (uc *Usecase) computeNextChurnHight(lastHeight int64) (int64, error) {
-	lastChurn, err := uc.computeLastChurn()
+func (uc *Usecase) computeNextChurnHight(ctx context.Context, lastHeight int64) (int64, error) {
+	lastChurn, err := uc.computeLastChurn(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -734,8 +792,8 @@ func (uc *Usecase) computeNextChurnHight(lastHeight int64) (int64, error) {
 	return next, nil
 }
 
-func (uc *Usecase) computeLastChurn() (int64, error) {
-	vaults, err := uc.thorchain.GetAsgardVaults()
+func (uc *Usecase) computeLastChurn(ctx context.Context) (int64, error) {
+	vaults, err := uc.thorchain.GetAsgardVaults(ctx)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to get Vaults")
 	}
@@ -754,8 +812,8 @@ func (uc *Usecase) calculatePoolActivationCountdown(lastHeight int64) int64 {
 	return newPoolCycle - lastHeight%newPoolCycle
 }
 
-func (uc *Usecase) updateConstantsByMimir() error {
-	mimir, err := uc.thorchain.GetMimir()
+func (uc *Usecase) updateConstantsByMimir(ctx context.Context) error {
+	mimir, err := uc.thorchain.GetMimir(ctx)
 	if err != nil {
 		return err
 	}
@@ -775,21 +833,63 @@ func (uc *Usecase) updateConstantsByMimir() error {
 }
 
 // GetTotalVolChanges returns an array of total changes and running total of all pools in rune.
-func (uc *Usecase) GetTotalVolChanges(inv models.Interval, from, to time.Time) ([]models.TotalVolChanges, error) {
+func (uc *Usecase) GetTotalVolChanges(ctx context.Context, inv models.Interval, from, to time.Time) ([]models.TotalVolChanges, error) {
+	if err := inv.Validate(); err != nil {
+		return nil, err
+	}
+
+	return uc.store.GetTotalVolChanges(ctx, inv, from, to)
+}
+
+// GetSlashHistory returns the amounts slashed from the specified pool, or all
+// pools if it's empty, per interval.
+func (uc *Usecase) GetSlashHistory(ctx context.Context, pool common.Asset, inv models.Interval, from, to time.Time) ([]models.SlashChanges, error) {
+	if err := inv.Validate(); err != nil {
+		return nil, err
+	}
+
+	return uc.store.GetSlashChanges(ctx, pool, inv, from, to)
+}
+
+// GetGasHistory returns the gas spent by outbound txs of the specified chain, or
+// all chains if it's empty, and the rune reimbursed for it per interval.
+func (uc *Usecase) GetGasHistory(ctx context.Context, chain common.Chain, inv models.Interval, from, to time.Time) ([]models.GasChanges, error) {
+	if err := inv.Validate(); err != nil {
+		return nil, err
+	}
+
+	changes, err := uc.store.GetGasChanges(ctx, chain, inv, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for i := range changes {
+		if changes[i].OutboundCount > 0 {
+			changes[i].AverageGas = float64(changes[i].GasUsed) / float64(changes[i].OutboundCount)
+		}
+		if changes[i].GasUsedInRune > 0 {
+			changes[i].Coverage = float64(changes[i].GasReplenished) / float64(changes[i].GasUsedInRune)
+		}
+	}
+	return changes, nil
+}
+
+// GetFeeHistory returns the network fees charged from events of the specified
+// pool, or all pools if it's empty, per interval.
+func (uc *Usecase) GetFeeHistory(ctx context.Context, pool common.Asset, inv models.Interval, from, to time.Time) ([]models.FeeChanges, error) {
 	if err := inv.Validate(); err != nil {
 		return nil, err
 	}
 
-	return uc.store.GetTotalVolChanges(inv, from, to)
+	return uc.store.GetFeeChanges(ctx, pool, inv, from, to)
 }
 
 // GetPoolAggChanges returns historical aggregated details of the specified pool.
-func (uc *Usecase) GetPoolAggChanges(pool common.Asset, inv models.Interval, from, to time.Time) ([]models.PoolAggChanges, error) {
+func (uc *Usecase) GetPoolAggChanges(ctx context.Context, pool common.Asset, inv models.Interval, from, to time.Time) ([]models.PoolAggChanges, error) {
 	if err := inv.Validate(); err != nil {
 		return nil, err
 	}
 
-	changes, err := uc.store.GetPoolAggChanges(pool, inv, from, to)
+	changes, err := uc.store.GetPoolAggChanges(ctx, pool, inv, from, to)
 	if err != nil {
 		return nil, err
 	}
@@ -799,3 +899,213 @@ func (uc *Usecase) GetPoolAggChanges(pool common.Asset, inv models.Interval, fro
 	}
 	return changes, nil
 }
+
+// GetPoolYield returns the earnings and APY of the specified pool per interval alongside
+// its trailing APYs at the end of the requested period.
+func (uc *Usecase) GetPoolYield(ctx context.Context, pool common.Asset, inv models.Interval, from, to time.Time) (*models.PoolYield, error) {
+	if err := inv.Validate(); err != nil {
+		return nil, err
+	}
+
+	changes, err := uc.store.GetPoolYieldChanges(ctx, pool, inv, from, to)
+	if err != nil {
+		return nil, err
+	}
+	n := periodsPerYear(inv)
+	for i := 0; i < len(changes); i++ {
+		calculateYield(&changes[i], n)
+	}
+
+	daily, err := uc.store.GetPoolYieldChanges(ctx, pool, models.DailyInterval, to.Add(-90*day), to)
+	if err != nil {
+		return nil, err
+	}
+	n = periodsPerYear(models.DailyInterval)
+	for i := 0; i < len(daily); i++ {
+		calculateYield(&daily[i], n)
+	}
+
+	yield := &models.PoolYield{
+		Intervals: changes,
+		APY7d:     calculateTrailingAPY(daily, to, 7*day),
+		APY30d:    calculateTrailingAPY(daily, to, 30*day),
+		APY90d:    calculateTrailingAPY(daily, to, 90*day),
+	}
+	return yield, nil
+}
+
+// calculateYield fills the calculated fields of ch where n is number of intervals in a year.
+func calculateYield(ch *models.PoolYieldChanges, n float64) {
+	ch.Price = calculatePrice(ch.AssetDepth, ch.RuneDepth)
+	ch.FeesEarned = int64(float64(ch.BuyFees)*ch.Price) + ch.SellFees
+	assetEarned := ch.GasUsed + ch.BuyFees
+	runeEarned := ch.GasReplenished + ch.Reward + ch.SellFees
+	ch.PoolEarned = int64(float64(assetEarned)*ch.Price) + runeEarned
+	if ch.RuneDepth > 0 {
+		periodicRate := float64(ch.PoolEarned) / float64(2*ch.RuneDepth)
+		ch.PoolAPY = calculateAPY(periodicRate, n)
+	}
+}
+
+// calculateTrailingAPY returns the APY based on the pool earnings of the daily changes
+// within the window before "to" and the latest pool depth.
+func calculateTrailingAPY(daily []models.PoolYieldChanges, to time.Time, window time.Duration) float64 {
+	if len(daily) == 0 {
+		return 0
+	}
+	runeDepth := daily[len(daily)-1].RuneDepth
+	if runeDepth <= 0 {
+		return 0
+	}
+
+	since := to.Add(-window)
+	var poolEarned int64
+	for _, ch := range daily {
+		if ch.Time.Before(since) {
+			continue
+		}
+		poolEarned += ch.PoolEarned
+	}
+	periodicRate := float64(poolEarned) / float64(2*runeDepth)
+	return calculateAPY(periodicRate, float64(year)/float64(window))
+}
+
+// periodsPerYear returns number of intervals in a year.
+func periodsPerYear(inv models.Interval) float64 {
+	d := intervalDuration(inv)
+	if d == 0 {
+		return 0
+	}
+	return float64(year) / float64(d)
+}
+
+// intervalDuration returns the length of the interval or zero if it's unbounded.
+func intervalDuration(inv models.Interval) time.Duration {
+	switch inv {
+	case models.FiveMinInterval:
+		return 5 * time.Minute
+	case models.HourlyInterval:
+		return time.Hour
+	case models.DailyInterval:
+		return day
+	case models.WeeklyInterval:
+		return 7 * day
+	case models.MonthlyInterval:
+		return month
+	case models.QuarterInterval:
+		return 3 * month
+	case models.YearlyInterval:
+		return year
+	}
+	return 0
+}
+
+// defaultTradeSizes is the trade sizes (in rune) used for depth curve when no
+// sizes are requested.
+var defaultTradeSizes = []int64{
+	1000000000,    // 10 RUNE
+	10000000000,   // 100 RUNE
+	100000000000,  // 1,000 RUNE
+	1000000000000, // 10,000 RUNE
+}
+
+// targetSlip is the slip used for calculating historical usable liquidity of pools.
+const targetSlip = 0.01
+
+// GetPoolDepthCurve returns the expected slip and fee of trades with the given sizes (in rune)
+// based on the current depths of the specified pool and the daily size of trades
+// causing 1% slip between from and to.
+func (uc *Usecase) GetPoolDepthCurve(ctx context.Context, pool common.Asset, sizes []int64, from, to time.Time) (*models.DepthCurve, error) {
+	if len(sizes) == 0 {
+		sizes = defaultTradeSizes
+	}
+	basics, err := uc.GetPoolBasics(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]models.DepthCurvePoint, len(sizes))
+	for i, size := range sizes {
+		points[i] = calculateDepthCurvePoint(size, basics.RuneDepth)
+	}
+
+	changes, err := uc.store.GetPoolAggChanges(ctx, pool, models.DailyInterval, from, to)
+	if err != nil {
+		return nil, err
+	}
+	history := make([]models.SlipDepth, len(changes))
+	for i, ch := range changes {
+		history[i] = models.SlipDepth{
+			Time:       ch.Time,
+			AssetDepth: ch.AssetDepth,
+			RuneDepth:  ch.RuneDepth,
+			AssetSize:  calculateSizeForSlip(ch.AssetDepth, targetSlip),
+			RuneSize:   calculateSizeForSlip(ch.RuneDepth, targetSlip),
+		}
+	}
+
+	curve := &models.DepthCurve{
+		AssetDepth: basics.AssetDepth,
+		RuneDepth:  basics.RuneDepth,
+		Price:      calculatePrice(basics.AssetDepth, basics.RuneDepth),
+		Points:     points,
+		History:    history,
+	}
+	return curve, nil
+}
+
+// calculateDepthCurvePoint returns the expected result of a trade with the given size (in rune)
+// on a pool with the given rune depth.
+func calculateDepthCurvePoint(size, runeDepth int64) models.DepthCurvePoint {
+	point := models.DepthCurvePoint{
+		TradeSize: size,
+	}
+	if size <= 0 || runeDepth <= 0 {
+		return point
+	}
+
+	// slip = x / (x + X)
+	// fee = (x^2 * X) / (x + X)^2
+	// output = (x * X^2) / (x + X)^2
+	x := float64(size)
+	X := float64(runeDepth)
+	point.Slip = x / (x + X)
+	point.Fee = int64(x * x * X / ((x + X) * (x + X)))
+	point.Output = int64(x * X * X / ((x + X) * (x + X)))
+	return point
+}
+
+// calculateSizeForSlip returns the size of a trade which causes the given slip
+// on the given depth.
+func calculateSizeForSlip(depth int64, slip float64) int64 {
+	// x = X * slip / (1 - slip)
+	return int64(float64(depth) * slip / (1 - slip))
+}
+
+// GetPoolDeviation returns the price deviation history of the specified pool
+// alongside its current alert if the pool is deviated more than the threshold.
+func (uc *Usecase) GetPoolDeviation(ctx context.Context, pool common.Asset, from, to time.Time) (*models.PoolDeviation, error) {
+	history, err := uc.store.GetPriceDeviations(ctx, pool, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	deviation := &models.PoolDeviation{
+		History: history,
+	}
+	if uc.detector != nil {
+		deviation.Alert, err = uc.detector.getAlert(ctx, pool)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return deviation, nil
+}
+
+// GetDeviationAlerts returns the pools which currently deviate more than the threshold.
+func (uc *Usecase) GetDeviationAlerts(ctx context.Context) ([]models.DeviationAlert, error) {
+	if uc.detector == nil {
+		return []models.DeviationAlert{}, nil
+	}
+	return uc.store.GetDeviationAlerts(ctx)
+}
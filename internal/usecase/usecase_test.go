@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"math"
 	"sync"
 	"testing"
@@ -82,7 +83,7 @@ type TestGetHealthStore struct {
 	isHealthy bool
 }
 
-func (s *TestGetHealthStore) Ping() error {
+func (s *TestGetHealthStore) Ping(ctx context.Context) error {
 	if s.isHealthy {
 		return nil
 	}
@@ -122,13 +123,13 @@ func (s *UsecaseSuite) TestGetHealth(c *C) {
 	c.Assert(err, IsNil)
 	time.Sleep(2 * time.Second)
 
-	health := uc.GetHealth()
+	health := uc.GetHealth(context.Background())
 	c.Assert(health.Database, Equals, store.isHealthy)
 	c.Assert(health.ScannerHeight, Equals, int64(3))
 
 	// Unhealthy DB situation
 	store.isHealthy = false
-	health = uc.GetHealth()
+	health = uc.GetHealth(context.Background())
 	c.Assert(health.Database, Equals, store.isHealthy)
 
 	err = uc.StopScanner()
@@ -163,7 +164,7 @@ type TestGetTxDetailsStore struct {
 	err        error
 }
 
-func (s *TestGetTxDetailsStore) GetTxDetails(address common.Address, txID common.TxID, asset common.Asset, eventTypes []string, offset, limit int64) ([]models.TxDetails, int64, error) {
+func (s *TestGetTxDetailsStore) GetTxDetails(ctx context.Context, address common.Address, txID common.TxID, asset common.Asset, eventTypes []string, offset, limit int64) ([]models.TxDetails, int64, error) {
 	s.address = address
 	s.txID = txID
 	s.asset = asset
@@ -255,7 +256,7 @@ func (s *UsecaseSuite) TestGetTxDetails(c *C) {
 	asset, _ := common.NewAsset("BNB.TOML-4BC")
 	eventTypes := []string{"stake"}
 	page := models.NewPage(0, 2)
-	details, count, err := uc.GetTxDetails(address, txID, asset, eventTypes, page)
+	details, count, err := uc.GetTxDetails(context.Background(), address, txID, asset, eventTypes, page)
 	c.Assert(err, IsNil)
 	c.Assert(details, DeepEquals, store.txDetails)
 	c.Assert(count, Equals, store.count)
@@ -272,7 +273,7 @@ func (s *UsecaseSuite) TestGetTxDetails(c *C) {
 	uc, err = NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	_, _, err = uc.GetTxDetails(address, txID, asset, eventTypes, page)
+	_, _, err = uc.GetTxDetails(context.Background(), address, txID, asset, eventTypes, page)
 	c.Assert(err, NotNil)
 }
 
@@ -282,7 +283,7 @@ type TestGetPoolsStore struct {
 	err   error
 }
 
-func (s *TestGetPoolsStore) GetPools() ([]common.Asset, error) {
+func (s *TestGetPoolsStore) GetPools(ctx context.Context) ([]common.Asset, error) {
 	return s.pools, s.err
 }
 
@@ -300,7 +301,7 @@ func (s *UsecaseSuite) TestGetPools(c *C) {
 	uc, err := NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	pools, err := uc.GetPools()
+	pools, err := uc.GetPools(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(pools, DeepEquals, store.pools)
 
@@ -310,7 +311,7 @@ func (s *UsecaseSuite) TestGetPools(c *C) {
 	uc, err = NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	_, err = uc.GetPools()
+	_, err = uc.GetPools(context.Background())
 	c.Assert(err, NotNil)
 }
 
@@ -323,19 +324,19 @@ type TestGetAssetDetailsStore struct {
 	err         error
 }
 
-func (s *TestGetAssetDetailsStore) GetPool(asset common.Asset) (common.Asset, error) {
+func (s *TestGetAssetDetailsStore) GetPool(ctx context.Context, asset common.Asset) (common.Asset, error) {
 	return s.pool, s.err
 }
 
-func (s *TestGetAssetDetailsStore) GetAssetDepth(asset common.Asset) (uint64, error) {
+func (s *TestGetAssetDetailsStore) GetAssetDepth(ctx context.Context, asset common.Asset) (uint64, error) {
 	return s.assetDepth, s.err
 }
 
-func (s *TestGetAssetDetailsStore) GetRuneDepth(asset common.Asset) (uint64, error) {
+func (s *TestGetAssetDetailsStore) GetRuneDepth(ctx context.Context, asset common.Asset) (uint64, error) {
 	return s.runeDepth, s.err
 }
 
-func (s *TestGetAssetDetailsStore) GetDateCreated(asset common.Asset) (uint64, error) {
+func (s *TestGetAssetDetailsStore) GetDateCreated(ctx context.Context, asset common.Asset) (uint64, error) {
 	return s.dateCreated, s.err
 }
 
@@ -353,7 +354,7 @@ func (s *UsecaseSuite) TestGetAssetDetails(c *C) {
 	uc, err := NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	details, err := uc.GetAssetDetails(store.pool)
+	details, err := uc.GetAssetDetails(context.Background(), store.pool)
 	c.Assert(err, IsNil)
 	c.Assert(details, DeepEquals, &models.AssetDetails{
 		PriceInRune: 1.5,
@@ -366,7 +367,7 @@ func (s *UsecaseSuite) TestGetAssetDetails(c *C) {
 	uc, err = NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	_, err = uc.GetAssetDetails(store.pool)
+	_, err = uc.GetAssetDetails(context.Background(), store.pool)
 	c.Assert(err, NotNil)
 }
 
@@ -391,7 +392,7 @@ type TestGetStatsStore struct {
 	err                error
 }
 
-func (s *TestGetStatsStore) GetUsersCount(from, to *time.Time) (uint64, error) {
+func (s *TestGetStatsStore) GetUsersCount(ctx context.Context, from, to *time.Time) (uint64, error) {
 	if s.err != nil {
 		return 0, s.err
 	}
@@ -408,7 +409,7 @@ func (s *TestGetStatsStore) GetUsersCount(from, to *time.Time) (uint64, error) {
 	return 0, errors.New("could not query users count")
 }
 
-func (s *TestGetStatsStore) GetTxsCount(from, to *time.Time) (uint64, error) {
+func (s *TestGetStatsStore) GetTxsCount(ctx context.Context, from, to *time.Time) (uint64, error) {
 	if s.err != nil {
 		return 0, s.err
 	}
@@ -425,7 +426,7 @@ func (s *TestGetStatsStore) GetTxsCount(from, to *time.Time) (uint64, error) {
 	return 0, errors.New("could not query txs count")
 }
 
-func (s *TestGetStatsStore) GetTotalVolume(from, to *time.Time) (uint64, error) {
+func (s *TestGetStatsStore) GetTotalVolume(ctx context.Context, from, to *time.Time) (uint64, error) {
 	if s.err != nil {
 		return 0, s.err
 	}
@@ -439,35 +440,35 @@ func (s *TestGetStatsStore) GetTotalVolume(from, to *time.Time) (uint64, error)
 	return 0, errors.New("could not query total volume count")
 }
 
-func (s *TestGetStatsStore) TotalStaked() (uint64, error) {
+func (s *TestGetStatsStore) TotalStaked(ctx context.Context) (uint64, error) {
 	return s.totalStaked, s.err
 }
 
-func (s *TestGetStatsStore) GetTotalDepth() (uint64, error) {
+func (s *TestGetStatsStore) GetTotalDepth(ctx context.Context) (uint64, error) {
 	return s.totalDepth, s.err
 }
 
-func (s *TestGetStatsStore) PoolCount() (uint64, error) {
+func (s *TestGetStatsStore) PoolCount(ctx context.Context) (uint64, error) {
 	return s.poolCount, s.err
 }
 
-func (s *TestGetStatsStore) TotalAssetBuys() (uint64, error) {
+func (s *TestGetStatsStore) TotalAssetBuys(ctx context.Context) (uint64, error) {
 	return s.totalAssetBuys, s.err
 }
 
-func (s *TestGetStatsStore) TotalAssetSells() (uint64, error) {
+func (s *TestGetStatsStore) TotalAssetSells(ctx context.Context) (uint64, error) {
 	return s.totalAssetSells, s.err
 }
 
-func (s *TestGetStatsStore) TotalStakeTx() (uint64, error) {
+func (s *TestGetStatsStore) TotalStakeTx(ctx context.Context) (uint64, error) {
 	return s.totalStakeTx, s.err
 }
 
-func (s *TestGetStatsStore) TotalWithdrawTx() (uint64, error) {
+func (s *TestGetStatsStore) TotalWithdrawTx(ctx context.Context) (uint64, error) {
 	return s.totalWithdrawTx, s.err
 }
 
-func (s *TestGetStatsStore) TotalEarned() (int64, error) {
+func (s *TestGetStatsStore) TotalEarned(ctx context.Context) (int64, error) {
 	return s.totalEarned, s.err
 }
 
@@ -493,7 +494,7 @@ func (s *UsecaseSuite) TestGetStats(c *C) {
 	uc, err := NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	stats, err := uc.GetStats()
+	stats, err := uc.GetStats(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(stats, DeepEquals, &models.StatsData{
 		DailyActiveUsers:   store.dailyActiveUsers,
@@ -520,7 +521,7 @@ func (s *UsecaseSuite) TestGetStats(c *C) {
 	uc, err = NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	_, err = uc.GetStats()
+	_, err = uc.GetStats(context.Background())
 	c.Assert(err, NotNil)
 }
 
@@ -530,7 +531,7 @@ type TestGetPoolBasicsStore struct {
 	err    error
 }
 
-func (s *TestGetPoolBasicsStore) GetPoolBasics(asset common.Asset) (models.PoolBasics, error) {
+func (s *TestGetPoolBasicsStore) GetPoolBasics(ctx context.Context, asset common.Asset) (models.PoolBasics, error) {
 	return s.basics, s.err
 }
 
@@ -547,12 +548,12 @@ func (s *UsecaseSuite) TestGetPoolBasics(c *C) {
 	uc, err := NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	stats, err := uc.GetPoolBasics(common.BNBAsset)
+	stats, err := uc.GetPoolBasics(context.Background(), common.BNBAsset)
 	c.Assert(err, IsNil)
 	c.Assert(stats, DeepEquals, store.basics)
 
 	store.basics.Status = models.Unknown
-	_, err = uc.GetPoolBasics(common.BNBAsset)
+	_, err = uc.GetPoolBasics(context.Background(), common.BNBAsset)
 	c.Assert(err, NotNil)
 
 	store = &TestGetPoolBasicsStore{
@@ -561,7 +562,7 @@ func (s *UsecaseSuite) TestGetPoolBasics(c *C) {
 	uc, err = NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	_, err = uc.GetPoolBasics(common.BTCAsset)
+	_, err = uc.GetPoolBasics(context.Background(), common.BTCAsset)
 	c.Assert(err, NotNil)
 }
 
@@ -574,11 +575,11 @@ type TestGetPoolSimpleDetailsStore struct {
 	err               error
 }
 
-func (s *TestGetPoolSimpleDetailsStore) GetPoolBasics(asset common.Asset) (models.PoolBasics, error) {
+func (s *TestGetPoolSimpleDetailsStore) GetPoolBasics(ctx context.Context, asset common.Asset) (models.PoolBasics, error) {
 	return s.basics, s.err
 }
 
-func (s *TestGetPoolSimpleDetailsStore) GetPoolVolume(asset common.Asset, from, to time.Time) (int64, error) {
+func (s *TestGetPoolSimpleDetailsStore) GetPoolVolume(ctx context.Context, asset common.Asset, from, to time.Time) (int64, error) {
 	s.from = from
 	s.to = to
 	return s.poolVolume24Hours, s.err
@@ -608,7 +609,7 @@ func (s *UsecaseSuite) TestGetPoolSimpleDetails(c *C) {
 	uc, err := NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	details, err := uc.GetPoolSimpleDetails(common.BNBAsset)
+	details, err := uc.GetPoolSimpleDetails(context.Background(), common.BNBAsset)
 	c.Assert(err, IsNil)
 	c.Assert(store.to.Sub(store.from), Equals, time.Hour*24)
 	c.Assert(details, DeepEquals, &models.PoolSimpleDetails{
@@ -626,7 +627,7 @@ func (s *UsecaseSuite) TestGetPoolSimpleDetails(c *C) {
 	})
 
 	store.basics.Status = models.Unknown
-	_, err = uc.GetPoolSimpleDetails(common.BNBAsset)
+	_, err = uc.GetPoolSimpleDetails(context.Background(), common.BNBAsset)
 	c.Assert(err, NotNil)
 
 	store = &TestGetPoolSimpleDetailsStore{
@@ -635,7 +636,7 @@ func (s *UsecaseSuite) TestGetPoolSimpleDetails(c *C) {
 	uc, err = NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	_, err = uc.GetPoolSimpleDetails(common.BNBAsset)
+	_, err = uc.GetPoolSimpleDetails(context.Background(), common.BNBAsset)
 	c.Assert(err, NotNil)
 }
 
@@ -645,7 +646,7 @@ type TestGetPoolDetailsThorchain struct {
 	err    error
 }
 
-func (t *TestGetPoolDetailsThorchain) GetPoolStatus(pool common.Asset) (models.PoolStatus, error) {
+func (t *TestGetPoolDetailsThorchain) GetPoolStatus(ctx context.Context, pool common.Asset) (models.PoolStatus, error) {
 	return t.status, t.err
 }
 
@@ -665,23 +666,23 @@ func (s *TestGetPoolDetailsStore) CreatePoolRecord(e *models.EventPool) error {
 	return s.err
 }
 
-func (s *TestGetPoolDetailsStore) GetPoolBasics(asset common.Asset) (models.PoolBasics, error) {
+func (s *TestGetPoolDetailsStore) GetPoolBasics(ctx context.Context, asset common.Asset) (models.PoolBasics, error) {
 	return s.basics, s.err
 }
 
-func (s *TestGetPoolDetailsStore) GetPoolROI12(asset common.Asset) (float64, error) {
+func (s *TestGetPoolDetailsStore) GetPoolROI12(ctx context.Context, asset common.Asset) (float64, error) {
 	return s.poolROI12, s.err
 }
 
-func (s *TestGetPoolDetailsStore) GetPoolVolume(asset common.Asset, from, to time.Time) (int64, error) {
+func (s *TestGetPoolDetailsStore) GetPoolVolume(ctx context.Context, asset common.Asset, from, to time.Time) (int64, error) {
 	return s.poolVolume24hr, s.err
 }
 
-func (s *TestGetPoolDetailsStore) GetStakersCount(asset common.Asset) (uint64, error) {
+func (s *TestGetPoolDetailsStore) GetStakersCount(ctx context.Context, asset common.Asset) (uint64, error) {
 	return s.stakersCount, s.err
 }
 
-func (s *TestGetPoolDetailsStore) GetSwappersCount(asset common.Asset) (uint64, error) {
+func (s *TestGetPoolDetailsStore) GetSwappersCount(ctx context.Context, asset common.Asset) (uint64, error) {
 	return s.swappersCount, s.err
 }
 
@@ -737,7 +738,7 @@ func (s *UsecaseSuite) TestGetPoolDetails(c *C) {
 	c.Assert(err, IsNil)
 
 	asset, _ := common.NewAsset("BNB.TOML-4BC")
-	stats, err := uc.GetPoolDetails(asset)
+	stats, err := uc.GetPoolDetails(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(stats, DeepEquals, &models.PoolDetails{
 		PoolBasics: models.PoolBasics{
@@ -798,7 +799,7 @@ func (s *UsecaseSuite) TestGetPoolDetails(c *C) {
 	})
 
 	client.status = models.Bootstrap
-	stats, err = uc.GetPoolDetails(asset)
+	stats, err = uc.GetPoolDetails(context.Background(), asset)
 	c.Assert(err, IsNil)
 	c.Assert(stats.Status, Equals, models.Bootstrap)
 
@@ -808,7 +809,7 @@ func (s *UsecaseSuite) TestGetPoolDetails(c *C) {
 	uc, err = NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	_, err = uc.GetPoolDetails(asset)
+	_, err = uc.GetPoolDetails(context.Background(), asset)
 	c.Assert(err, NotNil)
 }
 
@@ -818,7 +819,7 @@ type TestGetStakersStore struct {
 	err     error
 }
 
-func (s *TestGetStakersStore) GetStakerAddresses() ([]common.Address, error) {
+func (s *TestGetStakersStore) GetStakerAddresses(ctx context.Context) ([]common.Address, error) {
 	return s.stakers, s.err
 }
 
@@ -833,7 +834,7 @@ func (s *UsecaseSuite) TestGetStakers(c *C) {
 	uc, err := NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	stakers, err := uc.GetStakers()
+	stakers, err := uc.GetStakers(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(stakers, DeepEquals, store.stakers)
 
@@ -843,7 +844,7 @@ func (s *UsecaseSuite) TestGetStakers(c *C) {
 	uc, err = NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	_, err = uc.GetStakers()
+	_, err = uc.GetStakers(context.Background())
 	c.Assert(err, NotNil)
 }
 
@@ -856,7 +857,7 @@ type TestGetStakerDetailsStore struct {
 	err         error
 }
 
-func (s *TestGetStakerDetailsStore) GetStakerAddressDetails(_ common.Address) (models.StakerAddressDetails, error) {
+func (s *TestGetStakerDetailsStore) GetStakerAddressDetails(ctx context.Context, _ common.Address) (models.StakerAddressDetails, error) {
 	details := models.StakerAddressDetails{
 		PoolsDetails: s.pools,
 		TotalEarned:  s.totalEarned,
@@ -888,7 +889,7 @@ func (s *UsecaseSuite) TestGetStakerDetails(c *C) {
 	c.Assert(err, IsNil)
 
 	address, _ := common.NewAddress("bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38")
-	stats, err := uc.GetStakerDetails(address)
+	stats, err := uc.GetStakerDetails(context.Background(), address)
 	c.Assert(err, IsNil)
 	c.Assert(stats, DeepEquals, &models.StakerAddressDetails{
 		PoolsDetails: store.pools,
@@ -903,7 +904,7 @@ func (s *UsecaseSuite) TestGetStakerDetails(c *C) {
 	uc, err = NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	_, err = uc.GetStakerDetails(address)
+	_, err = uc.GetStakerDetails(context.Background(), address)
 	c.Assert(err, NotNil)
 }
 
@@ -919,7 +920,7 @@ type TestGetStakerAssetDetailsStore struct {
 	err             error
 }
 
-func (s *TestGetStakerAssetDetailsStore) GetStakersAddressAndAssetDetails(_ common.Address, _ common.Asset) (models.StakerAddressAndAssetDetails, error) {
+func (s *TestGetStakerAssetDetailsStore) GetStakersAddressAndAssetDetails(ctx context.Context, _ common.Address, _ common.Asset) (models.StakerAddressAndAssetDetails, error) {
 	details := models.StakerAddressAndAssetDetails{
 		Asset:           s.asset,
 		Units:           s.units,
@@ -951,7 +952,7 @@ func (s *UsecaseSuite) TestGetStakerAssetDetails(c *C) {
 
 	asset, _ := common.NewAsset("BNB.TOML-4BC")
 	address, _ := common.NewAddress("bnb1xlvns0n2mxh77mzaspn2hgav4rr4m8eerfju38")
-	stats, err := uc.GetStakerAssetDetails(address, asset)
+	stats, err := uc.GetStakerAssetDetails(context.Background(), address, asset)
 	c.Assert(err, IsNil)
 	c.Assert(stats, DeepEquals, &models.StakerAddressAndAssetDetails{
 		Asset:           store.asset,
@@ -969,7 +970,7 @@ func (s *UsecaseSuite) TestGetStakerAssetDetails(c *C) {
 	uc, err = NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	_, err = uc.GetStakerAssetDetails(address, asset)
+	_, err = uc.GetStakerAssetDetails(context.Background(), address, asset)
 	c.Assert(err, NotNil)
 }
 
@@ -981,15 +982,15 @@ type TestGetNetworkInfoStore struct {
 	poolBasics []models.PoolBasics
 }
 
-func (s *TestGetNetworkInfoStore) GetTotalDepth() (uint64, error) {
+func (s *TestGetNetworkInfoStore) GetTotalDepth(ctx context.Context) (uint64, error) {
 	return s.totalDepth, s.err
 }
 
-func (s *TestGetNetworkInfoStore) GetPools() ([]common.Asset, error) {
+func (s *TestGetNetworkInfoStore) GetPools(ctx context.Context) ([]common.Asset, error) {
 	return s.pools, nil
 }
 
-func (s *TestGetNetworkInfoStore) GetPoolBasics(asset common.Asset) (models.PoolBasics, error) {
+func (s *TestGetNetworkInfoStore) GetPoolBasics(ctx context.Context, asset common.Asset) (models.PoolBasics, error) {
 	for _, poolBasic := range s.poolBasics {
 		if poolBasic.Asset.Equals(asset) {
 			return poolBasic, nil
@@ -1008,15 +1009,15 @@ type TestGetNetworkInfoThorchain struct {
 	err        error
 }
 
-func (t *TestGetNetworkInfoThorchain) GetNodeAccounts() ([]thorchain.NodeAccount, error) {
+func (t *TestGetNetworkInfoThorchain) GetNodeAccounts(ctx context.Context) ([]thorchain.NodeAccount, error) {
 	return t.nodes, t.err
 }
 
-func (t *TestGetNetworkInfoThorchain) GetVaultData() (thorchain.VaultData, error) {
+func (t *TestGetNetworkInfoThorchain) GetVaultData(ctx context.Context) (thorchain.VaultData, error) {
 	return t.vaultData, t.err
 }
 
-func (t *TestGetNetworkInfoThorchain) GetConstants() (thorchain.ConstantValues, error) {
+func (t *TestGetNetworkInfoThorchain) GetConstants(ctx context.Context) (thorchain.ConstantValues, error) {
 	return thorchain.ConstantValues{
 		Int64Values: map[string]int64{
 			"EmissionCurve":        emissionCurve,
@@ -1028,11 +1029,11 @@ func (t *TestGetNetworkInfoThorchain) GetConstants() (thorchain.ConstantValues,
 	}, nil
 }
 
-func (t *TestGetNetworkInfoThorchain) GetAsgardVaults() ([]thorchain.Vault, error) {
+func (t *TestGetNetworkInfoThorchain) GetAsgardVaults(ctx context.Context) ([]thorchain.Vault, error) {
 	return t.vaults, t.err
 }
 
-func (t *TestGetNetworkInfoThorchain) GetLastChainHeight() (thorchain.LastHeights, error) {
+func (t *TestGetNetworkInfoThorchain) GetLastChainHeight(ctx context.Context) (thorchain.LastHeights, error) {
 	return t.lastHeight, t.err
 }
 
@@ -1089,7 +1090,7 @@ func (s *UsecaseSuite) TestZeroStandbyNodes(c *C) {
 	uc, err := NewUsecase(client, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	stats, err := uc.GetNetworkInfo()
+	stats, err := uc.GetNetworkInfo(context.Background())
 	c.Assert(err, IsNil)
 	var poolShareFactor float64 = 2700.0 / 5700.0
 	var blockReward uint64 = 1120 / (emissionCurve * blocksPerYear)
@@ -1198,7 +1199,7 @@ func (s *UsecaseSuite) TestGetNetworkInfo(c *C) {
 	uc, err := NewUsecase(client, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	stats, err := uc.GetNetworkInfo()
+	stats, err := uc.GetNetworkInfo(context.Background())
 	c.Assert(err, IsNil)
 	var poolShareFactor float64 = 2700.0 / 5700.0
 	var blockReward uint64 = 100000000 / (emissionCurve * blocksPerYear)
@@ -1243,17 +1244,17 @@ func (s *UsecaseSuite) TestGetNetworkInfo(c *C) {
 
 	// Store error situation
 	store.err = errors.New("could not fetch requested data")
-	_, err = uc.GetNetworkInfo()
+	_, err = uc.GetNetworkInfo(context.Background())
 	c.Assert(err, NotNil)
 
 	// Thorchain error situation
 	store.err = nil
 	client.err = errors.New("could not fetch requested data")
-	_, err = uc.GetNetworkInfo()
+	_, err = uc.GetNetworkInfo(context.Background())
 	c.Assert(err, NotNil)
 }
 
-func (t *TestGetNetworkInfoThorchain) GetMimir() (map[string]string, error) {
+func (t *TestGetNetworkInfoThorchain) GetMimir(ctx context.Context) (map[string]string, error) {
 	return map[string]string{
 		"mimir//NEWPOOLCYCLE": "50000",
 	}, nil
@@ -1319,7 +1320,7 @@ func (s *UsecaseSuite) TestParallelGetNetworkInfo(c *C) {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			_, err := uc.GetNetworkInfo()
+			_, err := uc.GetNetworkInfo(context.Background())
 			c.Assert(err, IsNil)
 		}()
 	}
@@ -1341,18 +1342,18 @@ func (s *UsecaseSuite) TestComputeNextChurnHight(c *C) {
 	uc, err := NewUsecase(client, s.dummyTendermint, s.dummyTendermint, s.dummyStore, s.config)
 	c.Assert(err, IsNil)
 
-	hight, err := uc.computeNextChurnHight(51836)
+	hight, err := uc.computeNextChurnHight(context.Background(), 51836)
 	c.Assert(err, IsNil)
 	c.Assert(hight, Equals, int64(51844))
 
 	client.lastHeight.Thorchain = 103693
-	hight, err = uc.computeNextChurnHight(103693)
+	hight, err = uc.computeNextChurnHight(context.Background(), 103693)
 	c.Assert(err, IsNil)
 	c.Assert(hight, Equals, int64(103702))
 
 	// Thorchain error situation
 	client.err = errors.New("could not fetch requested data")
-	_, err = uc.GetNetworkInfo()
+	_, err = uc.GetNetworkInfo(context.Background())
 	c.Assert(err, NotNil)
 }
 
@@ -1384,13 +1385,13 @@ func (s *UsecaseSuite) TestComputeLastChurn(c *C) {
 	uc, err := NewUsecase(client, s.dummyTendermint, s.dummyTendermint, s.dummyStore, s.config)
 	c.Assert(err, IsNil)
 
-	last, err := uc.computeLastChurn()
+	last, err := uc.computeLastChurn(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(last, Equals, int64(4))
 
 	// Thorchain error situation
 	client.err = errors.New("could not fetch requested data")
-	_, err = uc.GetNetworkInfo()
+	_, err = uc.GetNetworkInfo(context.Background())
 	c.Assert(err, NotNil)
 }
 
@@ -1404,7 +1405,7 @@ type TestUpdateConstsThorchain struct {
 	err        error
 }
 
-func (t *TestUpdateConstsThorchain) GetConstants() (thorchain.ConstantValues, error) {
+func (t *TestUpdateConstsThorchain) GetConstants(ctx context.Context) (thorchain.ConstantValues, error) {
 	return thorchain.ConstantValues{
 		Int64Values: map[string]int64{
 			"EmissionCurve":        emissionCurve,
@@ -1416,7 +1417,7 @@ func (t *TestUpdateConstsThorchain) GetConstants() (thorchain.ConstantValues, er
 	}, nil
 }
 
-func (t *TestUpdateConstsThorchain) GetMimir() (map[string]string, error) {
+func (t *TestUpdateConstsThorchain) GetMimir(ctx context.Context) (map[string]string, error) {
 	return map[string]string{
 		"mimir//NEWPOOLCYCLE":         "50",
 		"mimir//ROTATEPERBLOCKHEIGHT": "130",
@@ -1438,7 +1439,7 @@ func (s *UsecaseSuite) TestUpdateConstByMimir(c *C) {
 		},
 	})
 
-	err = uc.updateConstantsByMimir()
+	err = uc.updateConstantsByMimir(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(uc.consts, DeepEquals, thorchain.ConstantValues{
 		Int64Values: map[string]int64{
@@ -1465,7 +1466,7 @@ type TestGetTotalVolChangesStore struct {
 	err     error
 }
 
-func (s *TestGetTotalVolChangesStore) GetTotalVolChanges(_ models.Interval, _, _ time.Time) ([]models.TotalVolChanges, error) {
+func (s *TestGetTotalVolChangesStore) GetTotalVolChanges(ctx context.Context, _ models.Interval, _, _ time.Time) ([]models.TotalVolChanges, error) {
 	return s.changes, s.err
 }
 
@@ -1490,11 +1491,11 @@ func (s *UsecaseSuite) TestGetTotalVolChanges(c *C) {
 	uc, err := NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	changes, err := uc.GetTotalVolChanges(models.DailyInterval, now, now)
+	changes, err := uc.GetTotalVolChanges(context.Background(), models.DailyInterval, now, now)
 	c.Assert(err, IsNil)
 	c.Assert(changes, DeepEquals, store.changes)
 
-	_, err = uc.GetTotalVolChanges(-1, now, now)
+	_, err = uc.GetTotalVolChanges(context.Background(), -1, now, now)
 	c.Assert(err, NotNil)
 
 	store = &TestGetTotalVolChangesStore{
@@ -1503,7 +1504,7 @@ func (s *UsecaseSuite) TestGetTotalVolChanges(c *C) {
 	uc, err = NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	_, err = uc.GetTotalVolChanges(models.DailyInterval, now, now)
+	_, err = uc.GetTotalVolChanges(context.Background(), models.DailyInterval, now, now)
 	c.Assert(err, NotNil)
 }
 
@@ -1513,7 +1514,7 @@ type TestGetPoolAggChangesStore struct {
 	err     error
 }
 
-func (s *TestGetPoolAggChangesStore) GetPoolAggChanges(_ common.Asset, _ models.Interval, _, _ time.Time) ([]models.PoolAggChanges, error) {
+func (s *TestGetPoolAggChangesStore) GetPoolAggChanges(ctx context.Context, _ common.Asset, _ models.Interval, _, _ time.Time) ([]models.PoolAggChanges, error) {
 	return s.changes, s.err
 }
 
@@ -1574,7 +1575,7 @@ func (s *UsecaseSuite) TestGetPoolAggChanges(c *C) {
 	uc, err := NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	changes, err := uc.GetPoolAggChanges(common.BNBAsset, models.DailyInterval, now, now)
+	changes, err := uc.GetPoolAggChanges(context.Background(), common.BNBAsset, models.DailyInterval, now, now)
 	c.Assert(err, IsNil)
 	c.Assert(changes, DeepEquals, []models.PoolAggChanges{
 		{
@@ -1627,7 +1628,7 @@ func (s *UsecaseSuite) TestGetPoolAggChanges(c *C) {
 		},
 	})
 
-	_, err = uc.GetPoolAggChanges(common.BNBAsset, -1, now, now)
+	_, err = uc.GetPoolAggChanges(context.Background(), common.BNBAsset, -1, now, now)
 	c.Assert(err, NotNil)
 
 	store = &TestGetPoolAggChangesStore{
@@ -1636,7 +1637,186 @@ func (s *UsecaseSuite) TestGetPoolAggChanges(c *C) {
 	uc, err = NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	_, err = uc.GetPoolAggChanges(common.BNBAsset, models.DailyInterval, now, now)
+	_, err = uc.GetPoolAggChanges(context.Background(), common.BNBAsset, models.DailyInterval, now, now)
+	c.Assert(err, NotNil)
+}
+
+type TestGetPoolYieldStore struct {
+	StoreDummy
+	changes []models.PoolYieldChanges
+	err     error
+}
+
+func (s *TestGetPoolYieldStore) GetPoolYieldChanges(ctx context.Context, _ common.Asset, _ models.Interval, _, _ time.Time) ([]models.PoolYieldChanges, error) {
+	changes := make([]models.PoolYieldChanges, len(s.changes))
+	copy(changes, s.changes)
+	return changes, s.err
+}
+
+func (s *UsecaseSuite) TestGetPoolYield(c *C) {
+	now := time.Now()
+	store := &TestGetPoolYieldStore{
+		changes: []models.PoolYieldChanges{
+			{
+				Time:           now.Add(-day * 10),
+				AssetDepth:     100,
+				RuneDepth:      400,
+				BuyFees:        4,
+				SellFees:       10,
+				Reward:         20,
+				GasUsed:        2,
+				GasReplenished: 8,
+			},
+			{
+				Time:           now.Add(-day),
+				AssetDepth:     200,
+				RuneDepth:      400,
+				BuyFees:        10,
+				SellFees:       6,
+				Reward:         30,
+				GasUsed:        5,
+				GasReplenished: 10,
+			},
+		},
+	}
+	uc, err := NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
+	c.Assert(err, IsNil)
+
+	yield, err := uc.GetPoolYield(context.Background(), common.BNBAsset, models.DailyInterval, now.Add(-day*30), now)
+	c.Assert(err, IsNil)
+	c.Assert(yield, DeepEquals, &models.PoolYield{
+		Intervals: []models.PoolYieldChanges{
+			{
+				Time:           now.Add(-day * 10),
+				AssetDepth:     100,
+				RuneDepth:      400,
+				BuyFees:        4,
+				SellFees:       10,
+				Reward:         20,
+				GasUsed:        2,
+				GasReplenished: 8,
+				Price:          4,
+				FeesEarned:     26,
+				PoolEarned:     62,
+				PoolAPY:        calculateAPY(62.0/800, 360),
+			},
+			{
+				Time:           now.Add(-day),
+				AssetDepth:     200,
+				RuneDepth:      400,
+				BuyFees:        10,
+				SellFees:       6,
+				Reward:         30,
+				GasUsed:        5,
+				GasReplenished: 10,
+				Price:          2,
+				FeesEarned:     26,
+				PoolEarned:     76,
+				PoolAPY:        calculateAPY(76.0/800, 360),
+			},
+		},
+		APY7d:  calculateAPY(76.0/800, 360.0/7),
+		APY30d: calculateAPY(138.0/800, 12),
+		APY90d: calculateAPY(138.0/800, 4),
+	})
+
+	_, err = uc.GetPoolYield(context.Background(), common.BNBAsset, models.Interval(-1), now, now)
+	c.Assert(err, NotNil)
+
+	store = &TestGetPoolYieldStore{
+		err: errors.New("could not fetch requested data"),
+	}
+	uc, err = NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
+	c.Assert(err, IsNil)
+
+	_, err = uc.GetPoolYield(context.Background(), common.BNBAsset, models.DailyInterval, now, now)
+	c.Assert(err, NotNil)
+}
+
+type TestGetPoolDepthCurveStore struct {
+	StoreDummy
+	basics  models.PoolBasics
+	changes []models.PoolAggChanges
+	err     error
+}
+
+func (s *TestGetPoolDepthCurveStore) GetPoolBasics(ctx context.Context, _ common.Asset) (models.PoolBasics, error) {
+	return s.basics, nil
+}
+
+func (s *TestGetPoolDepthCurveStore) GetPoolAggChanges(ctx context.Context, _ common.Asset, _ models.Interval, _, _ time.Time) ([]models.PoolAggChanges, error) {
+	return s.changes, s.err
+}
+
+func (s *UsecaseSuite) TestGetPoolDepthCurve(c *C) {
+	now := time.Now()
+	store := &TestGetPoolDepthCurveStore{
+		basics: models.PoolBasics{
+			Asset:      common.BNBAsset,
+			AssetDepth: 1000,
+			RuneDepth:  4000,
+			Status:     models.Enabled,
+		},
+		changes: []models.PoolAggChanges{
+			{
+				Time:       now.Add(-day),
+				AssetDepth: 990,
+				RuneDepth:  9900,
+			},
+			{
+				Time:       now,
+				AssetDepth: 1000,
+				RuneDepth:  4000,
+			},
+		},
+	}
+	uc, err := NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
+	c.Assert(err, IsNil)
+
+	curve, err := uc.GetPoolDepthCurve(context.Background(), common.BNBAsset, []int64{1000, 4000}, now.Add(-day), now)
+	c.Assert(err, IsNil)
+	c.Assert(curve, DeepEquals, &models.DepthCurve{
+		AssetDepth: 1000,
+		RuneDepth:  4000,
+		Price:      4,
+		Points: []models.DepthCurvePoint{
+			{
+				TradeSize: 1000,
+				Slip:      0.2,
+				Fee:       160,
+				Output:    640,
+			},
+			{
+				TradeSize: 4000,
+				Slip:      0.5,
+				Fee:       1000,
+				Output:    1000,
+			},
+		},
+		History: []models.SlipDepth{
+			{
+				Time:       now.Add(-day),
+				AssetDepth: 990,
+				RuneDepth:  9900,
+				AssetSize:  10,
+				RuneSize:   100,
+			},
+			{
+				Time:       now,
+				AssetDepth: 1000,
+				RuneDepth:  4000,
+				AssetSize:  10,
+				RuneSize:   40,
+			},
+		},
+	})
+
+	curve, err = uc.GetPoolDepthCurve(context.Background(), common.BNBAsset, nil, now.Add(-day), now)
+	c.Assert(err, IsNil)
+	c.Assert(curve.Points, HasLen, len(defaultTradeSizes))
+
+	store.err = errors.New("could not fetch requested data")
+	_, err = uc.GetPoolDepthCurve(context.Background(), common.BNBAsset, nil, now.Add(-day), now)
 	c.Assert(err, NotNil)
 }
 
@@ -1657,7 +1837,7 @@ type TestFetchPoolStatusThorchain struct {
 	Status models.PoolStatus
 }
 
-func (s *TestFetchPoolStatusThorchain) GetPoolStatus(_ common.Asset) (models.PoolStatus, error) {
+func (s *TestFetchPoolStatusThorchain) GetPoolStatus(ctx context.Context, _ common.Asset) (models.PoolStatus, error) {
 	return s.Status, nil
 }
 
@@ -1702,7 +1882,7 @@ func (s *UsecaseSuite) TestFetchPoolStatus(c *C) {
 	c.Assert(err, IsNil)
 	uc.scanner = thorchain.NewBlockScanner(uc.tendermint, uc.tendermintBatch, &TestCallback{}, uc.conf.ScanInterval)
 	client.Status = models.Bootstrap
-	status, err := uc.fetchPoolStatus(common.BNBAsset)
+	status, err := uc.fetchPoolStatus(context.Background(), common.BNBAsset)
 	c.Assert(err, IsNil)
 	c.Assert(status, Equals, models.Bootstrap)
 	c.Assert(store.event, IsNil)
@@ -1712,7 +1892,7 @@ func (s *UsecaseSuite) TestFetchPoolStatus(c *C) {
 	uc.scanner.Stop()
 
 	client.Status = models.Enabled
-	status, err = uc.fetchPoolStatus(common.BNBAsset)
+	status, err = uc.fetchPoolStatus(context.Background(), common.BNBAsset)
 	c.Assert(err, IsNil)
 	c.Assert(status, Equals, models.Enabled)
 	c.Assert(store.event.Status, DeepEquals, models.Enabled)
@@ -1726,19 +1906,19 @@ type TestGetPoolAPYStore struct {
 	depth       int64
 }
 
-func (s *TestGetPoolAPYStore) GetPoolLastEnabledDate(_ common.Asset) (time.Time, error) {
+func (s *TestGetPoolAPYStore) GetPoolLastEnabledDate(ctx context.Context, _ common.Asset) (time.Time, error) {
 	return s.enabledDate, nil
 }
 
-func (s *TestGetPoolAPYStore) GetPoolEarned(_ common.Asset, _ time.Time) (int64, error) {
+func (s *TestGetPoolAPYStore) GetPoolEarned(ctx context.Context, _ common.Asset, _ time.Time) (int64, error) {
 	return s.earned, nil
 }
 
-func (s *TestGetPoolAPYStore) GetPoolStatus(_ common.Asset) (models.PoolStatus, error) {
+func (s *TestGetPoolAPYStore) GetPoolStatus(ctx context.Context, _ common.Asset) (models.PoolStatus, error) {
 	return s.status, nil
 }
 
-func (s *TestGetPoolAPYStore) GetPoolBasics(_ common.Asset) (models.PoolBasics, error) {
+func (s *TestGetPoolAPYStore) GetPoolBasics(ctx context.Context, _ common.Asset) (models.PoolBasics, error) {
 	return models.PoolBasics{
 		Status:    s.status,
 		RuneDepth: s.depth,
@@ -1752,7 +1932,7 @@ func (s *UsecaseSuite) TestGetPoolAPY(c *C) {
 	uc, err := NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	poolAPY, err := uc.getPoolAPY(common.BNBAsset)
+	poolAPY, err := uc.getPoolAPY(context.Background(), common.BNBAsset)
 	c.Assert(err, IsNil)
 	c.Assert(poolAPY, Equals, float64(0))
 
@@ -1760,7 +1940,7 @@ func (s *UsecaseSuite) TestGetPoolAPY(c *C) {
 	store.depth = 100
 	store.earned = 40
 	store.enabledDate = time.Now().Add(-40 * 24 * time.Hour)
-	poolAPY, err = uc.getPoolAPY(common.BNBAsset)
+	poolAPY, err = uc.getPoolAPY(context.Background(), common.BNBAsset)
 	c.Assert(err, IsNil)
 	c.Assert(poolAPY, Equals, math.Pow(1+float64(40.0/200.0), 12)-1)
 }
@@ -1784,11 +1964,11 @@ type TestTotalEnabledRuneDepthStore struct {
 	poolBasics []models.PoolBasics
 }
 
-func (s *TestTotalEnabledRuneDepthStore) GetPools() ([]common.Asset, error) {
+func (s *TestTotalEnabledRuneDepthStore) GetPools(ctx context.Context) ([]common.Asset, error) {
 	return s.pools, nil
 }
 
-func (s *TestTotalEnabledRuneDepthStore) GetPoolBasics(asset common.Asset) (models.PoolBasics, error) {
+func (s *TestTotalEnabledRuneDepthStore) GetPoolBasics(ctx context.Context, asset common.Asset) (models.PoolBasics, error) {
 	for _, poolBasic := range s.poolBasics {
 		if poolBasic.Asset.Equals(asset) {
 			return poolBasic, nil
@@ -1819,22 +1999,22 @@ func (s *UsecaseSuite) TestTotalEnabledRuneDepth(c *C) {
 	uc, err := NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
 	c.Assert(err, IsNil)
 
-	runeDepth, err := uc.totalEnabledRuneDepth()
+	runeDepth, err := uc.totalEnabledRuneDepth(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(runeDepth, Equals, int64(1400))
 
 	store.poolBasics[1].Status = models.Bootstrap
-	runeDepth, err = uc.totalEnabledRuneDepth()
+	runeDepth, err = uc.totalEnabledRuneDepth(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(runeDepth, Equals, int64(1000))
 
 	store.poolBasics[1].Status = models.Suspended
-	runeDepth, err = uc.totalEnabledRuneDepth()
+	runeDepth, err = uc.totalEnabledRuneDepth(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(runeDepth, Equals, int64(1000))
 
 	store.poolBasics[1].Status = models.Suspended
-	runeDepth, err = uc.totalEnabledRuneDepth()
+	runeDepth, err = uc.totalEnabledRuneDepth(context.Background())
 	c.Assert(err, IsNil)
 	c.Assert(runeDepth, Equals, int64(1000))
 }
@@ -1845,7 +2025,7 @@ type TestThorchainBalance struct {
 	runeDepth  int64
 }
 
-func (t *TestThorchainBalance) GetPools() ([]thorchain.Pool, error) {
+func (t *TestThorchainBalance) GetPools(ctx context.Context) ([]thorchain.Pool, error) {
 	return []thorchain.Pool{
 		{
 			Asset:        common.BNBAsset.String(),
@@ -1855,7 +2035,7 @@ func (t *TestThorchainBalance) GetPools() ([]thorchain.Pool, error) {
 	}, nil
 }
 
-func (t *TestThorchainBalance) GetPoolStatus(pool common.Asset) (models.PoolStatus, error) {
+func (t *TestThorchainBalance) GetPoolStatus(ctx context.Context, pool common.Asset) (models.PoolStatus, error) {
 	return models.Enabled, nil
 }
 
@@ -1880,7 +2060,7 @@ func (s *UsecaseSuite) TestGetThorchainBalances(c *C) {
 	c.Assert(err, IsNil)
 
 	time.Sleep(time.Second)
-	basic, err := uc.GetPoolBasics(common.BNBAsset)
+	basic, err := uc.GetPoolBasics(context.Background(), common.BNBAsset)
 	c.Assert(err, IsNil)
 	c.Assert(basic, DeepEquals, models.PoolBasics{
 		AssetDepth: 200,
@@ -1892,7 +2072,7 @@ func (s *UsecaseSuite) TestGetThorchainBalances(c *C) {
 	thorchain.assetDepth = 400
 	thorchain.runeDepth = 500
 	time.Sleep(2 * time.Second)
-	basic, err = uc.GetPoolBasics(common.BNBAsset)
+	basic, err = uc.GetPoolBasics(context.Background(), common.BNBAsset)
 	c.Assert(err, IsNil)
 	c.Assert(basic, DeepEquals, models.PoolBasics{
 		AssetDepth: 400,
@@ -1901,3 +2081,55 @@ func (s *UsecaseSuite) TestGetThorchainBalances(c *C) {
 		Status:     models.Bootstrap,
 	})
 }
+
+type TestGetGasHistoryStore struct {
+	StoreDummy
+	changes []models.GasChanges
+}
+
+func (s *TestGetGasHistoryStore) GetGasChanges(ctx context.Context, _ common.Chain, _ models.Interval, _, _ time.Time) ([]models.GasChanges, error) {
+	return s.changes, nil
+}
+
+func (s *UsecaseSuite) TestGetGasHistory(c *C) {
+	now := time.Now()
+	store := &TestGetGasHistoryStore{
+		changes: []models.GasChanges{
+			{
+				Time:           now,
+				Chain:          common.BNBChain,
+				GasUsed:        30,
+				GasUsedInRune:  60,
+				GasReplenished: 45,
+				OutboundCount:  4,
+			},
+			{
+				Time:    now,
+				Chain:   common.BTCChain,
+				GasUsed: 5,
+			},
+		},
+	}
+	uc, err := NewUsecase(s.dummyThorchain, s.dummyTendermint, s.dummyTendermint, store, s.config)
+	c.Assert(err, IsNil)
+
+	changes, err := uc.GetGasHistory(context.Background(), "", models.DailyInterval, now, now)
+	c.Assert(err, IsNil)
+	c.Assert(changes, DeepEquals, []models.GasChanges{
+		{
+			Time:           now,
+			Chain:          common.BNBChain,
+			GasUsed:        30,
+			GasUsedInRune:  60,
+			GasReplenished: 45,
+			OutboundCount:  4,
+			AverageGas:     7.5,
+			Coverage:       0.75,
+		},
+		{
+			Time:    now,
+			Chain:   common.BTCChain,
+			GasUsed: 5,
+		},
+	})
+}
@@ -0,0 +1,87 @@
+package pricefeed
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gitlab.com/thorchain/midgard/internal/config"
+)
+
+// RuneKey is the key of RUNE/USD price in the reference prices.
+const RuneKey = "RUNE"
+
+// Source represents api that any reference price source should provide.
+type Source interface {
+	// GetPrices returns USD prices of assets keyed by their full name (CHAIN.SYMBOL)
+	// alongside the RUNE/USD price keyed by RuneKey.
+	GetPrices() (map[string]float64, error)
+}
+
+// JSONSource implements Source and reads the prices from a JSON object which is
+// served over http(s) or stored in a local file.
+type JSONSource struct {
+	source     string
+	httpClient *http.Client
+	logger     zerolog.Logger
+}
+
+// NewJSONSource create a new instance of JSONSource.
+func NewJSONSource(cfg config.PriceFeedConfiguration) (*JSONSource, error) {
+	if cfg.Source == "" {
+		return nil, errors.New("price feed source is empty")
+	}
+
+	s := &JSONSource{
+		source: cfg.Source,
+		httpClient: &http.Client{
+			Timeout: cfg.ReadTimeout,
+		},
+		logger: log.With().Str("module", "price_feed").Logger(),
+	}
+	return s, nil
+}
+
+// GetPrices implements Source.GetPrices
+func (s *JSONSource) GetPrices() (map[string]float64, error) {
+	data, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	var prices map[string]float64
+	if err := json.Unmarshal(data, &prices); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal reference prices")
+	}
+	return prices, nil
+}
+
+func (s *JSONSource) read() ([]byte, error) {
+	if !strings.HasPrefix(s.source, "http://") && !strings.HasPrefix(s.source, "https://") {
+		s.logger.Debug().Str("file", s.source).Msg("reading reference prices")
+		data, err := ioutil.ReadFile(strings.TrimPrefix(s.source, "file://"))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read the price feed file")
+		}
+		return data, nil
+	}
+
+	s.logger.Debug().Str("url", s.source).Msg("reading reference prices")
+	resp, err := s.httpClient.Get(s.source)
+	if err != nil {
+		return nil, errors.Wrap(err, "http request failed")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("price feed responded with status %d", resp.StatusCode)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read response body")
+	}
+	return data, nil
+}
@@ -0,0 +1,102 @@
+package pricefeed
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gitlab.com/thorchain/midgard/internal/config"
+	. "gopkg.in/check.v1"
+)
+
+const pricesJSON = `{"RUNE": 0.5, "BNB.BNB": 17.25}`
+
+var _ = Suite(&PriceFeedSuite{})
+
+type PriceFeedSuite struct {
+	server *httptest.Server
+	dir    string
+}
+
+func Test(t *testing.T) {
+	TestingT(t)
+}
+
+func (s *PriceFeedSuite) SetUpSuite(c *C) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/prices", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(pricesJSON))
+	})
+	s.server = httptest.NewServer(mux)
+
+	var err error
+	s.dir, err = ioutil.TempDir("", "pricefeed")
+	c.Assert(err, IsNil)
+}
+
+func (s *PriceFeedSuite) TearDownSuite(c *C) {
+	s.server.Close()
+	os.RemoveAll(s.dir)
+}
+
+func (s *PriceFeedSuite) TestNewJSONSource(c *C) {
+	_, err := NewJSONSource(config.PriceFeedConfiguration{})
+	c.Assert(err, NotNil)
+}
+
+func (s *PriceFeedSuite) TestHTTPSource(c *C) {
+	source, err := NewJSONSource(config.PriceFeedConfiguration{
+		Source:      s.server.URL + "/prices",
+		ReadTimeout: time.Second,
+	})
+	c.Assert(err, IsNil)
+
+	prices, err := source.GetPrices()
+	c.Assert(err, IsNil)
+	c.Assert(prices, DeepEquals, map[string]float64{
+		RuneKey:   0.5,
+		"BNB.BNB": 17.25,
+	})
+
+	source, err = NewJSONSource(config.PriceFeedConfiguration{
+		Source:      s.server.URL + "/missing",
+		ReadTimeout: time.Second,
+	})
+	c.Assert(err, IsNil)
+	_, err = source.GetPrices()
+	c.Assert(err, NotNil)
+}
+
+func (s *PriceFeedSuite) TestFileSource(c *C) {
+	path := filepath.Join(s.dir, "prices.json")
+	err := ioutil.WriteFile(path, []byte(pricesJSON), 0644)
+	c.Assert(err, IsNil)
+
+	for _, src := range []string{path, "file://" + path} {
+		source, err := NewJSONSource(config.PriceFeedConfiguration{
+			Source: src,
+		})
+		c.Assert(err, IsNil)
+
+		prices, err := source.GetPrices()
+		c.Assert(err, IsNil)
+		c.Assert(prices, DeepEquals, map[string]float64{
+			RuneKey:   0.5,
+			"BNB.BNB": 17.25,
+		})
+	}
+
+	err = ioutil.WriteFile(path, []byte("not a json"), 0644)
+	c.Assert(err, IsNil)
+	source, err := NewJSONSource(config.PriceFeedConfiguration{
+		Source: path,
+	})
+	c.Assert(err, IsNil)
+	_, err = source.GetPrices()
+	c.Assert(err, NotNil)
+}
@@ -0,0 +1,284 @@
+// Package redis implements a minimal client of the redis protocol (RESP)
+// supporting pipelined commands over a pool of connections.
+package redis
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"gitlab.com/thorchain/midgard/internal/config"
+)
+
+// Error is an error reply of the server.
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+// Client sends commands to a redis compatible server. At most PoolSize
+// connections are open at once.
+type Client struct {
+	addr     string
+	password string
+	db       int
+	timeout  time.Duration
+	pool     chan *conn
+	// slots holds a token per open connection.
+	slots chan struct{}
+}
+
+// conn is a connection to the server along with its buffers.
+type conn struct {
+	net.Conn
+	r *bufio.Reader
+	w *bufio.Writer
+}
+
+// NewClient creates a new instance of Client. Connections are opened lazily.
+func NewClient(cfg config.RedisConfiguration) (*Client, error) {
+	if cfg.Addr == "" {
+		return nil, errors.New("redis address is empty")
+	}
+	size := cfg.PoolSize
+	if size < 1 {
+		size = 1
+	}
+	return &Client{
+		addr:     cfg.Addr,
+		password: cfg.Password,
+		db:       cfg.DB,
+		timeout:  cfg.Timeout,
+		pool:     make(chan *conn, size),
+		slots:    make(chan struct{}, size),
+	}, nil
+}
+
+// Do sends a command and returns its reply. Error replies are returned as
+// errors.
+func (c *Client) Do(ctx context.Context, args ...string) (interface{}, error) {
+	replies, err := c.Pipeline(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	if err, ok := replies[0].(Error); ok {
+		return nil, err
+	}
+	return replies[0], nil
+}
+
+// Pipeline sends the commands at once and returns their replies in order. The
+// replies are either nil, int64, string, []interface{} or Error.
+func (c *Client) Pipeline(ctx context.Context, cmds ...[]string) ([]interface{}, error) {
+	cn, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	replies, err := c.roundTrip(ctx, cn, cmds)
+	if err != nil {
+		c.discard(cn)
+		return nil, err
+	}
+	c.put(cn)
+	return replies, nil
+}
+
+// Close closes the idle connections.
+func (c *Client) Close() error {
+	for {
+		select {
+		case cn := <-c.pool:
+			c.discard(cn)
+		default:
+			return nil
+		}
+	}
+}
+
+func (c *Client) roundTrip(ctx context.Context, cn *conn, cmds [][]string) ([]interface{}, error) {
+	var deadline time.Time
+	if c.timeout > 0 {
+		deadline = time.Now().Add(c.timeout)
+	}
+	if d, ok := ctx.Deadline(); ok && (deadline.IsZero() || d.Before(deadline)) {
+		deadline = d
+	}
+	err := cn.SetDeadline(deadline)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cmd := range cmds {
+		writeCommand(cn.w, cmd)
+	}
+	err = cn.w.Flush()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not send commands")
+	}
+	replies := make([]interface{}, len(cmds))
+	for i := range cmds {
+		replies[i], err = readReply(cn.r)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read reply")
+		}
+	}
+	return replies, nil
+}
+
+// get returns an idle connection or opens a new one. If all the connections are
+// in use, it waits for one to be given back for up to the timeout.
+func (c *Client) get(ctx context.Context) (*conn, error) {
+	select {
+	case cn := <-c.pool:
+		return cn, nil
+	default:
+	}
+
+	var timeout <-chan time.Time
+	if c.timeout > 0 {
+		timer := time.NewTimer(c.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case cn := <-c.pool:
+		return cn, nil
+	case c.slots <- struct{}{}:
+	case <-timeout:
+		return nil, errors.New("timed out waiting for a connection")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	cn, err := c.dial(ctx)
+	if err != nil {
+		<-c.slots
+		return nil, err
+	}
+	return cn, nil
+}
+
+// dial opens a new connection and sets it up.
+func (c *Client) dial(ctx context.Context) (*conn, error) {
+	dialer := net.Dialer{Timeout: c.timeout}
+	nc, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not connect to %s", c.addr)
+	}
+	cn := &conn{
+		Conn: nc,
+		r:    bufio.NewReader(nc),
+		w:    bufio.NewWriter(nc),
+	}
+	var setup [][]string
+	if c.password != "" {
+		setup = append(setup, []string{"AUTH", c.password})
+	}
+	if c.db != 0 {
+		setup = append(setup, []string{"SELECT", strconv.Itoa(c.db)})
+	}
+	if len(setup) > 0 {
+		replies, err := c.roundTrip(ctx, cn, setup)
+		if err == nil {
+			for _, reply := range replies {
+				if e, ok := reply.(Error); ok {
+					err = e
+					break
+				}
+			}
+		}
+		if err != nil {
+			cn.Close()
+			return nil, errors.Wrap(err, "could not set up connection")
+		}
+	}
+	return cn, nil
+}
+
+// put gives back a connection to the pool, closing it if the pool is full.
+func (c *Client) put(cn *conn) {
+	select {
+	case c.pool <- cn:
+	default:
+		c.discard(cn)
+	}
+}
+
+// discard closes a connection and frees its slot.
+func (c *Client) discard(cn *conn) {
+	cn.Close()
+	<-c.slots
+}
+
+func writeCommand(w *bufio.Writer, args []string) {
+	fmt.Fprintf(w, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(w, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return "", errors.Errorf("malformed line %q", line)
+	}
+	return line[:len(line)-2], nil
+}
+
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if line == "" {
+		return nil, errors.New("empty reply")
+	}
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return Error(line[1:]), nil
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, n+2)
+		_, err = io.ReadFull(r, buf)
+		if err != nil {
+			return nil, err
+		}
+		return string(buf[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, n)
+		for i := range items {
+			items[i], err = readReply(r)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	}
+	return nil, errors.Errorf("unknown reply type %q", line[0])
+}
@@ -0,0 +1,139 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gitlab.com/thorchain/midgard/internal/config"
+	"gitlab.com/thorchain/midgard/pkg/clients/redis/redistest"
+	. "gopkg.in/check.v1"
+)
+
+var _ = Suite(&RedisSuite{})
+
+type RedisSuite struct {
+	server *redistest.Server
+}
+
+func Test(t *testing.T) {
+	TestingT(t)
+}
+
+func (s *RedisSuite) SetUpTest(c *C) {
+	var err error
+	s.server, err = redistest.NewServer("secret")
+	c.Assert(err, IsNil)
+}
+
+func (s *RedisSuite) TearDownTest(c *C) {
+	s.server.Close()
+}
+
+func (s *RedisSuite) newClient(c *C, password string) *Client {
+	client, err := NewClient(config.RedisConfiguration{
+		Addr:     s.server.Addr(),
+		Password: password,
+		DB:       1,
+		Timeout:  time.Second,
+		PoolSize: 2,
+	})
+	c.Assert(err, IsNil)
+	return client
+}
+
+func (s *RedisSuite) TestDo(c *C) {
+	client := s.newClient(c, "secret")
+	defer client.Close()
+	ctx := context.Background()
+
+	reply, err := client.Do(ctx, "GET", "key")
+	c.Assert(err, IsNil)
+	c.Assert(reply, IsNil)
+	reply, err = client.Do(ctx, "SET", "key", "value with spaces")
+	c.Assert(err, IsNil)
+	c.Assert(reply, Equals, "OK")
+	reply, err = client.Do(ctx, "GET", "key")
+	c.Assert(err, IsNil)
+	c.Assert(reply, Equals, "value with spaces")
+
+	_, err = client.Do(ctx, "INCR", "key")
+	c.Assert(err, FitsTypeOf, Error(""))
+	_, err = client.Do(ctx, "UNKNOWN")
+	c.Assert(err, ErrorMatches, "ERR unknown command 'UNKNOWN'")
+}
+
+func (s *RedisSuite) TestPipeline(c *C) {
+	client := s.newClient(c, "secret")
+	defer client.Close()
+
+	replies, err := client.Pipeline(context.Background(),
+		[]string{"INCR", "counter"},
+		[]string{"INCRBY", "counter", "2"},
+		[]string{"PEXPIRE", "counter", "60000"},
+		[]string{"GET", "counter"},
+		[]string{"DECR", "unknown", "extra"},
+	)
+	c.Assert(err, IsNil)
+	c.Assert(replies[:4], DeepEquals, []interface{}{int64(1), int64(3), int64(1), "3"})
+	c.Assert(replies[4], FitsTypeOf, Error(""))
+	c.Assert(s.server.TTL("counter") > 59*time.Second, Equals, true)
+
+	// The connection is reused.
+	commands := s.server.Commands()
+	_, err = client.Do(context.Background(), "PING")
+	c.Assert(err, IsNil)
+	c.Assert(s.server.Commands(), Equals, commands+1)
+}
+
+func (s *RedisSuite) TestAuth(c *C) {
+	client := s.newClient(c, "wrong")
+	defer client.Close()
+	_, err := client.Do(context.Background(), "PING")
+	c.Assert(err, ErrorMatches, "could not set up connection: ERR invalid password")
+}
+
+func (s *RedisSuite) TestServerDown(c *C) {
+	client := s.newClient(c, "secret")
+	defer client.Close()
+	_, err := client.Do(context.Background(), "PING")
+	c.Assert(err, IsNil)
+
+	s.server.Close()
+	_, err = client.Do(context.Background(), "PING")
+	c.Assert(err, NotNil)
+	_, err = client.Do(context.Background(), "PING")
+	c.Assert(err, NotNil)
+}
+
+func (s *RedisSuite) TestPoolSize(c *C) {
+	client := s.newClient(c, "secret")
+	defer client.Close()
+	ctx := context.Background()
+
+	// All the connections are in use, so no other is opened.
+	var conns []*conn
+	for i := 0; i < 2; i++ {
+		cn, err := client.get(ctx)
+		c.Assert(err, IsNil)
+		conns = append(conns, cn)
+	}
+	_, err := client.Do(ctx, "PING")
+	c.Assert(err, ErrorMatches, "timed out waiting for a connection")
+
+	// A connection given back is reused.
+	client.put(conns[0])
+	reply, err := client.Do(ctx, "PING")
+	c.Assert(err, IsNil)
+	c.Assert(reply, Equals, "PONG")
+
+	// A connection closed frees a slot for a new one.
+	client.discard(conns[1])
+	for i := 0; i < 2; i++ {
+		conns[i], err = client.get(ctx)
+		c.Assert(err, IsNil)
+	}
+	for _, cn := range conns {
+		client.put(cn)
+	}
+}
@@ -0,0 +1,270 @@
+// Package redistest provides an in-process redis server for tests. It supports
+// the string and counter commands used by Midgard.
+package redistest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server is a redis server listening on a local port.
+type Server struct {
+	mu       sync.Mutex
+	listener net.Listener
+	values   map[string]string
+	expiry   map[string]time.Time
+	commands int
+	password string
+	conns    map[net.Conn]bool
+}
+
+// NewServer starts a new Server. Clients must authenticate if password isn't
+// empty.
+func NewServer(password string) (*Server, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{
+		listener: l,
+		values:   map[string]string{},
+		expiry:   map[string]time.Time{},
+		password: password,
+		conns:    map[net.Conn]bool{},
+	}
+	go s.serve()
+	return s, nil
+}
+
+// Addr returns the address the server listens on.
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Close stops the server and closes the connections of the clients.
+func (s *Server) Close() {
+	s.listener.Close()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.conns {
+		c.Close()
+	}
+}
+
+// Commands returns the number of commands received.
+func (s *Server) Commands() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.commands
+}
+
+// Get returns the value of key.
+func (s *Server) Get(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.get(key)
+}
+
+// TTL returns the time to live of key, zero if it has no expiry.
+func (s *Server) TTL(key string) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t, ok := s.expiry[key]; ok {
+		return time.Until(t)
+	}
+	return 0
+}
+
+func (s *Server) serve() {
+	for {
+		c, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns[c] = true
+		s.mu.Unlock()
+		go s.handle(c)
+	}
+}
+
+func (s *Server) handle(c net.Conn) {
+	defer func() {
+		c.Close()
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.mu.Unlock()
+	}()
+	r := bufio.NewReader(c)
+	w := bufio.NewWriter(c)
+	authenticated := s.password == ""
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		if strings.ToUpper(args[0]) == "AUTH" {
+			if len(args) == 2 && args[1] == s.password {
+				authenticated = true
+				w.WriteString("+OK\r\n")
+			} else {
+				w.WriteString("-ERR invalid password\r\n")
+			}
+		} else if !authenticated {
+			w.WriteString("-NOAUTH Authentication required.\r\n")
+		} else {
+			w.WriteString(s.exec(args))
+		}
+		// Flush once the pipelined commands are all handled.
+		if r.Buffered() == 0 {
+			err = w.Flush()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// exec executes a command and returns its encoded reply.
+func (s *Server) exec(args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.commands++
+
+	cmd := strings.ToUpper(args[0])
+	switch {
+	case cmd == "PING":
+		return "+PONG\r\n"
+	case cmd == "SELECT" && len(args) == 2:
+		return "+OK\r\n"
+	case cmd == "GET" && len(args) == 2:
+		v, ok := s.get(args[1])
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(v), v)
+	case cmd == "SET" && len(args) == 3:
+		s.values[args[1]] = args[2]
+		delete(s.expiry, args[1])
+		return "+OK\r\n"
+	case cmd == "SET" && len(args) == 4 && strings.ToUpper(args[3]) == "NX":
+		if _, ok := s.get(args[1]); ok {
+			return "$-1\r\n"
+		}
+		s.values[args[1]] = args[2]
+		delete(s.expiry, args[1])
+		return "+OK\r\n"
+	case (cmd == "INCR" || cmd == "DECR") && len(args) == 2:
+		by := int64(1)
+		if cmd == "DECR" {
+			by = -1
+		}
+		return s.incrBy(args[1], by)
+	case cmd == "INCRBY" && len(args) == 3:
+		by, err := strconv.ParseInt(args[2], 10, 64)
+		if err != nil {
+			return "-ERR value is not an integer or out of range\r\n"
+		}
+		return s.incrBy(args[1], by)
+	case cmd == "PEXPIRE" && len(args) == 3:
+		ms, err := strconv.ParseInt(args[2], 10, 64)
+		if err != nil {
+			return "-ERR value is not an integer or out of range\r\n"
+		}
+		if _, ok := s.get(args[1]); !ok {
+			return ":0\r\n"
+		}
+		s.expiry[args[1]] = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		return ":1\r\n"
+	case cmd == "PEXPIREAT" && len(args) == 3:
+		ms, err := strconv.ParseInt(args[2], 10, 64)
+		if err != nil {
+			return "-ERR value is not an integer or out of range\r\n"
+		}
+		if _, ok := s.get(args[1]); !ok {
+			return ":0\r\n"
+		}
+		s.expiry[args[1]] = time.Unix(0, ms*int64(time.Millisecond))
+		return ":1\r\n"
+	case cmd == "DEL":
+		var n int
+		for _, key := range args[1:] {
+			if _, ok := s.get(key); ok {
+				delete(s.values, key)
+				delete(s.expiry, key)
+				n++
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", n)
+	}
+	return fmt.Sprintf("-ERR unknown command '%s'\r\n", args[0])
+}
+
+// get returns the value of key unless it expired. s.mu must be held.
+func (s *Server) get(key string) (string, bool) {
+	if t, ok := s.expiry[key]; ok && !time.Now().Before(t) {
+		delete(s.values, key)
+		delete(s.expiry, key)
+	}
+	v, ok := s.values[key]
+	return v, ok
+}
+
+// incrBy increments the counter of key. s.mu must be held.
+func (s *Server) incrBy(key string, by int64) string {
+	var n int64
+	if v, ok := s.get(key); ok {
+		var err error
+		n, err = strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return "-ERR value is not an integer or out of range\r\n"
+		}
+	}
+	n += by
+	s.values[key] = strconv.FormatInt(n, 10)
+	return fmt.Sprintf(":%d\r\n", n)
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if !strings.HasPrefix(line, "*") {
+		// Inline command.
+		args := strings.Fields(line)
+		if len(args) == 0 {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return args, nil
+	}
+	n, err := strconv.Atoi(line[1:])
+	if err != nil || n < 1 {
+		return nil, fmt.Errorf("invalid command header %q", line)
+	}
+	args := make([]string, n)
+	for i := range args {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimRight(line, "\r\n")[1:])
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		_, err = io.ReadFull(r, buf)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
@@ -0,0 +1,162 @@
+package thorchain
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// cacheEntry is a cached response body.
+type cacheEntry struct {
+	data      []byte
+	fetchedAt time.Time
+}
+
+// call is a request in flight shared by all the callers asking for the same key.
+type call struct {
+	done chan struct{}
+	data []byte
+	err  error
+}
+
+// bypassCacheKey is the key of the context value telling the cache to be bypassed.
+type bypassCacheKey struct{}
+
+// WithoutCache returns a context whose requests skip the cached responses, for
+// callers which need the current data like the scanner. Their responses are
+// still cached for the other callers.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheKey{}, true)
+}
+
+// fetchFunc requests the response body of a key from thornode.
+type fetchFunc func(ctx context.Context) ([]byte, error)
+
+// responseCache caches response bodies with a ttl per endpoint. Concurrent
+// misses of the same key share a single request. Entries which expired less
+// than staleTTL ago are still served while they are refreshed in background.
+type responseCache struct {
+	mu          sync.Mutex
+	entries     map[string]*cacheEntry
+	calls       map[string]*call
+	ttl         time.Duration
+	ttls        map[string]time.Duration
+	staleTTL    time.Duration
+	cleanup     time.Duration
+	cleanedUpAt time.Time
+	logger      zerolog.Logger
+}
+
+func newResponseCache(ttl time.Duration, ttls map[string]time.Duration, staleTTL, cleanup time.Duration, logger zerolog.Logger) *responseCache {
+	return &responseCache{
+		entries:     map[string]*cacheEntry{},
+		calls:       map[string]*call{},
+		ttl:         ttl,
+		ttls:        ttls,
+		staleTTL:    staleTTL,
+		cleanup:     cleanup,
+		cleanedUpAt: time.Now(),
+		logger:      logger,
+	}
+}
+
+// ttlOf returns the ttl of the endpoint at path. The ttls are configured by
+// path prefix, e.g. "pool" for "/pool/BNB.BNB", and the longest prefix wins.
+func (c *responseCache) ttlOf(path string) time.Duration {
+	path = strings.TrimPrefix(path, "/")
+	ttl := c.ttl
+	matched := -1
+	for prefix, d := range c.ttls {
+		prefix = strings.Trim(prefix, "/")
+		if len(prefix) <= matched {
+			continue
+		}
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			ttl = d
+			matched = len(prefix)
+		}
+	}
+	return ttl
+}
+
+// get returns the response body of path from the cache or fetches it. ctx only
+// bounds the wait: the request itself is shared with other callers and isn't
+// cancelled with it.
+func (c *responseCache) get(ctx context.Context, path string, fetch fetchFunc) ([]byte, error) {
+	ttl := c.ttlOf(path)
+
+	c.mu.Lock()
+	now := time.Now()
+	c.cleanupExpired(now)
+	entry, ok := c.entries[path]
+	if bypass, _ := ctx.Value(bypassCacheKey{}).(bool); bypass {
+		ok = false
+	}
+	if ok {
+		age := now.Sub(entry.fetchedAt)
+		if age < ttl {
+			c.mu.Unlock()
+			c.logger.Debug().Bool("cached", true).Msg(path)
+			return entry.data, nil
+		}
+		if age < ttl+c.staleTTL {
+			c.start(path, fetch)
+			c.mu.Unlock()
+			c.logger.Debug().Bool("cached", true).Bool("stale", true).Msg(path)
+			return entry.data, nil
+		}
+	}
+	cl := c.start(path, fetch)
+	c.mu.Unlock()
+
+	select {
+	case <-cl.done:
+		return cl.data, cl.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// start starts fetching path unless it's already in flight and returns the
+// call. c.mu must be held.
+func (c *responseCache) start(path string, fetch fetchFunc) *call {
+	if cl, ok := c.calls[path]; ok {
+		return cl
+	}
+	cl := &call{done: make(chan struct{})}
+	c.calls[path] = cl
+	go func() {
+		cl.data, cl.err = fetch(context.Background())
+
+		c.mu.Lock()
+		if cl.err == nil {
+			c.entries[path] = &cacheEntry{
+				data:      cl.data,
+				fetchedAt: time.Now(),
+			}
+		} else {
+			c.logger.Debug().Err(cl.err).Msg(path)
+		}
+		delete(c.calls, path)
+		c.mu.Unlock()
+		close(cl.done)
+	}()
+	return cl
+}
+
+// cleanupExpired deletes the entries which can't be served anymore, at most
+// once per cleanup interval. c.mu must be held.
+func (c *responseCache) cleanupExpired(now time.Time) {
+	if now.Sub(c.cleanedUpAt) < c.cleanup {
+		return
+	}
+	for path, entry := range c.entries {
+		if now.Sub(entry.fetchedAt) >= c.ttlOf(path)+c.staleTTL {
+			delete(c.entries, path)
+		}
+	}
+	c.cleanedUpAt = now
+}
@@ -0,0 +1,135 @@
+package thorchain
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	. "gopkg.in/check.v1"
+)
+
+var _ = Suite(&CacheSuite{})
+
+type CacheSuite struct{}
+
+// counter returns a fetchFunc returning the number of times it was called
+// after the given delay.
+func counter(calls *int32, delay time.Duration) fetchFunc {
+	return func(ctx context.Context) ([]byte, error) {
+		n := atomic.AddInt32(calls, 1)
+		time.Sleep(delay)
+		return []byte(strconv.Itoa(int(n))), nil
+	}
+}
+
+func (s *CacheSuite) TestSingleFlight(c *C) {
+	cache := newResponseCache(time.Minute, nil, 0, time.Minute, log.Logger)
+	var calls int32
+	fetch := counter(&calls, time.Millisecond*50)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, err := cache.get(context.Background(), "/pools", fetch)
+			c.Check(err, IsNil)
+			c.Check(string(data), Equals, "1")
+		}()
+	}
+	wg.Wait()
+	c.Assert(atomic.LoadInt32(&calls), Equals, int32(1))
+
+	// Errors are shared but not cached.
+	fail := func(ctx context.Context) ([]byte, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, errors.New("node down")
+	}
+	_, err := cache.get(context.Background(), "/vault", fail)
+	c.Assert(err, NotNil)
+	data, err := cache.get(context.Background(), "/vault", fetch)
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, "3")
+}
+
+func (s *CacheSuite) TestStaleWhileRevalidate(c *C) {
+	cache := newResponseCache(time.Millisecond*50, nil, time.Millisecond*100, time.Minute, log.Logger)
+	var calls int32
+	fetch := counter(&calls, time.Millisecond*20)
+
+	data, err := cache.get(context.Background(), "/pools", fetch)
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, "1")
+
+	// The stale value is served right away while it's refreshed.
+	time.Sleep(time.Millisecond * 60)
+	start := time.Now()
+	data, err = cache.get(context.Background(), "/pools", fetch)
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, "1")
+	c.Assert(time.Since(start) < time.Millisecond*20, Equals, true)
+
+	time.Sleep(time.Millisecond * 30)
+	data, err = cache.get(context.Background(), "/pools", fetch)
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, "2")
+
+	// Too old values are not served anymore.
+	time.Sleep(time.Millisecond * 160)
+	data, err = cache.get(context.Background(), "/pools", fetch)
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, "3")
+}
+
+func (s *CacheSuite) TestWaitCancelled(c *C) {
+	cache := newResponseCache(time.Minute, nil, 0, time.Minute, log.Logger)
+	var calls int32
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	_, err := cache.get(ctx, "/pools", counter(&calls, time.Millisecond*50))
+	c.Assert(err, Equals, context.DeadlineExceeded)
+
+	// The request still completes for the other callers.
+	time.Sleep(time.Millisecond * 60)
+	data, err := cache.get(context.Background(), "/pools", counter(&calls, 0))
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, "1")
+}
+
+func (s *CacheSuite) TestWithoutCache(c *C) {
+	cache := newResponseCache(time.Minute, nil, time.Minute, time.Minute, log.Logger)
+	var calls int32
+	fetch := counter(&calls, 0)
+
+	data, err := cache.get(context.Background(), "/vault", fetch)
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, "1")
+	data, err = cache.get(WithoutCache(context.Background()), "/vault", fetch)
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, "2")
+
+	// The fresh response is cached for the other callers.
+	data, err = cache.get(context.Background(), "/vault", fetch)
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, "2")
+}
+
+func (s *CacheSuite) TestTTLOf(c *C) {
+	ttls := map[string]time.Duration{
+		"constants":     time.Hour,
+		"vaults":        time.Minute,
+		"vaults/asgard": time.Second * 10,
+	}
+	cache := newResponseCache(time.Second*5, ttls, 0, time.Minute, log.Logger)
+
+	c.Assert(cache.ttlOf("/constants"), Equals, time.Hour)
+	c.Assert(cache.ttlOf("/vaults/yggdrasil"), Equals, time.Minute)
+	c.Assert(cache.ttlOf("/vaults/asgard"), Equals, time.Second*10)
+	c.Assert(cache.ttlOf("/pool/BNB.BNB"), Equals, time.Second*5)
+	c.Assert(cache.ttlOf("/constantsx"), Equals, time.Second*5)
+}
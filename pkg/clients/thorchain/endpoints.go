@@ -0,0 +1,243 @@
+package thorchain
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+
+	"gitlab.com/thorchain/midgard/internal/config"
+)
+
+const (
+	// healthWeight is the weight of the latest sample in the moving averages of
+	// the endpoint latency and error rate.
+	healthWeight = 0.3
+	// errorRatePenalty scales the latency of an endpoint by its error rate when
+	// ranking endpoints, so an endpoint failing half of the requests looks six
+	// times slower.
+	errorRatePenalty = 10
+)
+
+// ErrCircuitOpen is returned without calling the nodes when the circuit breakers
+// of all the endpoints are open.
+var ErrCircuitOpen = errors.New("thornode unavailable: circuit breaker is open")
+
+// endpoint is a node endpoint along with its health statistics and circuit
+// breaker state.
+type endpoint struct {
+	url       string
+	height    int64
+	latency   time.Duration
+	errorRate float64
+	failures  int
+	openedAt  time.Time
+}
+
+// score ranks the endpoints, the lower the better.
+func (e *endpoint) score() float64 {
+	return float64(e.latency+time.Millisecond) * (1 + errorRatePenalty*e.errorRate)
+}
+
+// isEndpointFailure tells whether err means the endpoint failed to serve a
+// request, so the request should fail over to another endpoint or be retried.
+// Client errors and cancelled requests are not the endpoint's fault.
+func isEndpointFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Temporary()
+	}
+	return !errors.Is(err, ErrCircuitOpen)
+}
+
+// heightProbe returns the latest block height known by the node behind the url.
+type heightProbe func(url string) (int64, error)
+
+// endpointPool keeps track of the health of a set of equivalent node endpoints
+// and routes requests to the best one. The endpoints are probed at most once
+// per interval, lazily when a request is made. Endpoints whose height is more
+// than maxLag blocks behind the highest one are refused.
+//
+// Each endpoint has a circuit breaker which opens after breakerThreshold
+// consecutive failures. An open endpoint is not called until breakerCooldown
+// has passed, then a single trial request closes it again on success.
+type endpointPool struct {
+	mu               sync.Mutex
+	endpoints        []*endpoint
+	probe            heightProbe
+	interval         time.Duration
+	maxLag           int64
+	breakerThreshold int
+	breakerCooldown  time.Duration
+	checking         bool
+	checkedAt        time.Time
+	logger           zerolog.Logger
+}
+
+func newEndpointPool(urls []string, probe heightProbe, cfg config.ThorChainConfiguration, logger zerolog.Logger) *endpointPool {
+	endpoints := make([]*endpoint, len(urls))
+	for i, url := range urls {
+		endpoints[i] = &endpoint{url: url}
+	}
+	threshold := cfg.BreakerThreshold
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &endpointPool{
+		endpoints:        endpoints,
+		probe:            probe,
+		interval:         cfg.HealthCheckInterval,
+		maxLag:           cfg.MaxHeightLag,
+		breakerThreshold: threshold,
+		breakerCooldown:  cfg.BreakerCooldown,
+		logger:           logger,
+	}
+}
+
+// do calls fn with the url of the best endpoint and fails over to the next ones
+// until a call succeeds or fails for a reason other than the endpoint. It
+// returns the error of the last call otherwise.
+func (p *endpointPool) do(fn func(url string) error) error {
+	candidates := p.candidates()
+	if len(candidates) == 0 {
+		return ErrCircuitOpen
+	}
+
+	var err error
+	for _, e := range candidates {
+		start := time.Now()
+		err = fn(e.url)
+		if !isEndpointFailure(err) {
+			p.report(e, time.Since(start), nil)
+			return err
+		}
+		p.report(e, time.Since(start), err)
+		p.logger.Warn().Err(err).Str("endpoint", e.url).Msg("request failed")
+	}
+	return err
+}
+
+// candidates returns the endpoints which can serve requests ordered from the
+// best one. Endpoints whose circuit breaker is open and lagging endpoints are
+// left out. Endpoints which failed lately come last.
+func (p *endpointPool) candidates() []*endpoint {
+	p.maybeCheck()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var available []*endpoint
+	var top int64
+	for _, e := range p.endpoints {
+		if e.failures >= p.breakerThreshold {
+			if now.Sub(e.openedAt) < p.breakerCooldown {
+				continue
+			}
+			// Half-open: let this request through and keep the others out until
+			// it succeeds or the cooldown passes again.
+			e.openedAt = now
+		}
+		available = append(available, e)
+		if e.height > top {
+			top = e.height
+		}
+	}
+	var candidates []*endpoint
+	for _, e := range available {
+		if top-e.height <= p.maxLag {
+			candidates = append(candidates, e)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if (candidates[i].failures == 0) != (candidates[j].failures == 0) {
+			return candidates[i].failures == 0
+		}
+		return candidates[i].score() < candidates[j].score()
+	})
+	return candidates
+}
+
+// report updates the statistics and the circuit breaker of the endpoint with
+// the outcome of a request.
+func (p *endpointPool) report(e *endpoint, latency time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var sample float64
+	if err != nil {
+		sample = 1
+		e.failures++
+		if e.failures == p.breakerThreshold {
+			e.openedAt = time.Now()
+			p.logger.Warn().Str("endpoint", e.url).Msg("circuit breaker opened")
+		}
+	} else {
+		e.latency = time.Duration(float64(e.latency)*(1-healthWeight) + float64(latency)*healthWeight)
+		if e.failures >= p.breakerThreshold {
+			p.logger.Info().Str("endpoint", e.url).Msg("circuit breaker closed")
+		}
+		e.failures = 0
+	}
+	e.errorRate = e.errorRate*(1-healthWeight) + sample*healthWeight
+}
+
+// maybeCheck starts a health check if the last one is older than the interval.
+// The first check is done synchronously so the first request is routed
+// knowing the state of the endpoints.
+func (p *endpointPool) maybeCheck() {
+	p.mu.Lock()
+	if p.checking || (!p.checkedAt.IsZero() && time.Since(p.checkedAt) < p.interval) {
+		p.mu.Unlock()
+		return
+	}
+	p.checking = true
+	first := p.checkedAt.IsZero()
+	p.mu.Unlock()
+
+	run := func() {
+		p.check()
+
+		p.mu.Lock()
+		p.checking = false
+		p.checkedAt = time.Now()
+		p.mu.Unlock()
+	}
+	if first {
+		run()
+	} else {
+		go run()
+	}
+}
+
+// check probes all the endpoints concurrently and updates their height.
+func (p *endpointPool) check() {
+	var wg sync.WaitGroup
+	for _, e := range p.endpoints {
+		wg.Add(1)
+		go func(e *endpoint) {
+			defer wg.Done()
+
+			start := time.Now()
+			height, err := p.probe(e.url)
+			p.report(e, time.Since(start), err)
+			if err != nil {
+				p.logger.Warn().Err(err).Str("endpoint", e.url).Msg("health check failed")
+				return
+			}
+			p.mu.Lock()
+			e.height = height
+			p.mu.Unlock()
+		}(e)
+	}
+	wg.Wait()
+}
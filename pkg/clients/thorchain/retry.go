@@ -0,0 +1,64 @@
+package thorchain
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// StatusError is returned when a node responds with a non-2xx status code.
+type StatusError struct {
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s responded with status %d: %s", e.URL, e.StatusCode, e.Body)
+}
+
+// Temporary tells whether the request may succeed if it's sent again.
+func (e *StatusError) Temporary() bool {
+	return e.StatusCode >= http.StatusInternalServerError || e.StatusCode == http.StatusTooManyRequests
+}
+
+// retrier retries the requests failing for transient reasons with an exponential
+// backoff and jitter.
+type retrier struct {
+	maxRetries int
+	backoff    time.Duration
+	maxBackoff time.Duration
+}
+
+// do calls fn until it succeeds, fails for a non transient reason or has been
+// retried maxRetries times. It stops waiting when ctx is done.
+func (r retrier) do(ctx context.Context, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= r.maxRetries || !isEndpointFailure(err) {
+			return err
+		}
+		select {
+		case <-time.After(r.delay(attempt)):
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "gave up retrying after %v", err)
+		}
+	}
+}
+
+// delay returns the wait before the retry following the given attempt: half of
+// the exponential backoff plus a random part of the other half.
+func (r retrier) delay(attempt int) time.Duration {
+	d := r.backoff << uint(attempt)
+	if d > r.maxBackoff || d <= 0 {
+		d = r.maxBackoff
+	}
+	if d <= 1 {
+		return d
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)))
+}
@@ -0,0 +1,153 @@
+package thorchain
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"gitlab.com/thorchain/midgard/internal/config"
+)
+
+// TendermintClient implements Tendermint over the rpc endpoints of several
+// nodes. Requests are routed to the healthiest node.
+type TendermintClient struct {
+	endpoints *endpointPool
+	clients   map[string]*rpchttp.HTTP
+}
+
+// NewTendermintClient creates a new instance of TendermintClient.
+func NewTendermintClient(cfg config.ThorChainConfiguration) (*TendermintClient, error) {
+	hosts := cfg.AllRPCHosts()
+	if len(hosts) == 0 {
+		return nil, errors.New("tendermint rpc host is empty")
+	}
+
+	tc := &TendermintClient{
+		clients: make(map[string]*rpchttp.HTTP, len(hosts)),
+	}
+	urls := make([]string, len(hosts))
+	for i, host := range hosts {
+		urls[i] = fmt.Sprintf("%s://%s", cfg.Scheme, host)
+		client, err := rpchttp.New(urls[i], "/websocket")
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not create rpc client of %s", urls[i])
+		}
+		tc.clients[urls[i]] = client
+	}
+	logger := log.With().Str("module", "tendermint_client").Logger()
+	tc.endpoints = newEndpointPool(urls, tc.latestHeight, cfg, logger)
+	return tc, nil
+}
+
+// BlockResults returns the results of the block at the given height.
+func (tc *TendermintClient) BlockResults(height *int64) (*coretypes.ResultBlockResults, error) {
+	var result *coretypes.ResultBlockResults
+	err := tc.endpoints.do(func(url string) error {
+		var err error
+		result, err = tc.clients[url].BlockResults(height)
+		return err
+	})
+	return result, err
+}
+
+// BlockchainInfo returns the metas of the blocks between the given heights.
+func (tc *TendermintClient) BlockchainInfo(minHeight, maxHeight int64) (*coretypes.ResultBlockchainInfo, error) {
+	var result *coretypes.ResultBlockchainInfo
+	err := tc.endpoints.do(func(url string) error {
+		var err error
+		result, err = tc.clients[url].BlockchainInfo(minHeight, maxHeight)
+		return err
+	})
+	return result, err
+}
+
+// NewBatch creates a new batch of requests sent to the healthiest node.
+func (tc *TendermintClient) NewBatch() *TendermintClientBatch {
+	return &TendermintClientBatch{
+		client: tc,
+	}
+}
+
+// latestHeight returns the latest block height of the node at the url. It's
+// used to check the health of endpoints.
+func (tc *TendermintClient) latestHeight(url string) (int64, error) {
+	status, err := tc.clients[url].Status()
+	if err != nil {
+		return 0, err
+	}
+	return status.SyncInfo.LatestBlockHeight, nil
+}
+
+// TendermintClientBatch implements TendermintBatch. The requests are queued
+// until Send which sends them all to the same node, failing over to the next
+// one as a whole.
+type TendermintClientBatch struct {
+	client  *TendermintClient
+	mu      sync.Mutex
+	heights []int64
+	results []*coretypes.ResultBlockResults
+}
+
+// BlockResults queues a request of the results of the block at the given height.
+// The returned result is filled by Send.
+func (b *TendermintClientBatch) BlockResults(height *int64) (*coretypes.ResultBlockResults, error) {
+	if height == nil {
+		return nil, errors.New("height is required in batch mode")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	result := &coretypes.ResultBlockResults{}
+	b.heights = append(b.heights, *height)
+	b.results = append(b.results, result)
+	return result, nil
+}
+
+// Send sends the queued requests and clears the batch.
+func (b *TendermintClientBatch) Send() ([]interface{}, error) {
+	b.mu.Lock()
+	defer func() {
+		b.heights = nil
+		b.results = nil
+		b.mu.Unlock()
+	}()
+
+	err := b.client.endpoints.do(func(url string) error {
+		batch := b.client.clients[url].NewBatch()
+		results := make([]*coretypes.ResultBlockResults, len(b.heights))
+		for i := range b.heights {
+			var err error
+			results[i], err = batch.BlockResults(&b.heights[i])
+			if err != nil {
+				return err
+			}
+		}
+		_, err := batch.Send()
+		if err != nil {
+			return err
+		}
+		for i, result := range results {
+			// Errors of single requests are not returned in batch mode.
+			if result.Height != b.heights[i] {
+				return errors.Errorf("missing results of block %d", b.heights[i])
+			}
+		}
+		for i, result := range results {
+			*b.results[i] = *result
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	results := make([]interface{}, len(b.results))
+	for i, result := range b.results {
+		results[i] = result
+	}
+	return results, nil
+}
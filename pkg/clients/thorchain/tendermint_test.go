@@ -0,0 +1,180 @@
+package thorchain
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	amino "github.com/tendermint/go-amino"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+	"github.com/tendermint/tendermint/types"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/midgard/internal/config"
+)
+
+var _ = Suite(&TendermintClientSuite{})
+
+type TendermintClientSuite struct{}
+
+// tendermintNode is a local stand-in of the tendermint rpc of a node having
+// blocks up to its height. It serves the status, blockchain and block_results
+// methods in single and batch mode.
+type tendermintNode struct {
+	height  int64
+	failing int32
+	hits    int32
+	cdc     *amino.Codec
+	server  *httptest.Server
+}
+
+func newTendermintNode(height int64) *tendermintNode {
+	n := &tendermintNode{
+		height: height,
+		cdc:    amino.NewCodec(),
+	}
+	coretypes.RegisterAmino(n.cdc)
+	n.server = httptest.NewServer(http.HandlerFunc(n.handle))
+	return n
+}
+
+func (n *tendermintNode) host() string {
+	return strings.TrimPrefix(n.server.URL, "http://")
+}
+
+func (n *tendermintNode) handle(w http.ResponseWriter, r *http.Request) {
+	if atomic.LoadInt32(&n.failing) == 1 {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(string(body)), "[") {
+		var reqs []rpctypes.RPCRequest
+		if err := json.Unmarshal(body, &reqs); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		resps := make([]rpctypes.RPCResponse, len(reqs))
+		for i, req := range reqs {
+			resps[i] = n.call(req)
+		}
+		json.NewEncoder(w).Encode(resps)
+		return
+	}
+	var req rpctypes.RPCRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	json.NewEncoder(w).Encode(n.call(req))
+}
+
+func (n *tendermintNode) call(req rpctypes.RPCRequest) rpctypes.RPCResponse {
+	var params struct {
+		Height    *int64 `json:"height"`
+		MinHeight int64  `json:"minHeight"`
+		MaxHeight int64  `json:"maxHeight"`
+	}
+	if err := n.cdc.UnmarshalJSON(req.Params, &params); err != nil {
+		return rpctypes.RPCInvalidParamsError(req.ID, err)
+	}
+
+	switch req.Method {
+	case "status":
+		atomic.AddInt32(&n.hits, 1)
+		status := &coretypes.ResultStatus{
+			SyncInfo: coretypes.SyncInfo{LatestBlockHeight: n.height},
+		}
+		return rpctypes.NewRPCSuccessResponse(n.cdc, req.ID, status)
+	case "blockchain":
+		atomic.AddInt32(&n.hits, 1)
+		info := &coretypes.ResultBlockchainInfo{LastHeight: n.height}
+		for h := params.MaxHeight; h >= params.MinHeight; h-- {
+			if h <= n.height {
+				info.BlockMetas = append(info.BlockMetas, &types.BlockMeta{Header: types.Header{Height: h}})
+			}
+		}
+		return rpctypes.NewRPCSuccessResponse(n.cdc, req.ID, info)
+	case "block_results":
+		atomic.AddInt32(&n.hits, 1)
+		if *params.Height > n.height {
+			return rpctypes.RPCInternalError(req.ID, errors.New("height must be less than or equal to the current blockchain height"))
+		}
+		return rpctypes.NewRPCSuccessResponse(n.cdc, req.ID, &coretypes.ResultBlockResults{Height: *params.Height})
+	}
+	return rpctypes.RPCMethodNotFoundError(req.ID)
+}
+
+func fetchBlocks(c *C, client *TendermintClient, from, to int64) {
+	info, err := client.BlockchainInfo(from, to)
+	c.Assert(err, IsNil)
+	c.Assert(info.BlockMetas, HasLen, int(to-from+1))
+
+	batch := client.NewBatch()
+	var results []*coretypes.ResultBlockResults
+	for h := from; h <= to; h++ {
+		result, err := batch.BlockResults(&h)
+		c.Assert(err, IsNil)
+		results = append(results, result)
+	}
+	_, err = batch.Send()
+	c.Assert(err, IsNil)
+	for i, result := range results {
+		c.Assert(result.Height, Equals, from+int64(i))
+	}
+}
+
+func (s *TendermintClientSuite) TestFailover(c *C) {
+	first := newTendermintNode(10)
+	defer first.server.Close()
+	second := newTendermintNode(10)
+	defer second.server.Close()
+
+	client, err := NewTendermintClient(config.ThorChainConfiguration{
+		Scheme:              "http",
+		RPCHost:             first.host(),
+		RPCHosts:            []string{second.host()},
+		HealthCheckInterval: time.Hour,
+		MaxHeightLag:        2,
+	})
+	c.Assert(err, IsNil)
+	fetchBlocks(c, client, 1, 5)
+
+	// Blocks are still fetched while one of the nodes is down.
+	first.server.Close()
+	fetchBlocks(c, client, 6, 10)
+
+	atomic.StoreInt32(&second.failing, 1)
+	_, err = client.BlockchainInfo(1, 10)
+	c.Assert(err, NotNil)
+}
+
+func (s *TendermintClientSuite) TestLaggingEndpoint(c *C) {
+	synced := newTendermintNode(10)
+	defer synced.server.Close()
+	lagging := newTendermintNode(3)
+	defer lagging.server.Close()
+
+	client, err := NewTendermintClient(config.ThorChainConfiguration{
+		Scheme:              "http",
+		RPCHosts:            []string{lagging.host(), synced.host()},
+		HealthCheckInterval: time.Hour,
+		MaxHeightLag:        2,
+	})
+	c.Assert(err, IsNil)
+	fetchBlocks(c, client, 1, 10)
+
+	// Only the health check reached the lagging node.
+	c.Assert(atomic.LoadInt32(&lagging.hits), Equals, int32(1))
+}
@@ -1,8 +1,10 @@
 package thorchain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"strings"
@@ -10,56 +12,71 @@ import (
 	"gitlab.com/thorchain/midgard/internal/common"
 	"gitlab.com/thorchain/midgard/internal/models"
 
-	"github.com/patrickmn/go-cache"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"gitlab.com/thorchain/midgard/internal/config"
 )
 
+// maxErrorBodySize is the maximum length of the response body kept in StatusError.
+const maxErrorBodySize = 256
+
 // Thorchain represents api that any thorchain client should provide.
 type Thorchain interface {
-	GetNodeAccounts() ([]NodeAccount, error)
-	GetVaultData() (VaultData, error)
-	GetConstants() (ConstantValues, error)
-	GetAsgardVaults() ([]Vault, error)
-	GetLastChainHeight() (LastHeights, error)
-	GetTx(txId common.TxID) (common.Tx, error)
-	GetPoolStatus(pool common.Asset) (models.PoolStatus, error)
-	GetMimir() (map[string]string, error)
-	GetPools() ([]Pool, error)
+	GetNodeAccounts(ctx context.Context) ([]NodeAccount, error)
+	GetVaultData(ctx context.Context) (VaultData, error)
+	GetConstants(ctx context.Context) (ConstantValues, error)
+	GetAsgardVaults(ctx context.Context) ([]Vault, error)
+	GetLastChainHeight(ctx context.Context) (LastHeights, error)
+	GetTx(ctx context.Context, txId common.TxID) (common.Tx, error)
+	GetObservedTx(ctx context.Context, txId common.TxID) (ObservedTx, error)
+	GetPoolStatus(ctx context.Context, pool common.Asset) (models.PoolStatus, error)
+	GetMimir(ctx context.Context) (map[string]string, error)
+	GetPools(ctx context.Context) ([]Pool, error)
 }
 
 // Client implements Thorchain and uses http to get requested data from thorchain.
+// Requests are routed to the healthiest of the configured nodes.
 type Client struct {
-	thorchainEndpoint string
-	httpClient        *http.Client
-	cache             *cache.Cache
-	logger            zerolog.Logger
+	endpoints  *endpointPool
+	retrier    retrier
+	httpClient *http.Client
+	cache      *responseCache
+	logger     zerolog.Logger
 }
 
 // NewClient create a new instance of Client.
 func NewClient(cfg config.ThorChainConfiguration) (*Client, error) {
-	if cfg.Host == "" {
+	hosts := cfg.AllHosts()
+	if len(hosts) == 0 {
 		return nil, errors.New("thorchain host is empty")
 	}
 
+	urls := make([]string, len(hosts))
+	for i, host := range hosts {
+		urls[i] = fmt.Sprintf("%s://%s/thorchain", cfg.Scheme, host)
+	}
 	sc := &Client{
-		thorchainEndpoint: fmt.Sprintf("%s://%s/thorchain", cfg.Scheme, cfg.Host),
 		httpClient: &http.Client{
 			Timeout: cfg.ReadTimeout,
 		},
-		cache:  cache.New(cfg.CacheTTL, cfg.CacheCleanup),
+		retrier: retrier{
+			maxRetries: cfg.MaxRetries,
+			backoff:    cfg.RetryBackoff,
+			maxBackoff: cfg.RetryMaxBackoff,
+		},
 		logger: log.With().Str("module", "thorchain_client").Logger(),
 	}
+	sc.cache = newResponseCache(cfg.CacheTTL, cfg.CacheTTLs, cfg.CacheStaleTTL, cfg.CacheCleanup, sc.logger)
+	sc.endpoints = newEndpointPool(urls, sc.lastHeight, cfg, sc.logger)
 	return sc, nil
 }
 
 // GetNodeAccounts fetch account info of chain nodes.
-func (c *Client) GetNodeAccounts() ([]NodeAccount, error) {
-	url := fmt.Sprintf("%s/nodeaccounts", c.thorchainEndpoint)
+func (c *Client) GetNodeAccounts(ctx context.Context) ([]NodeAccount, error) {
+	path := "/nodeaccounts"
 	var nodeAccounts []NodeAccount
-	err := c.requestEndpoint(url, &nodeAccounts)
+	err := c.requestEndpoint(ctx, path, &nodeAccounts)
 	if err != nil {
 		return nil, err
 	}
@@ -67,10 +84,10 @@ func (c *Client) GetNodeAccounts() ([]NodeAccount, error) {
 }
 
 // GetVaultData fetch the chain vault data.
-func (c *Client) GetVaultData() (VaultData, error) {
-	url := fmt.Sprintf("%s/vault", c.thorchainEndpoint)
+func (c *Client) GetVaultData(ctx context.Context) (VaultData, error) {
+	path := "/vault"
 	var vault VaultData
-	err := c.requestEndpoint(url, &vault)
+	err := c.requestEndpoint(ctx, path, &vault)
 	if err != nil {
 		return VaultData{}, err
 	}
@@ -78,10 +95,10 @@ func (c *Client) GetVaultData() (VaultData, error) {
 }
 
 // GetConstants fetch network constants values.
-func (c *Client) GetConstants() (ConstantValues, error) {
-	url := fmt.Sprintf("%s/constants", c.thorchainEndpoint)
+func (c *Client) GetConstants(ctx context.Context) (ConstantValues, error) {
+	path := "/constants"
 	var consts ConstantValues
-	err := c.requestEndpoint(url, &consts)
+	err := c.requestEndpoint(ctx, path, &consts)
 	if err != nil {
 		return ConstantValues{}, err
 	}
@@ -89,10 +106,10 @@ func (c *Client) GetConstants() (ConstantValues, error) {
 }
 
 // GetAsgardVaults fetch asgard vaults info.
-func (c *Client) GetAsgardVaults() ([]Vault, error) {
-	url := fmt.Sprintf("%s/vaults/asgard", c.thorchainEndpoint)
+func (c *Client) GetAsgardVaults(ctx context.Context) ([]Vault, error) {
+	path := "/vaults/asgard"
 	var vaults []Vault
-	err := c.requestEndpoint(url, &vaults)
+	err := c.requestEndpoint(ctx, path, &vaults)
 	if err != nil {
 		return nil, err
 	}
@@ -100,10 +117,10 @@ func (c *Client) GetAsgardVaults() ([]Vault, error) {
 }
 
 // GetLastChainHeight fetch the last block info.
-func (c *Client) GetLastChainHeight() (LastHeights, error) {
-	url := fmt.Sprintf("%s/lastblock", c.thorchainEndpoint)
+func (c *Client) GetLastChainHeight(ctx context.Context) (LastHeights, error) {
+	path := "/lastblock"
 	var last LastHeights
-	err := c.requestEndpoint(url, &last)
+	err := c.requestEndpoint(ctx, path, &last)
 	if err != nil {
 		return LastHeights{}, err
 	}
@@ -111,21 +128,31 @@ func (c *Client) GetLastChainHeight() (LastHeights, error) {
 }
 
 // GetTx fetch the tx details from thorchain by txID.
-func (c *Client) GetTx(txID common.TxID) (common.Tx, error) {
-	url := fmt.Sprintf("%s/tx/%s", c.thorchainEndpoint, txID.String())
-	var observedTx ObservedTx
-	err := c.requestEndpoint(url, &observedTx)
+func (c *Client) GetTx(ctx context.Context, txID common.TxID) (common.Tx, error) {
+	observedTx, err := c.GetObservedTx(ctx, txID)
 	if err != nil {
 		return common.Tx{}, err
 	}
 	return observedTx.Tx, nil
 }
 
+// GetObservedTx fetch the tx observed by thorchain by txID alongside its status
+// and outbounds.
+func (c *Client) GetObservedTx(ctx context.Context, txID common.TxID) (ObservedTx, error) {
+	path := fmt.Sprintf("/tx/%s", txID.String())
+	var observedTx ObservedTx
+	err := c.requestEndpoint(ctx, path, &observedTx)
+	if err != nil {
+		return ObservedTx{}, err
+	}
+	return observedTx, nil
+}
+
 // GetPoolStatus returns current pool status.
-func (c *Client) GetPoolStatus(pool common.Asset) (models.PoolStatus, error) {
-	url := fmt.Sprintf("%s/pool/%s", c.thorchainEndpoint, pool)
+func (c *Client) GetPoolStatus(ctx context.Context, pool common.Asset) (models.PoolStatus, error) {
+	path := fmt.Sprintf("/pool/%s", pool)
 	var result Pool
-	err := c.requestEndpoint(url, &result)
+	err := c.requestEndpoint(ctx, path, &result)
 	if err != nil {
 		return models.Unknown, errors.Wrap(err, "failed to get pool status")
 	}
@@ -137,50 +164,132 @@ func (c *Client) GetPoolStatus(pool common.Asset) (models.PoolStatus, error) {
 	return models.Unknown, fmt.Errorf("failed to convert %s to pool status", result.Status)
 }
 
-func (c *Client) requestEndpoint(url string, result interface{}) error {
-	data := c.checkCache(url)
-	if data != nil {
-		c.logger.Debug().Bool("cached", true).Msg(url)
-	} else {
-		c.logger.Debug().Msg(url)
-		resp, err := c.httpClient.Get(url)
+// requestEndpoint gets the response of the endpoint at path from the cache or
+// the best node.
+func (c *Client) requestEndpoint(ctx context.Context, path string, result interface{}) error {
+	data, err := c.cache.get(ctx, path, func(ctx context.Context) ([]byte, error) {
+		return c.fetch(ctx, path)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, result); nil != err {
+		return errors.Wrapf(err, "failed to unmarshal result as %T", result)
+	}
+	return nil
+}
+
+// fetch requests the endpoint at path from the best node. Requests failing for
+// transient reasons are retried on the other nodes then again after a backoff.
+// ErrCircuitOpen is returned right away when no node is available.
+func (c *Client) fetch(ctx context.Context, path string) ([]byte, error) {
+	var data []byte
+	err := c.retrier.do(ctx, func() error {
+		return c.endpoints.do(func(endpoint string) error {
+			var err error
+			data, err = c.get(ctx, endpoint+path)
+			return err
+		})
+	})
+	return data, err
+}
+
+// Proxy forwards the request to the endpoint at path of the best node and
+// writes the response to w. Requests failing because of the node fail over to
+// the other nodes, and the error of the last one is returned if they all fail.
+func (c *Client) Proxy(w http.ResponseWriter, r *http.Request, path string) error {
+	var resp *http.Response
+	err := c.endpoints.do(func(endpoint string) error {
+		url := endpoint + path
+		if r.URL.RawQuery != "" {
+			url += "?" + r.URL.RawQuery
+		}
+		req, err := http.NewRequest(r.Method, url, nil)
 		if err != nil {
-			return errors.Wrap(err, "http request failed")
+			return errors.Wrap(err, "could not create http request")
 		}
-		data, err = ioutil.ReadAll(resp.Body)
+		req.Header = r.Header.Clone()
+		req.Header.Add("X-Forwarded-Host", r.Host)
+		res, err := c.httpClient.Do(req.WithContext(r.Context()))
 		if err != nil {
-			return errors.Wrap(err, "could not read response body")
+			return errors.Wrap(err, "http request failed")
 		}
-		if err := resp.Body.Close(); nil != err {
-			return errors.Wrap(err, "could not close the http response properly")
+		statusErr := &StatusError{URL: url, StatusCode: res.StatusCode}
+		if statusErr.Temporary() {
+			res.Body.Close()
+			return statusErr
 		}
-		c.updateCache(url, data)
+		resp = res
+		return nil
+	})
+	if err != nil {
+		return err
 	}
+	defer resp.Body.Close()
 
-	if err := json.Unmarshal(data, result); nil != err {
-		return errors.Wrapf(err, "failed to unmarshal result as %T", result)
+	for key, values := range resp.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
 	}
-	return nil
+	w.WriteHeader(resp.StatusCode)
+	_, err = io.Copy(w, resp.Body)
+	return err
 }
 
-func (c *Client) checkCache(key string) []byte {
-	v, ok := c.cache.Get(key)
-	if ok {
-		return v.([]byte)
+// get returns the body of the response to a GET request. Non-2xx responses are
+// returned as *StatusError.
+func (c *Client) get(ctx context.Context, url string) ([]byte, error) {
+	c.logger.Debug().Msg(url)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create http request")
 	}
-	return nil
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "http request failed")
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read response body")
+	}
+	if err := resp.Body.Close(); nil != err {
+		return nil, errors.Wrap(err, "could not close the http response properly")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		if len(data) > maxErrorBodySize {
+			data = data[:maxErrorBodySize]
+		}
+		return nil, &StatusError{
+			URL:        url,
+			StatusCode: resp.StatusCode,
+			Body:       string(data),
+		}
+	}
+	return data, nil
 }
 
-func (c *Client) updateCache(key string, data []byte) {
-	c.cache.Set(key, data, cache.DefaultExpiration)
+// lastHeight returns the last thorchain height known by the node at the
+// endpoint. It's used to check the health of endpoints.
+func (c *Client) lastHeight(endpoint string) (int64, error) {
+	data, err := c.get(context.Background(), endpoint+"/lastblock")
+	if err != nil {
+		return 0, err
+	}
+	var last LastHeights
+	if err := json.Unmarshal(data, &last); nil != err {
+		return 0, errors.Wrap(err, "failed to unmarshal last heights")
+	}
+	return last.Thorchain, nil
 }
 
 // ping requests /ping endpoint of test mocked thorchain server and returns
 // the time field.
 func (c *Client) ping() (string, error) {
-	url := fmt.Sprintf("%s/ping", c.thorchainEndpoint)
+	path := "/ping"
 	var v map[string]interface{}
-	err := c.requestEndpoint(url, &v)
+	err := c.requestEndpoint(context.Background(), path, &v)
 	if err != nil {
 		return "", err
 	}
@@ -192,10 +301,10 @@ func (c *Client) ping() (string, error) {
 }
 
 // GetMimir fetch mimir values.
-func (c *Client) GetMimir() (map[string]string, error) {
-	url := fmt.Sprintf("%s/mimir", c.thorchainEndpoint)
+func (c *Client) GetMimir(ctx context.Context) (map[string]string, error) {
+	path := "/mimir"
 	values := make(map[string]string, 0)
-	err := c.requestEndpoint(url, &values)
+	err := c.requestEndpoint(ctx, path, &values)
 	if err != nil {
 		return nil, err
 	}
@@ -203,10 +312,10 @@ func (c *Client) GetMimir() (map[string]string, error) {
 }
 
 // GetPoolPrice fetch price values.
-func (c *Client) GetPools() ([]Pool, error) {
-	url := fmt.Sprintf("%s/pools", c.thorchainEndpoint)
+func (c *Client) GetPools(ctx context.Context) ([]Pool, error) {
+	path := "/pools"
 	var pools []Pool
-	err := c.requestEndpoint(url, &pools)
+	err := c.requestEndpoint(ctx, path, &pools)
 	if err != nil {
 		return nil, err
 	}
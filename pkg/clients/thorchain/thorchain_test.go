@@ -1,13 +1,17 @@
 package thorchain
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
 	"gitlab.com/thorchain/midgard/internal/config"
 	. "gopkg.in/check.v1"
 )
@@ -61,3 +65,261 @@ func (s *ClientSuite) TestCache(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(newT, Not(Equals), t)
 }
+
+// thornode is a local stand-in of a thorchain node serving the endpoints used
+// by health checks and pings which tell the node they were served by.
+type thornode struct {
+	name     string
+	height   int64
+	delay    time.Duration
+	failing  int32
+	failNext int32
+	pings    int32
+	hits     int32
+	server   *httptest.Server
+}
+
+func newThornode(name string, height int64, delay time.Duration) *thornode {
+	n := &thornode{
+		name:   name,
+		height: height,
+		delay:  delay,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/thorchain/lastblock", func(w http.ResponseWriter, r *http.Request) {
+		if !n.serve(w) {
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"thorchain": strconv.FormatInt(atomic.LoadInt64(&n.height), 10),
+		})
+	})
+	mux.HandleFunc("/thorchain/ping", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&n.pings, 1)
+		if !n.serve(w) {
+			return
+		}
+		if atomic.AddInt32(&n.failNext, -1) >= 0 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		atomic.AddInt32(&n.hits, 1)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"ping": "pong",
+			"node": n.name,
+		})
+	})
+	n.server = httptest.NewServer(mux)
+	return n
+}
+
+func (n *thornode) serve(w http.ResponseWriter) bool {
+	time.Sleep(n.delay)
+	if atomic.LoadInt32(&n.failing) == 1 {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return false
+	}
+	return true
+}
+
+func (n *thornode) host() string {
+	return strings.TrimPrefix(n.server.URL, "http://")
+}
+
+func (n *thornode) setFailing(failing bool) {
+	var v int32
+	if failing {
+		v = 1
+	}
+	atomic.StoreInt32(&n.failing, v)
+}
+
+func pingNode(c *C, client *Client) string {
+	var v map[string]interface{}
+	err := client.requestEndpoint(context.Background(), "/ping", &v)
+	c.Assert(err, IsNil)
+	return v["node"].(string)
+}
+
+func (s *ClientSuite) TestFailover(c *C) {
+	fast := newThornode("fast", 100, 0)
+	defer fast.server.Close()
+	slow := newThornode("slow", 100, time.Millisecond*50)
+	defer slow.server.Close()
+
+	client, err := NewClient(config.ThorChainConfiguration{
+		Scheme:              "http",
+		Hosts:               []string{slow.host(), fast.host()},
+		CacheTTL:            time.Nanosecond,
+		HealthCheckInterval: time.Hour,
+		MaxHeightLag:        10,
+	})
+	c.Assert(err, IsNil)
+
+	// The fastest node is preferred.
+	c.Assert(pingNode(c, client), Equals, "fast")
+	c.Assert(pingNode(c, client), Equals, "fast")
+
+	// Requests fail over to the other node without returning an error.
+	fast.setFailing(true)
+	c.Assert(pingNode(c, client), Equals, "slow")
+	c.Assert(pingNode(c, client), Equals, "slow")
+	c.Assert(atomic.LoadInt32(&fast.hits), Equals, int32(2))
+
+	// The node recovered is used again after the next health check.
+	fast.setFailing(false)
+	client.endpoints.check()
+	c.Assert(pingNode(c, client), Equals, "fast")
+
+	// All nodes are down.
+	fast.setFailing(true)
+	slow.setFailing(true)
+	var v map[string]interface{}
+	err = client.requestEndpoint(context.Background(), "/ping", &v)
+	c.Assert(err, NotNil)
+}
+
+func (s *ClientSuite) TestLaggingEndpoint(c *C) {
+	synced := newThornode("synced", 100, time.Millisecond*50)
+	defer synced.server.Close()
+	lagging := newThornode("lagging", 50, 0)
+	defer lagging.server.Close()
+
+	client, err := NewClient(config.ThorChainConfiguration{
+		Scheme:              "http",
+		Host:                lagging.host(),
+		Hosts:               []string{synced.host()},
+		CacheTTL:            time.Nanosecond,
+		HealthCheckInterval: time.Hour,
+		MaxHeightLag:        10,
+	})
+	c.Assert(err, IsNil)
+
+	// The lagging node is refused even though it's faster.
+	c.Assert(pingNode(c, client), Equals, "synced")
+	c.Assert(atomic.LoadInt32(&lagging.hits), Equals, int32(0))
+
+	// It's used again once it caught up.
+	atomic.StoreInt64(&lagging.height, 95)
+	client.endpoints.check()
+	c.Assert(pingNode(c, client), Equals, "lagging")
+}
+
+func (s *ClientSuite) TestRetry(c *C) {
+	node := newThornode("node", 100, 0)
+	defer node.server.Close()
+
+	client, err := NewClient(config.ThorChainConfiguration{
+		Scheme:              "http",
+		Host:                node.host(),
+		CacheTTL:            time.Nanosecond,
+		HealthCheckInterval: time.Hour,
+		MaxRetries:          2,
+		RetryBackoff:        time.Millisecond,
+		RetryMaxBackoff:     time.Millisecond * 10,
+		BreakerThreshold:    10,
+	})
+	c.Assert(err, IsNil)
+
+	// Transient errors are retried.
+	atomic.StoreInt32(&node.failNext, 2)
+	c.Assert(pingNode(c, client), Equals, "node")
+	c.Assert(atomic.LoadInt32(&node.pings), Equals, int32(3))
+
+	// Until the retries are exhausted.
+	atomic.StoreInt32(&node.failNext, 3)
+	var v map[string]interface{}
+	err = client.requestEndpoint(context.Background(), "/ping", &v)
+	statusErr, ok := errors.Cause(err).(*StatusError)
+	c.Assert(ok, Equals, true)
+	c.Assert(statusErr.StatusCode, Equals, http.StatusBadGateway)
+	c.Assert(atomic.LoadInt32(&node.pings), Equals, int32(6))
+
+	// Client errors are not.
+	err = client.requestEndpoint(context.Background(), "/missing", &v)
+	statusErr, ok = errors.Cause(err).(*StatusError)
+	c.Assert(ok, Equals, true)
+	c.Assert(statusErr.StatusCode, Equals, http.StatusNotFound)
+	c.Assert(statusErr.Temporary(), Equals, false)
+
+	// Waiting for a retry stops when the context is cancelled.
+	client.retrier.backoff = time.Hour
+	client.retrier.maxBackoff = time.Hour
+	atomic.StoreInt32(&node.failNext, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	err = client.requestEndpoint(ctx, "/ping", &v)
+	c.Assert(errors.Cause(err), Equals, context.DeadlineExceeded)
+}
+
+func (s *ClientSuite) TestCircuitBreaker(c *C) {
+	node := newThornode("node", 100, 0)
+	defer node.server.Close()
+
+	client, err := NewClient(config.ThorChainConfiguration{
+		Scheme:              "http",
+		Host:                node.host(),
+		CacheTTL:            time.Nanosecond,
+		HealthCheckInterval: time.Hour,
+		BreakerThreshold:    2,
+		BreakerCooldown:     time.Millisecond * 100,
+	})
+	c.Assert(err, IsNil)
+
+	atomic.StoreInt32(&node.failNext, 2)
+	var v map[string]interface{}
+	for i := 0; i < 2; i++ {
+		err = client.requestEndpoint(context.Background(), "/ping", &v)
+		c.Assert(err, NotNil)
+	}
+
+	// The breaker is open so the node isn't called.
+	err = client.requestEndpoint(context.Background(), "/ping", &v)
+	c.Assert(err, Equals, ErrCircuitOpen)
+	c.Assert(atomic.LoadInt32(&node.pings), Equals, int32(2))
+
+	// A trial request is let through after the cooldown and closes the breaker.
+	time.Sleep(time.Millisecond * 100)
+	c.Assert(pingNode(c, client), Equals, "node")
+	c.Assert(pingNode(c, client), Equals, "node")
+}
+
+func (s *ClientSuite) TestProxy(c *C) {
+	fast := newThornode("fast", 100, 0)
+	defer fast.server.Close()
+	slow := newThornode("slow", 100, time.Millisecond*50)
+	defer slow.server.Close()
+
+	client, err := NewClient(config.ThorChainConfiguration{
+		Scheme:              "http",
+		Hosts:               []string{slow.host(), fast.host()},
+		HealthCheckInterval: time.Hour,
+		MaxHeightLag:        10,
+	})
+	c.Assert(err, IsNil)
+	proxy := func() (int, map[string]interface{}) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/thorchain/ping", nil)
+		rec := httptest.NewRecorder()
+		err := client.Proxy(rec, req, "/ping")
+		c.Assert(err, IsNil)
+		var v map[string]interface{}
+		json.NewDecoder(rec.Body).Decode(&v)
+		return rec.Code, v
+	}
+
+	code, v := proxy()
+	c.Assert(code, Equals, http.StatusOK)
+	c.Assert(v["node"], Equals, "fast")
+
+	// Requests fail over to the other node.
+	fast.setFailing(true)
+	code, v = proxy()
+	c.Assert(code, Equals, http.StatusOK)
+	c.Assert(v["node"], Equals, "slow")
+
+	// All nodes are down.
+	slow.setFailing(true)
+	req := httptest.NewRequest(http.MethodGet, "/v1/thorchain/ping", nil)
+	err = client.Proxy(httptest.NewRecorder(), req, "/ping")
+	c.Assert(err, NotNil)
+}
@@ -2,6 +2,13 @@ package thorchain
 
 import "gitlab.com/thorchain/midgard/internal/common"
 
+// ObservedTxDone is the status of an observed tx whose outbounds are all sent.
+const ObservedTxDone = "done"
+
+// ObservedTx is an inbound tx observed by thorchain. Once its status is done,
+// OutHashes lists the outbound txs sent for it.
 type ObservedTx struct {
-	Tx common.Tx `json:"tx"`
+	Tx        common.Tx `json:"tx"`
+	Status    string    `json:"status"`
+	OutHashes []string  `json:"out_hashes"`
 }
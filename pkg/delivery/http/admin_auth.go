@@ -0,0 +1,38 @@
+package http
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"gitlab.com/thorchain/midgard/internal/config"
+)
+
+// AdminAuth returns a middleware restricting the admin routes to the requests
+// carrying the admin key as a bearer token. The admin routes are refused to
+// everyone if no key is configured.
+func AdminAuth(cfg config.AdminConfiguration) echo.MiddlewareFunc {
+	routes := map[string]bool{}
+	for _, r := range cfg.Routes {
+		routes[r] = true
+	}
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !routes[c.Path()] {
+				return next(c)
+			}
+			if cfg.Key == "" {
+				return echo.NewHTTPError(http.StatusForbidden, GeneralErrorResponse{Error: "admin routes are disabled"})
+			}
+			auth := c.Request().Header.Get(echo.HeaderAuthorization)
+			token := strings.TrimPrefix(auth, "Bearer ")
+			if token == auth || subtle.ConstantTimeCompare([]byte(token), []byte(cfg.Key)) != 1 {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+				return echo.NewHTTPError(http.StatusUnauthorized, GeneralErrorResponse{Error: "invalid admin key"})
+			}
+			return next(c)
+		}
+	}
+}
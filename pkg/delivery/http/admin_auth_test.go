@@ -0,0 +1,58 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/labstack/echo/v4"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/midgard/internal/config"
+)
+
+type AdminAuthSuite struct{}
+
+var _ = Suite(&AdminAuthSuite{})
+
+func (s *AdminAuthSuite) TestAdminAuth(c *C) {
+	newServer := func(key string) *httptest.Server {
+		e := echo.New()
+		e.Use(AdminAuth(config.AdminConfiguration{
+			Key:    key,
+			Routes: []string{"/admin/:id"},
+		}))
+		h := func(ctx echo.Context) error {
+			return ctx.NoContent(http.StatusOK)
+		}
+		e.POST("/admin/:id", h)
+		e.GET("/public", h)
+		return httptest.NewServer(e)
+	}
+	do := func(url, auth string) int {
+		req, err := http.NewRequest(http.MethodPost, url, nil)
+		c.Assert(err, IsNil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		c.Assert(err, IsNil)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	server := newServer("secret")
+	defer server.Close()
+	c.Assert(do(server.URL+"/admin/1", "Bearer secret"), Equals, http.StatusOK)
+	c.Assert(do(server.URL+"/admin/1", ""), Equals, http.StatusUnauthorized)
+	c.Assert(do(server.URL+"/admin/1", "secret"), Equals, http.StatusUnauthorized)
+	c.Assert(do(server.URL+"/admin/1", "Bearer other"), Equals, http.StatusUnauthorized)
+	resp, err := http.Get(server.URL + "/public")
+	c.Assert(err, IsNil)
+	resp.Body.Close()
+	c.Assert(resp.StatusCode, Equals, http.StatusOK)
+
+	// Admin routes are disabled without key.
+	disabled := newServer("")
+	defer disabled.Close()
+	c.Assert(do(disabled.URL+"/admin/1", "Bearer "), Equals, http.StatusForbidden)
+}
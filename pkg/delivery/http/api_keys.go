@@ -0,0 +1,412 @@
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+
+	"gitlab.com/thorchain/midgard/internal/config"
+	"gitlab.com/thorchain/midgard/internal/models"
+)
+
+// quotaPeriod is the period of the daily quotas, which reset at midnight UTC.
+const quotaPeriod = time.Hour * 24
+
+// APIKeyStore loads the api keys and accounts their usage.
+type APIKeyStore interface {
+	GetAPIKeys(ctx context.Context) ([]models.APIKey, error)
+	GetAPIKeysUsage(ctx context.Context, day time.Time) ([]models.APIKeyUsage, error)
+	AddAPIKeysUsage(usage []models.APIKeyUsage) error
+}
+
+// apiClient is the state of the limits of an api key or of an anonymous ip.
+type apiClient struct {
+	// keyHash is the hash of the api key, empty for an anonymous ip.
+	keyHash string
+	tier    config.APITier
+	// limitKey is the key of the rate limit and quota counters in the limiter
+	// store.
+	limitKey string
+	day      time.Time
+	// unsaved is the number of requests of the day not saved in the store yet.
+	unsaved int64
+}
+
+// rateDecision is the outcome of checking the limits of a request.
+type rateDecision struct {
+	quota      int64
+	remaining  int64
+	reset      time.Time
+	retryAfter time.Duration
+	// refusal is the reason the request is refused, empty if it's allowed.
+	refusal string
+}
+
+// APIKeyLimiter limits the requests of each api key according to its tier.
+// Requests without api key are limited per ip with the anonymous tier. The
+// rate limits and the daily quotas are counted in the limiter store. The
+// number of requests of each key per day is saved in the store, and seeds the
+// quota counters on start.
+type APIKeyLimiter struct {
+	mu     sync.Mutex
+	cfg    config.APIKeysConfiguration
+	store  APIKeyStore
+	limits LimiterStore
+	// keys are the api keys by hash.
+	keys    map[string]models.APIKey
+	clients map[string]*apiClient
+	exempt  map[string]bool
+	// pending is the usage of past days not saved in the store yet.
+	pending []models.APIKeyUsage
+	now     func() time.Time
+	quit    chan struct{}
+	done    chan struct{}
+	logger  zerolog.Logger
+}
+
+// NewAPIKeyLimiter creates a new instance of APIKeyLimiter. The keys of the
+// store are loaded along with their usage of the day. store can be nil to only
+// use the keys of the configuration.
+func NewAPIKeyLimiter(cfg config.APIKeysConfiguration, store APIKeyStore, limits LimiterStore, logger zerolog.Logger) (*APIKeyLimiter, error) {
+	if cfg.AnonymousTier != "" {
+		if _, ok := cfg.Tiers[cfg.AnonymousTier]; !ok {
+			return nil, errors.Errorf("anonymous tier %s is not defined", cfg.AnonymousTier)
+		}
+	}
+	for _, key := range cfg.Keys {
+		if _, ok := cfg.Tiers[key.Tier]; !ok {
+			return nil, errors.Errorf("tier %s of api key %s is not defined", key.Tier, key.Name)
+		}
+	}
+
+	l := &APIKeyLimiter{
+		cfg:     cfg,
+		store:   store,
+		limits:  limits,
+		clients: map[string]*apiClient{},
+		exempt:  make(map[string]bool, len(cfg.ExemptRoutes)),
+		now:     time.Now,
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+		logger:  logger,
+	}
+	for _, route := range cfg.ExemptRoutes {
+		l.exempt[route] = true
+	}
+	err := l.reloadKeys()
+	if err != nil {
+		return nil, err
+	}
+	if store == nil {
+		close(l.done)
+		return l, nil
+	}
+	if cfg.ReloadInterval <= 0 || cfg.UsageFlushInterval <= 0 {
+		return nil, errors.New("api keys reload and usage flush intervals must be positive")
+	}
+
+	today := l.now().UTC().Truncate(quotaPeriod)
+	usage, err := store.GetAPIKeysUsage(context.Background(), today)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load api keys usage")
+	}
+	for _, u := range usage {
+		// The counters already in the limiter store are more recent.
+		err = limits.Seed(context.Background(), quotaKey(keyLimitKey(u.KeyHash), today), u.Requests, today.Add(quotaPeriod))
+		if err != nil {
+			l.logger.Err(err).Msg("failed to seed api key quota")
+		}
+	}
+	go l.run()
+	return l, nil
+}
+
+// Middleware returns the middleware applying the limits to the requests.
+func (l *APIKeyLimiter) Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if l.exempt[c.Path()] {
+			return next(c)
+		}
+
+		now := l.now()
+		var client *apiClient
+		key := c.Request().Header.Get(l.cfg.Header)
+		l.mu.Lock()
+		if key != "" {
+			client = l.keyClient(hashAPIKey(key), now)
+			if client == nil {
+				l.mu.Unlock()
+				return echo.NewHTTPError(http.StatusUnauthorized, GeneralErrorResponse{Error: "invalid api key"})
+			}
+		} else {
+			client = l.anonymousClient(clientIP(c), now)
+			if client == nil {
+				l.mu.Unlock()
+				return echo.NewHTTPError(http.StatusUnauthorized, GeneralErrorResponse{Error: "api key required"})
+			}
+		}
+		tier := client.tier
+		l.mu.Unlock()
+
+		ctx := c.Request().Context()
+		decision := l.reserve(ctx, client.limitKey, tier, now)
+		if decision.refusal == "" {
+			limit, window := tokenBucket(tier.RateLimit, tier.BurstLimit)
+			result, err := l.limits.Allow(ctx, client.limitKey, limit, window, now)
+			if err != nil {
+				// Requests are allowed while the limiter store is unavailable.
+				l.logger.Err(err).Msg("failed to check rate limit")
+			} else if !result.Allowed {
+				if decision.quota > 0 {
+					decision.remaining = l.release(ctx, client.limitKey, decision)
+				}
+				decision.refusal = "rate limit exceeded"
+				decision.retryAfter = result.RetryAfter
+			}
+		}
+		if decision.refusal == "" {
+			l.mu.Lock()
+			l.count(client, now)
+			l.mu.Unlock()
+		}
+
+		header := c.Response().Header()
+		if decision.quota > 0 {
+			header.Set("X-RateLimit-Limit", strconv.FormatInt(decision.quota, 10))
+			header.Set("X-RateLimit-Remaining", strconv.FormatInt(decision.remaining, 10))
+			header.Set("X-RateLimit-Reset", strconv.FormatInt(decision.reset.Unix(), 10))
+		}
+		if decision.refusal != "" {
+			setRetryAfter(c, decision.retryAfter)
+			return echo.NewHTTPError(http.StatusTooManyRequests, GeneralErrorResponse{Error: decision.refusal})
+		}
+		return next(c)
+	}
+}
+
+// Stop stops reloading the keys and saves the usage not saved yet.
+func (l *APIKeyLimiter) Stop() {
+	select {
+	case <-l.quit:
+	default:
+		close(l.quit)
+	}
+	<-l.done
+}
+
+// hashAPIKey returns the hex encoded SHA-256 hash of key. Only the hashes of
+// the keys are kept, so that they aren't readable in the stores.
+func hashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// keyLimitKey returns the key of the counters of the api key of the given hash
+// in the limiter store.
+func keyLimitKey(keyHash string) string {
+	return "api:key:" + keyHash
+}
+
+// quotaKey returns the key of the quota counter of the given day in the
+// limiter store.
+func quotaKey(limitKey string, day time.Time) string {
+	return "quota:" + limitKey + ":" + day.Format("2006-01-02")
+}
+
+// keyClient returns the client of the api key of the given hash, nil if the
+// key is unknown. l.mu must be held.
+func (l *APIKeyLimiter) keyClient(keyHash string, now time.Time) *apiClient {
+	apiKey, ok := l.keys[keyHash]
+	if !ok {
+		return nil
+	}
+	return l.client(keyLimitKey(keyHash), keyHash, l.cfg.Tiers[apiKey.Tier], now)
+}
+
+// anonymousClient returns the client of requests without api key from ip, nil
+// if such requests aren't allowed. l.mu must be held.
+func (l *APIKeyLimiter) anonymousClient(ip string, now time.Time) *apiClient {
+	if l.cfg.AnonymousTier == "" {
+		return nil
+	}
+	return l.client("api:ip:"+ip, "", l.cfg.Tiers[l.cfg.AnonymousTier], now)
+}
+
+// client returns the client with the given id, which is also the key of its
+// rate limit counters. The limits follow the changes of the tier of a key.
+// l.mu must be held.
+func (l *APIKeyLimiter) client(id, keyHash string, tier config.APITier, now time.Time) *apiClient {
+	c, ok := l.clients[id]
+	if !ok {
+		c = &apiClient{
+			keyHash:  keyHash,
+			limitKey: id,
+			day:      now.UTC().Truncate(quotaPeriod),
+		}
+		l.clients[id] = c
+	}
+	c.tier = tier
+	return c
+}
+
+// reserve checks the daily quota of the client of limitKey and counts the
+// request if it's within it. Requests are allowed while the limiter store is
+// unavailable.
+func (l *APIKeyLimiter) reserve(ctx context.Context, limitKey string, tier config.APITier, now time.Time) rateDecision {
+	today := now.UTC().Truncate(quotaPeriod)
+	d := rateDecision{
+		quota: tier.DailyQuota,
+		reset: today.Add(quotaPeriod),
+	}
+	if d.quota <= 0 {
+		return d
+	}
+
+	key := quotaKey(limitKey, today)
+	used, err := l.limits.Add(ctx, key, 1, d.reset)
+	if err != nil {
+		l.logger.Err(err).Msg("failed to count daily quota")
+		d.quota = 0
+		return d
+	}
+	if used > d.quota {
+		l.uncount(ctx, key, d.reset)
+		d.refusal = "daily quota exceeded"
+		d.retryAfter = d.reset.Sub(now)
+		return d
+	}
+	d.remaining = d.quota - used
+	return d
+}
+
+// release uncounts a request reserved in the quota of the client of limitKey
+// and returns the remaining quota.
+func (l *APIKeyLimiter) release(ctx context.Context, limitKey string, d rateDecision) int64 {
+	used := l.uncount(ctx, quotaKey(limitKey, d.reset.Add(-quotaPeriod)), d.reset)
+	return d.quota - used
+}
+
+// uncount removes a request from the quota counter of key and returns its new
+// value. If this fails, the counter is off by one until it expires.
+func (l *APIKeyLimiter) uncount(ctx context.Context, key string, reset time.Time) int64 {
+	used, err := l.limits.Add(ctx, key, -1, reset)
+	if err != nil {
+		l.logger.Err(err).Msg("failed to uncount daily quota")
+	}
+	return used
+}
+
+// count accounts an allowed request to the usage of the client. l.mu must be
+// held.
+func (l *APIKeyLimiter) count(c *apiClient, now time.Time) {
+	today := now.UTC().Truncate(quotaPeriod)
+	if today.After(c.day) {
+		if c.unsaved > 0 {
+			l.pending = append(l.pending, models.APIKeyUsage{KeyHash: c.keyHash, Day: c.day, Requests: c.unsaved})
+		}
+		c.day = today
+		c.unsaved = 0
+	}
+	if c.keyHash != "" {
+		c.unsaved++
+	}
+}
+
+// reloadKeys loads the keys of the configuration and of the store.
+func (l *APIKeyLimiter) reloadKeys() error {
+	keys := make(map[string]models.APIKey, len(l.cfg.Keys))
+	for _, key := range l.cfg.Keys {
+		hash := hashAPIKey(key.Key)
+		keys[hash] = models.APIKey{
+			KeyHash: hash,
+			Name:    key.Name,
+			Tier:    key.Tier,
+		}
+	}
+	if l.store != nil {
+		stored, err := l.store.GetAPIKeys(context.Background())
+		if err != nil {
+			return errors.Wrap(err, "could not load api keys")
+		}
+		for _, key := range stored {
+			if _, ok := l.cfg.Tiers[key.Tier]; !ok {
+				l.logger.Warn().Str("name", key.Name).Str("tier", key.Tier).Msg("api key of undefined tier ignored")
+				continue
+			}
+			keys[key.KeyHash] = key
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.keys = keys
+	for id, c := range l.clients {
+		if c.keyHash != "" {
+			if _, ok := keys[c.keyHash]; !ok && c.unsaved == 0 {
+				delete(l.clients, id)
+			}
+		}
+	}
+	return nil
+}
+
+// saveUsage saves the usage of the keys not saved yet and forgets the
+// anonymous clients of past days.
+func (l *APIKeyLimiter) saveUsage() {
+	l.mu.Lock()
+	usage := l.pending
+	l.pending = nil
+	today := l.now().UTC().Truncate(quotaPeriod)
+	for id, c := range l.clients {
+		if c.unsaved > 0 {
+			usage = append(usage, models.APIKeyUsage{KeyHash: c.keyHash, Day: c.day, Requests: c.unsaved})
+			c.unsaved = 0
+		}
+		if c.keyHash == "" && c.day.Before(today) {
+			delete(l.clients, id)
+		}
+	}
+	l.mu.Unlock()
+	if len(usage) == 0 {
+		return
+	}
+
+	err := l.store.AddAPIKeysUsage(usage)
+	if err != nil {
+		l.logger.Err(err).Msg("failed to save api keys usage")
+		l.mu.Lock()
+		l.pending = append(l.pending, usage...)
+		l.mu.Unlock()
+	}
+}
+
+func (l *APIKeyLimiter) run() {
+	defer close(l.done)
+
+	reload := time.NewTicker(l.cfg.ReloadInterval)
+	defer reload.Stop()
+	flush := time.NewTicker(l.cfg.UsageFlushInterval)
+	defer flush.Stop()
+	for {
+		select {
+		case <-l.quit:
+			l.saveUsage()
+			return
+		case <-reload.C:
+			err := l.reloadKeys()
+			if err != nil {
+				l.logger.Err(err).Msg("failed to reload api keys")
+			}
+		case <-flush.C:
+			l.saveUsage()
+		}
+	}
+}
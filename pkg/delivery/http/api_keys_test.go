@@ -0,0 +1,210 @@
+package http
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/midgard/internal/config"
+	"gitlab.com/thorchain/midgard/internal/models"
+)
+
+type APIKeysSuite struct{}
+
+var _ = Suite(&APIKeysSuite{})
+
+// apiKeyStoreFake keeps the api keys and their usage in memory.
+type apiKeyStoreFake struct {
+	mu    sync.Mutex
+	keys  []models.APIKey
+	usage map[string]int64
+}
+
+func (s *apiKeyStoreFake) GetAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.keys, nil
+}
+
+func (s *apiKeyStoreFake) GetAPIKeysUsage(ctx context.Context, day time.Time) ([]models.APIKeyUsage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var usage []models.APIKeyUsage
+	for key, requests := range s.usage {
+		usage = append(usage, models.APIKeyUsage{KeyHash: key, Day: day, Requests: requests})
+	}
+	return usage, nil
+}
+
+func (s *apiKeyStoreFake) AddAPIKeysUsage(usage []models.APIKeyUsage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, u := range usage {
+		s.usage[u.KeyHash] += u.Requests
+	}
+	return nil
+}
+
+func (s *apiKeyStoreFake) requests(key string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.usage[hashAPIKey(key)]
+}
+
+var apiKeysConfig = config.APIKeysConfiguration{
+	Header: "X-API-Key",
+	Tiers: map[string]config.APITier{
+		"anonymous": {RateLimit: 1, BurstLimit: 2, DailyQuota: 3},
+		"standard":  {RateLimit: 100, BurstLimit: 100, DailyQuota: 5},
+		"unlimited": {RateLimit: 100, BurstLimit: 100},
+	},
+	AnonymousTier: "anonymous",
+	Keys: []config.APIKey{
+		{Key: "config-key", Name: "config", Tier: "unlimited"},
+	},
+	ExemptRoutes:       []string{"/health"},
+	ReloadInterval:     time.Hour,
+	UsageFlushInterval: time.Hour,
+}
+
+func newAPIKeysServer(c *C, limiter *APIKeyLimiter) *httptest.Server {
+	e := echo.New()
+	e.Use(limiter.Middleware)
+	h := func(ctx echo.Context) error {
+		return ctx.NoContent(http.StatusOK)
+	}
+	e.GET("/pools", h)
+	e.GET("/health", h)
+	return httptest.NewServer(e)
+}
+
+func getWithKey(c *C, url, key string) *http.Response {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	c.Assert(err, IsNil)
+	if key != "" {
+		req.Header.Set("X-API-Key", key)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	c.Assert(err, IsNil)
+	resp.Body.Close()
+	return resp
+}
+
+func (s *APIKeysSuite) TestLimits(c *C) {
+	store := &apiKeyStoreFake{
+		keys:  []models.APIKey{{KeyHash: hashAPIKey("db-key"), Name: "db", Tier: "standard"}},
+		usage: map[string]int64{hashAPIKey("db-key"): 2},
+	}
+	limiter, err := NewAPIKeyLimiter(apiKeysConfig, store, NewMemoryLimiterStore(), log.Logger)
+	c.Assert(err, IsNil)
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+	reset := now.UTC().Truncate(quotaPeriod).Add(quotaPeriod)
+	server := newAPIKeysServer(c, limiter)
+	defer server.Close()
+
+	// The usage of the day is loaded from the store.
+	for i := 0; i < 3; i++ {
+		resp := getWithKey(c, server.URL+"/pools", "db-key")
+		c.Assert(resp.StatusCode, Equals, http.StatusOK)
+		c.Assert(resp.Header.Get("X-RateLimit-Limit"), Equals, "5")
+		c.Assert(resp.Header.Get("X-RateLimit-Remaining"), Equals, []string{"2", "1", "0"}[i])
+		c.Assert(resp.Header.Get("X-RateLimit-Reset"), Equals, strconv.FormatInt(reset.Unix(), 10))
+	}
+	resp := getWithKey(c, server.URL+"/pools", "db-key")
+	c.Assert(resp.StatusCode, Equals, http.StatusTooManyRequests)
+	retryAfter := int64(math.Ceil(reset.Sub(now).Seconds()))
+	c.Assert(resp.Header.Get("Retry-After"), Equals, strconv.FormatInt(retryAfter, 10))
+
+	// Anonymous requests are limited per ip by the anonymous tier.
+	for i := 0; i < 2; i++ {
+		resp = getWithKey(c, server.URL+"/pools", "")
+		c.Assert(resp.StatusCode, Equals, http.StatusOK)
+	}
+	resp = getWithKey(c, server.URL+"/pools", "")
+	c.Assert(resp.StatusCode, Equals, http.StatusTooManyRequests)
+	// The window of the anonymous tier is 2s, and the requests are made at the
+	// same time so they weigh on the next window for up to 1s.
+	retryAfter, err = strconv.ParseInt(resp.Header.Get("Retry-After"), 10, 64)
+	c.Assert(err, IsNil)
+	c.Assert(retryAfter >= 1 && retryAfter <= 3, Equals, true)
+	c.Assert(resp.Header.Get("X-RateLimit-Remaining"), Equals, "1")
+
+	// Keys of the configuration have no quota.
+	for i := 0; i < 10; i++ {
+		resp = getWithKey(c, server.URL+"/pools", "config-key")
+		c.Assert(resp.StatusCode, Equals, http.StatusOK)
+		c.Assert(resp.Header.Get("X-RateLimit-Limit"), Equals, "")
+	}
+
+	resp = getWithKey(c, server.URL+"/pools", "unknown-key")
+	c.Assert(resp.StatusCode, Equals, http.StatusUnauthorized)
+	resp = getWithKey(c, server.URL+"/health", "unknown-key")
+	c.Assert(resp.StatusCode, Equals, http.StatusOK)
+
+	// The quotas are reset the next day.
+	now = reset.Add(time.Hour)
+	resp = getWithKey(c, server.URL+"/pools", "db-key")
+	c.Assert(resp.StatusCode, Equals, http.StatusOK)
+	c.Assert(resp.Header.Get("X-RateLimit-Remaining"), Equals, "4")
+
+	// The usage is saved on stop.
+	limiter.Stop()
+	c.Assert(store.requests("db-key"), Equals, int64(2+3+1))
+	c.Assert(store.requests("config-key"), Equals, int64(10))
+}
+
+func (s *APIKeysSuite) TestSharedQuota(c *C) {
+	store := &apiKeyStoreFake{
+		keys:  []models.APIKey{{KeyHash: hashAPIKey("db-key"), Name: "db", Tier: "standard"}},
+		usage: map[string]int64{hashAPIKey("db-key"): 2},
+	}
+	// The replicas share the limiter store, and the usage of the store only
+	// seeds the quota counters once.
+	limits := NewMemoryLimiterStore()
+	var servers []*httptest.Server
+	for i := 0; i < 2; i++ {
+		limiter, err := NewAPIKeyLimiter(apiKeysConfig, store, limits, log.Logger)
+		c.Assert(err, IsNil)
+		defer limiter.Stop()
+		server := newAPIKeysServer(c, limiter)
+		defer server.Close()
+		servers = append(servers, server)
+	}
+
+	for i := 0; i < 3; i++ {
+		resp := getWithKey(c, servers[i%2].URL+"/pools", "db-key")
+		c.Assert(resp.StatusCode, Equals, http.StatusOK)
+		c.Assert(resp.Header.Get("X-RateLimit-Remaining"), Equals, []string{"2", "1", "0"}[i])
+	}
+	for _, server := range servers {
+		resp := getWithKey(c, server.URL+"/pools", "db-key")
+		c.Assert(resp.StatusCode, Equals, http.StatusTooManyRequests)
+	}
+}
+
+func (s *APIKeysSuite) TestAnonymousRefused(c *C) {
+	cfg := apiKeysConfig
+	cfg.AnonymousTier = ""
+	limiter, err := NewAPIKeyLimiter(cfg, nil, NewMemoryLimiterStore(), log.Logger)
+	c.Assert(err, IsNil)
+	server := newAPIKeysServer(c, limiter)
+	defer server.Close()
+
+	resp := getWithKey(c, server.URL+"/pools", "")
+	c.Assert(resp.StatusCode, Equals, http.StatusUnauthorized)
+	resp = getWithKey(c, server.URL+"/pools", "config-key")
+	c.Assert(resp.StatusCode, Equals, http.StatusOK)
+
+	cfg.Keys = []config.APIKey{{Key: "key", Name: "name", Tier: "premium"}}
+	_, err = NewAPIKeyLimiter(cfg, nil, NewMemoryLimiterStore(), log.Logger)
+	c.Assert(err, NotNil)
+}
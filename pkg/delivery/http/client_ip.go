@@ -0,0 +1,99 @@
+package http
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+const clientIPKey = "client_ip"
+
+// ClientIP returns a middleware resolving the ip of the clients, which limits
+// and sticky routing are applied to. The X-Forwarded-For and X-Real-IP headers
+// are only read from the trusted proxies, given as ips or CIDR ranges, so other
+// clients can't choose their ip.
+func ClientIP(trustedProxies []string) (echo.MiddlewareFunc, error) {
+	var trusted []*net.IPNet
+	for _, p := range trustedProxies {
+		if !strings.Contains(p, "/") {
+			ip := net.ParseIP(p)
+			if ip == nil {
+				return nil, errors.Errorf("invalid trusted proxy %s", p)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			trusted = append(trusted, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid trusted proxy %s", p)
+		}
+		trusted = append(trusted, ipNet)
+	}
+	isTrusted := func(s string) bool {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return false
+		}
+		for _, ipNet := range trusted {
+			if ipNet.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ip := remoteIP(c.Request())
+			if isTrusted(ip) {
+				ip = forwardedIP(c.Request(), ip, isTrusted)
+			}
+			c.Set(clientIPKey, ip)
+			return next(c)
+		}
+	}, nil
+}
+
+// forwardedIP returns the ip of the client of a request sent by a trusted
+// proxy. It's the last ip of X-Forwarded-For which isn't a trusted proxy,
+// since the ones before were added by the client.
+func forwardedIP(req *http.Request, ip string, isTrusted func(string) bool) string {
+	if xff := req.Header[echo.HeaderXForwardedFor]; len(xff) > 0 {
+		ips := strings.Split(strings.Join(xff, ","), ",")
+		for i := len(ips) - 1; i >= 0; i-- {
+			ip = strings.TrimSpace(ips[i])
+			if !isTrusted(ip) {
+				break
+			}
+		}
+		return ip
+	}
+	if realIP := req.Header.Get(echo.HeaderXRealIP); realIP != "" {
+		return realIP
+	}
+	return ip
+}
+
+// clientIP returns the ip of the client resolved by the ClientIP middleware,
+// or the remote address of the request without it.
+func clientIP(c echo.Context) string {
+	if ip, ok := c.Get(clientIPKey).(string); ok {
+		return ip
+	}
+	return remoteIP(c.Request())
+}
+
+func remoteIP(req *http.Request) string {
+	ip, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return ip
+}
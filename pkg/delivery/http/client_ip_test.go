@@ -0,0 +1,50 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/labstack/echo/v4"
+	. "gopkg.in/check.v1"
+)
+
+type ClientIPSuite struct{}
+
+var _ = Suite(&ClientIPSuite{})
+
+func (s *ClientIPSuite) TestClientIP(c *C) {
+	mw, err := ClientIP([]string{"10.0.0.0/8", "192.168.1.1"})
+	c.Assert(err, IsNil)
+	e := echo.New()
+	e.Use(mw)
+	e.GET("/ip", func(c echo.Context) error {
+		return c.String(http.StatusOK, clientIP(c))
+	})
+	get := func(remoteAddr string, headers map[string]string) string {
+		req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+		req.RemoteAddr = remoteAddr
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Body.String()
+	}
+
+	// The headers of other clients are ignored.
+	c.Assert(get("1.2.3.4:1000", nil), Equals, "1.2.3.4")
+	c.Assert(get("1.2.3.4:1000", map[string]string{"X-Forwarded-For": "5.6.7.8"}), Equals, "1.2.3.4")
+	c.Assert(get("1.2.3.4:1000", map[string]string{"X-Real-IP": "5.6.7.8"}), Equals, "1.2.3.4")
+
+	// The ips added by the clients before the trusted proxies are ignored.
+	c.Assert(get("10.1.2.3:1000", map[string]string{"X-Forwarded-For": "5.6.7.8"}), Equals, "5.6.7.8")
+	c.Assert(get("10.1.2.3:1000", map[string]string{"X-Forwarded-For": "9.9.9.9, 5.6.7.8, 192.168.1.1"}), Equals, "5.6.7.8")
+	c.Assert(get("192.168.1.1:1000", map[string]string{"X-Real-IP": "5.6.7.8"}), Equals, "5.6.7.8")
+	c.Assert(get("192.168.1.2:1000", map[string]string{"X-Real-IP": "5.6.7.8"}), Equals, "192.168.1.2")
+	c.Assert(get("10.1.2.3:1000", nil), Equals, "10.1.2.3")
+
+	_, err = ClientIP([]string{"10.0.0.0/33"})
+	c.Assert(err, NotNil)
+	_, err = ClientIP([]string{"proxy"})
+	c.Assert(err, NotNil)
+}
@@ -41,9 +41,9 @@ func (h *Handlers) GetThorchainProxiedQueue(ctx echo.Context) error {
 }
 
 func (h *Handlers) GetNodes(ctx echo.Context) error {
-	nodes, err := h.thorChainClient.GetNodeAccounts()
+	nodes, err := h.thorChainClient.GetNodeAccounts(ctx.Request().Context())
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, GeneralErrorResponse{Error: err.Error()})
+		return errorResponse(err)
 	}
 	response := make([]thorchain.PubKeySet, 0)
 	for _, node := range nodes {
@@ -74,7 +74,7 @@ func (h *Handlers) GetSwagger(ctx echo.Context) error {
 
 // (GET /v1/health)
 func (h *Handlers) GetHealth(ctx echo.Context) error {
-	health := h.uc.GetHealth()
+	health := h.uc.GetHealth(ctx.Request().Context())
 	return ctx.JSON(http.StatusOK, health)
 }
 
@@ -97,23 +97,80 @@ func (h *Handlers) GetTxDetails(ctx echo.Context, params GetTxDetailsParams) err
 		eventTypes = strings.Split(*params.Type, ",")
 	}
 	page := models.NewPage(params.Offset, params.Limit)
-	txs, count, err := h.uc.GetTxDetails(address, txID, asset, eventTypes, page)
+	txs, count, err := h.uc.GetTxDetails(ctx.Request().Context(), address, txID, asset, eventTypes, page)
 	if err != nil {
 		h.logger.Err(err).Msg("failed to GetTxDetails")
-		return echo.NewHTTPError(http.StatusInternalServerError, GeneralErrorResponse{Error: err.Error()})
+		return errorResponse(err)
 	}
 
 	response := PrepareTxDetailsResponseForAPI(txs, count)
 	return ctx.JSON(http.StatusOK, response)
 }
 
+// (GET /v1/txs/pending?offset={offset}&limit={limit})
+func (h *Handlers) GetPendingTxs(ctx echo.Context, params GetPendingTxsParams) error {
+	page := models.NewPage(params.Offset, params.Limit)
+	txs, count, err := h.uc.GetPendingTxs(ctx.Request().Context(), page)
+	if err != nil {
+		h.logger.Err(err).Msg("failed to GetPendingTxs")
+		return errorResponse(err)
+	}
+
+	response := PreparePendingTxsResponseForAPI(txs, count)
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// (GET /v1/events/quarantine)
+func (h *Handlers) GetQuarantinedEvents(ctx echo.Context, params GetQuarantinedEventsParams) error {
+	page := models.NewPage(params.Offset, params.Limit)
+	events, count, err := h.uc.GetQuarantinedEvents(ctx.Request().Context(), page)
+	if err != nil {
+		h.logger.Err(err).Msg("failed to GetQuarantinedEvents")
+		return errorResponse(err)
+	}
+
+	response := PrepareQuarantinedEventsResponseForAPI(events, count)
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// (POST /v1/events/quarantine/reprocess)
+func (h *Handlers) ReprocessQuarantinedEvents(ctx echo.Context) error {
+	status, err := h.uc.StartReprocess()
+	if err != nil {
+		switch err {
+		case usecase.ErrNothingQuarantined:
+			return echo.NewHTTPError(http.StatusBadRequest, GeneralErrorResponse{Error: err.Error()})
+		case usecase.ErrRebuildInProgress:
+			return echo.NewHTTPError(http.StatusConflict, GeneralErrorResponse{Error: err.Error()})
+		}
+		h.logger.Err(err).Msg("failed to ReprocessQuarantinedEvents")
+		return errorResponse(err)
+	}
+
+	return ctx.JSON(http.StatusAccepted, status)
+}
+
+// (POST /v1/rebuild)
+func (h *Handlers) StartRebuild(ctx echo.Context) error {
+	status, err := h.uc.StartRebuild()
+	if err != nil {
+		if err == usecase.ErrRebuildInProgress {
+			return echo.NewHTTPError(http.StatusConflict, GeneralErrorResponse{Error: err.Error()})
+		}
+		h.logger.Err(err).Msg("failed to StartRebuild")
+		return errorResponse(err)
+	}
+
+	return ctx.JSON(http.StatusAccepted, status)
+}
+
 // (GET /v1/pools)
 func (h *Handlers) GetPools(ctx echo.Context) error {
 	h.logger.Debug().Str("path", ctx.Path()).Msg("GetAssets")
-	pools, err := h.uc.GetPools()
+	pools, err := h.uc.GetPools(ctx.Request().Context())
 	if err != nil {
 		h.logger.Error().Err(err).Msg("Failed to GetPools")
-		return echo.NewHTTPError(http.StatusInternalServerError, GeneralErrorResponse{Error: err.Error()})
+		return errorResponse(err)
 	}
 	assets := PoolsResponse{}
 	for _, pool := range pools {
@@ -134,7 +191,7 @@ func (h *Handlers) GetAssetInfo(ctx echo.Context, assetParam GetAssetInfoParams)
 
 	response := make(AssetsDetailedResponse, len(asts))
 	for i, ast := range asts {
-		details, err := h.uc.GetAssetDetails(ast)
+		details, err := h.uc.GetAssetDetails(ctx.Request().Context(), ast)
 		if err != nil {
 			h.logger.Error().Err(err).Str("asset", ast.String()).Msg("failed to get pool")
 			if err == store.ErrPoolNotFound {
@@ -155,10 +212,10 @@ func (h *Handlers) GetAssetInfo(ctx echo.Context, assetParam GetAssetInfoParams)
 
 // (GET /v1/stats)
 func (h *Handlers) GetStats(ctx echo.Context) error {
-	stats, err := h.uc.GetStats()
+	stats, err := h.uc.GetStats(ctx.Request().Context())
 	if err != nil {
 		h.logger.Err(err).Msg("failure with GetStats")
-		return echo.NewHTTPError(http.StatusInternalServerError, GeneralErrorResponse{Error: err.Error()})
+		return errorResponse(err)
 	}
 
 	response := StatsResponse{
@@ -198,10 +255,10 @@ func (h *Handlers) GetPoolsDetails(ctx echo.Context, assetParam GetPoolsDetailsP
 	switch view {
 	case "balances":
 		for i, asset := range assets {
-			basics, err := h.uc.GetPoolBasics(asset)
+			basics, err := h.uc.GetPoolBasics(ctx.Request().Context(), asset)
 			if err != nil {
 				h.logger.Err(err).Msg("GetPoolBasics failed")
-				return echo.NewHTTPError(http.StatusInternalServerError, GeneralErrorResponse{Error: err.Error()})
+				return errorResponse(err)
 			}
 			response[i] = PoolDetail{
 				Asset:      ConvertAssetForAPI(basics.Asset),
@@ -211,10 +268,10 @@ func (h *Handlers) GetPoolsDetails(ctx echo.Context, assetParam GetPoolsDetailsP
 		}
 	case "simple":
 		for i, asset := range assets {
-			details, err := h.uc.GetPoolSimpleDetails(asset)
+			details, err := h.uc.GetPoolSimpleDetails(ctx.Request().Context(), asset)
 			if err != nil {
 				h.logger.Err(err).Msg("GetPoolSimpleDetails failed")
-				return echo.NewHTTPError(http.StatusInternalServerError, GeneralErrorResponse{Error: err.Error()})
+				return errorResponse(err)
 			}
 
 			response[i] = PoolDetail{
@@ -241,10 +298,10 @@ func (h *Handlers) GetPoolsDetails(ctx echo.Context, assetParam GetPoolsDetailsP
 		}
 	case "full":
 		for i, asset := range assets {
-			details, err := h.uc.GetPoolDetails(asset)
+			details, err := h.uc.GetPoolDetails(ctx.Request().Context(), asset)
 			if err != nil {
 				h.logger.Err(err).Msg("GetPoolDetails failed")
-				return echo.NewHTTPError(http.StatusInternalServerError, GeneralErrorResponse{Error: err.Error()})
+				return errorResponse(err)
 			}
 			response[i] = PoolDetail{
 				Status:           pointy.String(details.Status.String()),
@@ -289,6 +346,8 @@ func (h *Handlers) GetPoolsDetails(ctx echo.Context, assetParam GetPoolsDetailsP
 				SwappingTxCount:  Uint64ToString(details.SwappingTxCount),
 				WithdrawTxCount:  Uint64ToString(uint64(details.WithdrawCount)),
 				PoolAPY:          Float64ToString(details.PoolAPY),
+				AssetSlashed:     Int64ToString(details.AssetSlashed),
+				RuneSlashed:      Int64ToString(details.RuneSlashed),
 			}
 		}
 	default:
@@ -301,10 +360,10 @@ func (h *Handlers) GetPoolsDetails(ctx echo.Context, assetParam GetPoolsDetailsP
 
 // (GET /v1/stakers)
 func (h *Handlers) GetStakersData(ctx echo.Context) error {
-	stakers, err := h.uc.GetStakers()
+	stakers, err := h.uc.GetStakers(ctx.Request().Context())
 	if err != nil {
 		h.logger.Err(err).Msg("failed to GetStakers")
-		return echo.NewHTTPError(http.StatusInternalServerError, GeneralErrorResponse{Error: err.Error()})
+		return errorResponse(err)
 	}
 	response := StakersResponse{}
 	for _, staker := range stakers {
@@ -321,7 +380,7 @@ func (h *Handlers) GetStakersAddressData(ctx echo.Context, address string) error
 			Error: err.Error(),
 		})
 	}
-	details, err := h.uc.GetStakerDetails(addr)
+	details, err := h.uc.GetStakerDetails(ctx.Request().Context(), addr)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, GeneralErrorResponse{
 			Error: err.Error(),
@@ -359,7 +418,7 @@ func (h *Handlers) GetStakersAddressAndAssetData(ctx echo.Context, address strin
 
 	response := make(StakersAssetDataResponse, len(asts))
 	for i, ast := range asts {
-		details, err := h.uc.GetStakerAssetDetails(addr, ast)
+		details, err := h.uc.GetStakerAssetDetails(ctx.Request().Context(), addr, ast)
 		if err != nil {
 			if err == store.ErrPoolNotFound {
 				return echo.NewHTTPError(http.StatusNotFound, GeneralErrorResponse{
@@ -394,9 +453,9 @@ func (h *Handlers) GetThorchainProxiedEndpoints(ctx echo.Context) error {
 
 // (GET /v1/network)
 func (h *Handlers) GetNetworkData(ctx echo.Context) error {
-	netInfo, err := h.uc.GetNetworkInfo()
+	netInfo, err := h.uc.GetNetworkInfo(ctx.Request().Context())
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, GeneralErrorResponse{Error: err.Error()})
+		return errorResponse(err)
 	}
 	response := NetworkResponse{
 		BondMetrics: &BondMetrics{
@@ -439,9 +498,9 @@ func (h *Handlers) GetTotalVolChanges(ctx echo.Context, params GetTotalVolChange
 	from := time.Unix(params.From, 0)
 	to := time.Unix(params.To, 0)
 
-	changes, err := h.uc.GetTotalVolChanges(inv, from, to)
+	changes, err := h.uc.GetTotalVolChanges(ctx.Request().Context(), inv, from, to)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, GeneralErrorResponse{Error: err.Error()})
+		return errorResponse(err)
 	}
 
 	response := make(TotalVolChangesResponse, len(changes))
@@ -458,6 +517,165 @@ func (h *Handlers) GetTotalVolChanges(ctx echo.Context, params GetTotalVolChange
 	return ctx.JSON(http.StatusOK, response)
 }
 
+// (GET /v1/history/rewards)
+func (h *Handlers) GetRewardsHistory(ctx echo.Context, params GetRewardsHistoryParams) error {
+	inv := models.GetIntervalFromString(params.Interval)
+	from := time.Unix(params.From, 0)
+	to := time.Unix(params.To, 0)
+
+	changes, err := h.uc.GetRewardsHistory(ctx.Request().Context(), inv, from, to)
+	if err != nil {
+		h.logger.Err(err).Msg("GetRewardsHistory failed")
+		return errorResponse(err)
+	}
+
+	response := make(RewardsHistoryResponse, len(changes))
+	for i, ch := range changes {
+		t := ch.Time.Unix()
+		response[i] = RewardsChanges{
+			Time:             &t,
+			BondReward:       Int64ToString(ch.BondReward),
+			PoolRewards:      Int64ToString(ch.PoolRewards),
+			TotalReward:      Int64ToString(ch.TotalReward),
+			Blocks:           Int64ToString(ch.Blocks),
+			ExpectedEmission: Int64ToString(ch.ExpectedEmission),
+		}
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// (GET /v1/history/reserve)
+func (h *Handlers) GetReserveHistory(ctx echo.Context, params GetReserveHistoryParams) error {
+	inv := models.GetIntervalFromString(params.Interval)
+	from := time.Unix(params.From, 0)
+	to := time.Unix(params.To, 0)
+
+	reserves, err := h.uc.GetReserveHistory(ctx.Request().Context(), inv, from, to)
+	if err != nil {
+		h.logger.Err(err).Msg("GetReserveHistory failed")
+		return errorResponse(err)
+	}
+
+	response := make(ReserveHistoryResponse, len(reserves))
+	for i, r := range reserves {
+		t := r.Time.Unix()
+		response[i] = ReserveChanges{
+			Time:         &t,
+			Height:       Int64ToString(r.Height),
+			TotalReserve: Int64ToString(r.TotalReserve),
+		}
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// (GET /v1/history/slashes)
+func (h *Handlers) GetSlashHistory(ctx echo.Context, params GetSlashHistoryParams) error {
+	inv := models.GetIntervalFromString(params.Interval)
+	from := time.Unix(params.From, 0)
+	to := time.Unix(params.To, 0)
+	var pool common.Asset
+	if params.Pool != nil {
+		var err error
+		pool, err = common.NewAsset(*params.Pool)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, GeneralErrorResponse{Error: err.Error()})
+		}
+	}
+
+	changes, err := h.uc.GetSlashHistory(ctx.Request().Context(), pool, inv, from, to)
+	if err != nil {
+		h.logger.Err(err).Msg("GetSlashHistory failed")
+		return errorResponse(err)
+	}
+
+	response := make(SlashHistoryResponse, len(changes))
+	for i, ch := range changes {
+		t := ch.Time.Unix()
+		response[i] = SlashChanges{
+			Time:        &t,
+			Pool:        ConvertAssetForAPI(ch.Pool),
+			AssetAmount: Int64ToString(ch.AssetAmount),
+			RuneAmount:  Int64ToString(ch.RuneAmount),
+			Count:       Int64ToString(ch.Count),
+		}
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// (GET /v1/history/gas)
+func (h *Handlers) GetGasHistory(ctx echo.Context, params GetGasHistoryParams) error {
+	inv := models.GetIntervalFromString(params.Interval)
+	from := time.Unix(params.From, 0)
+	to := time.Unix(params.To, 0)
+	var chain common.Chain
+	if params.Chain != nil {
+		var err error
+		chain, err = common.NewChain(*params.Chain)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, GeneralErrorResponse{Error: err.Error()})
+		}
+	}
+
+	changes, err := h.uc.GetGasHistory(ctx.Request().Context(), chain, inv, from, to)
+	if err != nil {
+		h.logger.Err(err).Msg("GetGasHistory failed")
+		return errorResponse(err)
+	}
+
+	response := make(GasHistoryResponse, len(changes))
+	for i, ch := range changes {
+		t := ch.Time.Unix()
+		response[i] = GasChanges{
+			Time:           &t,
+			Chain:          pointy.String(ch.Chain.String()),
+			GasUsed:        Int64ToString(ch.GasUsed),
+			GasUsedInRune:  Int64ToString(ch.GasUsedInRune),
+			GasReplenished: Int64ToString(ch.GasReplenished),
+			OutboundCount:  Int64ToString(ch.OutboundCount),
+			AverageGas:     Float64ToString(ch.AverageGas),
+			Coverage:       Float64ToString(ch.Coverage),
+		}
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// (GET /v1/history/fees)
+func (h *Handlers) GetFeeHistory(ctx echo.Context, params GetFeeHistoryParams) error {
+	inv := models.GetIntervalFromString(params.Interval)
+	from := time.Unix(params.From, 0)
+	to := time.Unix(params.To, 0)
+	var pool common.Asset
+	if params.Pool != nil {
+		var err error
+		pool, err = common.NewAsset(*params.Pool)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, GeneralErrorResponse{Error: err.Error()})
+		}
+	}
+
+	changes, err := h.uc.GetFeeHistory(ctx.Request().Context(), pool, inv, from, to)
+	if err != nil {
+		h.logger.Err(err).Msg("GetFeeHistory failed")
+		return errorResponse(err)
+	}
+
+	response := make(FeeHistoryResponse, len(changes))
+	for i, ch := range changes {
+		t := ch.Time.Unix()
+		response[i] = FeeChanges{
+			Time:             &t,
+			Pool:             ConvertAssetForAPI(ch.Pool),
+			Type:             pointy.String(ch.Type),
+			AssetAmount:      Int64ToString(ch.AssetAmount),
+			RuneAmount:       Int64ToString(ch.RuneAmount),
+			PoolDeduct:       Int64ToString(ch.PoolDeduct),
+			NetworkFeeInRune: Int64ToString(ch.NetworkFeeInRune),
+			Count:            Int64ToString(ch.Count),
+		}
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
 // (GET /v1/history/pools)
 func (h *Handlers) GetPoolAggChanges(ctx echo.Context, params GetPoolAggChangesParams) error {
 	inv := models.GetIntervalFromString(params.Interval)
@@ -468,9 +686,9 @@ func (h *Handlers) GetPoolAggChanges(ctx echo.Context, params GetPoolAggChangesP
 		return echo.NewHTTPError(http.StatusBadRequest, GeneralErrorResponse{Error: err.Error()})
 	}
 
-	changes, err := h.uc.GetPoolAggChanges(pool, inv, from, to)
+	changes, err := h.uc.GetPoolAggChanges(ctx.Request().Context(), pool, inv, from, to)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, GeneralErrorResponse{Error: err.Error()})
+		return errorResponse(err)
 	}
 
 	response := make([]PoolAggChanges, len(changes))
@@ -503,3 +721,255 @@ func (h *Handlers) GetPoolAggChanges(ctx echo.Context, params GetPoolAggChangesP
 
 	return ctx.JSON(http.StatusOK, response)
 }
+
+// (GET /v1/pools/{asset}/depth-curve)
+func (h *Handlers) GetPoolDepthCurve(ctx echo.Context, asset string, params GetPoolDepthCurveParams) error {
+	pool, err := common.NewAsset(asset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, GeneralErrorResponse{Error: err.Error()})
+	}
+	var sizes []int64
+	if params.Sizes != nil {
+		sizes, err = ParseTradeSizes(*params.Sizes)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, GeneralErrorResponse{Error: err.Error()})
+		}
+	}
+	to := time.Now()
+	if params.To != nil {
+		to = time.Unix(*params.To, 0)
+	}
+	from := to.Add(-time.Hour * 24 * 30)
+	if params.From != nil {
+		from = time.Unix(*params.From, 0)
+	}
+
+	curve, err := h.uc.GetPoolDepthCurve(ctx.Request().Context(), pool, sizes, from, to)
+	if err != nil {
+		h.logger.Err(err).Msg("GetPoolDepthCurve failed")
+		return errorResponse(err)
+	}
+
+	points := make([]DepthCurvePoint, len(curve.Points))
+	for i, p := range curve.Points {
+		points[i] = DepthCurvePoint{
+			TradeSize: Int64ToString(p.TradeSize),
+			Slip:      Float64ToString(p.Slip),
+			Fee:       Int64ToString(p.Fee),
+			Output:    Int64ToString(p.Output),
+		}
+	}
+	history := make([]SlipDepth, len(curve.History))
+	for i, d := range curve.History {
+		time := d.Time.Unix()
+		history[i] = SlipDepth{
+			Time:       &time,
+			AssetDepth: Int64ToString(d.AssetDepth),
+			RuneDepth:  Int64ToString(d.RuneDepth),
+			AssetSize:  Int64ToString(d.AssetSize),
+			RuneSize:   Int64ToString(d.RuneSize),
+		}
+	}
+	response := PoolDepthCurve{
+		AssetDepth: Int64ToString(curve.AssetDepth),
+		RuneDepth:  Int64ToString(curve.RuneDepth),
+		Price:      Float64ToString(curve.Price),
+		Points:     &points,
+		History:    &history,
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// (GET /v1/pools/{asset}/deviation)
+func (h *Handlers) GetPoolDeviation(ctx echo.Context, asset string, params GetPoolDeviationParams) error {
+	pool, err := common.NewAsset(asset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, GeneralErrorResponse{Error: err.Error()})
+	}
+	to := time.Now()
+	if params.To != nil {
+		to = time.Unix(*params.To, 0)
+	}
+	from := to.Add(-time.Hour * 24)
+	if params.From != nil {
+		from = time.Unix(*params.From, 0)
+	}
+
+	deviation, err := h.uc.GetPoolDeviation(ctx.Request().Context(), pool, from, to)
+	if err != nil {
+		h.logger.Err(err).Msg("GetPoolDeviation failed")
+		return errorResponse(err)
+	}
+
+	history := make([]PriceDeviation, len(deviation.History))
+	for i, d := range deviation.History {
+		history[i] = *ConvertPriceDeviationForAPI(d)
+	}
+	response := PoolDeviation{
+		History: &history,
+		Alert:   ConvertDeviationAlertForAPI(deviation.Alert),
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// (GET /v1/deviation/alerts)
+func (h *Handlers) GetDeviationAlerts(ctx echo.Context) error {
+	alerts, err := h.uc.GetDeviationAlerts(ctx.Request().Context())
+	if err != nil {
+		h.logger.Err(err).Msg("GetDeviationAlerts failed")
+		return errorResponse(err)
+	}
+	response := make(DeviationAlertsResponse, len(alerts))
+	for i := range alerts {
+		response[i] = *ConvertDeviationAlertForAPI(&alerts[i])
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// (GET /v1/analytics/swaps)
+func (h *Handlers) GetSwapAnalytics(ctx echo.Context, params GetSwapAnalyticsParams) error {
+	asset := common.EmptyAsset
+	if params.Asset != nil {
+		var err error
+		asset, err = common.NewAsset(*params.Asset)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, GeneralErrorResponse{Error: err.Error()})
+		}
+	}
+	to := time.Now()
+	if params.To != nil {
+		to = time.Unix(*params.To, 0)
+	}
+	from := to.Add(-time.Hour * 24 * 30)
+	if params.From != nil {
+		from = time.Unix(*params.From, 0)
+	}
+	limit := int64(10)
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if err := ValidatePagination(0, limit); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, GeneralErrorResponse{Error: err.Error()})
+	}
+
+	analytics, err := h.uc.GetSwapAnalytics(ctx.Request().Context(), asset, from, to, limit)
+	if err != nil {
+		h.logger.Err(err).Msg("GetSwapAnalytics failed")
+		return errorResponse(err)
+	}
+
+	swappers := make([]Swapper, len(analytics.TopSwappers))
+	for i, swapper := range analytics.TopSwappers {
+		swappers[i] = Swapper{
+			Address:   pointy.String(swapper.Address.String()),
+			SwapCount: pointy.Int64(swapper.SwapCount),
+			Volume:    Int64ToString(swapper.Volume),
+			Fees:      Int64ToString(swapper.Fees),
+		}
+	}
+	response := SwapAnalytics{
+		SwapCount:   pointy.Int64(analytics.SwapCount),
+		TradeSize:   ConvertDistributionForAPI(analytics.TradeSize),
+		Slip:        ConvertDistributionForAPI(analytics.Slip),
+		Fee:         ConvertDistributionForAPI(analytics.Fee),
+		TopSwappers: &swappers,
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// (GET /v1/pools/{asset}/stakers)
+func (h *Handlers) GetPoolStakers(ctx echo.Context, asset string, params GetPoolStakersParams) error {
+	pool, err := common.NewAsset(asset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, GeneralErrorResponse{Error: err.Error()})
+	}
+	if err := ValidatePagination(params.Offset, params.Limit); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, GeneralErrorResponse{Error: err.Error()})
+	}
+
+	page := models.NewPage(params.Offset, params.Limit)
+	stakers, err := h.uc.GetPoolStakers(ctx.Request().Context(), pool, page)
+	if err != nil {
+		h.logger.Err(err).Msg("GetPoolStakers failed")
+		return errorResponse(err)
+	}
+
+	list := make([]PoolStaker, len(stakers.Stakers))
+	for i, staker := range stakers.Stakers {
+		list[i] = PoolStaker{
+			Address: pointy.String(staker.Address.String()),
+			Units:   Int64ToString(staker.Units),
+			Share:   Float64ToString(staker.Share),
+		}
+	}
+	response := PoolStakers{
+		TotalCount:    pointy.Int64(stakers.TotalCount),
+		Stakers:       &list,
+		Concentration: ConvertPoolConcentrationForAPI(stakers.Concentration),
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// (GET /v1/history/pools/{asset}/concentration)
+func (h *Handlers) GetPoolConcentrationHistory(ctx echo.Context, asset string, params GetPoolConcentrationHistoryParams) error {
+	inv := models.GetIntervalFromString(params.Interval)
+	from := time.Unix(params.From, 0)
+	to := time.Unix(params.To, 0)
+	pool, err := common.NewAsset(asset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, GeneralErrorResponse{Error: err.Error()})
+	}
+
+	history, err := h.uc.GetPoolConcentrationHistory(ctx.Request().Context(), pool, inv, from, to)
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	response := make([]PoolConcentration, len(history))
+	for i, concentration := range history {
+		response[i] = *ConvertPoolConcentrationForAPI(concentration)
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// (GET /v1/history/pools/{asset}/yield)
+func (h *Handlers) GetPoolYieldHistory(ctx echo.Context, asset string, params GetPoolYieldHistoryParams) error {
+	inv := models.GetIntervalFromString(params.Interval)
+	from := time.Unix(params.From, 0)
+	to := time.Unix(params.To, 0)
+	pool, err := common.NewAsset(asset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, GeneralErrorResponse{Error: err.Error()})
+	}
+
+	yield, err := h.uc.GetPoolYield(ctx.Request().Context(), pool, inv, from, to)
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	intervals := make([]PoolYieldChanges, len(yield.Intervals))
+	for i, ch := range yield.Intervals {
+		time := ch.Time.Unix()
+		intervals[i] = PoolYieldChanges{
+			Time:           &time,
+			AssetDepth:     Int64ToString(ch.AssetDepth),
+			RuneDepth:      Int64ToString(ch.RuneDepth),
+			Price:          Float64ToString(ch.Price),
+			BuyFees:        Int64ToString(ch.BuyFees),
+			SellFees:       Int64ToString(ch.SellFees),
+			FeesEarned:     Int64ToString(ch.FeesEarned),
+			Reward:         Int64ToString(ch.Reward),
+			GasUsed:        Int64ToString(ch.GasUsed),
+			GasReplenished: Int64ToString(ch.GasReplenished),
+			PoolEarned:     Int64ToString(ch.PoolEarned),
+			PoolAPY:        Float64ToString(ch.PoolAPY),
+		}
+	}
+	response := PoolYieldHistory{
+		Intervals: &intervals,
+		Apy7d:     Float64ToString(yield.APY7d),
+		Apy30d:    Float64ToString(yield.APY30d),
+		Apy90d:    Float64ToString(yield.APY90d),
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
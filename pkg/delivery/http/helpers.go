@@ -1,28 +1,64 @@
 package http
 
 import (
+	"net/http"
 	"strconv"
 	"strings"
 
+	"github.com/labstack/echo/v4"
 	"github.com/openlyinc/pointy"
 	"github.com/pkg/errors"
 	"gitlab.com/thorchain/midgard/internal/common"
 	"gitlab.com/thorchain/midgard/internal/models"
+	"gitlab.com/thorchain/midgard/pkg/clients/thorchain"
 )
 
 const paginationMaxLimit = 50
 
+// maxTradeSizes is the maximum number of trade sizes of a depth curve request.
+const maxTradeSizes = 20
+
 func ConvertAssetForAPI(asset common.Asset) *Asset {
 	assetString := Asset(asset.String())
 	return &assetString
 }
 
 func ConvertEventDataForAPI(events models.Events) *Event {
-	return &Event{
+	event := &Event{
 		Fee:        Uint64ToString(events.Fee),
 		Slip:       Float64ToString(events.Slip),
 		StakeUnits: Int64ToString(events.StakeUnits),
 	}
+	if len(events.Slash) > 0 {
+		slash := ConvertCoinsForAPI(events.Slash)
+		event.Slash = &slash
+	}
+	if len(events.Legs) > 0 {
+		event.IntermediateRune = Int64ToString(events.IntermediateRune)
+		legs := make([]SwapLeg, len(events.Legs))
+		for i, leg := range events.Legs {
+			legs[i] = SwapLeg{
+				Fee:  Uint64ToString(leg.Fee),
+				Pool: ConvertAssetForAPI(leg.Pool),
+				Slip: Float64ToString(leg.Slip),
+			}
+		}
+		event.Legs = &legs
+	}
+	return event
+}
+
+func ConvertFeeBreakdownForAPI(fees models.FeeBreakdown) *FeeBreakdown {
+	breakdown := &FeeBreakdown{
+		NetworkFeeInRune: Int64ToString(fees.NetworkFeeInRune),
+		LiquidityFee:     Uint64ToString(fees.LiquidityFee),
+		Slip:             Float64ToString(fees.Slip),
+	}
+	if len(fees.NetworkFee) > 0 {
+		networkFee := ConvertCoinsForAPI(fees.NetworkFee)
+		breakdown.NetworkFee = &networkFee
+	}
+	return breakdown
 }
 
 func ConvertGasForAPI(gas models.TxGas) *Gas {
@@ -58,6 +94,60 @@ func ConvertCoinsForAPI(coins common.Coins) Coins {
 	return c
 }
 
+func ConvertPriceDeviationForAPI(deviation models.PriceDeviation) *PriceDeviation {
+	return &PriceDeviation{
+		Time:           pointy.Int64(deviation.Time.Unix()),
+		Height:         pointy.Int64(deviation.Height),
+		PoolPrice:      Float64ToString(deviation.PoolPrice),
+		ReferencePrice: Float64ToString(deviation.ReferencePrice),
+		Deviation:      Float64ToString(deviation.Deviation),
+	}
+}
+
+func ConvertDeviationAlertForAPI(alert *models.DeviationAlert) *DeviationAlert {
+	if alert == nil {
+		return nil
+	}
+
+	return &DeviationAlert{
+		Asset:     ConvertAssetForAPI(alert.Pool),
+		Since:     pointy.Int64(alert.Since.Unix()),
+		Deviation: ConvertPriceDeviationForAPI(alert.PriceDeviation),
+	}
+}
+
+func ConvertDistributionForAPI(distribution models.Distribution) *Distribution {
+	histogram := make([]HistogramBucket, len(distribution.Histogram))
+	for i, bucket := range distribution.Histogram {
+		histogram[i] = HistogramBucket{
+			From:  Float64ToString(bucket.From),
+			Count: pointy.Int64(bucket.Count),
+		}
+		if bucket.To != 0 {
+			histogram[i].To = Float64ToString(bucket.To)
+		}
+	}
+
+	return &Distribution{
+		Average:   Float64ToString(distribution.Average),
+		P50:       Float64ToString(distribution.P50),
+		P90:       Float64ToString(distribution.P90),
+		P99:       Float64ToString(distribution.P99),
+		Histogram: &histogram,
+	}
+}
+
+func ConvertPoolConcentrationForAPI(concentration models.PoolConcentration) *PoolConcentration {
+	return &PoolConcentration{
+		Time:         pointy.Int64(concentration.Time.Unix()),
+		StakersCount: pointy.Int64(concentration.StakersCount),
+		Units:        Int64ToString(concentration.Units),
+		Gini:         Float64ToString(concentration.Gini),
+		Top10Share:   Float64ToString(concentration.Top10Share),
+		Herfindahl:   Float64ToString(concentration.Herfindahl),
+	}
+}
+
 func ConvertTxForAPI(tx models.TxData) *Tx {
 	if tx.Address == "" {
 		return nil
@@ -95,6 +185,7 @@ func PrepareTxDetailsResponseForAPI(txData []models.TxDetails, count int64) TxsR
 		tx := TxDetails{
 			Date:    pointy.Int64(int64(d.Date)),
 			Events:  ConvertEventDataForAPI(d.Events),
+			Fees:    ConvertFeeBreakdownForAPI(d.Fees),
 			Gas:     ConvertGasForAPI(d.Gas),
 			Height:  Uint64ToString(d.Height),
 			In:      ConvertTxForAPI(d.In),
@@ -113,6 +204,48 @@ func PrepareTxDetailsResponseForAPI(txData []models.TxDetails, count int64) TxsR
 	}
 }
 
+func PreparePendingTxsResponseForAPI(txData []models.PendingTx, count int64) PendingTxsResponse {
+	txs := make([]PendingTx, len(txData))
+	for i, d := range txData {
+		txs[i] = PendingTx{
+			Age:               pointy.Int64(int64(d.Age.Seconds())),
+			Date:              pointy.Int64(d.Time.Unix()),
+			Height:            Int64ToString(d.Height),
+			In:                ConvertTxForAPI(d.In),
+			OutboundChain:     pointy.String(d.OutboundChain.String()),
+			Outbounds:         pointy.Int64(d.Outbounds),
+			ExpectedOutbounds: pointy.Int64(d.ExpectedOutbounds),
+			Pool:              ConvertAssetForAPI(d.Pool),
+			Stuck:             pointy.Bool(d.Stuck),
+			Type:              pointy.String(d.Type),
+		}
+	}
+
+	return PendingTxsResponse{
+		Count: &count,
+		Txs:   &txs,
+	}
+}
+
+func PrepareQuarantinedEventsResponseForAPI(data []models.QuarantinedEvent, count int64) QuarantinedEventsResponse {
+	events := make([]QuarantinedEvent, len(data))
+	for i, d := range data {
+		events[i] = QuarantinedEvent{
+			Attributes: &QuarantinedEvent_Attributes{AdditionalProperties: d.Attributes},
+			Date:       pointy.Int64(d.Time.Unix()),
+			Height:     Int64ToString(d.Height),
+			Id:         pointy.Int64(d.ID),
+			Reason:     pointy.String(d.Reason),
+			Type:       pointy.String(d.Type),
+		}
+	}
+
+	return QuarantinedEventsResponse{
+		Count:  &count,
+		Events: &events,
+	}
+}
+
 func Uint64ToString(v uint64) *string {
 	str := strconv.FormatUint(v, 10)
 	return &str
@@ -150,6 +283,28 @@ func ParseAssets(str string) (asts []common.Asset, err error) {
 	return asts, nil
 }
 
+// ParseTradeSizes parses comma separated sequence of at most maxTradeSizes positive
+// trade sizes.
+func ParseTradeSizes(str string) ([]int64, error) {
+	parts := strings.Split(str, ",")
+	if len(parts) > maxTradeSizes {
+		return nil, errors.Errorf("too many trade sizes: at most %d are allowed", maxTradeSizes)
+	}
+
+	sizes := make([]int64, len(parts))
+	for i, part := range parts {
+		size, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid trade size '%s'", part)
+		}
+		if size <= 0 {
+			return nil, errors.Errorf("invalid trade size '%s': must be positive", part)
+		}
+		sizes[i] = size
+	}
+	return sizes, nil
+}
+
 // ValidatePagination validates offset and limit of request pagination.
 func ValidatePagination(offset, limit int64) error {
 	if offset < 0 {
@@ -160,3 +315,13 @@ func ValidatePagination(offset, limit int64) error {
 	}
 	return nil
 }
+
+// errorResponse returns the http error of a request which failed with err:
+// 503 when thornode is unavailable and 500 otherwise.
+func errorResponse(err error) *echo.HTTPError {
+	status := http.StatusInternalServerError
+	if errors.Is(err, thorchain.ErrCircuitOpen) {
+		status = http.StatusServiceUnavailable
+	}
+	return echo.NewHTTPError(status, GeneralErrorResponse{Error: err.Error()})
+}
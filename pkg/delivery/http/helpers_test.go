@@ -1,9 +1,13 @@
 package http
 
 import (
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/pkg/errors"
 	"gitlab.com/thorchain/midgard/internal/common"
+	"gitlab.com/thorchain/midgard/pkg/clients/thorchain"
 	. "gopkg.in/check.v1"
 )
 
@@ -45,6 +49,34 @@ func (s *HelpersSuite) TestParseAssets(c *C) {
 	c.Check(got, IsNil)
 }
 
+func (s *HelpersSuite) TestParseTradeSizes(c *C) {
+	// Valid comma separated sequence of sizes
+	got, err := ParseTradeSizes("100,2000,30000")
+	c.Check(err, IsNil)
+	c.Assert(got, DeepEquals, []int64{100, 2000, 30000})
+
+	// Invalid empty size in sequence
+	got, err = ParseTradeSizes("100,,30000")
+	c.Check(err, NotNil)
+	c.Check(got, IsNil)
+
+	// Invalid negative size
+	got, err = ParseTradeSizes("100,-1")
+	c.Check(err, NotNil)
+	c.Check(got, IsNil)
+
+	// Up to maxTradeSizes sizes
+	sizes := strings.TrimSuffix(strings.Repeat("100,", maxTradeSizes), ",")
+	got, err = ParseTradeSizes(sizes)
+	c.Check(err, IsNil)
+	c.Check(got, HasLen, maxTradeSizes)
+
+	// Too many sizes
+	got, err = ParseTradeSizes(sizes + ",100")
+	c.Check(err, NotNil)
+	c.Check(got, IsNil)
+}
+
 func (s *HelpersSuite) TestConvertCoinForAPI(c *C) {
 	resp := ConvertCoinForAPI(common.NewCoin(common.BTCAsset, 10))
 	amount := "10"
@@ -62,3 +94,11 @@ func (s *HelpersSuite) TestConvertCoinForAPI(c *C) {
 		Amount: &amount,
 	})
 }
+
+func (s *HelpersSuite) TestErrorResponse(c *C) {
+	err := errorResponse(errors.New("query failed"))
+	c.Assert(err.Code, Equals, http.StatusInternalServerError)
+
+	err = errorResponse(errors.Wrap(thorchain.ErrCircuitOpen, "could not get node accounts"))
+	c.Assert(err.Code, Equals, http.StatusServiceUnavailable)
+}
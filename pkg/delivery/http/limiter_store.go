@@ -0,0 +1,314 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"gitlab.com/thorchain/midgard/pkg/clients/redis"
+)
+
+// limiterCleanupInterval is the interval of the removal of the stale counters
+// of the memory limiter store.
+const limiterCleanupInterval = time.Minute
+
+// LimiterStore counts the requests of the rate limited clients with a sliding
+// window: the requests of the previous window are weighted by the part of it
+// still covered by a window ending now. It also keeps the counters of the
+// daily quotas.
+type LimiterStore interface {
+	// Allow counts a request of key at now unless limit requests were already
+	// made in the window ending at now.
+	Allow(ctx context.Context, key string, limit int64, window time.Duration, now time.Time) (LimitResult, error)
+	// Add adds n to the counter of key, which expires at expireAt, and returns
+	// its new value.
+	Add(ctx context.Context, key string, n int64, expireAt time.Time) (int64, error)
+	// Seed sets the counter of key, which expires at expireAt, to value unless
+	// it exists already.
+	Seed(ctx context.Context, key string, value int64, expireAt time.Time) error
+}
+
+// LimitResult is the outcome of counting a request.
+type LimitResult struct {
+	Allowed bool
+	// RetryAfter is the time until a refused request would be allowed.
+	RetryAfter time.Duration
+}
+
+// tokenBucket converts a token bucket refilled at rate per second with bursts
+// of burst requests to the limit of a sliding window. The limit is zero if
+// rate isn't positive.
+func tokenBucket(rate float64, burst int) (int64, time.Duration) {
+	if rate <= 0 {
+		return 0, 0
+	}
+	return int64(burst), time.Duration(float64(burst) / rate * float64(time.Second))
+}
+
+// windowPosition returns the index of the window of now and how much of it has
+// elapsed, between 0 and 1.
+func windowPosition(window time.Duration, now time.Time) (int64, float64) {
+	ns := now.UnixNano()
+	index := ns / int64(window)
+	elapsed := float64(ns-index*int64(window)) / float64(window)
+	return index, elapsed
+}
+
+// decide tells whether a request is allowed given the number of requests of the
+// previous and current windows.
+func decide(limit, prev, cur int64, window time.Duration, elapsed float64) LimitResult {
+	if limit < 1 {
+		return LimitResult{RetryAfter: window}
+	}
+	estimate := float64(prev)*(1-elapsed) + float64(cur)
+	if estimate+1 <= float64(limit) {
+		return LimitResult{Allowed: true}
+	}
+
+	// Wait for the weight of the previous window to drop enough, or if the
+	// current window is already full, for the weight of it to drop in the next.
+	var wait float64
+	free := float64(limit - 1 - cur)
+	if free >= 0 && prev > 0 {
+		wait = 1 - free/float64(prev) - elapsed
+	} else {
+		wait = 1 - elapsed + math.Max(0, 1-float64(limit-1)/float64(cur))
+	}
+	return LimitResult{RetryAfter: time.Duration(wait * float64(window))}
+}
+
+// MemoryLimiterStore keeps the counters in memory. They aren't shared between
+// the replicas of Midgard.
+type MemoryLimiterStore struct {
+	mu       sync.Mutex
+	counters map[string]*windowCounter
+	totals   map[string]*expiringCounter
+	cleaned  time.Time
+}
+
+// windowCounter counts the requests of a key in the current and previous
+// windows.
+type windowCounter struct {
+	window time.Duration
+	index  int64
+	prev   int64
+	cur    int64
+}
+
+// expiringCounter is a counter of LimiterStore.Add.
+type expiringCounter struct {
+	value    int64
+	expireAt time.Time
+}
+
+// NewMemoryLimiterStore creates a new instance of MemoryLimiterStore.
+func NewMemoryLimiterStore() *MemoryLimiterStore {
+	return &MemoryLimiterStore{
+		counters: map[string]*windowCounter{},
+		totals:   map[string]*expiringCounter{},
+	}
+}
+
+// Allow implements LimiterStore.
+func (s *MemoryLimiterStore) Allow(ctx context.Context, key string, limit int64, window time.Duration, now time.Time) (LimitResult, error) {
+	if window <= 0 {
+		return LimitResult{Allowed: true}, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.cleaned) > limiterCleanupInterval {
+		s.cleanup(now)
+	}
+
+	index, elapsed := windowPosition(window, now)
+	c, ok := s.counters[key]
+	if !ok || c.window != window {
+		c = &windowCounter{window: window, index: index}
+		s.counters[key] = c
+	}
+	if c.index != index {
+		if c.index == index-1 {
+			c.prev = c.cur
+		} else {
+			c.prev = 0
+		}
+		c.index = index
+		c.cur = 0
+	}
+
+	result := decide(limit, c.prev, c.cur, window, elapsed)
+	if result.Allowed {
+		c.cur++
+	}
+	return result, nil
+}
+
+// Add implements LimiterStore.
+func (s *MemoryLimiterStore) Add(ctx context.Context, key string, n int64, expireAt time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := s.total(key, time.Now())
+	if c == nil {
+		c = &expiringCounter{}
+		s.totals[key] = c
+	}
+	c.value += n
+	c.expireAt = expireAt
+	return c.value, nil
+}
+
+// Seed implements LimiterStore.
+func (s *MemoryLimiterStore) Seed(ctx context.Context, key string, value int64, expireAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.total(key, time.Now()) == nil {
+		s.totals[key] = &expiringCounter{value: value, expireAt: expireAt}
+	}
+	return nil
+}
+
+// total returns the counter of key, nil if it doesn't exist or expired.
+// s.mu must be held.
+func (s *MemoryLimiterStore) total(key string, now time.Time) *expiringCounter {
+	if now.Sub(s.cleaned) > limiterCleanupInterval {
+		s.cleanup(now)
+	}
+	c, ok := s.totals[key]
+	if !ok || !now.Before(c.expireAt) {
+		return nil
+	}
+	return c
+}
+
+// cleanup removes the counters without requests in the last two windows and
+// the expired counters. s.mu must be held.
+func (s *MemoryLimiterStore) cleanup(now time.Time) {
+	for key, c := range s.counters {
+		index, _ := windowPosition(c.window, now)
+		if c.index < index-1 {
+			delete(s.counters, key)
+		}
+	}
+	for key, c := range s.totals {
+		if !now.Before(c.expireAt) {
+			delete(s.totals, key)
+		}
+	}
+	s.cleaned = now
+}
+
+// RedisLimiterStore keeps the counters in a redis server shared by the
+// replicas of Midgard. Each window has its own counter which expires once it's
+// no longer needed.
+type RedisLimiterStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisLimiterStore creates a new instance of RedisLimiterStore. The keys
+// of the counters start with prefix.
+func NewRedisLimiterStore(client *redis.Client, prefix string) *RedisLimiterStore {
+	return &RedisLimiterStore{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// Allow implements LimiterStore.
+func (s *RedisLimiterStore) Allow(ctx context.Context, key string, limit int64, window time.Duration, now time.Time) (LimitResult, error) {
+	if window <= 0 {
+		return LimitResult{Allowed: true}, nil
+	}
+
+	index, elapsed := windowPosition(window, now)
+	curKey := s.counterKey(key, window, index)
+	prevKey := s.counterKey(key, window, index-1)
+	ttl := strconv.FormatInt(int64(window*2/time.Millisecond)+1, 10)
+	replies, err := s.client.Pipeline(ctx,
+		[]string{"INCR", curKey},
+		[]string{"PEXPIRE", curKey, ttl},
+		[]string{"GET", prevKey},
+	)
+	if err != nil {
+		return LimitResult{}, err
+	}
+	for _, reply := range replies {
+		if err, ok := reply.(redis.Error); ok {
+			return LimitResult{}, err
+		}
+	}
+	cur, ok := replies[0].(int64)
+	if !ok {
+		return LimitResult{}, errors.Errorf("unexpected reply %v to INCR", replies[0])
+	}
+	var prev int64
+	if v, ok := replies[2].(string); ok {
+		prev, err = strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return LimitResult{}, errors.Wrapf(err, "invalid counter %s", prevKey)
+		}
+	}
+
+	// The request was counted already, so it's decided on the counter before.
+	result := decide(limit, prev, cur-1, window, elapsed)
+	if !result.Allowed {
+		// Refused requests aren't counted. If this fails, the counter is off
+		// by one until it expires.
+		s.client.Do(ctx, "DECR", curKey)
+	}
+	return result, nil
+}
+
+// Add implements LimiterStore.
+func (s *RedisLimiterStore) Add(ctx context.Context, key string, n int64, expireAt time.Time) (int64, error) {
+	key = s.prefix + key
+	replies, err := s.client.Pipeline(ctx,
+		[]string{"INCRBY", key, strconv.FormatInt(n, 10)},
+		[]string{"PEXPIREAT", key, unixMillis(expireAt)},
+	)
+	if err != nil {
+		return 0, err
+	}
+	for _, reply := range replies {
+		if err, ok := reply.(redis.Error); ok {
+			return 0, err
+		}
+	}
+	value, ok := replies[0].(int64)
+	if !ok {
+		return 0, errors.Errorf("unexpected reply %v to INCRBY", replies[0])
+	}
+	return value, nil
+}
+
+// Seed implements LimiterStore.
+func (s *RedisLimiterStore) Seed(ctx context.Context, key string, value int64, expireAt time.Time) error {
+	key = s.prefix + key
+	replies, err := s.client.Pipeline(ctx,
+		[]string{"SET", key, strconv.FormatInt(value, 10), "NX"},
+		[]string{"PEXPIREAT", key, unixMillis(expireAt)},
+	)
+	if err != nil {
+		return err
+	}
+	for _, reply := range replies {
+		if err, ok := reply.(redis.Error); ok {
+			return err
+		}
+	}
+	return nil
+}
+
+func unixMillis(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10)
+}
+
+func (s *RedisLimiterStore) counterKey(key string, window time.Duration, index int64) string {
+	return fmt.Sprintf("%s%s:%d:%d", s.prefix, key, window/time.Millisecond, index)
+}
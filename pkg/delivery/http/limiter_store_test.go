@@ -0,0 +1,152 @@
+package http
+
+import (
+	"context"
+	"time"
+
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/midgard/internal/config"
+	"gitlab.com/thorchain/midgard/pkg/clients/redis"
+	"gitlab.com/thorchain/midgard/pkg/clients/redis/redistest"
+)
+
+type LimiterStoreSuite struct {
+	server *redistest.Server
+}
+
+var _ = Suite(&LimiterStoreSuite{})
+
+func (s *LimiterStoreSuite) SetUpTest(c *C) {
+	var err error
+	s.server, err = redistest.NewServer("")
+	c.Assert(err, IsNil)
+}
+
+func (s *LimiterStoreSuite) TearDownTest(c *C) {
+	s.server.Close()
+}
+
+func (s *LimiterStoreSuite) newRedisStore(c *C) *RedisLimiterStore {
+	client, err := redis.NewClient(config.RedisConfiguration{
+		Addr:    s.server.Addr(),
+		Timeout: time.Second,
+	})
+	c.Assert(err, IsNil)
+	return NewRedisLimiterStore(client, "test:")
+}
+
+// checkSlidingWindow checks the limit of 4 requests per 10s window.
+func checkSlidingWindow(c *C, store LimiterStore) {
+	ctx := context.Background()
+	start := time.Unix(1000, 0)
+	allow := func(offset time.Duration) LimitResult {
+		result, err := store.Allow(ctx, "client", 4, time.Second*10, start.Add(offset))
+		c.Assert(err, IsNil)
+		return result
+	}
+	refused := func(offset, retryAfter time.Duration) {
+		result := allow(offset)
+		c.Assert(result.Allowed, Equals, false)
+		diff := result.RetryAfter - retryAfter
+		c.Assert(diff > -time.Millisecond && diff < time.Millisecond, Equals, true, Commentf("retry after %s", result.RetryAfter))
+	}
+
+	for i := 0; i < 4; i++ {
+		c.Assert(allow(time.Second*5).Allowed, Equals, true)
+	}
+	// The requests of the previous window weigh for the part of it covered by
+	// the sliding window, e.g. 4*0.75=3 at 2.5s in the next window.
+	refused(time.Second*5, time.Millisecond*7500)
+	refused(time.Second*9, time.Millisecond*3500)
+	refused(time.Second*11, time.Millisecond*1500)
+	c.Assert(allow(time.Millisecond*12500).Allowed, Equals, true)
+	refused(time.Millisecond*12500, time.Millisecond*2500)
+	// Requests of other keys are counted apart.
+	result, err := store.Allow(ctx, "other", 4, time.Second*10, start)
+	c.Assert(err, IsNil)
+	c.Assert(result.Allowed, Equals, true)
+	// Windows without requests reset the counters.
+	for i := 0; i < 4; i++ {
+		c.Assert(allow(time.Second*30).Allowed, Equals, true)
+	}
+}
+
+// checkCounters checks the counters of the daily quotas.
+func checkCounters(c *C, store LimiterStore) {
+	ctx := context.Background()
+	expireAt := time.Now().Add(time.Hour)
+	err := store.Seed(ctx, "quota", 5, expireAt)
+	c.Assert(err, IsNil)
+	// Existing counters aren't seeded again.
+	err = store.Seed(ctx, "quota", 1, expireAt)
+	c.Assert(err, IsNil)
+	value, err := store.Add(ctx, "quota", 2, expireAt)
+	c.Assert(err, IsNil)
+	c.Assert(value, Equals, int64(7))
+	value, err = store.Add(ctx, "quota", -1, expireAt)
+	c.Assert(err, IsNil)
+	c.Assert(value, Equals, int64(6))
+	value, err = store.Add(ctx, "other", 1, expireAt)
+	c.Assert(err, IsNil)
+	c.Assert(value, Equals, int64(1))
+
+	// Expired counters start over.
+	_, err = store.Add(ctx, "expired", 3, time.Now().Add(-time.Second))
+	c.Assert(err, IsNil)
+	value, err = store.Add(ctx, "expired", 1, expireAt)
+	c.Assert(err, IsNil)
+	c.Assert(value, Equals, int64(1))
+}
+
+func (s *LimiterStoreSuite) TestMemory(c *C) {
+	checkSlidingWindow(c, NewMemoryLimiterStore())
+	checkCounters(c, NewMemoryLimiterStore())
+}
+
+func (s *LimiterStoreSuite) TestRedisCounters(c *C) {
+	checkCounters(c, s.newRedisStore(c))
+
+	value, ok := s.server.Get("test:quota")
+	c.Assert(ok, Equals, true)
+	c.Assert(value, Equals, "6")
+	ttl := s.server.TTL("test:quota")
+	c.Assert(ttl > time.Minute*59 && ttl <= time.Hour, Equals, true)
+}
+
+func (s *LimiterStoreSuite) TestRedis(c *C) {
+	checkSlidingWindow(c, s.newRedisStore(c))
+
+	value, ok := s.server.Get("test:client:10000:103")
+	c.Assert(ok, Equals, true)
+	c.Assert(value, Equals, "4")
+	c.Assert(s.server.TTL("test:client:10000:103") > time.Second*19, Equals, true)
+}
+
+func (s *LimiterStoreSuite) TestRedisShared(c *C) {
+	replicas := []LimiterStore{s.newRedisStore(c), s.newRedisStore(c)}
+	now := time.Now()
+	for i := 0; i < 4; i++ {
+		result, err := replicas[i%2].Allow(context.Background(), "client", 4, time.Hour, now)
+		c.Assert(err, IsNil)
+		c.Assert(result.Allowed, Equals, true)
+	}
+	for _, store := range replicas {
+		result, err := store.Allow(context.Background(), "client", 4, time.Hour, now)
+		c.Assert(err, IsNil)
+		c.Assert(result.Allowed, Equals, false)
+	}
+
+	s.server.Close()
+	_, err := replicas[0].Allow(context.Background(), "client", 4, time.Hour, now)
+	c.Assert(err, NotNil)
+}
+
+func (s *LimiterStoreSuite) TestTokenBucket(c *C) {
+	limit, window := tokenBucket(3, 2)
+	c.Assert(limit, Equals, int64(2))
+	c.Assert(window, Equals, time.Second*2/3)
+	limit, window = tokenBucket(0, 2)
+	c.Assert(limit, Equals, int64(0))
+	c.Assert(window, Equals, time.Duration(0))
+}
@@ -0,0 +1,304 @@
+package http
+
+import (
+	"hash/fnv"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/yhat/wsutil"
+
+	"gitlab.com/thorchain/midgard/internal/config"
+)
+
+const (
+	roundRobin       = "round_robin"
+	leastConnections = "least_connections"
+)
+
+// proxyTarget is a node of a chain along with its health state.
+type proxyTarget struct {
+	url            *url.URL
+	httpProxy      *httputil.ReverseProxy
+	websocketProxy *wsutil.ReverseProxy
+	// healthy is the outcome of the last health check.
+	healthy   bool
+	failures  int
+	ejectedAt time.Time
+	conns     int
+}
+
+// nodePool balances the requests of a chain between its nodes. The nodes are
+// checked in background every interval once the pool is started, and unhealthy
+// nodes are left out. A node is also ejected for a while after consecutive
+// failed requests. Websocket connections of a client are routed to the same
+// node as long as it's available.
+type nodePool struct {
+	mu              sync.Mutex
+	chain           string
+	targets         []*proxyTarget
+	websocketPath   string
+	balancing       string
+	healthCheckPath string
+	client          *http.Client
+	interval        time.Duration
+	threshold       int
+	ejection        time.Duration
+	next            int
+	quit            chan struct{}
+	done            chan struct{}
+	logger          zerolog.Logger
+}
+
+func newNodePool(node config.NodeProxy, conf config.NodeProxyConfiguration, logger zerolog.Logger) (*nodePool, error) {
+	balancing := node.Balancing
+	if balancing == "" {
+		balancing = roundRobin
+	}
+	if balancing != roundRobin && balancing != leastConnections {
+		return nil, errors.Errorf("unknown balancing %s for chain %s", node.Balancing, node.Chain)
+	}
+	urls := node.AllTargets()
+	if len(urls) == 0 {
+		return nil, errors.Errorf("no target for chain %s", node.Chain)
+	}
+	threshold := conf.EjectionThreshold
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	p := &nodePool{
+		chain:           node.Chain,
+		websocketPath:   node.WebsocketPath,
+		balancing:       balancing,
+		healthCheckPath: node.HealthCheckPath,
+		client:          &http.Client{Timeout: conf.HealthCheckTimeout},
+		interval:        conf.HealthCheckInterval,
+		threshold:       threshold,
+		ejection:        conf.EjectionDuration,
+		quit:            make(chan struct{}),
+		done:            make(chan struct{}),
+		logger:          logger.With().Str("chain", node.Chain).Logger(),
+	}
+	for _, u := range urls {
+		httpTarget, err := url.Parse(u)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid target url for chain %s", node.Chain)
+		}
+		p.targets = append(p.targets, p.newTarget(httpTarget))
+	}
+	return p, nil
+}
+
+// newTarget creates the proxies of the node, which report their failures.
+func (p *nodePool) newTarget(httpTarget *url.URL) *proxyTarget {
+	t := &proxyTarget{
+		url:       httpTarget,
+		httpProxy: httputil.NewSingleHostReverseProxy(httpTarget),
+		healthy:   true,
+	}
+	t.httpProxy.ModifyResponse = func(resp *http.Response) error {
+		switch resp.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			p.report(t, errors.Errorf("node responded with %s", resp.Status))
+		default:
+			p.report(t, nil)
+		}
+		return nil
+	}
+	t.httpProxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		// Requests cancelled by the clients aren't the node's fault.
+		if r.Context().Err() == nil {
+			p.report(t, err)
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	}
+	if p.websocketPath != "" {
+		// Converting the http scheme to ws scheme
+		t.websocketProxy = wsutil.NewSingleHostReverseProxy(convertToWsTarget(httpTarget))
+		t.websocketProxy.Dial = func(network, addr string) (net.Conn, error) {
+			c, err := net.Dial(network, addr)
+			p.report(t, err)
+			return c, err
+		}
+	}
+	return t
+}
+
+// isWebsocket tells whether the path is proxied to the websocket of the nodes.
+func (p *nodePool) isWebsocket(path string) bool {
+	return p.websocketPath != "" && strings.HasPrefix(path, p.websocketPath)
+}
+
+// pick returns the node serving the next request, nil if none is available.
+// Websocket requests of the same client go to the same node. The node must be
+// released once the request is served.
+func (p *nodePool) pick(websocket bool, client string) *proxyTarget {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var available []*proxyTarget
+	for _, t := range p.targets {
+		if !t.healthy || (t.failures >= p.threshold && now.Sub(t.ejectedAt) < p.ejection) {
+			continue
+		}
+		available = append(available, t)
+	}
+	if len(available) == 0 {
+		return nil
+	}
+
+	var picked *proxyTarget
+	switch {
+	case websocket:
+		// Rendezvous hashing, so only the clients of a node which becomes
+		// unavailable are moved to other nodes.
+		var top uint64
+		for _, t := range available {
+			h := fnv.New64a()
+			h.Write([]byte(client + "|" + t.url.String()))
+			if score := h.Sum64(); picked == nil || score > top {
+				picked, top = t, score
+			}
+		}
+	case p.balancing == leastConnections:
+		// Ties are broken in turn.
+		for i := range available {
+			t := available[(p.next+i)%len(available)]
+			if picked == nil || t.conns < picked.conns {
+				picked = t
+			}
+		}
+		p.next++
+	default:
+		picked = available[p.next%len(available)]
+		p.next++
+	}
+	picked.conns++
+	return picked
+}
+
+// release ends a request served by the node.
+func (p *nodePool) release(t *proxyTarget) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	t.conns--
+}
+
+// report updates the ejection state of the node with the outcome of a request.
+func (p *nodePool) report(t *proxyTarget, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err == nil {
+		if t.failures >= p.threshold {
+			p.logger.Info().Str("target", t.url.String()).Msg("node restored")
+		}
+		t.failures = 0
+		return
+	}
+	t.failures++
+	if t.failures >= p.threshold {
+		if t.failures == p.threshold {
+			p.logger.Warn().Err(err).Str("target", t.url.String()).Msg("node ejected")
+		}
+		t.ejectedAt = time.Now()
+	}
+}
+
+// start starts checking the nodes in background, right away then every
+// interval. The nodes are deemed healthy until their first check completes.
+// The nodes aren't checked if the interval isn't positive.
+func (p *nodePool) start() {
+	if p.interval <= 0 {
+		close(p.done)
+		return
+	}
+	go p.run()
+}
+
+// stop stops checking the nodes.
+func (p *nodePool) stop() {
+	select {
+	case <-p.quit:
+	default:
+		close(p.quit)
+	}
+	<-p.done
+}
+
+func (p *nodePool) run() {
+	defer close(p.done)
+
+	p.check()
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.quit:
+			return
+		case <-ticker.C:
+			p.check()
+		}
+	}
+}
+
+// check probes all the nodes concurrently and updates their health.
+func (p *nodePool) check() {
+	var wg sync.WaitGroup
+	for _, t := range p.targets {
+		wg.Add(1)
+		go func(t *proxyTarget) {
+			defer wg.Done()
+
+			err := p.probe(t.url)
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			if err != nil && t.healthy {
+				p.logger.Warn().Err(err).Str("target", t.url.String()).Msg("health check failed")
+			} else if err == nil && !t.healthy {
+				p.logger.Info().Str("target", t.url.String()).Msg("node healthy")
+			}
+			t.healthy = err == nil
+		}(t)
+	}
+	wg.Wait()
+}
+
+// probe checks the health of the node: its health check path must respond with
+// a 2xx status, or it must accept connections if there's none.
+func (p *nodePool) probe(target *url.URL) error {
+	if p.healthCheckPath == "" {
+		port := target.Port()
+		if port == "" {
+			port = "80"
+			if target.Scheme == "https" {
+				port = "443"
+			}
+		}
+		c, err := net.DialTimeout("tcp", net.JoinHostPort(target.Hostname(), port), p.client.Timeout)
+		if err != nil {
+			return err
+		}
+		return c.Close()
+	}
+
+	u := *target
+	u.Path = strings.TrimSuffix(u.Path, "/") + p.healthCheckPath
+	resp, err := p.client.Get(u.String())
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("health check responded with %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package http
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/midgard/internal/config"
+)
+
+type NodePoolSuite struct{}
+
+var _ = Suite(&NodePoolSuite{})
+
+var nodePoolConfig = config.NodeProxyConfiguration{
+	RateLimit:           100,
+	BurstLimit:          100,
+	HealthCheckInterval: time.Hour,
+	HealthCheckTimeout:  time.Second,
+	EjectionThreshold:   2,
+	EjectionDuration:    time.Hour,
+}
+
+func newNodeServer(name string, status *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			return
+		}
+		if status != nil {
+			w.WriteHeader(*status)
+		}
+		fmt.Fprint(w, name)
+	}))
+}
+
+func getBody(c *C, url string) (int, string) {
+	resp, err := http.Get(url)
+	c.Assert(err, IsNil)
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, IsNil)
+	return resp.StatusCode, string(data)
+}
+
+func (s *NodePoolSuite) TestBalancing(c *C) {
+	node1 := newNodeServer("node1", nil)
+	defer node1.Close()
+	node2 := newNodeServer("node2", nil)
+	defer node2.Close()
+	down := newNodeServer("down", nil)
+	down.Close()
+	status := http.StatusOK
+	failing := newNodeServer("failing", &status)
+	defer failing.Close()
+
+	conf := nodePoolConfig
+	conf.FullNodes = []config.NodeProxy{{
+		Chain:   "bnb",
+		Target:  node1.URL,
+		Targets: []string{node2.URL, down.URL, failing.URL},
+	}}
+	proxy, err := NewProxyHandler(conf, "/v1/nodes", NewMemoryLimiterStore(), log.Logger)
+	c.Assert(err, IsNil)
+	defer proxy.Stop()
+	e := echo.New()
+	proxy.RegisterHandler(e)
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	// The node which is down fails the health check.
+	waitChecked(c, proxy.nodes["bnb"], down.URL, false)
+	var bodies []string
+	for i := 0; i < 6; i++ {
+		code, body := getBody(c, server.URL+"/v1/nodes/bnb/block")
+		c.Assert(code, Equals, http.StatusOK)
+		bodies = append(bodies, body)
+	}
+	c.Assert(bodies, DeepEquals, []string{"node1", "node2", "failing", "node1", "node2", "failing"})
+
+	// The failing node is ejected after 2 failed requests.
+	status = http.StatusBadGateway
+	bodies = nil
+	for i := 0; i < 8; i++ {
+		_, body := getBody(c, server.URL+"/v1/nodes/bnb/block")
+		bodies = append(bodies, body)
+	}
+	c.Assert(bodies, DeepEquals, []string{"node1", "node2", "failing", "node1", "node2", "failing", "node1", "node2"})
+
+	node1.Close()
+	node2.Close()
+	for i := 0; i < 4; i++ {
+		code, _ := getBody(c, server.URL+"/v1/nodes/bnb/block")
+		c.Assert(code, Equals, http.StatusBadGateway)
+	}
+	// No node is left.
+	code, _ := getBody(c, server.URL+"/v1/nodes/bnb/block")
+	c.Assert(code, Equals, http.StatusServiceUnavailable)
+}
+
+func (s *NodePoolSuite) TestHealthCheckPath(c *C) {
+	healthy := newNodeServer("healthy", nil)
+	defer healthy.Close()
+	unhealthy := httptest.NewServer(http.NotFoundHandler())
+	defer unhealthy.Close()
+
+	pool, err := newNodePool(config.NodeProxy{
+		Chain:           "btc",
+		Targets:         []string{unhealthy.URL, healthy.URL},
+		HealthCheckPath: "/health",
+	}, nodePoolConfig, log.Logger)
+	c.Assert(err, IsNil)
+	pool.start()
+	defer pool.stop()
+	waitChecked(c, pool, unhealthy.URL, false)
+	for i := 0; i < 3; i++ {
+		t := pool.pick(false, "")
+		c.Assert(t.url.String(), Equals, healthy.URL)
+		pool.release(t)
+	}
+}
+
+func (s *NodePoolSuite) TestLeastConnections(c *C) {
+	conf := nodePoolConfig
+	conf.HealthCheckInterval = 0
+	pool, err := newNodePool(config.NodeProxy{
+		Chain:     "eth",
+		Targets:   []string{"http://node1", "http://node2", "http://node3"},
+		Balancing: leastConnections,
+	}, conf, log.Logger)
+	c.Assert(err, IsNil)
+
+	t1 := pool.pick(false, "")
+	t2 := pool.pick(false, "")
+	t3 := pool.pick(false, "")
+	c.Assert([]string{t1.url.Host, t2.url.Host, t3.url.Host}, DeepEquals, []string{"node1", "node2", "node3"})
+	pool.release(t2)
+	c.Assert(pool.pick(false, ""), Equals, t2)
+	pool.release(t1)
+	c.Assert(pool.pick(false, ""), Equals, t1)
+
+	_, err = newNodePool(config.NodeProxy{Chain: "eth", Target: "http://node1", Balancing: "random"}, conf, log.Logger)
+	c.Assert(err, ErrorMatches, "unknown balancing random for chain eth")
+	_, err = newNodePool(config.NodeProxy{Chain: "eth"}, conf, log.Logger)
+	c.Assert(err, ErrorMatches, "no target for chain eth")
+}
+
+func (s *NodePoolSuite) TestStickyWebsocket(c *C) {
+	conf := nodePoolConfig
+	conf.HealthCheckInterval = 0
+	pool, err := newNodePool(config.NodeProxy{
+		Chain:         "bnb",
+		Targets:       []string{"http://node1", "http://node2", "http://node3"},
+		WebsocketPath: "/websocket",
+	}, conf, log.Logger)
+	c.Assert(err, IsNil)
+	c.Assert(pool.isWebsocket("/websocket"), Equals, true)
+	c.Assert(pool.isWebsocket("/status"), Equals, false)
+
+	sticky := pool.pick(true, "1.2.3.4")
+	for i := 0; i < 5; i++ {
+		c.Assert(pool.pick(true, "1.2.3.4"), Equals, sticky)
+	}
+	picked := map[*proxyTarget]bool{}
+	for i := 0; i < 20; i++ {
+		picked[pool.pick(true, fmt.Sprintf("10.0.0.%d", i))] = true
+	}
+	c.Assert(len(picked) > 1, Equals, true)
+
+	// The clients of an ejected node move to another one.
+	pool.report(sticky, fmt.Errorf("connection refused"))
+	pool.report(sticky, fmt.Errorf("connection refused"))
+	moved := pool.pick(true, "1.2.3.4")
+	c.Assert(moved, Not(Equals), sticky)
+	pool.report(sticky, nil)
+	c.Assert(pool.pick(true, "1.2.3.4"), Equals, sticky)
+}
+
+// waitChecked waits for the background health check to find the node healthy
+// or not.
+func waitChecked(c *C, pool *nodePool, url string, healthy bool) {
+	for i := 0; i < 100; i++ {
+		pool.mu.Lock()
+		for _, t := range pool.targets {
+			if t.url.String() == url && t.healthy == healthy {
+				pool.mu.Unlock()
+				return
+			}
+		}
+		pool.mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+	}
+	c.Fatalf("node %s wasn't checked", url)
+}
@@ -7,12 +7,15 @@ import (
 	"bytes"
 	"compress/gzip"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
 	"net/http"
 	"strings"
+	"time"
 )
 
 // AssetDetail defines model for AssetDetail.
@@ -63,11 +66,110 @@ type BondMetrics struct {
 	TotalStandbyBond *string `json:"totalStandbyBond,omitempty"`
 }
 
+// DepthCurvePoint defines model for DepthCurvePoint.
+type DepthCurvePoint struct {
+
+	// Expected liquidity fee of the trade in rune
+	Fee *string `json:"fee,omitempty"`
+
+	// Expected value of the trade output in rune
+	Output *string `json:"output,omitempty"`
+
+	// Expected slip of the trade (tradeSize / (tradeSize + runeDepth))
+	Slip *string `json:"slip,omitempty"`
+
+	// Value of the trade in rune
+	TradeSize *string `json:"tradeSize,omitempty"`
+}
+
+// DeviationAlert defines model for DeviationAlert.
+type DeviationAlert struct {
+	Asset     *Asset          `json:"asset,omitempty"`
+	Deviation *PriceDeviation `json:"deviation,omitempty"`
+
+	// Time when the deviation exceeded the threshold in unix timestamp
+	Since *int64 `json:"since,omitempty"`
+}
+
+// Distribution defines model for Distribution.
+type Distribution struct {
+	Average   *string            `json:"average,omitempty"`
+	Histogram *[]HistogramBucket `json:"histogram,omitempty"`
+	P50       *string            `json:"p50,omitempty"`
+	P90       *string            `json:"p90,omitempty"`
+	P99       *string            `json:"p99,omitempty"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	Error string `json:"error"`
 }
 
+// FeeChanges defines model for FeeChanges.
+type FeeChanges struct {
+
+	// Sum of fees charged in asset
+	AssetAmount *string `json:"assetAmount,omitempty"`
+
+	// Number of charged events
+	Count *string `json:"count,omitempty"`
+
+	// Sum of network fees in RUNE
+	NetworkFeeInRune *string `json:"networkFeeInRune,omitempty"`
+	Pool             *Asset  `json:"pool,omitempty"`
+
+	// Sum of RUNE deducted from the pool to the reserve
+	PoolDeduct *string `json:"poolDeduct,omitempty"`
+
+	// Sum of fees charged in RUNE
+	RuneAmount *string `json:"runeAmount,omitempty"`
+
+	// Determining start of current time bucket in unix timestamp
+	Time *int64 `json:"time,omitempty"`
+
+	// Type of the events which fees are charged from
+	Type *string `json:"type,omitempty"`
+}
+
+// GasChanges defines model for GasChanges.
+type GasChanges struct {
+
+	// gasUsed / outboundCount
+	AverageGas *string `json:"averageGas,omitempty"`
+	Chain      *string `json:"chain,omitempty"`
+
+	// gasReplenished / gasUsedInRune
+	Coverage *string `json:"coverage,omitempty"`
+
+	// Sum of rune paid by the network to reimburse gasUsed
+	GasReplenished *string `json:"gasReplenished,omitempty"`
+
+	// Sum of gas spent by outbound transactions (in chain's gas asset)
+	GasUsed *string `json:"gasUsed,omitempty"`
+
+	// gasUsed valued in rune at the pool price of each gas event
+	GasUsedInRune *string `json:"gasUsedInRune,omitempty"`
+
+	// Number of outbound transactions
+	OutboundCount *string `json:"outboundCount,omitempty"`
+
+	// Determining start of current time bucket in unix timestamp
+	Time *int64 `json:"time,omitempty"`
+}
+
+// HistogramBucket defines model for HistogramBucket.
+type HistogramBucket struct {
+
+	// Number of swaps in the bucket
+	Count *int64 `json:"count,omitempty"`
+
+	// Inclusive lower bound of the bucket
+	From *string `json:"from,omitempty"`
+
+	// Exclusive upper bound of the bucket. Not provided for the last bucket.
+	To *string `json:"to,omitempty"`
+}
+
 // NetworkInfo defines model for NetworkInfo.
 type NetworkInfo struct {
 
@@ -115,6 +217,30 @@ type NodeKey struct {
 	Secp256k1 *string `json:"secp256k1,omitempty"`
 }
 
+// PendingTx defines model for PendingTx.
+type PendingTx struct {
+
+	// Seconds since the tx was observed
+	Age  *int64 `json:"age,omitempty"`
+	Date *int64 `json:"date,omitempty"`
+
+	// Number of outbound txs required to complete the tx. Zero means the first outbound completes it.
+	ExpectedOutbounds *int64  `json:"expectedOutbounds,omitempty"`
+	Height            *string `json:"height,omitempty"`
+	In                *Tx     `json:"in,omitempty"`
+
+	// Chain on which the outbound tx is expected
+	OutboundChain *string `json:"outboundChain,omitempty"`
+
+	// Number of outbound txs observed so far
+	Outbounds *int64 `json:"outbounds,omitempty"`
+	Pool      *Asset `json:"pool,omitempty"`
+
+	// True if the tx has been pending for longer than the stuck threshold
+	Stuck *bool   `json:"stuck,omitempty"`
+	Type  *string `json:"type,omitempty"`
+}
+
 // PoolAggChanges defines model for PoolAggChanges.
 type PoolAggChanges struct {
 
@@ -182,6 +308,41 @@ type PoolAggChanges struct {
 	WithdrawCount *int64 `json:"withdrawCount,omitempty"`
 }
 
+// PoolConcentration defines model for PoolConcentration.
+type PoolConcentration struct {
+
+	// Gini coefficient of the stakers units (0 means equal ownership and 1 means a single owner)
+	Gini *string `json:"gini,omitempty"`
+
+	// Herfindahl index (sum of squared shares of the stakers)
+	Herfindahl   *string `json:"herfindahl,omitempty"`
+	StakersCount *int64  `json:"stakersCount,omitempty"`
+
+	// Time of the calculation in unix timestamp
+	Time *int64 `json:"time,omitempty"`
+
+	// Share of the 10 largest stakers from the pool units
+	Top10Share *string `json:"top10Share,omitempty"`
+
+	// Total units of the pool
+	Units *string `json:"units,omitempty"`
+}
+
+// PoolDepthCurve defines model for PoolDepthCurve.
+type PoolDepthCurve struct {
+
+	// Current asset depth of the pool
+	AssetDepth *string            `json:"assetDepth,omitempty"`
+	History    *[]SlipDepth       `json:"history,omitempty"`
+	Points     *[]DepthCurvePoint `json:"points,omitempty"`
+
+	// Current asset price in rune
+	Price *string `json:"price,omitempty"`
+
+	// Current rune depth of the pool
+	RuneDepth *string `json:"runeDepth,omitempty"`
+}
+
 // PoolDetail defines model for PoolDetail.
 type PoolDetail struct {
 	Asset *Asset `json:"asset,omitempty"`
@@ -195,6 +356,9 @@ type PoolDetail struct {
 	// Asset return on investment
 	AssetROI *string `json:"assetROI,omitempty"`
 
+	// Total amount of asset changed by slashes
+	AssetSlashed *string `json:"assetSlashed,omitempty"`
+
 	// Total Asset staked
 	AssetStakedTotal *string `json:"assetStakedTotal,omitempty"`
 
@@ -267,6 +431,9 @@ type PoolDetail struct {
 	// RUNE return on investment
 	RuneROI *string `json:"runeROI,omitempty"`
 
+	// Total amount of rune changed by slashes
+	RuneSlashed *string `json:"runeSlashed,omitempty"`
+
 	// Total RUNE staked
 	RuneStakedTotal *string `json:"runeStakedTotal,omitempty"`
 
@@ -308,6 +475,214 @@ type PoolDetail struct {
 	WithdrawTxCount *string `json:"withdrawTxCount,omitempty"`
 }
 
+// PoolDeviation defines model for PoolDeviation.
+type PoolDeviation struct {
+	Alert   *DeviationAlert   `json:"alert,omitempty"`
+	History *[]PriceDeviation `json:"history,omitempty"`
+}
+
+// PoolStaker defines model for PoolStaker.
+type PoolStaker struct {
+	Address *string `json:"address,omitempty"`
+
+	// Share of the staker from the pool units (0 to 1)
+	Share *string `json:"share,omitempty"`
+
+	// Units of the staker in the pool
+	Units *string `json:"units,omitempty"`
+}
+
+// PoolStakers defines model for PoolStakers.
+type PoolStakers struct {
+	Concentration *PoolConcentration `json:"concentration,omitempty"`
+	Stakers       *[]PoolStaker      `json:"stakers,omitempty"`
+
+	// Number of the pool stakers
+	TotalCount *int64 `json:"totalCount,omitempty"`
+}
+
+// PoolYieldChanges defines model for PoolYieldChanges.
+type PoolYieldChanges struct {
+
+	// Depth of asset at the end of current time bucket
+	AssetDepth *string `json:"assetDepth,omitempty"`
+
+	// Liquidity fees of buy swaps (in asset)
+	BuyFees *string `json:"buyFees,omitempty"`
+
+	// (buyFees * price) + sellFees
+	FeesEarned *string `json:"feesEarned,omitempty"`
+
+	// Total amount of rune, paid by the network to neutralize "gasUsed" changes
+	GasReplenished *string `json:"gasReplenished,omitempty"`
+
+	// Total amount of asset used as gas for network transactions
+	GasUsed *string `json:"gasUsed,omitempty"`
+
+	// (1 + (poolEarned/poolDepth)) ^ (number of intervals in a year) -1
+	PoolAPY *string `json:"poolAPY,omitempty"`
+
+	// ((gasUsed + buyFees) * price) + gasReplenished + reward + sellFees
+	PoolEarned *string `json:"poolEarned,omitempty"`
+
+	// Asset price in rune at the end of current time bucket
+	Price *string `json:"price,omitempty"`
+
+	// Sum of "rewards" events in rune
+	Reward *string `json:"reward,omitempty"`
+
+	// Depth of rune at the end of current time bucket
+	RuneDepth *string `json:"runeDepth,omitempty"`
+
+	// Liquidity fees of sell swaps (in rune)
+	SellFees *string `json:"sellFees,omitempty"`
+
+	// Determining end of current time bucket in unix timestamp
+	Time *int64 `json:"time,omitempty"`
+}
+
+// PoolYieldHistory defines model for PoolYieldHistory.
+type PoolYieldHistory struct {
+
+	// APY based on the pool earnings in the last 30 days of the period
+	Apy30d *string `json:"apy30d,omitempty"`
+
+	// APY based on the pool earnings in the last 7 days of the period
+	Apy7d *string `json:"apy7d,omitempty"`
+
+	// APY based on the pool earnings in the last 90 days of the period
+	Apy90d    *string             `json:"apy90d,omitempty"`
+	Intervals *[]PoolYieldChanges `json:"intervals,omitempty"`
+}
+
+// PriceDeviation defines model for PriceDeviation.
+type PriceDeviation struct {
+
+	// (poolPrice - referencePrice) / referencePrice
+	Deviation *string `json:"deviation,omitempty"`
+
+	// Height of the block
+	Height *int64 `json:"height,omitempty"`
+
+	// USD price of the asset implied by the pool (price * RUNE/USD reference price)
+	PoolPrice *string `json:"poolPrice,omitempty"`
+
+	// Reference USD price of the asset
+	ReferencePrice *string `json:"referencePrice,omitempty"`
+
+	// Time of the block in unix timestamp
+	Time *int64 `json:"time,omitempty"`
+}
+
+// QuarantinedEvent defines model for QuarantinedEvent.
+type QuarantinedEvent struct {
+	Attributes *QuarantinedEvent_Attributes `json:"attributes,omitempty"`
+	Date       *int64                       `json:"date,omitempty"`
+	Height     *string                      `json:"height,omitempty"`
+	Id         *int64                       `json:"id,omitempty"`
+	Reason     *string                      `json:"reason,omitempty"`
+	Type       *string                      `json:"type,omitempty"`
+}
+
+// QuarantinedEvent_Attributes defines model for QuarantinedEvent.Attributes.
+type QuarantinedEvent_Attributes struct {
+	AdditionalProperties map[string]string `json:"-"`
+}
+
+// RebuildStatus defines model for RebuildStatus.
+type RebuildStatus struct {
+	Error      *string    `json:"error,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+
+	// Last block replayed into the new tables
+	Height *int64 `json:"height,omitempty"`
+
+	// Ratio of the blocks replayed from startHeight to targetHeight between 0 and 1
+	Progress *float64 `json:"progress,omitempty"`
+
+	// Number of events quarantined in the new tables
+	Quarantined *int64 `json:"quarantined,omitempty"`
+
+	// First archived block, from which the replay starts
+	StartHeight *int64     `json:"startHeight,omitempty"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	State       *string    `json:"state,omitempty"`
+
+	// Last archived block to replay
+	TargetHeight *int64 `json:"targetHeight,omitempty"`
+}
+
+// ReserveChanges defines model for ReserveChanges.
+type ReserveChanges struct {
+
+	// Height of the last block recorded in the bucket
+	Height *string `json:"height,omitempty"`
+
+	// Determining start of current time bucket in unix timestamp
+	Time *int64 `json:"time,omitempty"`
+
+	// Balance of the reserve at the end of the bucket
+	TotalReserve *string `json:"totalReserve,omitempty"`
+}
+
+// RewardsChanges defines model for RewardsChanges.
+type RewardsChanges struct {
+
+	// Number of blocks with rewards
+	Blocks *string `json:"blocks,omitempty"`
+
+	// Sum of rewards paid to bonders
+	BondReward *string `json:"bondReward,omitempty"`
+
+	// Emission of the blocks according to the emission schedule and reserve balance (zero if reserve balance is not known)
+	ExpectedEmission *string `json:"expectedEmission,omitempty"`
+
+	// Sum of liquidity rewards paid to pools (negative if pools paid to the reserve)
+	PoolRewards *string `json:"poolRewards,omitempty"`
+
+	// Determining start of current time bucket in unix timestamp
+	Time *int64 `json:"time,omitempty"`
+
+	// bondReward + poolRewards
+	TotalReward *string `json:"totalReward,omitempty"`
+}
+
+// SlashChanges defines model for SlashChanges.
+type SlashChanges struct {
+
+	// Sum of asset amounts changed by slashes
+	AssetAmount *string `json:"assetAmount,omitempty"`
+
+	// Number of slash events
+	Count *string `json:"count,omitempty"`
+	Pool  *Asset  `json:"pool,omitempty"`
+
+	// Sum of rune amounts changed by slashes
+	RuneAmount *string `json:"runeAmount,omitempty"`
+
+	// Determining start of current time bucket in unix timestamp
+	Time *int64 `json:"time,omitempty"`
+}
+
+// SlipDepth defines model for SlipDepth.
+type SlipDepth struct {
+
+	// Depth of asset at the end of current time bucket
+	AssetDepth *string `json:"assetDepth,omitempty"`
+
+	// Size of a sell trade in asset causing 1% slip
+	AssetSize *string `json:"assetSize,omitempty"`
+
+	// Depth of rune at the end of current time bucket
+	RuneDepth *string `json:"runeDepth,omitempty"`
+
+	// Size of a buy trade in rune causing 1% slip
+	RuneSize *string `json:"runeSize,omitempty"`
+
+	// Determining end of current time bucket in unix timestamp
+	Time *int64 `json:"time,omitempty"`
+}
+
 // Stakers defines model for Stakers.
 type Stakers string
 
@@ -399,6 +774,35 @@ type StatsData struct {
 	TotalWithdrawTx *string `json:"totalWithdrawTx,omitempty"`
 }
 
+// SwapAnalytics defines model for SwapAnalytics.
+type SwapAnalytics struct {
+
+	// Distribution of a swap metric. Trade size and fee are in rune.
+	Fee *Distribution `json:"fee,omitempty"`
+
+	// Distribution of a swap metric. Trade size and fee are in rune.
+	Slip *Distribution `json:"slip,omitempty"`
+
+	// Number of swaps in the window
+	SwapCount   *int64     `json:"swapCount,omitempty"`
+	TopSwappers *[]Swapper `json:"topSwappers,omitempty"`
+
+	// Distribution of a swap metric. Trade size and fee are in rune.
+	TradeSize *Distribution `json:"tradeSize,omitempty"`
+}
+
+// Swapper defines model for Swapper.
+type Swapper struct {
+	Address *string `json:"address,omitempty"`
+
+	// Total liquidity fee of the swaps in rune
+	Fees      *string `json:"fees,omitempty"`
+	SwapCount *int64  `json:"swapCount,omitempty"`
+
+	// Total trade size of the swaps in rune
+	Volume *string `json:"volume,omitempty"`
+}
+
 // ThorchainBooleanConstants defines model for ThorchainBooleanConstants.
 type ThorchainBooleanConstants struct {
 	StrictBondStakeRatio *bool `json:"StrictBondStakeRatio,omitempty"`
@@ -491,16 +895,17 @@ type TotalVolChanges struct {
 
 // TxDetails defines model for TxDetails.
 type TxDetails struct {
-	Date    *int64  `json:"date,omitempty"`
-	Events  *Event  `json:"events,omitempty"`
-	Gas     *Gas    `json:"gas,omitempty"`
-	Height  *string `json:"height,omitempty"`
-	In      *Tx     `json:"in,omitempty"`
-	Options *Option `json:"options,omitempty"`
-	Out     *[]Tx   `json:"out,omitempty"`
-	Pool    *Asset  `json:"pool,omitempty"`
-	Status  *string `json:"status,omitempty"`
-	Type    *string `json:"type,omitempty"`
+	Date    *int64        `json:"date,omitempty"`
+	Events  *Event        `json:"events,omitempty"`
+	Fees    *FeeBreakdown `json:"fees,omitempty"`
+	Gas     *Gas          `json:"gas,omitempty"`
+	Height  *string       `json:"height,omitempty"`
+	In      *Tx           `json:"in,omitempty"`
+	Options *Option       `json:"options,omitempty"`
+	Out     *[]Tx         `json:"out,omitempty"`
+	Pool    *Asset        `json:"pool,omitempty"`
+	Status  *string       `json:"status,omitempty"`
+	Type    *string       `json:"type,omitempty"`
 }
 
 // Asset defines model for asset.
@@ -517,9 +922,26 @@ type Coins []Coin
 
 // Event defines model for event.
 type Event struct {
-	Fee        *string `json:"fee,omitempty"`
-	Slip       *string `json:"slip,omitempty"`
-	StakeUnits *string `json:"stakeUnits,omitempty"`
+	Fee *string `json:"fee,omitempty"`
+
+	// Rune received by the first leg and spent by the second leg of a double swap
+	IntermediateRune *string `json:"intermediateRune,omitempty"`
+
+	// Details of each leg of a double swap
+	Legs       *[]SwapLeg `json:"legs,omitempty"`
+	Slash      *Coins     `json:"slash,omitempty"`
+	Slip       *string    `json:"slip,omitempty"`
+	StakeUnits *string    `json:"stakeUnits,omitempty"`
+}
+
+// FeeBreakdown defines model for feeBreakdown.
+type FeeBreakdown struct {
+	LiquidityFee *string `json:"liquidityFee,omitempty"`
+	NetworkFee   *Coins  `json:"networkFee,omitempty"`
+
+	// Network fee charged from the outbound to cover its gas (in RUNE)
+	NetworkFeeInRune *string `json:"networkFeeInRune,omitempty"`
+	Slip             *string `json:"slip,omitempty"`
 }
 
 // Gas defines model for gas.
@@ -535,6 +957,13 @@ type Option struct {
 	WithdrawBasisPoints *string `json:"withdrawBasisPoints,omitempty"`
 }
 
+// SwapLeg defines model for swapLeg.
+type SwapLeg struct {
+	Fee  *string `json:"fee,omitempty"`
+	Pool *Asset  `json:"pool,omitempty"`
+	Slip *string `json:"slip,omitempty"`
+}
+
 // Tx defines model for tx.
 type Tx struct {
 	Address *string `json:"address,omitempty"`
@@ -546,6 +975,15 @@ type Tx struct {
 // AssetsDetailedResponse defines model for AssetsDetailedResponse.
 type AssetsDetailedResponse []AssetDetail
 
+// DeviationAlertsResponse defines model for DeviationAlertsResponse.
+type DeviationAlertsResponse []DeviationAlert
+
+// FeeHistoryResponse defines model for FeeHistoryResponse.
+type FeeHistoryResponse []FeeChanges
+
+// GasHistoryResponse defines model for GasHistoryResponse.
+type GasHistoryResponse []GasChanges
+
 // GeneralErrorResponse defines model for GeneralErrorResponse.
 type GeneralErrorResponse Error
 
@@ -554,9 +992,18 @@ type GetPoolAggChangesResponse []PoolAggChanges
 
 // HealthResponse defines model for HealthResponse.
 type HealthResponse struct {
-	CatchingUp    *bool  `json:"catching_up,omitempty"`
-	Database      *bool  `json:"database,omitempty"`
-	ScannerHeight *int64 `json:"scannerHeight,omitempty"`
+	CatchingUp *bool `json:"catching_up,omitempty"`
+	Database   *bool `json:"database,omitempty"`
+
+	// Number of txs waiting for their outbound
+	PendingTxs *int64 `json:"pendingTxs,omitempty"`
+
+	// Status of the latest rebuild of the derived tables
+	Rebuild       *RebuildStatus `json:"rebuild,omitempty"`
+	ScannerHeight *int64         `json:"scannerHeight,omitempty"`
+
+	// Number of txs waiting for their outbound longer than the stuck threshold
+	StuckTxs *int64 `json:"stuckTxs,omitempty"`
 }
 
 // NetworkResponse defines model for NetworkResponse.
@@ -565,12 +1012,51 @@ type NetworkResponse NetworkInfo
 // NodeKeyResponse defines model for NodeKeyResponse.
 type NodeKeyResponse []NodeKey
 
+// PendingTxsResponse defines model for PendingTxsResponse.
+type PendingTxsResponse struct {
+	Count *int64       `json:"count,omitempty"`
+	Txs   *[]PendingTx `json:"txs,omitempty"`
+}
+
+// PoolConcentrationHistoryResponse defines model for PoolConcentrationHistoryResponse.
+type PoolConcentrationHistoryResponse []PoolConcentration
+
+// PoolDepthCurveResponse defines model for PoolDepthCurveResponse.
+type PoolDepthCurveResponse PoolDepthCurve
+
+// PoolDeviationResponse defines model for PoolDeviationResponse.
+type PoolDeviationResponse PoolDeviation
+
+// PoolStakersResponse defines model for PoolStakersResponse.
+type PoolStakersResponse PoolStakers
+
+// PoolYieldHistoryResponse defines model for PoolYieldHistoryResponse.
+type PoolYieldHistoryResponse PoolYieldHistory
+
 // PoolsDetailedResponse defines model for PoolsDetailedResponse.
 type PoolsDetailedResponse []PoolDetail
 
 // PoolsResponse defines model for PoolsResponse.
 type PoolsResponse []Asset
 
+// QuarantinedEventsResponse defines model for QuarantinedEventsResponse.
+type QuarantinedEventsResponse struct {
+	Count  *int64              `json:"count,omitempty"`
+	Events *[]QuarantinedEvent `json:"events,omitempty"`
+}
+
+// RebuildResponse defines model for RebuildResponse.
+type RebuildResponse RebuildStatus
+
+// ReserveHistoryResponse defines model for ReserveHistoryResponse.
+type ReserveHistoryResponse []ReserveChanges
+
+// RewardsHistoryResponse defines model for RewardsHistoryResponse.
+type RewardsHistoryResponse []RewardsChanges
+
+// SlashHistoryResponse defines model for SlashHistoryResponse.
+type SlashHistoryResponse []SlashChanges
+
 // StakersAddressDataResponse defines model for StakersAddressDataResponse.
 type StakersAddressDataResponse StakersAddressData
 
@@ -583,6 +1069,9 @@ type StakersResponse []Stakers
 // StatsResponse defines model for StatsResponse.
 type StatsResponse StatsData
 
+// SwapAnalyticsResponse defines model for SwapAnalyticsResponse.
+type SwapAnalyticsResponse SwapAnalytics
+
 // ThorchainConstantsResponse defines model for ThorchainConstantsResponse.
 type ThorchainConstantsResponse ThorchainConstants
 
@@ -604,6 +1093,22 @@ type TxsResponse struct {
 	Txs   *[]TxDetails `json:"txs,omitempty"`
 }
 
+// GetSwapAnalyticsParams defines parameters for GetSwapAnalytics.
+type GetSwapAnalyticsParams struct {
+
+	// Pool asset name. All pools are included if not provided.
+	Asset *string `json:"asset,omitempty"`
+
+	// Start time of the window as unix timestamp. Defaults to 30 days before "to".
+	From *int64 `json:"from,omitempty"`
+
+	// End time of the window as unix timestamp. Defaults to now.
+	To *int64 `json:"to,omitempty"`
+
+	// Number of top swappers. Defaults to 10.
+	Limit *int64 `json:"limit,omitempty"`
+}
+
 // GetAssetInfoParams defines parameters for GetAssetInfo.
 type GetAssetInfoParams struct {
 
@@ -611,11 +1116,21 @@ type GetAssetInfoParams struct {
 	Asset string `json:"asset"`
 }
 
-// GetPoolAggChangesParams defines parameters for GetPoolAggChanges.
-type GetPoolAggChangesParams struct {
+// GetQuarantinedEventsParams defines parameters for GetQuarantinedEvents.
+type GetQuarantinedEventsParams struct {
 
-	// Pool asset name
-	Pool string `json:"pool"`
+	// pagination offset
+	Offset int64 `json:"offset"`
+
+	// pagination limit
+	Limit int64 `json:"limit"`
+}
+
+// GetFeeHistoryParams defines parameters for GetFeeHistory.
+type GetFeeHistoryParams struct {
+
+	// Pool asset name. Fees of all pools are returned if it's not specified.
+	Pool *string `json:"pool,omitempty"`
 
 	// Interval of calculations
 	Interval string `json:"interval"`
@@ -627,8 +1142,11 @@ type GetPoolAggChangesParams struct {
 	To int64 `json:"to"`
 }
 
-// GetTotalVolChangesParams defines parameters for GetTotalVolChanges.
-type GetTotalVolChangesParams struct {
+// GetGasHistoryParams defines parameters for GetGasHistory.
+type GetGasHistoryParams struct {
+
+	// Chain name. Gas of all chains is returned if it's not specified.
+	Chain *string `json:"chain,omitempty"`
 
 	// Interval of calculations
 	Interval string `json:"interval"`
@@ -640,31 +1158,161 @@ type GetTotalVolChangesParams struct {
 	To int64 `json:"to"`
 }
 
-// GetPoolsDetailsParams defines parameters for GetPoolsDetails.
-type GetPoolsDetailsParams struct {
+// GetPoolAggChangesParams defines parameters for GetPoolAggChanges.
+type GetPoolAggChangesParams struct {
 
-	// Specifies the returning view
-	View *string `json:"view,omitempty"`
+	// Pool asset name
+	Pool string `json:"pool"`
 
-	// One or more comma separated unique asset (CHAIN.SYMBOL)
-	Asset string `json:"asset"`
-}
+	// Interval of calculations
+	Interval string `json:"interval"`
 
-// GetStakersAddressAndAssetDataParams defines parameters for GetStakersAddressAndAssetData.
-type GetStakersAddressAndAssetDataParams struct {
+	// Start time of the query as unix timestamp
+	From int64 `json:"from"`
 
-	// One or more comma separated unique asset (CHAIN.SYMBOL)
-	Asset string `json:"asset"`
+	// End time of the query as unix timestamp
+	To int64 `json:"to"`
 }
 
-// GetTxDetailsParams defines parameters for GetTxDetails.
-type GetTxDetailsParams struct {
+// GetPoolConcentrationHistoryParams defines parameters for GetPoolConcentrationHistory.
+type GetPoolConcentrationHistoryParams struct {
 
-	// Address of sender or recipient of any in/out tx in event
-	Address *string `json:"address,omitempty"`
+	// Interval of calculations
+	Interval string `json:"interval"`
 
-	// ID of any in/out tx in event
-	Txid *string `json:"txid,omitempty"`
+	// Start time of the query as unix timestamp
+	From int64 `json:"from"`
+
+	// End time of the query as unix timestamp
+	To int64 `json:"to"`
+}
+
+// GetPoolYieldHistoryParams defines parameters for GetPoolYieldHistory.
+type GetPoolYieldHistoryParams struct {
+
+	// Interval of calculations
+	Interval string `json:"interval"`
+
+	// Start time of the query as unix timestamp
+	From int64 `json:"from"`
+
+	// End time of the query as unix timestamp
+	To int64 `json:"to"`
+}
+
+// GetReserveHistoryParams defines parameters for GetReserveHistory.
+type GetReserveHistoryParams struct {
+
+	// Interval of calculations
+	Interval string `json:"interval"`
+
+	// Start time of the query as unix timestamp
+	From int64 `json:"from"`
+
+	// End time of the query as unix timestamp
+	To int64 `json:"to"`
+}
+
+// GetRewardsHistoryParams defines parameters for GetRewardsHistory.
+type GetRewardsHistoryParams struct {
+
+	// Interval of calculations
+	Interval string `json:"interval"`
+
+	// Start time of the query as unix timestamp
+	From int64 `json:"from"`
+
+	// End time of the query as unix timestamp
+	To int64 `json:"to"`
+}
+
+// GetSlashHistoryParams defines parameters for GetSlashHistory.
+type GetSlashHistoryParams struct {
+
+	// Pool asset name. Slashes of all pools are returned if it's not specified.
+	Pool *string `json:"pool,omitempty"`
+
+	// Interval of calculations
+	Interval string `json:"interval"`
+
+	// Start time of the query as unix timestamp
+	From int64 `json:"from"`
+
+	// End time of the query as unix timestamp
+	To int64 `json:"to"`
+}
+
+// GetTotalVolChangesParams defines parameters for GetTotalVolChanges.
+type GetTotalVolChangesParams struct {
+
+	// Interval of calculations
+	Interval string `json:"interval"`
+
+	// Start time of the query as unix timestamp
+	From int64 `json:"from"`
+
+	// End time of the query as unix timestamp
+	To int64 `json:"to"`
+}
+
+// GetPoolsDetailsParams defines parameters for GetPoolsDetails.
+type GetPoolsDetailsParams struct {
+
+	// Specifies the returning view
+	View *string `json:"view,omitempty"`
+
+	// One or more comma separated unique asset (CHAIN.SYMBOL)
+	Asset string `json:"asset"`
+}
+
+// GetPoolDepthCurveParams defines parameters for GetPoolDepthCurve.
+type GetPoolDepthCurveParams struct {
+
+	// One to 20 comma separated trade sizes in rune. Defaults to 10, 100, 1000 and 10000 RUNE.
+	Sizes *string `json:"sizes,omitempty"`
+
+	// Start time of the history as unix timestamp. Defaults to 30 days before "to".
+	From *int64 `json:"from,omitempty"`
+
+	// End time of the history as unix timestamp. Defaults to now.
+	To *int64 `json:"to,omitempty"`
+}
+
+// GetPoolDeviationParams defines parameters for GetPoolDeviation.
+type GetPoolDeviationParams struct {
+
+	// Start time of the history as unix timestamp. Defaults to 24 hours before "to".
+	From *int64 `json:"from,omitempty"`
+
+	// End time of the history as unix timestamp. Defaults to now.
+	To *int64 `json:"to,omitempty"`
+}
+
+// GetPoolStakersParams defines parameters for GetPoolStakers.
+type GetPoolStakersParams struct {
+
+	// pagination offset
+	Offset int64 `json:"offset"`
+
+	// pagination limit
+	Limit int64 `json:"limit"`
+}
+
+// GetStakersAddressAndAssetDataParams defines parameters for GetStakersAddressAndAssetData.
+type GetStakersAddressAndAssetDataParams struct {
+
+	// One or more comma separated unique asset (CHAIN.SYMBOL)
+	Asset string `json:"asset"`
+}
+
+// GetTxDetailsParams defines parameters for GetTxDetails.
+type GetTxDetailsParams struct {
+
+	// Address of sender or recipient of any in/out tx in event
+	Address *string `json:"address,omitempty"`
+
+	// ID of any in/out tx in event
+	Txid *string `json:"txid,omitempty"`
 
 	// Any asset used in event (CHAIN.SYMBOL)
 	Asset *string `json:"asset,omitempty"`
@@ -679,20 +1327,116 @@ type GetTxDetailsParams struct {
 	Limit int64 `json:"limit"`
 }
 
+// GetPendingTxsParams defines parameters for GetPendingTxs.
+type GetPendingTxsParams struct {
+
+	// pagination offset
+	Offset int64 `json:"offset"`
+
+	// pagination limit
+	Limit int64 `json:"limit"`
+}
+
+// Getter for additional properties for QuarantinedEvent_Attributes. Returns the specified
+// element and whether it was found
+func (a QuarantinedEvent_Attributes) Get(fieldName string) (value string, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for QuarantinedEvent_Attributes
+func (a *QuarantinedEvent_Attributes) Set(fieldName string, value string) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]string)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for QuarantinedEvent_Attributes to handle AdditionalProperties
+func (a *QuarantinedEvent_Attributes) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]string)
+		for fieldName, fieldBuf := range object {
+			var fieldVal string
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("error unmarshaling field %s", fieldName))
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for QuarantinedEvent_Attributes to handle AdditionalProperties
+func (a QuarantinedEvent_Attributes) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("error marshaling '%s'", fieldName))
+		}
+	}
+	return json.Marshal(object)
+}
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Get Swap Analytics
+	// (GET /v1/analytics/swaps)
+	GetSwapAnalytics(ctx echo.Context, params GetSwapAnalyticsParams) error
 	// Get Asset Information
 	// (GET /v1/assets)
 	GetAssetInfo(ctx echo.Context, params GetAssetInfoParams) error
+	// Get Price Deviation Alerts
+	// (GET /v1/deviation/alerts)
+	GetDeviationAlerts(ctx echo.Context) error
 	// Get Documents
 	// (GET /v1/doc)
 	GetDocs(ctx echo.Context) error
+	// Get quarantined events
+	// (GET /v1/events/quarantine)
+	GetQuarantinedEvents(ctx echo.Context, params GetQuarantinedEventsParams) error
+	// Reprocess quarantined events
+	// (POST /v1/events/quarantine/reprocess)
+	ReprocessQuarantinedEvents(ctx echo.Context) error
 	// Get Health
 	// (GET /v1/health)
 	GetHealth(ctx echo.Context) error
+	// Get Fee History
+	// (GET /v1/history/fees)
+	GetFeeHistory(ctx echo.Context, params GetFeeHistoryParams) error
+	// Get Gas History
+	// (GET /v1/history/gas)
+	GetGasHistory(ctx echo.Context, params GetGasHistoryParams) error
 	// Get Pool Aggregated Changes
 	// (GET /v1/history/pools)
 	GetPoolAggChanges(ctx echo.Context, params GetPoolAggChangesParams) error
+	// Get Pool Concentration History
+	// (GET /v1/history/pools/{asset}/concentration)
+	GetPoolConcentrationHistory(ctx echo.Context, asset string, params GetPoolConcentrationHistoryParams) error
+	// Get Pool Yield History
+	// (GET /v1/history/pools/{asset}/yield)
+	GetPoolYieldHistory(ctx echo.Context, asset string, params GetPoolYieldHistoryParams) error
+	// Get Reserve History
+	// (GET /v1/history/reserve)
+	GetReserveHistory(ctx echo.Context, params GetReserveHistoryParams) error
+	// Get Rewards History
+	// (GET /v1/history/rewards)
+	GetRewardsHistory(ctx echo.Context, params GetRewardsHistoryParams) error
+	// Get Slash History
+	// (GET /v1/history/slashes)
+	GetSlashHistory(ctx echo.Context, params GetSlashHistoryParams) error
 	// Get Total Volume Changes
 	// (GET /v1/history/total_volume)
 	GetTotalVolChanges(ctx echo.Context, params GetTotalVolChangesParams) error
@@ -708,6 +1452,18 @@ type ServerInterface interface {
 	// Get Pools Details
 	// (GET /v1/pools/detail)
 	GetPoolsDetails(ctx echo.Context, params GetPoolsDetailsParams) error
+	// Get Pool Depth Curve
+	// (GET /v1/pools/{asset}/depth-curve)
+	GetPoolDepthCurve(ctx echo.Context, asset string, params GetPoolDepthCurveParams) error
+	// Get Pool Price Deviation
+	// (GET /v1/pools/{asset}/deviation)
+	GetPoolDeviation(ctx echo.Context, asset string, params GetPoolDeviationParams) error
+	// Get Pool Stakers
+	// (GET /v1/pools/{asset}/stakers)
+	GetPoolStakers(ctx echo.Context, asset string, params GetPoolStakersParams) error
+	// Rebuild derived tables
+	// (POST /v1/rebuild)
+	StartRebuild(ctx echo.Context) error
 	// Get Stakers
 	// (GET /v1/stakers)
 	GetStakersData(ctx echo.Context) error
@@ -738,6 +1494,9 @@ type ServerInterface interface {
 	// Get details of a tx by address, asset or tx-id
 	// (GET /v1/txs)
 	GetTxDetails(ctx echo.Context, params GetTxDetailsParams) error
+	// Get txs waiting for outbound
+	// (GET /v1/txs/pending)
+	GetPendingTxs(ctx echo.Context, params GetPendingTxsParams) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -745,6 +1504,45 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// GetSwapAnalytics converts echo context to params.
+func (w *ServerInterfaceWrapper) GetSwapAnalytics(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSwapAnalyticsParams
+	// ------------- Optional query parameter "asset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "asset", ctx.QueryParams(), &params.Asset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter asset: %s", err))
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetSwapAnalytics(ctx, params)
+	return err
+}
+
 // GetAssetInfo converts echo context to params.
 func (w *ServerInterfaceWrapper) GetAssetInfo(ctx echo.Context) error {
 	var err error
@@ -763,6 +1561,15 @@ func (w *ServerInterfaceWrapper) GetAssetInfo(ctx echo.Context) error {
 	return err
 }
 
+// GetDeviationAlerts converts echo context to params.
+func (w *ServerInterfaceWrapper) GetDeviationAlerts(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetDeviationAlerts(ctx)
+	return err
+}
+
 // GetDocs converts echo context to params.
 func (w *ServerInterfaceWrapper) GetDocs(ctx echo.Context) error {
 	var err error
@@ -772,6 +1579,40 @@ func (w *ServerInterfaceWrapper) GetDocs(ctx echo.Context) error {
 	return err
 }
 
+// GetQuarantinedEvents converts echo context to params.
+func (w *ServerInterfaceWrapper) GetQuarantinedEvents(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetQuarantinedEventsParams
+	// ------------- Required query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// ------------- Required query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetQuarantinedEvents(ctx, params)
+	return err
+}
+
+// ReprocessQuarantinedEvents converts echo context to params.
+func (w *ServerInterfaceWrapper) ReprocessQuarantinedEvents(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ReprocessQuarantinedEvents(ctx)
+	return err
+}
+
 // GetHealth converts echo context to params.
 func (w *ServerInterfaceWrapper) GetHealth(ctx echo.Context) error {
 	var err error
@@ -781,6 +1622,84 @@ func (w *ServerInterfaceWrapper) GetHealth(ctx echo.Context) error {
 	return err
 }
 
+// GetFeeHistory converts echo context to params.
+func (w *ServerInterfaceWrapper) GetFeeHistory(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFeeHistoryParams
+	// ------------- Optional query parameter "pool" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pool", ctx.QueryParams(), &params.Pool)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter pool: %s", err))
+	}
+
+	// ------------- Required query parameter "interval" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "interval", ctx.QueryParams(), &params.Interval)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter interval: %s", err))
+	}
+
+	// ------------- Required query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetFeeHistory(ctx, params)
+	return err
+}
+
+// GetGasHistory converts echo context to params.
+func (w *ServerInterfaceWrapper) GetGasHistory(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetGasHistoryParams
+	// ------------- Optional query parameter "chain" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "chain", ctx.QueryParams(), &params.Chain)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter chain: %s", err))
+	}
+
+	// ------------- Required query parameter "interval" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "interval", ctx.QueryParams(), &params.Interval)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter interval: %s", err))
+	}
+
+	// ------------- Required query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetGasHistory(ctx, params)
+	return err
+}
+
 // GetPoolAggChanges converts echo context to params.
 func (w *ServerInterfaceWrapper) GetPoolAggChanges(ctx echo.Context) error {
 	var err error
@@ -820,6 +1739,187 @@ func (w *ServerInterfaceWrapper) GetPoolAggChanges(ctx echo.Context) error {
 	return err
 }
 
+// GetPoolConcentrationHistory converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPoolConcentrationHistory(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "asset" -------------
+	var asset string
+
+	err = runtime.BindStyledParameter("simple", false, "asset", ctx.Param("asset"), &asset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter asset: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPoolConcentrationHistoryParams
+	// ------------- Required query parameter "interval" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "interval", ctx.QueryParams(), &params.Interval)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter interval: %s", err))
+	}
+
+	// ------------- Required query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetPoolConcentrationHistory(ctx, asset, params)
+	return err
+}
+
+// GetPoolYieldHistory converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPoolYieldHistory(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "asset" -------------
+	var asset string
+
+	err = runtime.BindStyledParameter("simple", false, "asset", ctx.Param("asset"), &asset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter asset: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPoolYieldHistoryParams
+	// ------------- Required query parameter "interval" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "interval", ctx.QueryParams(), &params.Interval)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter interval: %s", err))
+	}
+
+	// ------------- Required query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetPoolYieldHistory(ctx, asset, params)
+	return err
+}
+
+// GetReserveHistory converts echo context to params.
+func (w *ServerInterfaceWrapper) GetReserveHistory(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReserveHistoryParams
+	// ------------- Required query parameter "interval" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "interval", ctx.QueryParams(), &params.Interval)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter interval: %s", err))
+	}
+
+	// ------------- Required query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetReserveHistory(ctx, params)
+	return err
+}
+
+// GetRewardsHistory converts echo context to params.
+func (w *ServerInterfaceWrapper) GetRewardsHistory(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRewardsHistoryParams
+	// ------------- Required query parameter "interval" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "interval", ctx.QueryParams(), &params.Interval)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter interval: %s", err))
+	}
+
+	// ------------- Required query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetRewardsHistory(ctx, params)
+	return err
+}
+
+// GetSlashHistory converts echo context to params.
+func (w *ServerInterfaceWrapper) GetSlashHistory(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSlashHistoryParams
+	// ------------- Optional query parameter "pool" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pool", ctx.QueryParams(), &params.Pool)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter pool: %s", err))
+	}
+
+	// ------------- Required query parameter "interval" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "interval", ctx.QueryParams(), &params.Interval)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter interval: %s", err))
+	}
+
+	// ------------- Required query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetSlashHistory(ctx, params)
+	return err
+}
+
 // GetTotalVolChanges converts echo context to params.
 func (w *ServerInterfaceWrapper) GetTotalVolChanges(ctx echo.Context) error {
 	var err error
@@ -904,6 +2004,118 @@ func (w *ServerInterfaceWrapper) GetPoolsDetails(ctx echo.Context) error {
 	return err
 }
 
+// GetPoolDepthCurve converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPoolDepthCurve(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "asset" -------------
+	var asset string
+
+	err = runtime.BindStyledParameter("simple", false, "asset", ctx.Param("asset"), &asset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter asset: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPoolDepthCurveParams
+	// ------------- Optional query parameter "sizes" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sizes", ctx.QueryParams(), &params.Sizes)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sizes: %s", err))
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetPoolDepthCurve(ctx, asset, params)
+	return err
+}
+
+// GetPoolDeviation converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPoolDeviation(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "asset" -------------
+	var asset string
+
+	err = runtime.BindStyledParameter("simple", false, "asset", ctx.Param("asset"), &asset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter asset: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPoolDeviationParams
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetPoolDeviation(ctx, asset, params)
+	return err
+}
+
+// GetPoolStakers converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPoolStakers(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "asset" -------------
+	var asset string
+
+	err = runtime.BindStyledParameter("simple", false, "asset", ctx.Param("asset"), &asset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter asset: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPoolStakersParams
+	// ------------- Required query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// ------------- Required query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetPoolStakers(ctx, asset, params)
+	return err
+}
+
+// StartRebuild converts echo context to params.
+func (w *ServerInterfaceWrapper) StartRebuild(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.StartRebuild(ctx)
+	return err
+}
+
 // GetStakersData converts echo context to params.
 func (w *ServerInterfaceWrapper) GetStakersData(ctx echo.Context) error {
 	var err error
@@ -1061,6 +2273,31 @@ func (w *ServerInterfaceWrapper) GetTxDetails(ctx echo.Context) error {
 	return err
 }
 
+// GetPendingTxs converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPendingTxs(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPendingTxsParams
+	// ------------- Required query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// ------------- Required query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetPendingTxs(ctx, params)
+	return err
+}
+
 // RegisterHandlers adds each server route to the EchoRouter.
 func RegisterHandlers(router interface {
 	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
@@ -1078,15 +2315,30 @@ func RegisterHandlers(router interface {
 		Handler: si,
 	}
 
+	router.GET("/v1/analytics/swaps", wrapper.GetSwapAnalytics)
 	router.GET("/v1/assets", wrapper.GetAssetInfo)
+	router.GET("/v1/deviation/alerts", wrapper.GetDeviationAlerts)
 	router.GET("/v1/doc", wrapper.GetDocs)
+	router.GET("/v1/events/quarantine", wrapper.GetQuarantinedEvents)
+	router.POST("/v1/events/quarantine/reprocess", wrapper.ReprocessQuarantinedEvents)
 	router.GET("/v1/health", wrapper.GetHealth)
+	router.GET("/v1/history/fees", wrapper.GetFeeHistory)
+	router.GET("/v1/history/gas", wrapper.GetGasHistory)
 	router.GET("/v1/history/pools", wrapper.GetPoolAggChanges)
+	router.GET("/v1/history/pools/:asset/concentration", wrapper.GetPoolConcentrationHistory)
+	router.GET("/v1/history/pools/:asset/yield", wrapper.GetPoolYieldHistory)
+	router.GET("/v1/history/reserve", wrapper.GetReserveHistory)
+	router.GET("/v1/history/rewards", wrapper.GetRewardsHistory)
+	router.GET("/v1/history/slashes", wrapper.GetSlashHistory)
 	router.GET("/v1/history/total_volume", wrapper.GetTotalVolChanges)
 	router.GET("/v1/network", wrapper.GetNetworkData)
 	router.GET("/v1/nodes", wrapper.GetNodes)
 	router.GET("/v1/pools", wrapper.GetPools)
 	router.GET("/v1/pools/detail", wrapper.GetPoolsDetails)
+	router.GET("/v1/pools/:asset/depth-curve", wrapper.GetPoolDepthCurve)
+	router.GET("/v1/pools/:asset/deviation", wrapper.GetPoolDeviation)
+	router.GET("/v1/pools/:asset/stakers", wrapper.GetPoolStakers)
+	router.POST("/v1/rebuild", wrapper.StartRebuild)
 	router.GET("/v1/stakers", wrapper.GetStakersData)
 	router.GET("/v1/stakers/:address", wrapper.GetStakersAddressData)
 	router.GET("/v1/stakers/:address/pools", wrapper.GetStakersAddressAndAssetData)
@@ -1097,97 +2349,163 @@ func RegisterHandlers(router interface {
 	router.GET("/v1/thorchain/pool_addresses", wrapper.GetThorchainProxiedEndpoints)
 	router.GET("/v1/thorchain/queue", wrapper.GetThorchainProxiedQueue)
 	router.GET("/v1/txs", wrapper.GetTxDetails)
+	router.GET("/v1/txs/pending", wrapper.GetPendingTxs)
 
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+w823IbuXK/gpokVdJZmqJkSfbqKaQlrZVjXSLJu7W1dlzgTJOENQOMAAxFni39Vn4g",
-	"P5bCZS7kYGZAyjqp5ORN4gB9Q3ej0Wj0n0HIkpRRoFIEJ38GHETKqAD9z1AIkOIUJCYxRLf2k/oSMiqB",
-	"SvUnTtOYhFgSRve+C0bVbyKcQYLVX0RComH9M4dJcBL8016Jb88ME3saj0ETPPcCuUwhOAkw53gZPD8/",
-	"94IIRMhJqnAEJwEbf4dQIkUDJpTQKYosiQgrSIjQCeOJJknB+wUocByfcc74Vky00a6huqgE9QElIASe",
-	"giFD3jAWD6fTDzNMpyBeT6CreHxk+gtIdAsy4xRhivQwxCYoZSxGoQHTV3A+Ao7lbCvKU85S4JIY3Qqx",
-	"DGeETr9lqfrX0jdmLAasVy3CEo+xwVH/KkJMKfCPQKYzjduseHASECqPD4OCY0IlTEGtUPGT0R+XFIwE",
-	"hBLBTDOKhMQyE0oUlySaYh4p5Fcgnxh/+OG6ZOFe0AnroK5uAnYuUmLTNLII/grL19Mxi8BHuTYiXOnu",
-	"38HnKDQvcTnaMhTNaMI4kjMsjfMpWHg90gs8XVQXZqxnaD9wJ/EDcDGMIg5CnGKJf7gW11G00xbHSM5A",
-	"C1Tov4QGgIjQfylhE1qlXe8W21LuJeF1TNupSE59oSUYiRRCMiFhziOmUak2Fuurs7WZ6tjlEeXcO4ml",
-	"eA21kY3aUhfuNGZjHKPR2c3dE04L73E/YzycYUI/MCokpq9AaB1F94Zq6TeuAlDK2YJApPgxEBDQKGWE",
-	"yv4KE2f211dkokCxNRPacL9hY+7QxMonLOQ4ZuHD67FSoNialTiH0MDEv2eQwesxoMFvTfyjmr1GOJM4",
-	"/pXFrx5wriF6QcQpFSQ0Z3GWwErkeb8QPyLsZBn1ixd7gVwIfwEsTDDhYn2zwLOUBMdU4FCNEBqKxVWc",
-	"zGz4UuPRbCi+QUSEJXzggCVEnnJJOQnhNqPV0FxITujUxWwvGBmzf8I8EnVqx+VXB7xeMGY0avms96XG",
-	"705yGI0uQXISOqjBc+B4CsNQkjmokerH1bUamiFIEaZ3SD0WURaBKOVVUmhB3klMo/HSD6Ywg5uBJnhB",
-	"kixpo/MSLwjNEm86LchWOi/NmA3ohIhg2kqmHuFPpR7eTuQqxG4aCe2UpZLkJrI0INvJXIPZSad2jW1U",
-	"ai/sTaMG10rhKrwO+lymZpIjNSOD/Oc6CA6PGeHKFf1hh311wK0elOsmXEhIOAwt965GjsgM65VOvi6m",
-	"FWfes+DV6fdDvpesorjKkjHwCo6rVYFVPOl4zTO2OewVL2r9YsWNtU6tDLUzCZ0Ob36vE7+zj35CO6XP",
-	"RX9BmkhxA/ySUTnbW9PC3V30H2j/AL3Zd+mYRXV7feGUbUweMxIRuWyhpeLgG4g5hVTOKhqvA9J2uigs",
-	"5IdZxmmZQKqNUWA0nzqy0IsdsSfqMJMZIA6JPZhIkkCROsPFfLRDqKV+N+h5bbOMxXczzOEch9JpL2b3",
-	"a5GvKK27zRasE9jCGCwCL2vIsTSbg17NWxDA59DkjWKYSEQoyoe1OLYHaPRpKnpBZogCphM2fi4tT3vV",
-	"nVp0cHS0/3Mdo/2A0mwckxA9wNJFtIAwPTg6ftivAyg+tYJwEbuWB3ZHipWvLlHZGFyJyWTXI21uWCI5",
-	"I8Ko+zgLH0A6gx8TqKZyVodf2q2GqyHqA4z6Lcw4Byq94LevtYEuzJgmEL8ROYs4dpp3BcpTMczl7LJl",
-	"gxHonxVX42yJxBNOhZ8LGGfLX/VJqA7yLksUwC8Bzyh8w4nC8CWo4kAw17c6DkqnWNxCGgMlYtYiuCQn",
-	"W+HooRSTCI2XepWozdpKhihkkuOY/A3QFwX5s4DoS5DrTQN6NagbrxF6JvTNDppioQ+9Be7KAQntQH/a",
-	"R6OrUX90Neqhs/uP/bP7j7su9MqxNom1kDj6CQmI83EuKOoU5HCqmmT9UZmMktx2ms2LM03TwptY4Etg",
-	"FzpH5wSWUfC3c030RmauZnRZ+QtEkVFot3ENu9nE1edOC9cwWg1cKUSXhasxm5h4Rck2sPECS4uRa3F0",
-	"kqsGlUB8siLEReopSOCJiYCaF1cpV0bJQv8mJE5SP5wZJVL4668OvfQcGz6KvXxdhdMj5F+7pJWP20Bg",
-	"Tdvyj0netG2vVix2FYxfGuMY0xAat8EzzKnLyoaFSzZhrQZmJK63hAmAvsuYYtEI2wapLnfJTR6QUUTo",
-	"HIRMgHbt9pq7JqaHHVv+OFvqIZ0x6+3nq7M3X7LB4C0M7+7O7leTcm7I5wA2ndOc59H2a6hUohNq81Rb",
-	"Ww2fOjXov3absYlWWUwAhAGj0DWBuYtJ2km15DgCJGKSuoklFP1LA/z7RSd0q6HZsirkUjQ76+h2u4XT",
-	"5FirWqIQ2nRzAwr1a2Mg0XJyVZ+NQe2l2uJTOWs/lhbDmkiO8s10zOQMCRLZtW0lscmod6rhP/qLCVp2",
-	"0U96J7STGkD66Lj2FBOAFhjdmts02elMlF9Ft9cXaMfmXHMT1r7JLPft9cVuC9D9gxawbA5cLV7CqJw1",
-	"kuZlSlo4ypIaobR5OX18neM4y4MeZAY2wPIwPk1Pxe6aQH1Wu2rTglX2XZZJnRtQUzcMwe+f2JsnXBil",
-	"uYN+o6OITlU3MA8OZ7wTLqFIjeuyH3eUf6PjezaxSlWA6G8YHK/u0XpZW7boil127dA6mF3doHvmwNC2",
-	"T6tZTsvSRuS7S5fheqt5a5jNe7TaIv02ae2qrdvWQLs2aQXax4OprcGxSdfwtWqQRea5SbeC2WaTrhHb",
-	"tEkrBN67tI5fatv0jkZW4trtZslnh9bI8i26GUW/8RB0v+jUIXMO6lQcUwTTCS2j5DEra2Z6jYlbb8oU",
-	"xwfH5QHEg1KZmbwkzZLg5I9gzJgUkuM01fYGFI9j/VdEhPnzqwvOk5qwAct2PFIHIK4IpFNNtXZLQRMG",
-	"T1HYoauZH0KL+jG0M86WQns4pTTthz0PhJ7idp3x8lqr+qHeVn6Zehm1EgucpLGaLcd0vD/5fhA/fn8f",
-	"zflRmiWTcBa+ozKePEYH8+O/RYvHp+/wNDlyMeYovKsdL/XdiE78v7Tc0ObZm/YjY8AmRmETBJhTQqcV",
-	"J4dwyJkQusBMU9VvzOW7T45liJeDUEFaC5j2FJINpDair2Xhy9rBH3HEbye9qPB8cZa7hNSaCIuwhHPC",
-	"RYUujyzOTN+1fcIbTuvO/uXJxRcmAHMwraxn7gD4FlIOQqdh2RMFLmYk1dLUuuOvOrLBbiNM4qW5df0s",
-	"nH7lVI3Ir0AzNQbtWKdc1uZVvPKue2FJvLxfNEHv2nX0waiDzkszZoXSFlguYnIQXeQo0Xf6+OqlcXPV",
-	"hTKKUbZsPPqMs+X6xtQO7E7tT42+COLYG1zr0UKHfPZI0Qyi3YmD/lp4RvSrcuq7HX7WtW6lo/VmbiOf",
-	"3UlZM1FexDRotIGwHgDZiK0lBpS2aLIlAF7jLN/scm+vcUVIEBpq78dlvwNRw9F4A2T5ubkR0W9FfNWE",
-	"KHew3Vrg8pJFuezIPAcqy7BrXvNOchLKEaOR1qNbLAlzvSdqRdMCXwH4pmMc4V3kW6P6uad2u2/Hh5tC",
-	"ulBb5gocI7ZN4dzpWdVq9jZx5AXjjrjGhrSuwhE91V1mk42/PZiCik0Wv6xbr9f4mnSKf+1ujTWPGt4K",
-	"KWvrUKNnhKNfcUwiLBm/xRI8455RXur0O2DuOecUBOFQYLsD31rnU5aNY7gjU3qJF8OpL41nCRGCMPoh",
-	"syU7HnPOMYn/CkuF6y7GYnZTLKP/5ClsMzej0SWZcl2PdaEiobnJyHjM/TdM4nuSgMG9+SRBpr6zPuHw",
-	"4XpyPRaKPkXqDVAcy6Xn9EtTeqqc3gXNq7T95+nqrHPGR+f32038fTqNOBbEV7JX8HSj4rRlGPuSamQD",
-	"m2vAdbyVJd4yiSXcANcmucGb03zqLUi+NPbsOU9ZB6HT+3J3vAFOmO+ZSW93n1j48DndCG0F3zn4iqcU",
-	"qWb2gl5lyQgmjMN5FsfbAbnKkuFEAt8ewnUmt6HjtxmR8IkI+Qs2Z3jPeb9Pp8q/fCIJ2fY9suu1lGN3",
-	"a9xJYywkM6YREV9voyYp5wQRy7wfxuRkvpxR8+SpxiTL5Jhl1FfdVWz6clrW46AaVacwwVmsX/HfFflV",
-	"n6Bl7XVUPY58pTq/1ysu+p+oAmo9L/kW7jnXp3i85ci7eO8SVlgdwaYeZYsgu8aqIUXezGnyxgTbYMiF",
-	"GsdSc8DqGGyG6QmZf/xsUKxnh3W+3zfJWb+uEFkYmvw4h4nyBK7bCfNDZZLyA/aaJugFGc3/srWSQU8d",
-	"UgJLnFmDAkEviEwQrIDUsT3nSVT3+YaZpVg7ESV59smdj/WUj0tnFUL/54maPMciGW2s0T0BcL8niEna",
-	"/AqvqA3wMDmr/X83cVnNduTil0miojP32ZSTEO4xnzasep7IGGFBRBmJevAvFxuen/Pl7lplYR7JJcz9",
-	"fmNxcepF4bN2LuZ5lX51H2oJQKKLFoMI5uJfizCgz7jJHteexdimKejGPFwY3lygxww4AYHuP17fflCz",
-	"TRMEukQalkAxoQ8QoTnB+l5vRCb8v/5TSD0s5ZBirhPZRYsfhMcsk+tV6WNAHHCkc+JzTGI8js3tvX1D",
-	"ofPOfaSIVFSlmAsQK1fa2jZs8wbOkjWChWSKDjmDRD+N0JvZG2F4y3vXKEISfbGsPkaQAo0U0FwGgMWy",
-	"XwgpYiAQZRLNWByhkBNJQhxXWe2je1bk8M21at4AwVQ4KTiw6Nn8v5ixLNYPw/myQn5EOIQyXupcIZH6",
-	"6rG+UEEvmAMXZi0H/aP+obEjoDglwUnwtj/oD5QrxXKmNXNvvr9nG42c/BlYk6nFB6ZPU335Kr0xNJA+",
-	"yt9EA2XZdLYyRTIUEZHGeIlwnnjNWz+hOeaEZULLwAhrgkMQPURoGGeRik1iLEHYKn0lBWWFJisQmZfp",
-	"OvLXzwsVgxwnKrARwckf6xxdU0CMo4RxQCFLEoyE0lAsIVolbOfDx+HFVf/u98vR9afd6rXvH8HoatS/",
-	"v768Hr3ZP9sPeub/D8OrN4P9Q7UTqa0l0KsY9AKKE+3Cta+rPpiUPINe5eH7uo1/7a129zoYDJocSjFu",
-	"r6EF2HMvOPSZ7my9pd+yZ0mCldfVfQBMncdFtW3Xc08rVMTCRm26e8LTKfA9q5PobX9QKJHRk6lGr9Yi",
-	"YmGWKOKcy33KQhMI1MWz9hSrAeUqJuFg8TQnQBkdnipdCvLfDMtfc55N66lGtlu7KSkvaFtX5dzoXgLG",
-	"zTmZNy29gm20Y60bWJ1rC7vgjCi3udwzl21dDJrR2gfi6ZTD1MjXhOo5V3bBbR8SJ4Nrr+A6LPqmLHDX",
-	"plYt0Fg3VJdh2tDS3y576xTkqUl9fMJxmMU4vx5xISR5JrMNaR4hHyWEBr1gxjKuAl6s4DwBPAT2wjXo",
-	"BUvA3BX/9hyVLFwW713VYpidBov68c5FuNpVW4n2OM6v03RGoxdQJNkL6dnKxzY3BqwblNbOYWkNRcOV",
-	"NQvTZ+Vv8+Kw3Gporp4r1boaXWNVGlpF4Wq2tp7q6DC2/1f1fyxVb2pIVFd0c1NsUzrrWm7j/O22yJWG",
-	"gw4Vtt9PzefNeVzvDlnnLafAdjuzPOln8dtxxCKovA0XTq7sq/st+FnrJOngZx1/zpPfPl80PaqwlLed",
-	"y4sPsjRlXDk8RosjT16y49zwt+N1tXPjq0S5hrgVCe1FxWPAzRe/2j+xCI5We1I2RkUiz3t2uOk76/tN",
-	"f0bzDkDhnhN4avAv9lPpUSKTPFfOMItjXYFsC5PL6iRBdKDVM0N8HPP/9fOXuxuqOy4QqOhBZrVLlEXI",
-	"G1ugtr6icFC3r4zjvJzJqVG28nVrx7ne/7LOZdHAcpW/vT8toc8v8jXtHUjbWK6WXHeY0ufq0wBnFfiY",
-	"jve/Lyazg+n7o8e384GMHo+OJxTmi+NFuJAhnUmRhNnxYZKbXop1NJNrZgHzlXWzpZds09Ktbnm15fPf",
-	"MDy6ra6eDisNVxsPiqsMDWlUFnH/r1zVfzhv2dghuFEf9XlqXSnllipoe9JqCIXHNF5F57hX30w1OlEp",
-	"tnWfss15/mKoMwgKbk1Cq5/3zWxPyGQJNtnvBIczQk2KXWfW1xNjK3k4N6NmhlfabVvErnUv0OZZuLuV",
-	"GUUWrrjd2AurFQHtWlE0Y83bsBaeqN7q19wqYBSzEKsNiHF1BHAeqnNSbgz0skRhqzNYc4vkurgU6RZr",
-	"5QZhtVZ0VVpxtXRlW2nVG/FuL62yluZF0qo3MPaVVrUv8bq0lHcoeye/RGSrkH6A3MoK2xfJrd7Dul1u",
-	"JsNVSKQusse8ZGhbSa02St5eQKZ26UXCWW0o7atQeZ9oK5lFl940xvf2irM4BNZZXnieEO2CmfIhGgFX",
-	"cQaHkKQEbC8vukSE7umr2gUi9n71Be8fnXGGI0qa4FhsmIA/9ST44Pz44PD47bvTs/13Px8fH42Gb98e",
-	"HIzeHx+ejn4+fzsYDPbPT9++Gx2eDU4PDoaD0fHZh7Pj4dFo8O796XB02JSbW5DohSwM6bLaPS2nviWw",
-	"87rcqMdxm5PmEYQqGFqXaiJvqfExlT0NxTxOMStKX8ZLiqeEmrtGNpkY2bhQFR83yLjaHsLBycAnG1yh",
-	"JNY1qG5C8m+b0GF6PgcnR4MOorZLCS/adobKbR9WZjhe5qerntVv5dQXb0hkalZ09av1UBmPVewoZXqy",
-	"t7d/8K4/6A/6+yfvB+8HgRJg+V04Bnx9/u8AAAD//9qYBwccawAA",
+	"H4sIAAAAAAAC/+x963YbN9Lgq+D07nfGmtASJV+S+NdKlmV7P1/0SfLMyU6yOWA3SCJqAjSAlsjJ8Wvt",
+	"C+yL7UHh0t1soBukpIxn4/kxsdhAoQBUFaoKVYXfs5wvlpwRpmT24vdMELnkTBL441hKouQpUZiWpLiw",
+	"n/SXnDNFmNL/xMtlSXOsKGcHv0nO9G8yn5MF1v+iiiwA1n8XZJq9yP7bQT3egWkmD2AcM0z2ZZSp9ZJk",
+	"LzIsBF5nX758GWUFkbmgSz1G9iLjk99IrpDGAVNG2QwVFkWENSRE2ZSLBaCk4Z2SGwp/HJdEKPlw82gP",
+	"lDKV10ShC6IqwRBmCJohPkVLQXOCCgcOYUB8X0M8I+QNlYqL9cPN44yQl3PMZkTeYQ5TQlBuoADir7F8",
+	"cMRfY3l3xGdYthEnjAhcvhKCi51Q78MYoIaQI/oDWhAp8YwYNNQ55+XxbGan+HDL2B7nLnTMedlayzcE",
+	"l2q+E+ZLwZdEKGpEU45VPqds9mu11H9a/CaclwQD0xdY4QmWJPx1SVhB2exqBcDac/lQLSZEaPTVSqJb",
+	"TJUWMVMukJoTKhCv1IRXrMhGmREz2YuMMvX8aebXiTJFZkTokQSZVLQshpb8wjS7VFhVsOIyx4wR8YbQ",
+	"2RxWJ2Esqar8+k5zQiVnM6J/xUx/QgASqbkgcs7LpDnX5GIEdYheDK1ITSxzIAkkYeYaw/e0mGFR6Al9",
+	"IOqWi+t75zoL9y2b8gHsumeN7Ys0gQGOvCD/SR5QqNkBUthwK8TPPQ/cBz/yiqWSqVrJ5Ml7JAPT347O",
+	"aqFkQCIlMJM41y2B47TUe8lZTpgSMOkHP646I95Z1DaBoQVRguZG8OqhTslSzV9W4obcO0O1wQ+greVK",
+	"oRujXLfW2GPAv4Gp1XweCFELPQHPTU1sbmgigPOlwtdEyAfB2MJOwFealgH8fqKkLO5C0kNINgdIwHSt",
+	"m/es5x9gdxhi2N3sAJ7T4tSepFgZA8RP4eFQ9+MMYe0FBPQAQfdfFRaYKcpI8epGQ/2D5T+5cdZm0lQ3",
+	"0b2/k+BzDRlZpL6MMquK3TuPbKh4cRQtH1t1yP4lFCmQUyYBS0nEzcPbgnacu9sCwgBCE1xillt74ILc",
+	"YlHIP2AWMM59zAIAtYyayxLL+YNPAUa5+wSkBtNG3xwZx0UhiJSnWOF7J/3uEP2iqizN4atlaONYQ1TC",
+	"v7TspayJOziQdsU8bfk3RtrtxHDY+0MDI7kkOZ3S3M0Rs6I+Re6iVGwzre1OErs9su6rhZp8CLJRUWrp",
+	"Lu6s5BNcopNX55e3eOntHP3HMcPlWtH8AXBsQh/gQ6nRwq4xMN/VnIt8jil7yZlUmD3AKnaHGBYXdnGt",
+	"gwAtBV9RUqDcQUCEFUtOmWpP4pX99QEn4YfYeRIgVX7FRhaR2FTeYakmJc+vH24qfoidp1I6CJFJ/FdF",
+	"KvJwEwDwOyP/WffeQJwrXP6Nlw/u4dwY6A7nqdKQ0A0vq0Xb3/11+1WuVsbwkfenTbf9KeDChLH8TZIZ",
+	"sTtHc9qlGjwFVuSlIFiRInFdwIa/qFjTFyyVoGwWmuwoOzFsD4peF9tJ/TUAb5RNOCt6PsOhGf0eRIez",
+	"4r1x5QTW7oYIPCPHuaI3RLfs+n6PTROkEYPjG9oixgsis9EmBiMH8lJhVkzWaTClaRwHusAruqgWfXi+",
+	"xyvKqkUynhZkL57vTZst8CQFxawXTWiRjiU070eyDXEYR8oG11Kv5DZraUD2o7kBcxBPEI19WIIUTsYR",
+	"wPVi2IY3gF+I1WoX5jmnTHXZbUpId9hXqyXJtXle0s8VLahaw+Wntd2VwAVBlCGhJVBgXrxSy0r1gL3B",
+	"ZbUBzvTpgypLuuyBqT+3QT6C/1zSfxJ00PzjOxgCVmZvL7gvrml3uL91MY+iHN6P1oX6XU8OB23QL6gP",
+	"jIaXeJRJyvLA/K7ogqDbOXE+bdsDkVVOSEEKM293eabnXjG6QoouiFR4sdztOm2UnVK9apPKTaaNVfOr",
+	"cayC4WGuA/bRFeyD1HurbU1Nqlj4jdnPRuEjJniagft2JvAiWfF443qcVPl1yIU5ypbPxsHBlj/Gfv8x",
+	"8Sg1t+0dMiLu5y4IQT5XVGhV4x+22S8BuI2AiTCNHi+cHtfeqstqYWMlwKUkZgSoBPqEeC0Pg6mvdx0Q",
+	"684MgGDmGvCMkLfMKUVBpGxDgxxl6OLTh1chgNqeSubBJbjciyqPL4YeBxXQhhRoKvjCO4OQ4vBv60sM",
+	"YaNpeMvVjk1Ms2mAu4giYuHdOULBsldCEKaAs9EEKHs3bne/dCTNeukFqdlbdDun+dxMRvOvm5BesDTp",
+	"2oiWiemVr3EgmGCG5SdJCnTgwwZewnqH6FWbiUGmzXktVjrgL8iyJIzKOYxix7PkGhil3SO675o00BLT",
+	"Ak3WsJCOwhVHgtDFpBKSuNEi43ySPQPoyCG51HQwWfu1adlF6BFlCBblLxKaA2Ps9QwWY1K3CaAcFE56",
+	"I6xqZjF3l3yKCM7nMBpQTkQHaWxkj3wJzuprYZ4QkW+eN3ELOzZlfXiCAFRzh14aJwMndgC/ZXlZSa3p",
+	"lvyWCGSWk0/b4LvryUPanANVLZdhUPvoA1doKfgN1fqI88Fox5FrkSYsmiEzXWnhlfyAuDh2DgJjCiDT",
+	"bFSrC925bigEBryOgxmkTzvGh7bO39iUyYZx33dqtRwB1rRvWOK9XRtNbU/KZsfnP3WRf3SIvkOParcB",
+	"+isCJOU5Ee85U/ODDUNqbw/9b3R4hB4fhgjFDnXx8W1wbb2N0oNLw0cRQQbMgYbRBj7VfrwYWamX80qw",
+	"OqQtqErAPEGNhs0u+G1Axb0CJWBhHf8gOFwMDPb9Qdga7PfSOFYDuJxjQc5wrriIOnB61lfWBmofL1g7",
+	"dgdmsAMkcYMbJc4OsJv2jjdmUJdkCgL5Iq51Odv8mkTNcn2MIdNEA4P4iETRYwPgunp7cfTs2eGP3RHt",
+	"B7SsJiXN0TVZh5CWJF8ePXt+fdgF4D/1ggghWwesdaVkSNu5JLkmAQQWprEXV+gWS8QnsNqJYabaK5oa",
+	"fmF9AB/tUS7TzvuVRM4Y0gqTlnklUQ7lffS/iOBoQbANYJhSIVXd3TWXiMKJk4DnPC4n6KAJr1Yttcap",
+	"oe1pws+IM6tKa7Qb80VUIrdWfTpT+vq5LUWSoykW6TIp2byCcN0AB4qKIDp15DXHEk0IYT4UUqsFw5G/",
+	"3TBqZ6sQVi20haxVpWyUVQxOkGyUCTI1kdIFryYl0Zel2S+dtQzyUTsGPWxWN76GRI69jvEWtY06BB2Z",
+	"yqa+GfSDmzuLpZqH9Fh3/gFcq3UTVkT02Sj8fplpoEvTJgbi71TNC4FvWT+UW98spDRU68hhAj/rWU2q",
+	"tVGF08h2Uq3/BpdiUWvpZ7DVf8VgrP+cNcfocV8M2Xp2yguHth5jFLP6GKmUwKV2hf3sLKqfM0c3W5mA",
+	"m+OaRa8kJCWB8aWZzI/dsgnJ/mwfnXw42T/5cDJCr67e7L+6erMX87XEltWvOPoOSVK6diEogoa8mXBJ",
+	"Z63GDXtyO8oW/nortvHQQP6cOV9GjyNb/57O54D0Vmzuvds9XH6HpagY6edxgB1ncf15kMMBRi+Da4IY",
+	"4nDdZhsWbxDZFjzuR+lhcliOQXR1oxpIAsLDPon45u7qzqsYVTKdfsGEgT7WDJMHbl9lUCK4r0Or5dpt",
+	"sWCxY7mdstA5mWeU0S4irymjKOdkOqU5JQanZsC6nfPYqpDkc4VLxG8ZEXJOl3BTcWi/YSQpm5XEfA6u",
+	"ypyIKWUFnpddTN74b4iygqzQI2mIVep4YK2daQtQbiC4F6VTIV9uE6RBF7GLJDtgjsu8Ko0Fu6sPmS8P",
+	"x2DIBjhT/+zGOhyjUruNpfIb0Xa4w7aEpm4+RAjabCafejhZss7XyCIJ63wRWf3SsmxT0etFwF5eifUW",
+	"Ebh0aUYPXVqZoLT0VOH2TXMIYviUbk+0dVpvebw5SI0zc8ctu4+4nr7NtWLS4mv0FBtHHlWLX2HBQqfu",
+	"sVfRjLsIgBkJDCoi3IeyQqtsUdjW+RNSn4QJEQPevSFSLSIed6P962DsdG2ygSWEcRM5YFcAmBj04wHj",
+	"YlKtocmgl0nfnz3+uRqPn5Djy8tXV4N3A5NqfUbIcewCyH4wmoLBckrsjbVWojvjaT8f/GsvPprsXQu4",
+	"RQMw0fuYSbXWAmAQaxPmAPEVQWQpQ/8RgX+1GoRuab9aNxe5XppHm8PtDS9OTIVrUoke0MY4RobQv0ZN",
+	"lh5fs/5sWPVg6cR/vyPZN4uh7EXZhKs5krQgchjFmLh41HQ0oL8agbtno2JspwjIFBrX7TT19cAYptxY",
+	"56CY0hIbXXx8ix7ZO15/566lntnui49v93qAHh71gNX3unrzFpypeRS1JFaCxdGcFIXSJ+XA4Wxip4yQ",
+	"Q6ZhBFYC8wE+Db6LgfrUpxs1NHxeKfDm665bGvtXt/zxLfZMabIyHoO9MkjqBubR07kYhEsZ0u2G+Ces",
+	"qZy7+2dDVB7E/pZ6Svv0h23tOfwbfDl09uumG0f/yLgm+jQA3SvIWcBEqec/OAZSj/9NRHtO/9rh0Cs2",
+	"ANf42a+P3rTDH44AexwA0KHDX4NOkYz6yAkc/p3xeinTDpZ4+PeC2eXw7yAbO/z1AMmnv27cPf4fwWD1",
+	"WHvDU0o5+WEwd/THh9iPmsdXq0EagnbDhLNhbMegVYx+rurstFH0CjcZMz3jo+e1CyUBU1XJ5sXIhHMl",
+	"lcDLJfAbYXhSwr8KKs0/fwnBudUdtpiybY8oU0RoBNkMsI6awNAjcSls09bsXVgMiNNHk2otQXJqoul3",
+	"VyUMmLjccau0Ee27YZi6aOLtinlt6y/ohhEPJdo0K0kE0DYpa+GwgwRnj00wDTh4tN9NcXS4t4Wn51PT",
+	"x2NBN6ghfaNcCmogMmvDz7h1LRVZg04uCGFXP+CQgSCHQbL1K1sLoJ09rVBSo/8K9AEvKK3h3AX+rpnn",
+	"IFuXg3AixIMadfuopWXHaxpZ7vz+c9wE3qf5/Ih5ioTT4AaX5iIcrQkWez22dXR/Htkpo++Q3aq95l61",
+	"90NbyEaV7t/Ff7u7yIe7K/TLlMBw9V0dcJwedG+3MNz7vvLqlWVv6iN0Q5Yt10/GIYvt/Cc0wZroeEPT",
+	"IFho3L36AeGsT8aowOv62oEIysNRE8v193cb6vv0kX6846x+TJ2V5/KtTrvWCZOmobS1ms5OtrKsNiSI",
+	"nib0R4+RIFMiCMvJuREgBxu/hK/1XEjY5pWe/t1HPkNOfXJw1XlYAn26PK0j6DVYI8fpYllS4o8Qo/ia",
+	"dn8FU+hAd/RTseIxLJla0+26EzyMMCrp7N68XYTFuT/O7hRn6nK2MsloXoelGitcnrdaxcJe65G2iHDs",
+	"ixxMTRoXBNvU/AhqSQln7ZpP3UOoVeWpxIpI5Yo8uV8LIqiOGVTaTpSd7LxY7toom1JzEh+3r6b1OoKj",
+	"cBsGewf5AkA6gixLvIYgXpuNxchtjV0K0wk+c4bMBslrwdGiVFmPB3YLJI1YdtfD68tr9/eEqFtCGBqb",
+	"aIEmMib8sMbGKEcamUYNsD6t3uoGjdZOSm87+8YMugOeQdQsFvkc9hyWYGRmXseomhUxS7HNoNuRglSW",
+	"4ZwPQ1RMH08QzAnq0RTTmOeiuS8RampP0uRg6XntKok2CpV15FDa2VE2CT3noqg3uic351+TKNgbun9i",
+	"PORuXq76Wlsx7ZtWeI1bZdTCtTF646EtU4NTymresSSWi37N3fY2Bp3ikPYf8fe5KO5XCyplUDNxXzZk",
+	"D841CegNtKKOuHZahyqq0oQKbJS2Q4/+SQTX8dabH6hEjCt0zfgti1+w1clJwYnXVQY2l2AJ9dIeMTLD",
+	"kJVDp/Yn16BBCXtfHSGHd7umBPQdai5PEsG2iubtlJpt/SfQSCbewQynFOq+PfGPW8X8J2Q8G5t0qzl8",
+	"LfmbddDVH+7/gq7hwhb6V1tawV7GmNIWZrQcV1Kv0OF/RG+v/4Co4wHEbQxJXZIjBe2vw53Q8Bh39Gko",
+	"oWgd5VryY513lL3I1IRNDqe/HZWff/uhuBHPltVims/z75kqp5+Lo5vn/yxWn29/I7fTZ6GJBypYdugR",
+	"ZC1k+N21jK+ViTFPnPEw+rIw3m/gb+MQzgWXEio1Alb70aS9cChbHRniQOjYjh4w/THuNv5iK/x6Nr4u",
+	"wnkfMYf9qDsRIu+chlND6o3U10o5mAE1Xskm7zu8Zbfh9AQnh+6YoeDA9E49ctN0QZaCSLC/6oDwRsHw",
+	"VNJREb4tMC3XJr36kwzKlVPdwuU6V7oNemTvXOs6ko1L173wxtJyfbWKQR+6CIB4qgE835s2LUx7YF2t",
+	"4iBS7iUG78Ka2eHxCmGaKU6qdTRialKtO+819AK71NfPMWj6wE4G1xuRBBEdrpx1FES/ECfw1UtGBBWz",
+	"9gbk7NUqBs7VF06a3FYyexCzOFJJyEQo2ucTtOIbbEBGT4iHsgU+e+JbNmbmDjsn7WGswuZlg667PzBQ",
+	"JKJui8FcuF10oL/78InYQE7ADlNBUEq2qhnHyt/1hk00S5M16tBt1ecWL19uVZbllrKC3yYnx1xaQkpP",
+	"/TAdgppas/xd+ixjq7/cNvJjSuKJZcGChH7hoqUDm8ufsKA3vWym6nJzaQiEVsaXHD4xyed1KevOWl0q",
+	"QXOl62qAfAOXcugRsN5heuBrAL+C7i2TCyV3sDb3db8+f7otpLd6F1pwzLJtC+cSejUA9S6HK7q9HWXG",
+	"i38tq8mv12Qd+JaERmBXrL2ZXv+4M7Wky8/YPnTwOcHF33BJC6y4uEi/uTpxtXZ+Ilgk9jklkgriR7sk",
+	"qXx7amoy0Bl7j1fHs1Qcna/UJ+kl9DnDtPxPstZjgUvu3G9jeucZ2aVvxYr3dGZCwt7aK/LEvv8T01Jf",
+	"nZqxt+8k6Sy11zucX3+cfoT6IIDqOWG4VOvE7u9N+V4t9Op6cen9oDzQGRcnZ1e7dfxpNisEljR1ZT+Q",
+	"W4jXW+dlKqpmbcj2FPCx3IkTL7jCipwTASy51TOMpusFUWJ94i9EEvpp7tCxuLXWdm6iPRK76+PuHc+v",
+	"Py23GrYx3hlJXZ56SWGyb9mHanFCplyQs6osdwPyoVocTxURu0P4WKld8Pj7nCryjkr1GhvfUmK/n2Yz",
+	"LV/e0QVN69J7pNTPQXRPt+hJqu8rXVkhmiptdCctnEjBq9S5Kofm3Sdqno3oTNK/q5qGkNYi747Lph7U",
+	"weqUTHFVKhsZbKM4UpSWjRcmunrkAxXIebiqHP+K8hm9dnxqxZvg/vgHMAL+wORTon7Krk/ZJO7ZOmer",
+	"9TWeEnIiCL6Gwocmznioi25yTwXTlsZZMNDYNLNF0JJ1brUKF07Yqr7ZZmaNrPLc3PXYWmPBcJRwnTJX",
+	"paxZr8xdLuOiyCxyZg/CxcxGGdyhBkb94i4GIuWPaSgxxV/jhu8YEtcpRO96wHR/B6AX2CwSjvGzfqFw",
+	"TCq8gKFIuIyx/hUJkhN6UwdVmtqBJZmZZCJXUVl/klAuEb7BDYDZCmQ3tHs8kpkMiizN+b40cgRa0krp",
+	"tu9IuFInUEbCSsumoyyc9+ZTihNEW0uCdPbK+4TOIptWF4hPxn24pvyHuph8q1L5RtFFjqAuOKLKZEr0",
+	"pzCGVyy0JFaI/mHcZgVkd0i5Xiy0YRAcFWJ8r/TihLFyvt0TLKmsjaCE+TsqTebc7aRy+kao1ZY+JCe2",
+	"kuhwQRY8CEat3p4mYfgFpNaUuye7sHmogCygwo0Obpf/w6vC+1zMsi+jQG1i+4Y9OjfVY4/P3+rnzgQl",
+	"El29+XhhCo/CS49sberBS1RSpi9cbigGpjihU/F//49U0GwpyBKKUlFmtBLKGcITXqnNRKaJFqe4gPvK",
+	"G0xLHZkKCUe2kC3cCe4jjaTGaomFJLKVTQwy3r5QqTm0jbBUXOOh5mRh0om0QvdYmrnpTjqvQSOygJxe",
+	"/bEgS8IKDdStAcFyve8XqeDExMXBIym5oIrmuGxOdR9dcX+/aoIH3SuPpmiFhkNWIzM7JOe8KuGBObFu",
+	"oF9QQXJVruEehyoIC+luVDbKbogwYYLZeP/Z/lPD0IThJc1eZE/2x/vjbJQtsZoDZR7cHB74Rx0PTP28",
+	"F79nlonD76b5d1NMvuySiJwwRUtbdsy7zEcml1y36fjyYSSES85mkha2EC9f1rdkE1fGYYQIVXMi7Iuj",
+	"EM5Rp6A9vqUFPPuiedL4yQrz3l37NkhPWeAFUXBx8o9gARAT/8TwguyjYxfcYZ+XycsKommniDVK0u+3",
+	"onR0Ecyrj+8/njw+fKUDuKmGDPuYjTINNnuRueSH+sm8DlcH4oKE8mXC6wsjhOWGVbKPrMEnNQ27lKIJ",
+	"eBTQz5niP2f7Ebzswxs1WgmWaScOlRU7oMn4bQwpxe+KUiOztUFcbQQOx7HxS/CN9KJg33HLXjwb+4fI",
+	"sheHAdx+GWXCPqkILHY0HseOBt/uIPz865dR9jSl92vCiMAlPBxUd9ZnXrVYYLE2jIJAFDWfgR0ZsWDe",
+	"XI9JA/fCfUCqN94FBiD7yIkOwng1m7e6KI4KKiEpALu7csOJlKEbLCivJIhGI0OnOCdyZDlSm+028QTU",
+	"j6AgAKcYPP0wIAQ+MqIly0JzS84XC4ykPriwIkUbsUcv3xy//bB/+dP7k4/v9poy4B8dIQB/vzz+8Hh8",
+	"+DT7JUxnTijU7zUpUZE+IbETMcFCSLdvD0VNMAp6W++wJyifXHcANQyGD5r6Ge3bOZc2Hc05aMq1fbOM",
+	"SLNlvvh3/WaZV9I3UtqCdNKumWBs5m2XeANGzzKZTELfHtlB/VrxPLo8l7d4NiPiwB7r6Mn+2DOcATaD",
+	"rdJ0W/C8Wmgkw1PmeWye7SFlZMj2SDIwz1OHQDbKFNYG7T8y95shj1/cnI0/6qBOUxogkPYDrptvS+Va",
+	"jWJ/0ZlVqCA518c3KF+6peEs248KpPnLRitTgYwvaoS4KIgwlr35CVTPNagE12SpUKXVHt1p/RcBqU2C",
+	"50RKUiDr2ZlWZbkOLv1m7uGgirLEM8qwfRZvagRGSJj4j3Fp0j3F3ME1TjlUG5i4E7Lv9NwGj+BpOr6v",
+	"07Sz5j382UyWM3SVRen0wO+8iaSWKhjdLXx6JMQ3aUkFqXc+SxLEVZ3IL+dY60+20WRtc8xcZ5+DVhdG",
+	"rgncebGtlTnSTFGS1icL95qQJdJ3MJTN9pFZFxQmZCB8xt1rC80l4u4BkDqj0KCbtwfljFgDziVSgn0A",
+	"PTfzFaWiZdkaBRsu40KZeLM5waWaI+NY1VoGUJo5OHCxoEw/fdJlvwu3XSEm3CCro2Gysnmy93Sg6s4/",
+	"3stp7KfZR8xmCQeP4voldHAs2KeL5sRtgUPMieLj87dBsffGDLcL95quPSxrYfuZmXINB+7eYlDVaL0Z",
+	"2fLzWYJ0LldTe5cV5tigzJ2+QJQmSiM4+TNCXA2Jbe3RM1s6A7fsUlOvz9ilVP3FOCI8MjGzxt4L1HJ5",
+	"iktJtrJJXTCKRqlR8ltGRnSr0nsauPuNZwvKslE255XIRlkBSbW3hFxnNvQ7G2VrgkXwKZZh2xkw69qk",
+	"/WbxFkfY9mbydhgpfkd8djo5a8rt4b8zQpBt1WHCGU7jwYRXKB0X5t6tpzsKcxdjH8JsZJRqtxFVW3Dp",
+	"aywTudR45gyDvsaeP61LlMod+RP6f2PQbwy6BYPWRNvDoJpGYwwKzJLm+hXg4cazmSAzY/rVV5JgXHk+",
+	"cylOHR7beCBru9Nwa6dr58Abcq98Y6c/OzttkmifJ0dT53HNDbZHmMMOfgc6/nLQqQ85eDS2etgH8Vvv",
+	"lDRfnFGusqVQ/sD0px46VmjBpUKH47H/VaIcM3MD97kiUpEiyrmtUpW7abTDPKyvqO7iIv3Gw39yHo5R",
+	"6kM5voHCWwP2H7ZeFKwpKYtBEeCedrGFKX3efPDQrR1BulqfEwDNkEanNyuBKaSUHp//JAMVdkzNvqgo",
+	"aBVG/CYCvomAr04ENCn0QVkfBoqyvKhrXfWy+aRd+mopuOI5LyM1sNrneohJbY2tRBb9xiB/LgZpU0cP",
+	"hduGPeTt6371k7etTgetEVlQpUjrntYMU5dEMyFG4MkJunA2Yoh8jTNXNs3F3naKn0W4BRD7xi3fuCXI",
+	"LU3q6OUWQ98xbnGly1LMPlf5zPRxFxL+GiLdrwnZh7veP1wajL9dQXxjvn8R8zXJty+YTTeLMh5kZP1a",
+	"13zo5z5TqQwa+2eOWwwQZL4Q720m1H07WL7RdoO2N8ijh7yhJbKJg5ueRnuBvdtlvkty0WHwIRK230/N",
+	"5+3naPv3zM1hAEP4OfGCyB1nxAviMgeuyVoGZwXgd5oPL3SdhL75bI7v5pR21+Kj2xpT0qLH19CXSFZL",
+	"GwzDmU8qMNAj/prd5go9HzZg1CDXWqGDwr/NvP3mu5XSkPwFlb4LVnNs35+OOrWky64dENOXVvZLa7to",
+	"dPTYN5TcRuSL/VRLlMJEomthqHP+R/Wra3VtNknBSzYyTVIE8//vocyNXSJFD21BO+S2s01dzv0KL+4+",
+	"zqsU7wxYks6y9Nktrj6VwAWxMXgFnULEsYJMGNl+MMUFw8HIrSuc0YY5C2UX6/pTZoCNUrdRMm68Rv/V",
+	"e2Y1wSqOjsYdcq3ziXzZrc38jZG+yIL/s68njPW/dOplO0EHfof/jep/jiOcCiPeNVPH6p1ffapOIp73",
+	"mauzM9/XZP2g7lwYBsE4UcHReCxoUGy4JeZT5Dv6Vz/UvPlQTuiZHh/n1M5k0OCokoZ5N6WHkzOQbqEN",
+	"dA+NSosEKWLJEz1ixU37q5cqOzPk0VOkDaRvHJnIkZYiHpQhNzJmIkzZeDVzkCVt21YMhcCQyDxZ28dF",
+	"3UUqFUg23yMNndaO39qRGsEIjSh3XfqysF85b33LR7kX3rH7/aCcY8fwHGOzUP7FySrJOSlXaWklPgdl",
+	"jXKs8jmqls2EL8wYERupJxCiG0sn6XAorIpN9ri/RJH7yvUA0JsPrrkdT5WKIZ+DSaZxheIhC7/0DwSH",
+	"bxrMt51dRR2e6Hp5NyjaYnPwu0X0y528K8a523waWvri5JT1Tbn5xMaABP/UfOk9+OqHfvTjt9V0fjT7",
+	"4dnnJzdjVXx+9nzKyM3q+SpfqZzNlVzk1fOni4iQ9zAf2BrvTn5w69pOvs72pbvIup4ft1OmCAgXG+FR",
+	"bsFZT3hye0LHrKgf7fi33NU/nX9o862VYXo0Nt8GUaodSXBW8ol5hUDVEtPembIC/mqmtMRocMckdOjZ",
+	"lwZgsDMD+NmaDO/932SCPTuvFtik3ixwPqfMlO3BtvxYK1O8lZgeK9SieyTloe86cLjqhR3WpaVftnr4",
+	"tHRfMekgb1ba7KcKrWOstLghtkS3l0QeSOOTVqUwKrnOq9DDMV6Q4DWiQ+XcQK9Lf+5069QpGN9DNBp1",
+	"O2qjKlG7Bnt7tcpmSdhdV8sDuYfVqmvU3mm1PJitV6tGoLtaWjr86tWsuyxZG9I9rFtduf5O6+bBJK6b",
+	"yavwK9Jdss+uFO+uKwUA7mGBTE3gOy0OgNiaoMzAfmVWMrlixoZ+b8um+Wuv7pRXiXdidsNMWV5WEKH1",
+	"DEFyuqR6CB0+wdaIsgMo/7bSZhcMfof37oJ6RkBL2iHG6DQR4aOz50dPnz/5/vTV4fc/Pn/+7OT4yZOj",
+	"o5Mfnj89Pfnx7Ml4PD48O33y/cnTV+PTo6Pj8cnzVy9fPT9+djL+/ofT45OnkVmoFS3uOIVjtraqXCVJ",
+	"4bHvUey2qmN2F9QSlFANQ/qSEG31M1oH11S/DRW8jSilgOnd5vLNG3b3IJhV38nQyDHFmg0na2ddjSx9",
+	"a6G+ekyLpjw8WBKmHVjbVhJSK1dGCAtiC5HcYqq0vLSnBxWN/PTVCPGyIFKZ8r8bjmEqEJ4Rf4VjEtf5",
+	"tH2b7IDto6uVRBZvGMyWW/FXNDlnUzqrBCnq2xrAc1pqlbbQdwZSVfl12MlsIF+tvlUc+gM8vH6x+872",
+	"lWwRl6MEU9AVAuLt/lSizF5kc6WWLw4ODo++19U89w9f/DD+YZx9GTW/y0CDX778vwEAJTfzcTHbAAA=",
 }
 
 // GetSwagger returns the Swagger specification corresponding to the generated code
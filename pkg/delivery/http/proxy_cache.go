@@ -0,0 +1,268 @@
+package http
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"gitlab.com/thorchain/midgard/internal/config"
+)
+
+// latestParams are the values of params referring to the latest data, e.g. the
+// "latest" block tag of Ethereum.
+var latestParams = []string{"latest", "pending", "safe", "finalized"}
+
+var proxyCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "midgard",
+	Subsystem: "node_proxy_cache",
+	Name:      "requests_total",
+	Help:      "Number of cacheable node proxy requests by chain and result (hit or miss).",
+}, []string{"chain", "result"})
+
+func init() {
+	prometheus.MustRegister(proxyCacheRequests)
+}
+
+// proxyCacheEntry is a cached response along with its expiry.
+type proxyCacheEntry struct {
+	key     string
+	resp    *cachedResponse
+	expires time.Time
+}
+
+func (e *proxyCacheEntry) size() int64 {
+	return int64(len(e.key) + len(e.resp.body))
+}
+
+// proxyCache is a LRU cache of node responses bounded by the size of the
+// responses.
+type proxyCache struct {
+	mu      sync.Mutex
+	maxSize int64
+	size    int64
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+func newProxyCache(maxSize int64) *proxyCache {
+	return &proxyCache{
+		maxSize: maxSize,
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+	}
+}
+
+// get returns the response of key unless it's expired.
+func (c *proxyCache) get(key string) *cachedResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	e := el.Value.(*proxyCacheEntry)
+	if !time.Now().Before(e.expires) {
+		c.remove(el)
+		return nil
+	}
+	c.lru.MoveToFront(el)
+	return e.resp
+}
+
+// set caches the response of key for ttl, evicting the least recently used
+// responses to make room for it. Responses larger than the cache are dropped.
+func (c *proxyCache) set(key string, resp *cachedResponse, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := &proxyCacheEntry{
+		key:     key,
+		resp:    resp,
+		expires: time.Now().Add(ttl),
+	}
+	if e.size() > c.maxSize {
+		return
+	}
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+	for c.size+e.size() > c.maxSize {
+		c.remove(c.lru.Back())
+	}
+	c.entries[key] = c.lru.PushFront(e)
+	c.size += e.size()
+}
+
+// remove drops the entry of el. c.mu must be held.
+func (c *proxyCache) remove(el *list.Element) {
+	e := c.lru.Remove(el).(*proxyCacheEntry)
+	delete(c.entries, e.key)
+	c.size -= e.size()
+}
+
+// cacheableRequest is a request matching a cache rule.
+type cacheableRequest struct {
+	key string
+	ttl time.Duration
+	// id is the id of a JSON-RPC request, nil for other requests.
+	id json.RawMessage
+}
+
+// newCacheableRequest returns the request of the chain if it matches one of the
+// rules and has a concrete value of the identifying param of the rule, nil
+// otherwise. GET requests are identified by their query and JSON-RPC requests
+// by their method and params.
+func newCacheableRequest(chain string, rules []config.NodeProxyCacheRule, req *http.Request) *cacheableRequest {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	var method, args string
+	var params interface{}
+	var id json.RawMessage
+	switch req.Method {
+	case http.MethodGet:
+		query := req.URL.Query()
+		values := make(map[string]interface{}, len(query))
+		for k, vs := range query {
+			// Nodes may read any of repeated params.
+			if len(vs) != 1 {
+				return nil
+			}
+			values[k] = vs[0]
+		}
+		params = values
+		// Encode sorts the params so their order doesn't matter.
+		args = query.Encode()
+	case http.MethodPost:
+		if req.Body == nil {
+			return nil
+		}
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			return nil
+		}
+		var rpc struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params interface{}     `json:"params"`
+		}
+		// Batches aren't cached.
+		if json.Unmarshal(body, &rpc) != nil || rpc.Method == "" || rpc.ID == nil {
+			return nil
+		}
+		// Marshal sorts the keys of the objects so their order doesn't matter.
+		encoded, err := json.Marshal(rpc.Params)
+		if err != nil {
+			return nil
+		}
+		method, params, args, id = rpc.Method, rpc.Params, string(encoded), rpc.ID
+	default:
+		return nil
+	}
+
+	for _, rule := range rules {
+		if rule.Path != "" && !matchAny([]string{rule.Path}, req.URL.Path) {
+			continue
+		}
+		if rule.Method != "" && (method == "" || !matchAny([]string{rule.Method}, method)) {
+			continue
+		}
+		if !isConcrete(paramValue(params, rule.Param)) {
+			return nil
+		}
+		return &cacheableRequest{
+			key: strings.Join([]string{chain, req.Method, req.URL.Path, method, args}, "|"),
+			ttl: rule.TTL,
+			id:  id,
+		}
+	}
+	return nil
+}
+
+// response returns the cached response body for the request, with the id of
+// the request for a JSON-RPC request.
+func (r *cacheableRequest) response(body []byte) []byte {
+	if r.id == nil {
+		return body
+	}
+	var resp map[string]json.RawMessage
+	if json.Unmarshal(body, &resp) != nil {
+		return body
+	}
+	resp["id"] = r.id
+	withID, err := json.Marshal(resp)
+	if err != nil {
+		return body
+	}
+	return withID
+}
+
+// cacheable tells whether the response body can be cached. Errors and null
+// results aren't, e.g. the ones of blocks which aren't produced yet.
+func (r *cacheableRequest) cacheable(body []byte) bool {
+	var resp interface{}
+	if json.Unmarshal(body, &resp) != nil || resp == nil {
+		return false
+	}
+	fields, ok := resp.(map[string]interface{})
+	if !ok {
+		return r.id == nil
+	}
+	if fields["error"] != nil {
+		return false
+	}
+	result, ok := fields["result"]
+	if !ok {
+		// JSON-RPC responses must have a result.
+		return r.id == nil
+	}
+	return result != nil
+}
+
+// paramValue returns the value of the param of a request, nil if it's missing.
+// Object params are looked up by name and array params by position.
+func paramValue(params interface{}, name string) interface{} {
+	switch p := params.(type) {
+	case map[string]interface{}:
+		return p[name]
+	case []interface{}:
+		i, err := strconv.Atoi(name)
+		if err != nil || i < 0 || i >= len(p) {
+			return nil
+		}
+		return p[i]
+	}
+	return nil
+}
+
+// isConcrete tells whether the value of a param identifies finalized data: a
+// positive height or block number, or a hash. Empty values, zero heights and
+// "latest"-style values refer to the latest data.
+func isConcrete(v interface{}) bool {
+	switch v := v.(type) {
+	case float64:
+		return v > 0
+	case string:
+		// Tendermint accepts quoted values in queries.
+		v = strings.Trim(v, `"`)
+		if v == "" || matchAny(latestParams, v) {
+			return false
+		}
+		if n, err := strconv.ParseInt(v, 0, 64); err == nil {
+			return n > 0
+		}
+		return true
+	}
+	return false
+}
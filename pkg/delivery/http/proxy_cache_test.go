@@ -0,0 +1,169 @@
+package http
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog/log"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/midgard/internal/config"
+)
+
+type ProxyCacheSuite struct{}
+
+var _ = Suite(&ProxyCacheSuite{})
+
+func (s *ProxyCacheSuite) TestCache(c *C) {
+	var calls int32
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		data, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(string(data), "eth_getTransactionByHash") || r.URL.Query().Get("height") == "99":
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"call %d"}}`, n)
+		case strings.Contains(string(data), "0x64") || r.URL.Query().Get("height") == "100":
+			fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":null}`)
+		case r.Method == http.MethodPost:
+			fmt.Fprintf(w, `{"id":1,"jsonrpc":"2.0","result":"call %d"}`, n)
+		default:
+			fmt.Fprintf(w, `{"result":"call %d"}`, n)
+		}
+	}))
+	defer node.Close()
+	conf := config.NodeProxyConfiguration{
+		RateLimit:    100,
+		BurstLimit:   100,
+		CacheMaxSize: 1 << 20,
+		FullNodes: []config.NodeProxy{
+			{
+				Chain:  "cache",
+				Target: node.URL,
+				CacheRules: []config.NodeProxyCacheRule{
+					{Path: "/block", Param: "height", TTL: time.Hour},
+					{Path: "/tx", Param: "hash", TTL: time.Millisecond * 50},
+					{Method: "eth_getBlockByNumber", Param: "0", TTL: time.Hour},
+					{Method: "eth_getTransactionByHash", Param: "0", TTL: time.Hour},
+				},
+			},
+		},
+	}
+	proxy, err := NewProxyHandler(conf, "/v1/nodes", NewMemoryLimiterStore(), log.Logger)
+	c.Assert(err, IsNil)
+	e := echo.New()
+	proxy.RegisterHandler(e)
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	get := func(path string) string {
+		_, body := getBody(c, server.URL+"/v1/nodes/cache"+path)
+		return body
+	}
+	post := func(body string) string {
+		resp, err := http.Post(server.URL+"/v1/nodes/cache/", "application/json", strings.NewReader(body))
+		c.Assert(err, IsNil)
+		defer resp.Body.Close()
+		c.Assert(resp.Header.Get("Content-Type"), Equals, "application/json")
+		data, err := ioutil.ReadAll(resp.Body)
+		c.Assert(err, IsNil)
+		return string(data)
+	}
+	hits := testutil.ToFloat64(proxyCacheRequests.WithLabelValues("cache", "hit"))
+	misses := testutil.ToFloat64(proxyCacheRequests.WithLabelValues("cache", "miss"))
+
+	c.Assert(get("/block?height=1&hash=a"), Equals, `{"result":"call 1"}`)
+	c.Assert(get("/block?hash=a&height=1"), Equals, `{"result":"call 1"}`)
+	c.Assert(get("/block?height=2"), Equals, `{"result":"call 2"}`)
+	// Queries without a concrete value of the identifying param aren't cached.
+	c.Assert(get("/block"), Equals, `{"result":"call 3"}`)
+	c.Assert(get("/block"), Equals, `{"result":"call 4"}`)
+	c.Assert(get("/block?height=0"), Equals, `{"result":"call 5"}`)
+	c.Assert(get("/block?height=0"), Equals, `{"result":"call 6"}`)
+	c.Assert(get("/block?height="), Equals, `{"result":"call 7"}`)
+	c.Assert(get("/block?height=1&height=2"), Equals, `{"result":"call 8"}`)
+	c.Assert(get("/block?hash=a"), Equals, `{"result":"call 9"}`)
+	c.Assert(get("/status?height=1"), Equals, `{"result":"call 10"}`)
+	c.Assert(get("/status?height=1"), Equals, `{"result":"call 11"}`)
+
+	// The responses expire after the ttl of the rule.
+	c.Assert(get("/tx?hash=a"), Equals, `{"result":"call 12"}`)
+	c.Assert(get("/tx?hash=a"), Equals, `{"result":"call 12"}`)
+	time.Sleep(time.Millisecond * 60)
+	c.Assert(get("/tx?hash=a"), Equals, `{"result":"call 13"}`)
+
+	// Errors and null results aren't cached.
+	get("/block?height=99")
+	c.Assert(get("/block?height=99"), Matches, `.*call 15.*`)
+	get("/block?height=100")
+	get("/block?height=100")
+	c.Assert(atomic.LoadInt32(&calls), Equals, int32(17))
+
+	// JSON-RPC responses get the id of the request.
+	c.Assert(post(`{"jsonrpc":"2.0","id":1,"method":"eth_getBlockByNumber","params":["0x1",false]}`), Equals, `{"id":1,"jsonrpc":"2.0","result":"call 18"}`)
+	c.Assert(post(`{"jsonrpc":"2.0","id":"abc","method":"eth_getBlockByNumber","params":["0x1",false]}`), Equals, `{"id":"abc","jsonrpc":"2.0","result":"call 18"}`)
+	c.Assert(post(`{"jsonrpc":"2.0","id":2,"method":"eth_getBlockByNumber","params":["latest",false]}`), Equals, `{"id":1,"jsonrpc":"2.0","result":"call 19"}`)
+	c.Assert(post(`{"jsonrpc":"2.0","id":2,"method":"eth_getBlockByNumber","params":["latest",false]}`), Equals, `{"id":1,"jsonrpc":"2.0","result":"call 20"}`)
+	post(`{"jsonrpc":"2.0","id":1,"method":"eth_getBlockByNumber","params":["0x64",false]}`)
+	post(`{"jsonrpc":"2.0","id":1,"method":"eth_getBlockByNumber","params":["0x64",false]}`)
+	post(`{"jsonrpc":"2.0","id":1,"method":"eth_getTransactionByHash","params":["0xabc"]}`)
+	c.Assert(post(`{"jsonrpc":"2.0","id":1,"method":"eth_getTransactionByHash","params":["0xabc"]}`), Matches, `.*call 24.*`)
+	c.Assert(post(`{"jsonrpc":"2.0","id":1,"method":"eth_getBlockByNumber","params":["0x0",false]}`), Matches, `.*call 25.*`)
+	c.Assert(post(`{"jsonrpc":"2.0","id":1,"method":"eth_getBlockByNumber","params":[]}`), Matches, `.*call 26.*`)
+
+	c.Assert(testutil.ToFloat64(proxyCacheRequests.WithLabelValues("cache", "hit"))-hits, Equals, float64(3))
+	c.Assert(testutil.ToFloat64(proxyCacheRequests.WithLabelValues("cache", "miss"))-misses, Equals, float64(13))
+
+	// Rules must name their identifying param.
+	conf.FullNodes[0].CacheRules = []config.NodeProxyCacheRule{{Path: "/block", TTL: time.Hour}}
+	_, err = NewProxyHandler(conf, "/v1/nodes", NewMemoryLimiterStore(), log.Logger)
+	c.Assert(err, NotNil)
+}
+
+func (s *ProxyCacheSuite) TestIsConcrete(c *C) {
+	c.Assert(isConcrete("123"), Equals, true)
+	c.Assert(isConcrete(`"123"`), Equals, true)
+	c.Assert(isConcrete("0x1b4"), Equals, true)
+	c.Assert(isConcrete("0xe670ec64341771606e55d6b4ca35a1a6b75ee3d5145a99d05921026d1527331"), Equals, true)
+	c.Assert(isConcrete("ABCDEF"), Equals, true)
+	c.Assert(isConcrete(float64(5)), Equals, true)
+	c.Assert(isConcrete(""), Equals, false)
+	c.Assert(isConcrete("0"), Equals, false)
+	c.Assert(isConcrete("0x0"), Equals, false)
+	c.Assert(isConcrete("latest"), Equals, false)
+	c.Assert(isConcrete(float64(0)), Equals, false)
+	c.Assert(isConcrete(nil), Equals, false)
+	c.Assert(isConcrete(map[string]interface{}{"blockHash": "0x1"}), Equals, false)
+}
+
+func (s *ProxyCacheSuite) TestLRU(c *C) {
+	cache := newProxyCache(30)
+	resp := func(body string) *cachedResponse {
+		return &cachedResponse{body: []byte(body)}
+	}
+	cache.set("a", resp("0123456789"), time.Hour)
+	cache.set("b", resp("0123456789"), time.Hour)
+	c.Assert(cache.get("a"), NotNil)
+	// b is the least recently used.
+	cache.set("c", resp("0123456789"), time.Hour)
+	c.Assert(cache.get("b"), IsNil)
+	c.Assert(cache.get("a"), NotNil)
+	c.Assert(cache.get("c"), NotNil)
+	c.Assert(cache.size, Equals, int64(22))
+
+	// Responses larger than the cache aren't cached.
+	cache.set("d", resp(strings.Repeat("0", 30)), time.Hour)
+	c.Assert(cache.get("d"), IsNil)
+	c.Assert(cache.get("a"), NotNil)
+
+	cache.set("a", resp("0"), -time.Second)
+	c.Assert(cache.get("a"), IsNil)
+	c.Assert(cache.size, Equals, int64(11))
+}
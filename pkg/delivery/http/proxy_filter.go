@@ -0,0 +1,224 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"gitlab.com/thorchain/midgard/internal/config"
+)
+
+// requestFilter refuses the requests of a chain whose path or JSON-RPC methods
+// aren't allowed, or whose body is too large.
+type requestFilter struct {
+	allowedPaths   []string
+	allowedMethods []string
+	deniedMethods  []string
+	maxBodySize    int64
+}
+
+func newRequestFilter(node config.NodeProxy, conf config.NodeProxyConfiguration) *requestFilter {
+	f := &requestFilter{
+		allowedPaths:   node.AllowedPaths,
+		allowedMethods: node.AllowedMethods,
+		deniedMethods:  append(append([]string{}, conf.DeniedMethods...), node.DeniedMethods...),
+		maxBodySize:    conf.MaxBodySize,
+	}
+	if node.MaxBodySize > 0 {
+		f.maxBodySize = node.MaxBodySize
+	}
+	return f
+}
+
+// check returns an error if the request isn't allowed. The body is read to
+// find the JSON-RPC methods and replaced with the checked requests encoded
+// again.
+func (f *requestFilter) check(req *http.Request) error {
+	path := req.URL.Path
+	if len(f.allowedPaths) > 0 && !matchAny(f.allowedPaths, path) {
+		return echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("path %s is not allowed", path))
+	}
+	// Tendermint also serves the methods at the path of their name.
+	if method := strings.Trim(path, "/"); method != "" && matchAny(f.deniedMethods, method) {
+		return echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("method %s is not allowed", method))
+	}
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	if f.maxBodySize > 0 && req.ContentLength > f.maxBodySize {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "request body is too large")
+	}
+	var r io.Reader = req.Body
+	if f.maxBodySize > 0 {
+		r = io.LimitReader(req.Body, f.maxBodySize+1)
+	}
+	body, err := ioutil.ReadAll(r)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "could not read request body")
+	}
+	if f.maxBodySize > 0 && int64(len(body)) > f.maxBodySize {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "request body is too large")
+	}
+	body, err = f.checkMessage(body)
+	if err != nil {
+		return err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	return nil
+}
+
+// checkMessage returns an error if the JSON-RPC methods of the request or
+// websocket message aren't allowed. Otherwise it returns the message encoded
+// again.
+func (f *requestFilter) checkMessage(msg []byte) ([]byte, error) {
+	methods, msg, err := jsonRPCMethods(msg)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	for _, method := range methods {
+		if matchAny(f.deniedMethods, method) || (len(f.allowedMethods) > 0 && !matchAny(f.allowedMethods, method)) {
+			return nil, echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("method %s is not allowed", method))
+		}
+	}
+	return msg, nil
+}
+
+// jsonRPCMembers are the members of JSON-RPC requests.
+var jsonRPCMembers = []string{"jsonrpc", "id", "method", "params"}
+
+// jsonRPCMethods returns the methods of the JSON-RPC request or batch of
+// requests in body, none if it isn't a JSON-RPC request, along with the body
+// encoded again so the node gets exactly what was checked. Bodies which aren't
+// JSON objects or arrays are returned as is.
+func jsonRPCMethods(body []byte) ([]string, []byte, error) {
+	trimmed := bytes.TrimSpace(body)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		req, err := decodeJSONRPCRequest(trimmed)
+		if err != nil {
+			return nil, nil, err
+		}
+		method, err := jsonRPCMethod(req)
+		if err != nil {
+			return nil, nil, err
+		}
+		body, err = json.Marshal(req)
+		if err != nil || method == nil {
+			return nil, body, err
+		}
+		return []string{*method}, body, nil
+	case bytes.HasPrefix(trimmed, []byte("[")):
+		var batch []json.RawMessage
+		err := json.Unmarshal(trimmed, &batch)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid JSON-RPC batch: %s", err)
+		}
+		if len(batch) == 0 {
+			return nil, nil, fmt.Errorf("empty JSON-RPC batch")
+		}
+		reqs := make([]map[string]json.RawMessage, len(batch))
+		methods := make([]string, len(batch))
+		for i, raw := range batch {
+			reqs[i], err = decodeJSONRPCRequest(raw)
+			if err != nil {
+				return nil, nil, err
+			}
+			method, err := jsonRPCMethod(reqs[i])
+			if err != nil {
+				return nil, nil, err
+			}
+			if method == nil {
+				return nil, nil, fmt.Errorf("JSON-RPC request without method in batch")
+			}
+			methods[i] = *method
+		}
+		body, err = json.Marshal(reqs)
+		return methods, body, err
+	}
+	return nil, body, nil
+}
+
+// decodeJSONRPCRequest decodes the members of a JSON-RPC request. Requests with
+// duplicate members, or with members matching the JSON-RPC ones only when
+// ignoring the case, are refused since nodes may read them differently.
+func decodeJSONRPCRequest(data []byte) (map[string]json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON request: %s", err)
+	}
+	if tok != json.Delim('{') {
+		return nil, fmt.Errorf("JSON-RPC request is not an object")
+	}
+	req := map[string]json.RawMessage{}
+	keys := map[string]bool{}
+	for dec.More() {
+		tok, err = dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON request: %s", err)
+		}
+		key := tok.(string)
+		if keys[strings.ToLower(key)] {
+			return nil, fmt.Errorf("duplicate member %s in JSON-RPC request", key)
+		}
+		keys[strings.ToLower(key)] = true
+		for _, m := range jsonRPCMembers {
+			if key != m && strings.EqualFold(key, m) {
+				return nil, fmt.Errorf("invalid member %s in JSON-RPC request", key)
+			}
+		}
+		var value json.RawMessage
+		err = dec.Decode(&value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON request: %s", err)
+		}
+		req[key] = value
+	}
+	_, err = dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON request: %s", err)
+	}
+	if _, err = dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid JSON request: data after the request")
+	}
+	return req, nil
+}
+
+// jsonRPCMethod returns the method of the request, nil if there's none.
+func jsonRPCMethod(req map[string]json.RawMessage) (*string, error) {
+	raw, ok := req["method"]
+	if !ok {
+		return nil, nil
+	}
+	var method string
+	err := json.Unmarshal(raw, &method)
+	if err != nil {
+		return nil, fmt.Errorf("method of JSON-RPC request is not a string")
+	}
+	return &method, nil
+}
+
+// matchAny tells whether s matches one of the patterns, ignoring the case.
+// Patterns ending with "*" match the values starting with the rest of them.
+func matchAny(patterns []string, s string) bool {
+	s = strings.ToLower(s)
+	for _, p := range patterns {
+		p = strings.ToLower(p)
+		if strings.HasSuffix(p, "*") {
+			if strings.HasPrefix(s, p[:len(p)-1]) {
+				return true
+			}
+		} else if s == p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package http
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/midgard/internal/config"
+)
+
+type ProxyFilterSuite struct{}
+
+var _ = Suite(&ProxyFilterSuite{})
+
+func (s *ProxyFilterSuite) TestFilter(c *C) {
+	node := httptest.NewServer(dummyHandler("ETH"))
+	defer node.Close()
+	conf := config.NodeProxyConfiguration{
+		RateLimit:     100,
+		BurstLimit:    100,
+		DeniedMethods: []string{"admin_*", "broadcast_*"},
+		MaxBodySize:   100,
+		FullNodes: []config.NodeProxy{
+			{
+				Chain:          "eth",
+				Target:         node.URL,
+				AllowedPaths:   []string{"/", "/broadcast_tx_sync", "/status/*"},
+				AllowedMethods: []string{"eth_get*", "eth_blockNumber", "admin_peers"},
+				DeniedMethods:  []string{"eth_getLogs"},
+			},
+		},
+	}
+	proxy, err := NewProxyHandler(conf, "/v1/nodes", NewMemoryLimiterStore(), log.Logger)
+	c.Assert(err, IsNil)
+	e := echo.New()
+	proxy.RegisterHandler(e)
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	post := func(path, body string) (int, string) {
+		resp, err := http.Post(server.URL+"/v1/nodes/eth"+path, "application/json", strings.NewReader(body))
+		c.Assert(err, IsNil)
+		defer resp.Body.Close()
+		data, err := ioutil.ReadAll(resp.Body)
+		c.Assert(err, IsNil)
+		return resp.StatusCode, string(data)
+	}
+
+	code, body := post("/", `{"jsonrpc":"2.0","id":1,"method":"eth_getBalance","params":[]}`)
+	c.Assert(code, Equals, http.StatusOK)
+	// The requests are encoded again.
+	c.Assert(body, Equals, `[CHAIN: ETH][METHOD: POST][PATH: /][BODY: {"id":1,"jsonrpc":"2.0","method":"eth_getBalance","params":[]}]`)
+	code, _ = post("/", `[{"method":"eth_blockNumber"},{"method":"ETH_GETBLOCKBYNUMBER"}]`)
+	c.Assert(code, Equals, http.StatusOK)
+	code, _ = post("/status/sync", "")
+	c.Assert(code, Equals, http.StatusOK)
+	code, _ = post("/", "not json")
+	c.Assert(code, Equals, http.StatusOK)
+
+	code, body = post("/", `{"method":"eth_sendRawTransaction"}`)
+	c.Assert(code, Equals, http.StatusForbidden)
+	c.Assert(body, Equals, "{\"message\":\"method eth_sendRawTransaction is not allowed\"}\n")
+	// Denied methods win over allowed ones.
+	code, _ = post("/", `{"method":"admin_peers"}`)
+	c.Assert(code, Equals, http.StatusForbidden)
+	code, _ = post("/", `{"method":"eth_getLogs"}`)
+	c.Assert(code, Equals, http.StatusForbidden)
+	// A single denied method refuses the batch.
+	code, _ = post("/", `[{"method":"eth_blockNumber"},{"method":"admin_addPeer"}]`)
+	c.Assert(code, Equals, http.StatusForbidden)
+	code, _ = post("/", `[]`)
+	c.Assert(code, Equals, http.StatusBadRequest)
+	code, _ = post("/", `[{"id":1}]`)
+	c.Assert(code, Equals, http.StatusBadRequest)
+	code, _ = post("/", `{"method":`)
+	c.Assert(code, Equals, http.StatusBadRequest)
+	// Members which nodes may read differently are refused.
+	code, _ = post("/", `{"method":"eth_getBalance","method":"admin_addPeer"}`)
+	c.Assert(code, Equals, http.StatusBadRequest)
+	code, _ = post("/", `{"method":"eth_getBalance","Method":"admin_addPeer"}`)
+	c.Assert(code, Equals, http.StatusBadRequest)
+	code, _ = post("/", `{"METHOD":"admin_addPeer"}`)
+	c.Assert(code, Equals, http.StatusBadRequest)
+	code, _ = post("/", `[{"method":"eth_blockNumber","params":[],"PARAMS":[]}]`)
+	c.Assert(code, Equals, http.StatusBadRequest)
+	code, _ = post("/", `{"method":"eth_getBalance"}{"method":"admin_addPeer"}`)
+	c.Assert(code, Equals, http.StatusBadRequest)
+	code, _ = post("/", `{"method":["admin_addPeer"]}`)
+	c.Assert(code, Equals, http.StatusBadRequest)
+	code, body = post("/", `{"m\u0065thod":"eth_blockNumber"}`)
+	c.Assert(code, Equals, http.StatusOK)
+	c.Assert(body, Equals, `[CHAIN: ETH][METHOD: POST][PATH: /][BODY: {"method":"eth_blockNumber"}]`)
+	code, _ = post("/", `{"method":"eth_getBalance","params":["`+strings.Repeat("0", 100)+`"]}`)
+	c.Assert(code, Equals, http.StatusRequestEntityTooLarge)
+
+	code, body = post("/net_info", "")
+	c.Assert(code, Equals, http.StatusForbidden)
+	c.Assert(body, Equals, "{\"message\":\"path /net_info is not allowed\"}\n")
+	code, _ = post("/broadcast_tx_sync", "")
+	c.Assert(code, Equals, http.StatusForbidden)
+}
+
+func (s *ProxyFilterSuite) TestMatchAny(c *C) {
+	patterns := []string{"eth_get*", "net_version"}
+	c.Assert(matchAny(patterns, "eth_getBalance"), Equals, true)
+	c.Assert(matchAny(patterns, "eth_get"), Equals, true)
+	c.Assert(matchAny(patterns, "NET_VERSION"), Equals, true)
+	c.Assert(matchAny(patterns, "net_version2"), Equals, false)
+	c.Assert(matchAny(patterns, "eth_call"), Equals, false)
+	c.Assert(matchAny(nil, "eth_call"), Equals, false)
+}
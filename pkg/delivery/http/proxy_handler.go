@@ -3,60 +3,75 @@ package http
 import (
 	"fmt"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 	"path"
 	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
-	"github.com/yhat/wsutil"
+	"github.com/rs/zerolog"
 	"gitlab.com/thorchain/midgard/internal/config"
 )
 
-// ProxyHandler will proxy the request to the specified node.
+// ProxyHandler will proxy the request to the nodes of the specified chain.
 type ProxyHandler struct {
-	nodes    map[string]nodeProxy
+	nodes    map[string]*nodePool
+	filters  map[string]*requestFilter
+	rules    map[string][]config.NodeProxyCacheRule
+	cache    *proxyCache
 	basePath string
 	maxRate  float64
 	maxBurst int
+	limits   LimiterStore
+	logger   zerolog.Logger
 }
 
-type nodeProxy struct {
-	httpProxy      *httputil.ReverseProxy
-	websocketProxy *wsutil.ReverseProxy
-	websocketPath  string
-}
-
-// NewProxyHandler returns a new ProxyHandler with given params.
-func NewProxyHandler(conf config.NodeProxyConfiguration, basePath string) (*ProxyHandler, error) {
-	nodes := make(map[string]nodeProxy, len(conf.FullNodes))
+// NewProxyHandler returns a new ProxyHandler with given params. The requests
+// per ip are counted in limits. The nodes are health-checked in background
+// until the handler is stopped.
+func NewProxyHandler(conf config.NodeProxyConfiguration, basePath string, limits LimiterStore, logger zerolog.Logger) (*ProxyHandler, error) {
+	nodes := make(map[string]*nodePool, len(conf.FullNodes))
+	filters := make(map[string]*requestFilter, len(conf.FullNodes))
+	rules := make(map[string][]config.NodeProxyCacheRule, len(conf.FullNodes))
 	for _, n := range conf.FullNodes {
-		httpTarget, err := url.Parse(n.Target)
+		pool, err := newNodePool(n, conf, logger)
 		if err != nil {
-			return nil, errors.Wrapf(err, "invalid target url for chain %s", n.Chain)
-		}
-		node := nodeProxy{
-			httpProxy: httputil.NewSingleHostReverseProxy(httpTarget),
+			return nil, err
 		}
-		if n.WebsocketPath != "" {
-			// Converting the http scheme to ws scheme
-			wsTarget := convertToWsTarget(httpTarget)
-			node.websocketProxy = wsutil.NewSingleHostReverseProxy(wsTarget)
-			node.websocketPath = n.WebsocketPath
+		nodes[n.Chain] = pool
+		filters[n.Chain] = newRequestFilter(n, conf)
+		for _, rule := range n.CacheRules {
+			if rule.Param == "" {
+				return nil, errors.Errorf("cache rule of chain %s has no identifying param", n.Chain)
+			}
 		}
-		nodes[n.Chain] = node
+		rules[n.Chain] = n.CacheRules
 	}
 
 	h := &ProxyHandler{
 		nodes:    nodes,
+		filters:  filters,
+		rules:    rules,
+		cache:    newProxyCache(conf.CacheMaxSize),
 		basePath: basePath,
 		maxRate:  conf.RateLimit,
 		maxBurst: conf.BurstLimit,
+		limits:   limits,
+		logger:   logger,
+	}
+	for _, pool := range nodes {
+		pool.start()
 	}
 	return h, nil
 }
 
+// Stop stops the health checks of the nodes.
+func (h *ProxyHandler) Stop() {
+	for _, pool := range h.nodes {
+		pool.stop()
+	}
+}
+
 func convertToWsTarget(httpTarget *url.URL) *url.URL {
 	u := *httpTarget
 	if u.Scheme == "https" {
@@ -68,13 +83,18 @@ func convertToWsTarget(httpTarget *url.URL) *url.URL {
 }
 
 // RegisterHandler register the handler to echo server.
+// Route returns the path of the proxy route.
+func (h *ProxyHandler) Route() string {
+	return path.Join(h.basePath, "/:chain/*")
+}
+
 func (h *ProxyHandler) RegisterHandler(e *echo.Echo) {
-	e.Any(path.Join(h.basePath, "/:chain/*"), h.handler, rateLimiter(h.maxRate, h.maxBurst))
+	e.Any(h.Route(), h.handler, rateLimiter(h.limits, "proxy:", h.maxRate, h.maxBurst, h.logger))
 }
 
 func (h *ProxyHandler) handler(ctx echo.Context) error {
 	chain := ctx.Param("chain")
-	node, ok := h.nodes[chain]
+	pool, ok := h.nodes[chain]
 	if !ok {
 		return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("could not find chain %s", chain))
 	}
@@ -82,12 +102,56 @@ func (h *ProxyHandler) handler(ctx echo.Context) error {
 	req := ctx.Request()
 	// Remove the /{basePath}/{chain} part from the Path
 	req.URL.Path = strings.TrimPrefix(req.URL.Path, path.Join(h.basePath, chain))
+	err := h.filters[chain].check(req)
+	if err != nil {
+		return err
+	}
 	res := ctx.Response()
 	// Delete duplicate header
 	res.Header().Del("Access-Control-Allow-Origin")
 
-	if node.websocketPath != "" && strings.HasPrefix(req.URL.Path, node.websocketPath) {
-		node.websocketProxy.ServeHTTP(res, req)
+	websocket := pool.isWebsocket(req.URL.Path)
+	var cacheable *cacheableRequest
+	if !websocket {
+		cacheable = newCacheableRequest(chain, h.rules[chain], req)
+	}
+	if cacheable != nil {
+		// Let the proxy decompress the responses so they can be served to any
+		// client from the cache.
+		req.Header.Del("Accept-Encoding")
+		if resp := h.cache.get(cacheable.key); resp != nil {
+			proxyCacheRequests.WithLabelValues(chain, "hit").Inc()
+			return ctx.Blob(http.StatusOK, resp.contentType, cacheable.response(resp.body))
+		}
+		proxyCacheRequests.WithLabelValues(chain, "miss").Inc()
+	}
+
+	node := pool.pick(websocket, clientIP(ctx))
+	if node == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, fmt.Sprintf("no available node for chain %s", chain))
+	}
+	defer pool.release(node)
+
+	if websocket {
+		// The messages are checked like the requests.
+		w := &websocketFilterWriter{
+			ResponseWriter: res,
+			filter:         h.filters[chain],
+			logger:         h.logger.With().Str("chain", chain).Logger(),
+		}
+		node.websocketProxy.ServeHTTP(w, req)
+	} else if cacheable != nil {
+		rec := &responseRecorder{
+			ResponseWriter: res.Writer,
+			onHeader:       func(int) {},
+		}
+		node.httpProxy.ServeHTTP(rec, req)
+		if rec.status == http.StatusOK && cacheable.cacheable(rec.body.Bytes()) {
+			h.cache.set(cacheable.key, &cachedResponse{
+				contentType: res.Header().Get(echo.HeaderContentType),
+				body:        rec.body.Bytes(),
+			}, cacheable.ttl)
+		}
 	} else {
 		node.httpProxy.ServeHTTP(res, req)
 	}
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
 	"gitlab.com/thorchain/midgard/internal/config"
 	"golang.org/x/net/websocket"
 	. "gopkg.in/check.v1"
@@ -38,7 +39,7 @@ func (s *ProxyHandlerSuite) TestHTTPProxy(c *C) {
 			},
 		},
 	}
-	proxy, err := NewProxyHandler(conf, "/v1/nodes")
+	proxy, err := NewProxyHandler(conf, "/v1/nodes", NewMemoryLimiterStore(), log.Logger)
 	c.Assert(err, IsNil)
 
 	e := echo.New()
@@ -94,7 +95,7 @@ func (s *ProxyHandlerSuite) TestWebsocketProxy(c *C) {
 			},
 		},
 	}
-	proxy, err := NewProxyHandler(conf, "/v1/nodes")
+	proxy, err := NewProxyHandler(conf, "/v1/nodes", NewMemoryLimiterStore(), log.Logger)
 	c.Assert(err, IsNil)
 
 	e := echo.New()
@@ -1,36 +1,34 @@
 package http
 
 import (
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
-	"github.com/patrickmn/go-cache"
-	"golang.org/x/time/rate"
+	"github.com/rs/zerolog"
 )
 
 type rateLimiterConfig struct {
 	skipper middleware.Skipper
-	ips     *cache.Cache
-	rate    rate.Limit
+	store   LimiterStore
+	prefix  string
+	rate    float64
 	burst   int
+	logger  zerolog.Logger
 }
 
-func (r *rateLimiterConfig) getLimiter(ip string) *rate.Limiter {
-	limiter, exists := r.ips.Get(ip)
-	if !exists {
-		limiter := rate.NewLimiter(r.rate, r.burst)
-		r.ips.Set(ip, limiter, cache.DefaultExpiration)
-		return limiter
-	}
-	return limiter.(*rate.Limiter)
-}
-
-func rateLimiter(r float64, b int) echo.MiddlewareFunc {
+// rateLimiter limits the requests per ip to rate per second with bursts of
+// burst requests. The requests are counted in store under keys starting with
+// prefix.
+func rateLimiter(store LimiterStore, prefix string, r float64, b int, logger zerolog.Logger) echo.MiddlewareFunc {
 	return rateLimiterWithConfig(rateLimiterConfig{
-		ips:   cache.New(10*time.Minute, 15*time.Minute),
-		rate:  rate.Limit(r),
-		burst: b,
+		store:  store,
+		prefix: prefix,
+		rate:   r,
+		burst:  b,
+		logger: logger,
 	})
 }
 
@@ -38,16 +36,30 @@ func rateLimiterWithConfig(config rateLimiterConfig) echo.MiddlewareFunc {
 	if config.skipper == nil {
 		config.skipper = middleware.DefaultSkipper
 	}
+	limit, window := tokenBucket(config.rate, config.burst)
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			limiter := config.getLimiter(c.RealIP())
 			if config.skipper(c) {
 				return next(c)
 			}
-			if limiter.Allow() == false {
+			key := config.prefix + "ip:" + clientIP(c)
+			result, err := config.store.Allow(c.Request().Context(), key, limit, window, time.Now())
+			if err != nil {
+				// Requests are allowed while the store is unavailable.
+				config.logger.Err(err).Msg("failed to check rate limit")
+				return next(c)
+			}
+			if !result.Allowed {
+				setRetryAfter(c, result.RetryAfter)
 				return echo.ErrTooManyRequests
 			}
 			return next(c)
 		}
 	}
 }
+
+// setRetryAfter sets the Retry-After header in whole seconds.
+func setRetryAfter(c echo.Context, d time.Duration) {
+	seconds := int64(math.Ceil(d.Seconds()))
+	c.Response().Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+}
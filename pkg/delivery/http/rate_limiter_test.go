@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
 	. "gopkg.in/check.v1"
 )
 
@@ -18,7 +19,7 @@ func (s *RateLimiterHandlerSuite) TestRateLimiter(c *C) {
 	h := func(ctx echo.Context) error {
 		return nil
 	}
-	e.GET("/foo", h, rateLimiter(3, 2))
+	e.GET("/foo", h, rateLimiter(NewMemoryLimiterStore(), "", 3, 2, log.Logger))
 	server := httptest.NewServer(e)
 	defer server.Close()
 	for i := 0; i < 2; i++ {
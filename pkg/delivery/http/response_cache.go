@@ -0,0 +1,181 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+
+	"gitlab.com/thorchain/midgard/internal/config"
+)
+
+// cachedResponse is a response body recorded at a block height and data
+// generation.
+type cachedResponse struct {
+	contentType string
+	body        []byte
+}
+
+// responseCache caches the responses of the routes whose data only changes
+// when a new block is processed or the data is rebuilt. All the entries are
+// dropped when the height or the data generation advances.
+type responseCache struct {
+	mu         sync.Mutex
+	height     func() int64
+	generation func() int64
+	routes     map[string]bool
+	maxAge     int
+	maxEntries int
+	at         int64
+	gen        int64
+	entries    map[string]*cachedResponse
+}
+
+// ResponseCache returns a middleware caching the responses of the configured
+// routes until the height returned by height or the data generation returned
+// by generation advances. The responses carry an ETag of the request, the
+// height and the generation so clients can revalidate them with If-None-Match.
+func ResponseCache(height, generation func() int64, cfg config.ResponseCacheConfiguration) echo.MiddlewareFunc {
+	rc := &responseCache{
+		height:     height,
+		generation: generation,
+		routes:     make(map[string]bool, len(cfg.Routes)),
+		maxAge:     int(cfg.MaxAge.Seconds()),
+		maxEntries: cfg.MaxEntries,
+		entries:    map[string]*cachedResponse{},
+	}
+	for _, route := range cfg.Routes {
+		rc.routes[route] = true
+	}
+	return rc.middleware
+}
+
+func (rc *responseCache) middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		if req.Method != http.MethodGet || !rc.routes[c.Path()] {
+			return next(c)
+		}
+
+		// Query().Encode sorts the params so their order doesn't matter.
+		key := req.URL.Path + "?" + req.URL.Query().Encode()
+		height, gen := rc.height(), rc.generation()
+		etag := responseETag(key, height, gen)
+		header := c.Response().Header()
+		if etagMatch(req.Header.Get("If-None-Match"), etag) {
+			rc.setCacheHeaders(header, etag)
+			return c.NoContent(http.StatusNotModified)
+		}
+		if resp := rc.get(key, height, gen); resp != nil {
+			rc.setCacheHeaders(header, etag)
+			return c.Blob(http.StatusOK, resp.contentType, resp.body)
+		}
+
+		rec := &responseRecorder{
+			ResponseWriter: c.Response().Writer,
+			onHeader: func(status int) {
+				if status == http.StatusOK {
+					rc.setCacheHeaders(header, etag)
+				}
+			},
+		}
+		c.Response().Writer = rec
+		defer func() {
+			c.Response().Writer = rec.ResponseWriter
+		}()
+		err := next(c)
+		if err == nil && rec.status == http.StatusOK {
+			rc.set(key, height, gen, &cachedResponse{
+				contentType: header.Get(echo.HeaderContentType),
+				body:        rec.body.Bytes(),
+			})
+		}
+		return err
+	}
+}
+
+func (rc *responseCache) setCacheHeaders(header http.Header, etag string) {
+	header.Set("ETag", etag)
+	header.Set("Cache-Control", fmt.Sprintf("public, max-age=%d", rc.maxAge))
+}
+
+// get returns the response of key recorded at height and gen if any.
+func (rc *responseCache) get(key string, height, gen int64) *cachedResponse {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if height != rc.at || gen != rc.gen {
+		return nil
+	}
+	return rc.entries[key]
+}
+
+// set records the response of key at height and gen. Responses of an older
+// height or generation than the cached ones are dropped.
+func (rc *responseCache) set(key string, height, gen int64, resp *cachedResponse) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if height < rc.at || gen < rc.gen {
+		return
+	}
+	if height > rc.at || gen > rc.gen {
+		rc.at = height
+		rc.gen = gen
+		rc.entries = map[string]*cachedResponse{}
+	}
+	if len(rc.entries) >= rc.maxEntries {
+		return
+	}
+	rc.entries[key] = resp
+}
+
+// responseETag returns the ETag of the response to the request identified by
+// key at the given height and data generation. It's weak because responses with
+// the same key, height and generation are equivalent but not always byte for
+// byte the same.
+func responseETag(key string, height, gen int64) string {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return fmt.Sprintf(`W/"%d-%d-%x"`, height, gen, h.Sum64())
+}
+
+// etagMatch tells whether the If-None-Match header matches etag using the weak
+// comparison.
+func etagMatch(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// responseRecorder records the status and the body of a response while
+// writing it. onHeader is called before the header is written.
+type responseRecorder struct {
+	http.ResponseWriter
+	status   int
+	body     bytes.Buffer
+	onHeader func(status int)
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.onHeader(status)
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
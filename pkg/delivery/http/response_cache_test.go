@@ -0,0 +1,120 @@
+package http
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/midgard/internal/config"
+)
+
+type ResponseCacheSuite struct{}
+
+var _ = Suite(&ResponseCacheSuite{})
+
+func (s *ResponseCacheSuite) TestResponseCache(c *C) {
+	var height, gen, calls int64 = 1, 0, 0
+	e := echo.New()
+	e.Use(ResponseCache(func() int64 {
+		return atomic.LoadInt64(&height)
+	}, func() int64 {
+		return atomic.LoadInt64(&gen)
+	}, config.ResponseCacheConfiguration{
+		Routes:     []string{"/pools/:asset", "/failing"},
+		MaxAge:     time.Second * 5,
+		MaxEntries: 10,
+	}))
+	// h returns the number of times a handler was called.
+	h := func(ctx echo.Context) error {
+		n := atomic.AddInt64(&calls, 1)
+		return ctx.String(http.StatusOK, strconv.FormatInt(n, 10))
+	}
+	e.GET("/pools/:asset", h)
+	e.GET("/stats", h)
+	e.GET("/failing", func(ctx echo.Context) error {
+		atomic.AddInt64(&calls, 1)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	})
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	get := func(path, etag string) (*http.Response, string) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		c.Assert(err, IsNil)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		c.Assert(err, IsNil)
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		c.Assert(err, IsNil)
+		return resp, string(body)
+	}
+
+	resp, body := get("/pools/BNB.BNB?a=1&b=2", "")
+	c.Assert(resp.StatusCode, Equals, http.StatusOK)
+	c.Assert(body, Equals, "1")
+	c.Assert(resp.Header.Get("Cache-Control"), Equals, "public, max-age=5")
+	etag := resp.Header.Get("ETag")
+	c.Assert(etag, Not(Equals), "")
+
+	// The params order doesn't matter.
+	resp, body = get("/pools/BNB.BNB?b=2&a=1", "")
+	c.Assert(body, Equals, "1")
+	c.Assert(resp.Header.Get("ETag"), Equals, etag)
+	resp, body = get("/pools/BNB.BNB?a=2", "")
+	c.Assert(body, Equals, "2")
+	c.Assert(resp.Header.Get("ETag"), Not(Equals), etag)
+
+	resp, body = get("/pools/BNB.BNB?a=1&b=2", etag)
+	c.Assert(resp.StatusCode, Equals, http.StatusNotModified)
+	c.Assert(body, Equals, "")
+	c.Assert(resp.Header.Get("ETag"), Equals, etag)
+
+	// Other routes and errors are not cached.
+	_, body = get("/stats", "")
+	c.Assert(body, Equals, "3")
+	resp, _ = get("/stats", "")
+	c.Assert(resp.Header.Get("ETag"), Equals, "")
+	resp, _ = get("/failing", "")
+	c.Assert(resp.StatusCode, Equals, http.StatusInternalServerError)
+	c.Assert(resp.Header.Get("ETag"), Equals, "")
+	c.Assert(resp.Header.Get("Cache-Control"), Equals, "")
+	get("/failing", "")
+	c.Assert(atomic.LoadInt64(&calls), Equals, int64(6))
+
+	// A new block invalidates the responses.
+	atomic.StoreInt64(&height, 2)
+	resp, body = get("/pools/BNB.BNB?a=1&b=2", etag)
+	c.Assert(resp.StatusCode, Equals, http.StatusOK)
+	c.Assert(body, Equals, "7")
+	c.Assert(resp.Header.Get("ETag"), Not(Equals), etag)
+	etag = resp.Header.Get("ETag")
+
+	// So does a rebuild of the data at the same height.
+	atomic.StoreInt64(&gen, 1)
+	resp, body = get("/pools/BNB.BNB?a=1&b=2", etag)
+	c.Assert(resp.StatusCode, Equals, http.StatusOK)
+	c.Assert(body, Equals, "8")
+	c.Assert(resp.Header.Get("ETag"), Not(Equals), etag)
+	_, body = get("/pools/BNB.BNB?a=1&b=2", "")
+	c.Assert(body, Equals, "8")
+}
+
+func (s *ResponseCacheSuite) TestETagMatch(c *C) {
+	etag := responseETag("/v1/stats?", 10, 0)
+	c.Assert(etagMatch("", etag), Equals, false)
+	c.Assert(etagMatch(etag, etag), Equals, true)
+	c.Assert(etagMatch(`"abc", `+etag, etag), Equals, true)
+	c.Assert(etagMatch(etag[2:], etag), Equals, true)
+	c.Assert(etagMatch("*", etag), Equals, true)
+	c.Assert(etagMatch(responseETag("/v1/stats?", 11, 0), etag), Equals, false)
+	c.Assert(etagMatch(responseETag("/v1/stats?", 10, 1), etag), Equals, false)
+}
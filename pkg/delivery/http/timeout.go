@@ -0,0 +1,40 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// timeoutErrorMessage is the error of the requests whose deadline is exceeded.
+const timeoutErrorMessage = "request timed out"
+
+// Timeout returns a middleware setting a deadline on the context of the
+// requests. The deadline of a route is looked up in timeouts by its path, e.g.
+// "/v1/history/pools", and defaults to timeout. Zero means no deadline.
+// Requests failing after their deadline was exceeded are answered with 504.
+func Timeout(timeout time.Duration, timeouts map[string]time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			d, ok := timeouts[c.Path()]
+			if !ok {
+				d = timeout
+			}
+			if d <= 0 {
+				return next(c)
+			}
+
+			ctx, cancel := context.WithTimeout(c.Request().Context(), d)
+			defer cancel()
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			err := next(c)
+			if err != nil && ctx.Err() == context.DeadlineExceeded {
+				return echo.NewHTTPError(http.StatusGatewayTimeout, GeneralErrorResponse{Error: timeoutErrorMessage})
+			}
+			return err
+		}
+	}
+}
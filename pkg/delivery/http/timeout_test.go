@@ -0,0 +1,63 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	. "gopkg.in/check.v1"
+)
+
+type TimeoutSuite struct{}
+
+var _ = Suite(&TimeoutSuite{})
+
+func (s *TimeoutSuite) TestTimeout(c *C) {
+	e := echo.New()
+	e.Use(Timeout(time.Millisecond*50, map[string]time.Duration{
+		"/slow/:id":       time.Second,
+		"/nodes/:chain/*": 0,
+	}))
+	// h waits for the given delay unless the request is cancelled first.
+	h := func(delay time.Duration) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			select {
+			case <-time.After(delay):
+				return ctx.NoContent(http.StatusOK)
+			case <-ctx.Request().Context().Done():
+				return errorResponse(ctx.Request().Context().Err())
+			}
+		}
+	}
+	e.GET("/fast", h(0))
+	e.GET("/hanging", h(time.Minute))
+	e.GET("/slow/:id", h(time.Millisecond*100))
+	e.GET("/nodes/:chain/*", h(time.Millisecond*100))
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/fast")
+	c.Assert(err, IsNil)
+	c.Assert(resp.StatusCode, Equals, http.StatusOK)
+
+	start := time.Now()
+	resp, err = http.Get(server.URL + "/hanging")
+	c.Assert(err, IsNil)
+	c.Assert(resp.StatusCode, Equals, http.StatusGatewayTimeout)
+	c.Assert(time.Since(start) < time.Second, Equals, true)
+	var body GeneralErrorResponse
+	c.Assert(json.NewDecoder(resp.Body).Decode(&body), IsNil)
+	c.Assert(body.Error, Equals, timeoutErrorMessage)
+
+	// The route has a longer timeout.
+	resp, err = http.Get(server.URL + "/slow/1")
+	c.Assert(err, IsNil)
+	c.Assert(resp.StatusCode, Equals, http.StatusOK)
+
+	// The route has no deadline.
+	resp, err = http.Get(server.URL + "/nodes/bnb/status")
+	c.Assert(err, IsNil)
+	c.Assert(resp.StatusCode, Equals, http.StatusOK)
+}
@@ -0,0 +1,188 @@
+package http
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// Websocket opcodes, see RFC 6455.
+const (
+	wsContinuation = 0x0
+	wsClose        = 0x8
+)
+
+// maxWebsocketMessageSize is the maximum size of the websocket messages when
+// the size of the request bodies isn't limited.
+const maxWebsocketMessageSize = 16 << 20
+
+// websocketFilterWriter makes the websocket proxy read the messages of the
+// client through a websocketFilter once it hijacks the connection.
+type websocketFilterWriter struct {
+	http.ResponseWriter
+	filter *requestFilter
+	logger zerolog.Logger
+}
+
+// Hijack implements http.Hijacker.
+func (w *websocketFilterWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer is not a hijacker")
+	}
+	conn, rw, err := hj.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+	return &websocketFilter{
+		Conn:   conn,
+		r:      rw.Reader,
+		filter: w.filter,
+		logger: w.logger,
+	}, rw, nil
+}
+
+// websocketFilter reads the frames sent by a websocket client and passes the
+// messages on once their JSON-RPC methods are checked, encoded again. The
+// connection is closed when a message isn't allowed.
+type websocketFilter struct {
+	net.Conn
+	r      *bufio.Reader
+	filter *requestFilter
+	logger zerolog.Logger
+	// pending is the data checked already and not read yet.
+	pending []byte
+	// held is the control frames received in the middle of a fragmented
+	// message, which are passed on along with it.
+	held []byte
+	// opcode, mask and message are the first frame's opcode and mask key and
+	// the unmasked payload of the message being received.
+	opcode  byte
+	mask    []byte
+	message []byte
+	err     error
+}
+
+// Read implements net.Conn.
+func (f *websocketFilter) Read(p []byte) (int, error) {
+	for len(f.pending) == 0 {
+		if f.err != nil {
+			return 0, f.err
+		}
+		f.err = f.readFrame()
+	}
+	n := copy(p, f.pending)
+	f.pending = f.pending[n:]
+	return n, nil
+}
+
+// readFrame reads the next frame of the client. Control frames are passed on
+// as is, and data frames once their message is complete and allowed.
+func (f *websocketFilter) readFrame() error {
+	header := make([]byte, 2, 14)
+	_, err := io.ReadFull(f.r, header)
+	if err != nil {
+		return err
+	}
+	fin := header[0]&0x80 != 0
+	opcode := header[0] & 0x0f
+	masked := header[1]&0x80 != 0
+	var size uint64
+	switch n := header[1] & 0x7f; n {
+	case 126:
+		ext := make([]byte, 2)
+		if _, err = io.ReadFull(f.r, ext); err != nil {
+			return err
+		}
+		header = append(header, ext...)
+		size = uint64(binary.BigEndian.Uint16(ext))
+	case 127:
+		ext := make([]byte, 8)
+		if _, err = io.ReadFull(f.r, ext); err != nil {
+			return err
+		}
+		header = append(header, ext...)
+		size = binary.BigEndian.Uint64(ext)
+	default:
+		size = uint64(n)
+	}
+	if !masked {
+		return errors.New("websocket frame of client is not masked")
+	}
+	mask := make([]byte, 4)
+	if _, err = io.ReadFull(f.r, mask); err != nil {
+		return err
+	}
+	header = append(header, mask...)
+	limit := f.filter.maxBodySize
+	if limit <= 0 {
+		limit = maxWebsocketMessageSize
+	}
+	if size > uint64(limit) || uint64(len(f.message))+size > uint64(limit) {
+		f.logger.Warn().Msg("websocket message is too large")
+		return errors.New("websocket message is too large")
+	}
+	payload := make([]byte, size)
+	if _, err = io.ReadFull(f.r, payload); err != nil {
+		return err
+	}
+
+	if opcode >= wsClose {
+		frame := append(header, payload...)
+		if f.message == nil {
+			f.pending = frame
+		} else {
+			f.held = append(f.held, frame...)
+		}
+		return nil
+	}
+	if opcode != wsContinuation {
+		f.opcode, f.mask = opcode, mask
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	f.message = append(f.message, payload...)
+	if f.message == nil {
+		f.message = []byte{}
+	}
+	if !fin {
+		return nil
+	}
+
+	msg, err := f.filter.checkMessage(f.message)
+	if err != nil {
+		f.logger.Warn().Err(err).Msg("websocket message refused")
+		return err
+	}
+	f.pending = append(f.held, encodeFrame(f.opcode, f.mask, msg)...)
+	f.held, f.message = nil, nil
+	return nil
+}
+
+// encodeFrame encodes a message in a single frame masked with the given key.
+func encodeFrame(opcode byte, mask, payload []byte) []byte {
+	frame := []byte{0x80 | opcode, 0x80}
+	switch size := len(payload); {
+	case size < 126:
+		frame[1] |= byte(size)
+	case size <= 0xffff:
+		frame[1] |= 126
+		frame = append(frame, 0, 0)
+		binary.BigEndian.PutUint16(frame[2:], uint16(size))
+	default:
+		frame[1] |= 127
+		frame = append(frame, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(frame[2:], uint64(size))
+	}
+	frame = append(frame, mask...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	return frame
+}
@@ -0,0 +1,132 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/net/websocket"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/midgard/internal/config"
+)
+
+type WebsocketFilterSuite struct{}
+
+var _ = Suite(&WebsocketFilterSuite{})
+
+func (s *WebsocketFilterSuite) TestProxy(c *C) {
+	node := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		io.Copy(ws, ws)
+	}))
+	defer node.Close()
+	conf := config.NodeProxyConfiguration{
+		RateLimit:     100,
+		BurstLimit:    100,
+		DeniedMethods: []string{"unsafe_*"},
+		FullNodes: []config.NodeProxy{
+			{
+				Chain:         "bnb",
+				Target:        node.URL,
+				WebsocketPath: "/websocket",
+			},
+		},
+	}
+	proxy, err := NewProxyHandler(conf, "/v1/nodes", NewMemoryLimiterStore(), log.Logger)
+	c.Assert(err, IsNil)
+	e := echo.New()
+	proxy.RegisterHandler(e)
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/v1/nodes/bnb/websocket"
+	ws, err := websocket.Dial(wsURL, "", server.URL)
+	c.Assert(err, IsNil)
+	defer ws.Close()
+
+	var msg string
+	err = websocket.Message.Send(ws, `{"method":"subscribe","params":{"query":"tm.event='NewBlock'"}}`)
+	c.Assert(err, IsNil)
+	err = websocket.Message.Receive(ws, &msg)
+	c.Assert(err, IsNil)
+	c.Assert(msg, Equals, `{"method":"subscribe","params":{"query":"tm.event='NewBlock'"}}`)
+
+	// The connection is closed on a denied method.
+	err = websocket.Message.Send(ws, `{"method":"unsafe_flush_mempool"}`)
+	c.Assert(err, IsNil)
+	err = websocket.Message.Receive(ws, &msg)
+	c.Assert(err, NotNil)
+}
+
+func (s *WebsocketFilterSuite) TestFrames(c *C) {
+	filter := &requestFilter{
+		deniedMethods: []string{"unsafe_*"},
+		maxBodySize:   100,
+	}
+	mask := []byte{1, 2, 3, 4}
+	// frame encodes a frame of the client.
+	frame := func(fin bool, opcode byte, payload string) []byte {
+		f := encodeFrame(opcode, mask, []byte(payload))
+		if !fin {
+			f[0] &^= 0x80
+		}
+		return f
+	}
+	read := func(frames ...[]byte) ([]byte, error) {
+		f := &websocketFilter{
+			r:      bufio.NewReader(bytes.NewReader(bytes.Join(frames, nil))),
+			filter: filter,
+			logger: log.Logger,
+		}
+		return ioutil.ReadAll(f)
+	}
+
+	// Fragmented messages are passed on in a frame after the control frames
+	// received meanwhile.
+	data, err := read(
+		frame(false, 0x1, `{"method":`),
+		frame(true, 0x9, "ping"),
+		frame(true, 0x0, `"status"}`),
+		frame(true, 0xa, "pong"),
+	)
+	c.Assert(err, IsNil)
+	c.Assert(data, DeepEquals, bytes.Join([][]byte{
+		frame(true, 0x9, "ping"),
+		frame(true, 0x1, `{"method":"status"}`),
+		frame(true, 0xa, "pong"),
+	}, nil))
+
+	// Messages are encoded again.
+	data, err = read(frame(true, 0x1, `{ "id": 1, "method": "status" }`))
+	c.Assert(err, IsNil)
+	c.Assert(data, DeepEquals, frame(true, 0x1, `{"id":1,"method":"status"}`))
+
+	// Messages whose method is denied aren't passed on.
+	data, err = read(
+		frame(true, 0x1, `{"method":"status"}`),
+		frame(false, 0x1, `{"method":`),
+		frame(true, 0x0, `"unsafe_flush_mempool"}`),
+	)
+	c.Assert(err, NotNil)
+	c.Assert(data, DeepEquals, frame(true, 0x1, `{"method":"status"}`))
+	_, err = read(frame(true, 0x1, `{"method":"status","Method":"unsafe_flush_mempool"}`))
+	c.Assert(err, NotNil)
+
+	// Messages are limited to the max body size.
+	_, err = read(
+		frame(false, 0x1, `{"method":"status","params":["`+strings.Repeat("0", 60)),
+		frame(true, 0x0, strings.Repeat("0", 60)+`"]}`),
+	)
+	c.Assert(err, NotNil)
+
+	// Frames of the client must be masked.
+	unmasked := frame(true, 0x1, `{"method":"status"}`)
+	unmasked[1] &^= 0x80
+	_, err = read(unmasked)
+	c.Assert(err, NotNil)
+}
@@ -32,6 +32,7 @@ func main() {
 	router.HandleFunc("/thorchain/events/tx/{id}", eventsTxMockedEndpoint).Methods("GET")
 	router.HandleFunc("/thorchain/pool_addresses", poolAddressesMockedEndpoint).Methods("GET")
 	router.HandleFunc("/thorchain/vaults/asgard", asgardVaultsMockedEndpoint).Methods("GET")
+	router.HandleFunc("/prices", pricesMockedEndpoint).Methods("GET")
 
 	// used to debug incorrect dynamically generated requests
 	router.PathPrefix("/").HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
@@ -100,7 +101,7 @@ func eventsMockedEndpoint(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 	writer.Header().Set("Content-Type", "application/json")
-	fmt.Fprintf(writer, string(content))
+	writer.Write(content)
 }
 
 func poolAddressesMockedEndpoint(writer http.ResponseWriter, request *http.Request) {
@@ -134,3 +135,15 @@ func asgardVaultsMockedEndpoint(writer http.ResponseWriter, request *http.Reques
 	writer.Header().Set("Content-Type", "application/json")
 	fmt.Fprintf(writer, "[{\"chains\":[\"BNB\"]}]")
 }
+
+func pricesMockedEndpoint(writer http.ResponseWriter, request *http.Request) {
+	log.Println("pricesMockedEndpoint Hit!")
+
+	content, err := ioutil.ReadFile("./prices/prices.json")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(writer, string(content))
+}